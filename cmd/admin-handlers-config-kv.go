@@ -458,6 +458,10 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 				off = !crypto.EnabledVault(kv)
 			case config.KmsKesSubSys:
 				off = !crypto.EnabledKes(kv)
+			case config.KmsKeyStoreSubSys:
+				off = !crypto.EnabledKeyStore(kv)
+			case config.KmsKMIPSubSys:
+				off = !crypto.EnabledKMIP(kv)
 			case config.PolicyOPASubSys:
 				off = !opa.Enabled(kv)
 			case config.IdentityOpenIDSubSys:
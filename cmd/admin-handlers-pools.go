@@ -0,0 +1,184 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// validatePoolsReq checks the request, returns the object layer with
+// pools and the index of the pool given as the pool query parameter.
+func validatePoolsReq(ctx context.Context, w http.ResponseWriter, r *http.Request, withPool bool) (*erasureServerPools, int) {
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1
+	}
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objAPI == nil {
+		return nil, -1
+	}
+
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1
+	}
+	if !withPool {
+		return z, -1
+	}
+
+	idx := globalEndpoints.GetPoolIdx(mux.Vars(r)["pool"])
+	if idx == -1 {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errDecommissionInvalidPool), r.URL)
+		return nil, -1
+	}
+	return z, idx
+}
+
+// proxyDecommissionRequest forwards the request to the server running
+// the decommission of the pool, the server of the first drive of the
+// pool. Returns true if the request was forwarded.
+func proxyDecommissionRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, idx int) bool {
+	ep := globalEndpoints[idx].Endpoints[0]
+	if ep.IsLocal {
+		return false
+	}
+	for nodeIdx, proxyEp := range globalProxyEndpoints {
+		if proxyEp.Host == ep.Host {
+			return proxyRequestByNodeIndex(ctx, w, r, nodeIdx)
+		}
+	}
+	return false
+}
+
+// StartDecommission - POST /minio/admin/v3/pools/decommission?pool=http://server{1...4}/disk{1...4}
+//
+// Starts, or resumes, moving the objects of a pool to the other pools.
+func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	if proxyDecommissionRequest(ctx, w, r, idx) {
+		return
+	}
+
+	if err := z.Decommission(r.Context(), idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CancelDecommission - POST /minio/admin/v3/pools/cancel?pool=http://server{1...4}/disk{1...4}
+//
+// Stops the decommission of a pool, the pool accepts new objects again.
+func (a adminAPIHandlers) CancelDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	if proxyDecommissionRequest(ctx, w, r, idx) {
+		return
+	}
+
+	if err := z.DecommissionCancel(r.Context(), idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// StatusPool - GET /minio/admin/v3/pools/status?pool=http://server{1...4}/disk{1...4}
+//
+// Returns the status of a pool and the progress of its decommission.
+func (a adminAPIHandlers) StatusPool(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StatusPool")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	status, err := z.Status(r.Context(), idx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListPools - GET /minio/admin/v3/pools/list
+//
+// Returns the status of all the pools.
+func (a adminAPIHandlers) ListPools(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListPools")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsReq(ctx, w, r, false)
+	if z == nil {
+		return
+	}
+
+	pools := make([]madmin.PoolStatus, len(z.serverPools))
+	for idx := range z.serverPools {
+		status, err := z.Status(r.Context(), idx)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		pools[idx] = status
+	}
+
+	data, err := json.Marshal(pools)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,294 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// SiteReplicationAdd - PUT /minio/admin/v3/site-replication/add
+//
+// Adds the given sites, one of which must be this site, for replication of
+// IAM entities, buckets and bucket metadata.
+func (a adminAPIHandlers) SiteReplicationAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationAdd")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationAddAction)
+	if objAPI == nil {
+		return
+	}
+
+	var sites []madmin.PeerSite
+	if err := parseSRRequest(cred.SecretKey, r, &sites); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	status, err := globalSiteReplicationSys.AddPeerClusters(ctx, sites)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, body)
+}
+
+// SRPeerJoin - PUT /minio/admin/v3/site-replication/peer/join
+//
+// used internally to tell current cluster to enable SR with
+// the provided peer clusters and service account.
+func (a adminAPIHandlers) SRPeerJoin(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerJoin")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationAddAction)
+	if objAPI == nil {
+		return
+	}
+
+	var joinArg madmin.SRPeerJoinReq
+	if err := parseSRRequest(cred.SecretKey, r, &joinArg); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerJoinReq(ctx, joinArg); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerBucketOps - PUT /minio/admin/v3/site-replication/peer/bucket-ops?bucket=x&operation=y
+//
+// used internally to create, configure replication of, or delete a bucket
+// on behalf of a peer site.
+func (a adminAPIHandlers) SRPeerBucketOps(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerBucketOps")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objAPI == nil {
+		return
+	}
+
+	q := r.URL.Query()
+	bucket := q.Get("bucket")
+	if bucket == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidBucketName), r.URL)
+		return
+	}
+
+	var err error
+	switch madmin.BktOp(q.Get("operation")) {
+	case madmin.MakeWithVersioningBktOp:
+		var lockEnabled bool
+		if v := q.Get("lockEnabled"); v != "" {
+			if lockEnabled, err = strconv.ParseBool(v); err != nil {
+				writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+				return
+			}
+		}
+		opts := BucketOptions{
+			Location:    q.Get("location"),
+			LockEnabled: lockEnabled,
+		}
+		err = globalSiteReplicationSys.PeerBucketMakeWithVersioningHandler(ctx, bucket, opts)
+	case madmin.ConfigureReplBktOp:
+		err = globalSiteReplicationSys.PeerBucketConfigureReplHandler(ctx, bucket)
+	case madmin.DeleteBucketBktOp:
+		err = globalSiteReplicationSys.PeerBucketDeleteHandler(ctx, bucket, false)
+	case madmin.ForceDeleteBucketBktOp:
+		err = globalSiteReplicationSys.PeerBucketDeleteHandler(ctx, bucket, true)
+	default:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerReplicateIAMItem - PUT /minio/admin/v3/site-replication/peer/iam-item
+//
+// used internally to apply an IAM change made on a peer site.
+func (a adminAPIHandlers) SRPeerReplicateIAMItem(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerReplicateIAMItem")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objAPI == nil {
+		return
+	}
+
+	var item madmin.SRIAMItem
+	if err := parseSRRequest(cred.SecretKey, r, &item); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerIAMItemHandler(ctx, item); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerReplicateBucketItem - PUT /minio/admin/v3/site-replication/peer/bucket-meta
+//
+// used internally to apply a bucket metadata change made on a peer site.
+func (a adminAPIHandlers) SRPeerReplicateBucketItem(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerReplicateBucketItem")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objAPI == nil {
+		return
+	}
+
+	var item madmin.SRBucketMeta
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&item); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerBucketMetaHandler(ctx, item); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SiteReplicationInfo - GET /minio/admin/v3/site-replication/info
+//
+// Returns the site replication configuration of this site.
+func (a adminAPIHandlers) SiteReplicationInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationInfo")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objAPI == nil {
+		return
+	}
+
+	info, err := globalSiteReplicationSys.GetClusterInfo(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, body)
+}
+
+// SRPeerGetMetaInfo - GET /minio/admin/v3/site-replication/peer/metainfo
+//
+// used internally to fetch the replicated state of this site.
+func (a adminAPIHandlers) SRPeerGetMetaInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerGetMetaInfo")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objAPI == nil {
+		return
+	}
+
+	info, err := globalSiteReplicationSys.SiteReplicationMetaInfo(ctx, objAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, body)
+}
+
+// SiteReplicationStatus - GET /minio/admin/v3/site-replication/status
+//
+// Returns the replication status of IAM entities and bucket metadata across
+// all sites.
+func (a adminAPIHandlers) SiteReplicationStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objAPI == nil {
+		return
+	}
+
+	info, err := globalSiteReplicationSys.SiteReplicationStatus(ctx, objAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, body)
+}
+
+// parseSRRequest decrypts the request body with secretKey and decodes it
+// into v.
+func parseSRRequest(secretKey string, r *http.Request, v interface{}) error {
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		return errSRInvalidRequest(errDataTooLarge)
+	}
+	data, err := madmin.DecryptData(secretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
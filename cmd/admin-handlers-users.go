@@ -75,6 +75,11 @@ func (a adminAPIHandlers) RemoveUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if globalSiteReplicationSys.isReplicatorUser(accessKey) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errIAMActionNotAllowed), r.URL)
+		return
+	}
+
 	if err := globalIAMSys.DeleteUser(accessKey); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -87,6 +92,18 @@ func (a adminAPIHandlers) RemoveUser(w http.ResponseWriter, r *http.Request) {
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook.
+	if err := srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemUser,
+		UserInfo: &madmin.SRUserInfo{
+			AccessKey:   accessKey,
+			IsDeleteReq: true,
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
 
 // ListUsers - GET /minio/admin/v3/list-users
@@ -223,6 +240,17 @@ func (a adminAPIHandlers) UpdateGroupMembers(w http.ResponseWriter, r *http.Requ
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook.
+	if err = srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemGroupInfo,
+		GroupInfo: &madmin.SRGroupInfo{
+			UpdateReq: updReq,
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
 
 // GetGroup - /minio/admin/v3/group?group=mygroup1
@@ -315,6 +343,18 @@ func (a adminAPIHandlers) SetGroupStatus(w http.ResponseWriter, r *http.Request)
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook.
+	if err = srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemGroupInfo,
+		GroupInfo: &madmin.SRGroupInfo{
+			UpdateReq: madmin.GroupAddRemove{Group: group},
+			Status:    status,
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
 
 // SetUserStatus - PUT /minio/admin/v3/set-user-status?accessKey=<access_key>&status=[enabled|disabled]
@@ -338,6 +378,11 @@ func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if globalSiteReplicationSys.isReplicatorUser(accessKey) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errIAMActionNotAllowed), r.URL)
+		return
+	}
+
 	if err := globalIAMSys.SetUserStatus(accessKey, madmin.AccountStatus(status)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -350,6 +395,23 @@ func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request)
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook. GetUser reports disabled users as not
+	// found but still returns their credentials.
+	cred, _ := globalIAMSys.GetUser(accessKey)
+	if err := srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemUser,
+		UserInfo: &madmin.SRUserInfo{
+			AccessKey: accessKey,
+			UserInfo: madmin.UserInfo{
+				SecretKey: cred.SecretKey,
+				Status:    madmin.AccountStatus(status),
+			},
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
 
 // AddUser - PUT /minio/admin/v3/add-user?accessKey=<access_key>
@@ -440,6 +502,11 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if globalSiteReplicationSys.isReplicatorUser(accessKey) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errIAMActionNotAllowed), r.URL)
+		return
+	}
+
 	if err = globalIAMSys.CreateUser(accessKey, uinfo); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -452,6 +519,18 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook.
+	if err = srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemUser,
+		UserInfo: &madmin.SRUserInfo{
+			AccessKey: accessKey,
+			UserInfo:  uinfo,
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
 
 // AddServiceAccount - PUT /minio/admin/v3/add-service-account
@@ -548,6 +627,31 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	// Call site replication hook.
+	var sessionPolicy []byte
+	if createReq.Policy != nil {
+		if sessionPolicy, err = json.Marshal(createReq.Policy); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+	if err = srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemSvcAcc,
+		SvcAccChange: &madmin.SRSvcAccChange{
+			Create: &madmin.SRSvcAccCreate{
+				Parent:        targetUser,
+				AccessKey:     newCred.AccessKey,
+				SecretKey:     newCred.SecretKey,
+				Groups:        targetGroups,
+				SessionPolicy: sessionPolicy,
+				Status:        auth.AccountOn,
+			},
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	var createResp = madmin.AddServiceAccountResp{
 		Credentials: auth.Credentials{
 			AccessKey: newCred.AccessKey,
@@ -653,6 +757,29 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	// Call site replication hook.
+	var sessionPolicy []byte
+	if updateReq.NewPolicy != nil {
+		if sessionPolicy, err = json.Marshal(updateReq.NewPolicy); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+	if err = srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemSvcAcc,
+		SvcAccChange: &madmin.SRSvcAccChange{
+			Update: &madmin.SRSvcAccUpdate{
+				AccessKey:     accessKey,
+				SecretKey:     updateReq.NewSecretKey,
+				Status:        updateReq.NewStatus,
+				SessionPolicy: sessionPolicy,
+			},
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	writeSuccessNoContent(w)
 }
 
@@ -898,6 +1025,19 @@ func (a adminAPIHandlers) DeleteServiceAccount(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Call site replication hook.
+	if err = srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemSvcAcc,
+		SvcAccChange: &madmin.SRSvcAccChange{
+			Delete: &madmin.SRSvcAccDelete{
+				AccessKey: serviceAccount,
+			},
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	writeSuccessNoContent(w)
 }
 
@@ -1194,6 +1334,15 @@ func (a adminAPIHandlers) RemoveCannedPolicy(w http.ResponseWriter, r *http.Requ
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook.
+	if err := srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemPolicy,
+		Name: policyName,
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
 
 // AddCannedPolicy - PUT /minio/admin/v3/add-canned-policy?name=<policy_name>
@@ -1246,6 +1395,21 @@ func (a adminAPIHandlers) AddCannedPolicy(w http.ResponseWriter, r *http.Request
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook.
+	policyJSON, err := json.Marshal(iamPolicy)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if err = srHookIAM(ctx, madmin.SRIAMItem{
+		Type:   madmin.SRIAMItemPolicy,
+		Name:   policyName,
+		Policy: policyJSON,
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
 
 // SetPolicyForUserOrGroup - PUT /minio/admin/v3/set-policy?policy=xxx&user-or-group=?[&is-group]
@@ -1288,4 +1452,17 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	// Call site replication hook.
+	if err := srHookIAM(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemPolicyMapping,
+		PolicyMapping: &madmin.SRPolicyMapping{
+			UserOrGroup: entityName,
+			IsGroup:     isGroup,
+			Policy:      policyName,
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 }
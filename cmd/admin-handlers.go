@@ -1004,12 +1004,24 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusServiceUnavailable,
 			}
-		case errors.Is(err, crypto.ErrKESKeyExists):
+		case errors.Is(err, crypto.ErrKESKeyExists), errors.Is(err, kms.ErrKeyExists):
 			apiErr = APIError{
 				Code:           "XMinioKMSKeyExists",
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusConflict,
 			}
+		case errors.Is(err, kms.ErrKeyNotFound):
+			apiErr = APIError{
+				Code:           "XMinioKMSKeyNotFound",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusNotFound,
+			}
+		case errors.Is(err, kms.ErrNotSupported):
+			apiErr = APIError{
+				Code:           "XMinioKMSNotSupported",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusNotImplemented,
+			}
 		default:
 			apiErr = errorCodes.ToAPIErrWithErr(toAdminAPIErrCode(ctx, err), err)
 		}
@@ -1246,6 +1258,13 @@ func (a adminAPIHandlers) KMSCreateKeyHandler(w http.ResponseWriter, r *http.Req
 	writeSuccessResponseHeadersOnly(w)
 }
 
+// kmsKeyStatus is the status of a KMS master key, including its
+// versions if the KMS keeps track of them.
+type kmsKeyStatus struct {
+	madmin.KMSKeyStatus
+	Versions []int `json:"versions,omitempty"`
+}
+
 // KMSKeyStatusHandler - GET /minio/admin/v3/kms/key/status?key-id=<master-key-id>
 func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "KMSKeyStatus")
@@ -1271,8 +1290,13 @@ func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Req
 	if keyID == "" {
 		keyID = stat.DefaultKey
 	}
-	var response = madmin.KMSKeyStatus{
-		KeyID: keyID,
+	var response = kmsKeyStatus{
+		KMSKeyStatus: madmin.KMSKeyStatus{
+			KeyID: keyID,
+		},
+	}
+	if status, err := GlobalKMS.KeyStatus(keyID); err == nil {
+		response.Versions = status.Versions
 	}
 
 	kmsContext := kms.Context{"MinIO admin API": "KMSKeyStatusHandler"} // Context for a test key operation
@@ -1322,6 +1346,191 @@ func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Req
 	writeSuccessResponseJSON(w, resp)
 }
 
+// KMSListKeysHandler - GET /minio/admin/v3/kms/key/list
+// ----------
+// Lists the names of the master keys of the KMS.
+func (a adminAPIHandlers) KMSListKeysHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSListKeys")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSListKeysAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if GlobalKMS == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	keys, err := GlobalKMS.ListKeys()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	resp, err := json.Marshal(keys)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// KMSRotateKeyHandler - POST /minio/admin/v3/kms/key/rotate?key-id=<master-key-id>
+// ----------
+// Creates a new version of a master key and re-wraps the object keys
+// sealed under any previous version in the background.
+func (a adminAPIHandlers) KMSRotateKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSRotateKey")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSRotateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if GlobalKMS == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	keyID, err := kmsKeyIDOrDefault(r.URL.Query().Get("key-id"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if err = GlobalKMS.RotateKey(keyID); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if globalIsGateway { // Gateways do not scan objects
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+	err = updateKMSRewrapConfig(ctx, objectAPI, func(config *kmsRewrapConfig) error {
+		config.Set(kmsRewrapRule{KeyID: keyID, Created: UTCNow()})
+		return nil
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// KMSAddRewrapRuleHandler - POST /minio/admin/v3/kms/key/rewrap?key-id=<master-key-id>&new-key-id=<master-key-id>
+// ----------
+// Re-wraps the object keys sealed under key-id under new-key-id in the
+// background. If new-key-id is omitted they are re-wrapped under the
+// latest version of key-id.
+func (a adminAPIHandlers) KMSAddRewrapRuleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSAddRewrapRule")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSRotateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if GlobalKMS == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	keyID, err := kmsKeyIDOrDefault(r.URL.Query().Get("key-id"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	rule := kmsRewrapRule{
+		KeyID:    keyID,
+		NewKeyID: r.URL.Query().Get("new-key-id"),
+		Created:  UTCNow(),
+	}
+	if rule.NewKeyID == keyID {
+		rule.NewKeyID = ""
+	}
+	// Fail early if the objects cannot be re-wrapped under the new key.
+	if _, err = GlobalKMS.KeyStatus(rule.TargetKeyID()); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	err = updateKMSRewrapConfig(ctx, objectAPI, func(config *kmsRewrapConfig) error {
+		config.Set(rule)
+		return nil
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// KMSListRewrapRulesHandler - GET /minio/admin/v3/kms/key/rewrap
+// ----------
+// Lists the rules the data scanner re-wraps object keys by.
+func (a adminAPIHandlers) KMSListRewrapRulesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSListRewrapRules")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSKeyStatusAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	config, err := loadKMSRewrapConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	resp, err := json.Marshal(config)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// KMSRemoveRewrapRuleHandler - DELETE /minio/admin/v3/kms/key/rewrap?key-id=<master-key-id>
+// ----------
+// Stops re-wrapping the object keys sealed under key-id. Object keys
+// re-wrapped so far remain sealed under the new key.
+func (a adminAPIHandlers) KMSRemoveRewrapRuleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSRemoveRewrapRule")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSRotateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	keyID := r.URL.Query().Get("key-id")
+	err := updateKMSRewrapConfig(ctx, objectAPI, func(config *kmsRewrapConfig) error {
+		if !config.Remove(keyID) {
+			return errKMSRewrapRuleNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// kmsKeyIDOrDefault returns keyID, or the default key ID of the KMS if
+// keyID is empty.
+func kmsKeyIDOrDefault(keyID string) (string, error) {
+	if keyID != "" {
+		return keyID, nil
+	}
+	stat, err := GlobalKMS.Stat()
+	if err != nil {
+		return "", err
+	}
+	return stat.DefaultKey, nil
+}
+
 // HealthInfoHandler - GET /minio/admin/v3/healthinfo
 // ----------
 // Get server health info
@@ -1757,7 +1966,13 @@ func fetchKMSStatus() madmin.KMS {
 	if len(stat.Endpoints) == 0 {
 		kmsStat.Status = stat.Name
 	} else {
-		if err := checkConnection(stat.Endpoints[0], 15*time.Second); err != nil {
+		// Only HTTP endpoints can be checked here. Other KMS
+		// implementations, like KMIP, verify connectivity in Stat.
+		var err error
+		if strings.HasPrefix(stat.Endpoints[0], "http://") || strings.HasPrefix(stat.Endpoints[0], "https://") {
+			err = checkConnection(stat.Endpoints[0], 15*time.Second)
+		}
+		if err != nil {
 			kmsStat.Status = string(madmin.ItemOffline)
 		} else {
 			kmsStat.Status = string(madmin.ItemOnline)
@@ -188,6 +188,43 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			// RemoveRemoteTargetHandler
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-remote-target").HandlerFunc(
 				httpTraceHdrs(adminAPI.RemoveRemoteTargetHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			// ResyncRemoteTargetHandler
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/resync-remote-target").HandlerFunc(
+				httpTraceHdrs(adminAPI.ResyncRemoteTargetHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			// ResyncRemoteTargetStatusHandler
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/resync-remote-target-status").HandlerFunc(
+				httpTraceHdrs(adminAPI.ResyncRemoteTargetStatusHandler)).Queries("bucket", "{bucket:.*}")
+
+			// Remote Tier management operations
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier").HandlerFunc(httpTraceHdrs(adminAPI.AddTierHandler))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/tier/{tier}").HandlerFunc(httpTraceHdrs(adminAPI.EditTierHandler))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier").HandlerFunc(httpTraceHdrs(adminAPI.ListTierHandler))
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/tier/{tier}").HandlerFunc(httpTraceHdrs(adminAPI.RemoveTierHandler))
+
+			// Site replication operations
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/add").HandlerFunc(httpTraceHdrs(adminAPI.SiteReplicationAdd))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/info").HandlerFunc(httpTraceHdrs(adminAPI.SiteReplicationInfo))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/status").HandlerFunc(httpTraceHdrs(adminAPI.SiteReplicationStatus))
+
+			// Site replication internal operations, only called by peer sites
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/join").HandlerFunc(httpTraceHdrs(adminAPI.SRPeerJoin))
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/site-replication/peer/bucket-ops").HandlerFunc(httpTraceHdrs(adminAPI.SRPeerBucketOps)).Queries("bucket", "{bucket:.*}").Queries("operation", "{operation:.*}")
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/iam-item").HandlerFunc(httpTraceHdrs(adminAPI.SRPeerReplicateIAMItem))
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/bucket-meta").HandlerFunc(httpTraceHdrs(adminAPI.SRPeerReplicateBucketItem))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/peer/metainfo").HandlerFunc(httpTraceHdrs(adminAPI.SRPeerGetMetaInfo))
+
+			// Batch job operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/start-job").HandlerFunc(httpTraceHdrs(adminAPI.StartBatchJob))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-jobs").HandlerFunc(httpTraceHdrs(adminAPI.ListBatchJobs))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/describe-job").HandlerFunc(httpTraceHdrs(adminAPI.DescribeBatchJob)).Queries("jobId", "{jobId:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/status-job").HandlerFunc(httpTraceHdrs(adminAPI.BatchJobStatus)).Queries("jobId", "{jobId:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/cancel-job").HandlerFunc(httpTraceHdrs(adminAPI.CancelBatchJob)).Queries("id", "{id:.*}")
+
+			// Pool decommissioning operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decommission").HandlerFunc(httpTraceHdrs(adminAPI.StartDecommission)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/cancel").HandlerFunc(httpTraceHdrs(adminAPI.CancelDecommission)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/pools/status").HandlerFunc(httpTraceHdrs(adminAPI.StatusPool)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/pools/list").HandlerFunc(httpTraceHdrs(adminAPI.ListPools))
 		}
 
 		if globalIsDistErasure {
@@ -208,6 +245,13 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		//
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/key/create").HandlerFunc(httpTraceAll(adminAPI.KMSCreateKeyHandler)).Queries("key-id", "{key-id:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/key/status").HandlerFunc(httpTraceAll(adminAPI.KMSKeyStatusHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/key/list").HandlerFunc(httpTraceAll(adminAPI.KMSListKeysHandler))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/kms/key/rotate").HandlerFunc(httpTraceAll(adminAPI.KMSRotateKeyHandler))
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/kms/key/rewrap").HandlerFunc(httpTraceAll(adminAPI.KMSAddRewrapRuleHandler))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/key/rewrap").HandlerFunc(httpTraceAll(adminAPI.KMSListRewrapRulesHandler))
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/kms/key/rewrap").HandlerFunc(httpTraceAll(adminAPI.KMSRemoveRewrapRuleHandler)).Queries("key-id", "{key-id:.*}")
+		}
 
 		if !globalIsGateway {
 			// Keep obdinfo for backward compatibility with mc
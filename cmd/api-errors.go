@@ -79,6 +79,8 @@ const (
 	ErrInvalidAccessKeyID
 	ErrInvalidBucketName
 	ErrInvalidDigest
+	ErrInvalidChecksum
+	ErrInvalidAttributeName
 	ErrInvalidRange
 	ErrInvalidRangePartNumber
 	ErrInvalidCopyPartRange
@@ -106,7 +108,11 @@ const (
 	ErrNoSuchLifecycleConfiguration
 	ErrNoSuchBucketSSEConfig
 	ErrNoSuchCORSConfiguration
+	ErrCORSForbidden
 	ErrNoSuchWebsiteConfiguration
+	ErrInvalidTargetBucketForLogging
+	ErrNoSuchInventoryConfiguration
+	ErrInvalidInventoryDestination
 	ErrReplicationConfigurationNotFoundError
 	ErrRemoteDestinationNotFoundError
 	ErrReplicationDestinationMissingLock
@@ -122,6 +128,8 @@ const (
 	ErrReplicationSourceNotVersionedError
 	ErrReplicationNeedsVersioningError
 	ErrReplicationBucketNeedsVersioningError
+	ErrReplicationResyncInProgress
+	ErrReplicationExistingObjectsDisabled
 	ErrObjectRestoreAlreadyInProgress
 	ErrNoSuchKey
 	ErrNoSuchUpload
@@ -203,6 +211,7 @@ const (
 	ErrIncompatibleEncryptionMethod
 	ErrKMSNotConfigured
 	ErrKMSAuthFailure
+	ErrKMSKeyNotFound
 
 	ErrNoAccessKey
 	ErrInvalidToken
@@ -221,6 +230,7 @@ const (
 
 	// S3 extended errors.
 	ErrContentSHA256Mismatch
+	ErrContentChecksumMismatch
 
 	// Add new extended error codes here.
 
@@ -506,6 +516,16 @@ var errorCodes = errorCodeMap{
 		Description:    "The Content-Md5 you specified is not valid.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid checksum provided.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidAttributeName: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid attribute name specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidRange: {
 		Code:           "InvalidRange",
 		Description:    "The requested range is not satisfiable",
@@ -826,11 +846,31 @@ var errorCodes = errorCodeMap{
 		Description:    "The CORS configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evaluation of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrNoSuchWebsiteConfiguration: {
 		Code:           "NoSuchWebsiteConfiguration",
 		Description:    "The specified bucket does not have a website configuration",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchInventoryConfiguration: {
+		Code:           "NoSuchConfiguration",
+		Description:    "The specified inventory configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidInventoryDestination: {
+		Code:           "InvalidArgument",
+		Description:    "The destination bucket of the inventory does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrReplicationConfigurationNotFoundError: {
 		Code:           "ReplicationConfigurationNotFoundError",
 		Description:    "The replication configuration was not found",
@@ -906,6 +946,16 @@ var errorCodes = errorCodeMap{
 		Description:    "Versioning must be 'Enabled' on the bucket to add a replication target",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrReplicationResyncInProgress: {
+		Code:           "XMinioAdminReplicationResyncInProgress",
+		Description:    "A resync of the remote target is already in progress",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrReplicationExistingObjectsDisabled: {
+		Code:           "XMinioAdminReplicationExistingObjectsDisabled",
+		Description:    "Existing object replication must be 'Enabled' on a replication rule of the remote target to resync it",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrNoSuchObjectLockConfiguration: {
 		Code:           "NoSuchObjectLockConfiguration",
 		Description:    "The specified object does not have a ObjectLock configuration",
@@ -1082,6 +1132,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Server side encryption specified but KMS authorization failed",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrKMSKeyNotFound: {
+		Code:           "KMS.NotFoundException",
+		Description:    "Invalid keyId",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrNoAccessKey: {
 		Code:           "AccessDenied",
 		Description:    "No AWSAccessKey was presented",
@@ -1099,6 +1154,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The provided 'x-amz-content-sha256' header does not match what was computed.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrContentChecksumMismatch: {
+		Code:           "XAmzContentChecksumMismatch",
+		Description:    "The provided 'x-amz-checksum' header does not match what was computed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	/// MinIO extensions.
 	ErrStorageFull: {
@@ -1823,6 +1883,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrMissingSSECustomerKeyMD5
 	case crypto.ErrCustomerKeyMD5Mismatch:
 		apiErr = ErrSSECustomerKeyMD5Mismatch
+	case hash.ErrInvalidChecksum:
+		apiErr = ErrInvalidChecksum
 	case errObjectTampered:
 		apiErr = ErrObjectTampered
 	case errEncryptedObject:
@@ -1881,6 +1943,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrIncompleteBody
 	case ObjectExistsAsDirectory:
 		apiErr = ErrObjectExistsAsDirectory
+	case ObjectLocked:
+		apiErr = ErrObjectLocked
 	case PrefixAccessDenied:
 		apiErr = ErrAccessDenied
 	case ParentIsObject:
@@ -1931,6 +1995,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrSignatureDoesNotMatch
 	case hash.SHA256Mismatch:
 		apiErr = ErrContentSHA256Mismatch
+	case hash.ChecksumMismatch:
+		apiErr = ErrContentChecksumMismatch
 	case ObjectTooLarge:
 		apiErr = ErrEntityTooLarge
 	case ObjectTooSmall:
@@ -1945,6 +2011,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketPolicy
 	case BucketLifecycleNotFound:
 		apiErr = ErrNoSuchLifecycleConfiguration
+	case BucketCorsConfigNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteConfigNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
+	case BucketInventoryConfigNotFound:
+		apiErr = ErrNoSuchInventoryConfiguration
 	case BucketSSEConfigNotFound:
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
@@ -1977,6 +2049,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrRemoteTargetNotVersionedError
 	case BucketReplicationSourceNotVersioned:
 		apiErr = ErrReplicationSourceNotVersionedError
+	case BucketReplicationResyncInProgress:
+		apiErr = ErrReplicationResyncInProgress
+	case BucketReplicationExistingObjectsDisabled:
+		apiErr = ErrReplicationExistingObjectsDisabled
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
 	case PydioQuotaExceeded:
@@ -2134,6 +2210,12 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case AdminError:
+			apiErr = APIError{
+				Code:           e.Code,
+				Description:    e.Message,
+				HTTPStatusCode: e.StatusCode,
+			}
 		case minio.ErrorResponse:
 			apiErr = APIError{
 				Code:           e.Code,
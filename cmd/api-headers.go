@@ -182,6 +182,11 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 
 	if objInfo.ReplicationStatus.String() != "" {
 		w.Header()[xhttp.AmzBucketReplicationStatus] = []string{objInfo.ReplicationStatus.String()}
+		// and the status for each target, as "<arn>=<status>".
+		st := objInfo.targetReplicationStatus()
+		for _, arn := range st.arns() {
+			w.Header()[xhttp.MinIOReplicationTargetStatus] = append(w.Header()[xhttp.MinIOReplicationTargetStatus], arn+"="+st[arn].String())
+		}
 	}
 
 	if lc, err := globalLifecycleSys.Get(objInfo.Bucket); err == nil {
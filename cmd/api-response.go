@@ -160,6 +160,7 @@ type Part struct {
 	LastModified string
 	ETag         string
 	Size         int64
+	ContentChecksums
 }
 
 // ListPartsResponse - format for list parts response.
@@ -349,6 +350,37 @@ type CompleteMultipartUploadResponse struct {
 	Bucket   string
 	Key      string
 	ETag     string
+	ContentChecksums
+}
+
+// GetObjectAttributesResponse - format for get object attributes response.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse" json:"-"`
+
+	ETag         string                 `xml:",omitempty"`
+	Checksum     *ContentChecksums      `xml:",omitempty"`
+	ObjectParts  *ObjectAttributesParts `xml:",omitempty"`
+	StorageClass string                 `xml:",omitempty"`
+	ObjectSize   *int64                 `xml:",omitempty"`
+}
+
+// ObjectAttributesParts - parts of a multipart object in the get object
+// attributes response.
+type ObjectAttributesParts struct {
+	IsTruncated          bool
+	MaxParts             int
+	NextPartNumberMarker int
+	PartNumberMarker     int
+	PartsCount           int
+	Parts                []ObjectAttributesPart `xml:"Part"`
+}
+
+// ObjectAttributesPart - part of a multipart object in the get object
+// attributes response.
+type ObjectAttributesPart struct {
+	ContentChecksums
+	PartNumber int
+	Size       int64
 }
 
 // DeleteError structure.
@@ -673,6 +705,7 @@ func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) Lis
 		newPart.ETag = "\"" + part.ETag + "\""
 		newPart.Size = part.Size
 		newPart.LastModified = part.LastModified.UTC().Format(iso8601TimeFormat)
+		newPart.ContentChecksums = newContentChecksums(part.Checksum)
 		listPartsResponse.Parts[index] = newPart
 	}
 	return listPartsResponse
@@ -90,29 +90,14 @@ type rejectedAPI struct {
 }
 
 var rejectedAPIs = []rejectedAPI{
-	{
-		api:     "inventory",
-		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
-		queries: []string{"inventory", ""},
-	},
-	{
-		api:     "cors",
-		methods: []string{http.MethodPut, http.MethodDelete},
-		queries: []string{"cors", ""},
-	},
 	{
 		api:     "metrics",
 		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
 		queries: []string{"metrics", ""},
 	},
-	{
-		api:     "website",
-		methods: []string{http.MethodPut},
-		queries: []string{"website", ""},
-	},
 	{
 		api:     "logging",
-		methods: []string{http.MethodPut, http.MethodDelete},
+		methods: []string{http.MethodDelete},
 		queries: []string{"logging", ""},
 	},
 	{
@@ -186,6 +171,16 @@ func registerAPIRouter(router *mux.Router) {
 	// API Router
 	apiRouter := router.PathPrefix(SlashSeparator).Subrouter()
 
+	// Static website endpoints `<bucket>.website.<domain>`, registered
+	// first so that they take precedence over bucket DNS style requests.
+	for _, domainName := range globalDomainNames {
+		websiteRouter := apiRouter.Host("{bucket:.+}." + websiteDomainPrefix + domainName).Subrouter()
+		websiteRouter.Methods(http.MethodGet, http.MethodHead).Path("/{object:.*}").HandlerFunc(
+			collectAPIStats("website", maxClients(httpTraceHdrs(api.WebsiteHandler))))
+		websiteRouter.PathPrefix(SlashSeparator).HandlerFunc(
+			collectAPIStats("website", httpTraceAll(websiteMethodNotAllowedHandler)))
+	}
+
 	var routers []*mux.Router
 	for _, domainName := range globalDomainNames {
 		if IsKubernetes() {
@@ -263,6 +258,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetObjectLegalHold
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobjectlegalhold", maxClients(httpTraceAll(api.GetObjectLegalHoldHandler)))).Queries("legal-hold", "")
+		// GetObjectAttributes
+		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectattributes", maxClients(httpTraceHdrs(api.GetObjectAttributesHandler)))).Queries("attributes", "")
 		// GetObject
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobject", maxClients(httpTraceHdrs(api.GetObjectHandler))))
@@ -305,6 +303,12 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketEncryption
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketencryption", maxClients(httpTraceAll(api.GetBucketEncryptionHandler)))).Queries("encryption", "")
+		// GetBucketWebsite
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketwebsite", maxClients(httpTraceAll(api.GetBucketWebsiteHandler)))).Queries("website", "")
+		// GetBucketCors
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketcors", maxClients(httpTraceAll(api.GetBucketCorsHandler)))).Queries("cors", "")
 		// GetBucketObjectLockConfig
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketobjectlockconfiguration", maxClients(httpTraceAll(api.GetBucketObjectLockConfigHandler)))).Queries("object-lock", "")
@@ -328,27 +332,24 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketACL -- this is a dummy call.
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketacl", maxClients(httpTraceAll(api.PutBucketACLHandler)))).Queries("acl", "")
-		// GetBucketCors - this is a dummy call.
-		router.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketcors", maxClients(httpTraceAll(api.GetBucketCorsHandler)))).Queries("cors", "")
-		// GetBucketWebsiteHandler - this is a dummy call.
-		router.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketwebsite", maxClients(httpTraceAll(api.GetBucketWebsiteHandler)))).Queries("website", "")
 		// GetBucketAccelerateHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketaccelerate", maxClients(httpTraceAll(api.GetBucketAccelerateHandler)))).Queries("accelerate", "")
 		// GetBucketRequestPaymentHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketrequestpayment", maxClients(httpTraceAll(api.GetBucketRequestPaymentHandler)))).Queries("requestPayment", "")
-		// GetBucketLoggingHandler - this is a dummy call.
+		// GetBucketLogging
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketlogging", maxClients(httpTraceAll(api.GetBucketLoggingHandler)))).Queries("logging", "")
+		// GetBucketInventoryConfiguration
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketinventoryconfiguration", maxClients(httpTraceAll(api.GetBucketInventoryConfigurationHandler)))).Queries("inventory", "", "id", "{id:.*}")
+		// ListBucketInventoryConfigurations
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("listbucketinventoryconfigurations", maxClients(httpTraceAll(api.ListBucketInventoryConfigurationsHandler)))).Queries("inventory", "")
 		// GetBucketTaggingHandler
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbuckettagging", maxClients(httpTraceAll(api.GetBucketTaggingHandler)))).Queries("tagging", "")
-		//DeleteBucketWebsiteHandler
-		router.Methods(http.MethodDelete).HandlerFunc(
-			collectAPIStats("deletebucketwebsite", maxClients(httpTraceAll(api.DeleteBucketWebsiteHandler)))).Queries("website", "")
 		// DeleteBucketTaggingHandler
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebuckettagging", maxClients(httpTraceAll(api.DeleteBucketTaggingHandler)))).Queries("tagging", "")
@@ -377,6 +378,18 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketEncryption
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketencryption", maxClients(httpTraceAll(api.PutBucketEncryptionHandler)))).Queries("encryption", "")
+		// PutBucketCors
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketcors", maxClients(httpTraceAll(api.PutBucketCorsHandler)))).Queries("cors", "")
+		// PutBucketLogging
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketlogging", maxClients(httpTraceAll(api.PutBucketLoggingHandler)))).Queries("logging", "")
+		// PutBucketInventoryConfiguration
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketinventoryconfiguration", maxClients(httpTraceAll(api.PutBucketInventoryConfigurationHandler)))).Queries("inventory", "", "id", "{id:.*}")
+		// PutBucketWebsite
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketwebsite", maxClients(httpTraceAll(api.PutBucketWebsiteHandler)))).Queries("website", "")
 
 		// PutBucketPolicy
 		router.Methods(http.MethodPut).HandlerFunc(
@@ -418,6 +431,15 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketEncryption
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketencryption", maxClients(httpTraceAll(api.DeleteBucketEncryptionHandler)))).Queries("encryption", "")
+		// DeleteBucketCors
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketcors", maxClients(httpTraceAll(api.DeleteBucketCorsHandler)))).Queries("cors", "")
+		// DeleteBucketWebsite
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketwebsite", maxClients(httpTraceAll(api.DeleteBucketWebsiteHandler)))).Queries("website", "")
+		// DeleteBucketInventoryConfiguration
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketinventoryconfiguration", maxClients(httpTraceAll(api.DeleteBucketInventoryConfigurationHandler)))).Queries("inventory", "", "id", "{id:.*}")
 		// DeleteBucket
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucket", maxClients(httpTraceAll(api.DeleteBucketHandler))))
@@ -454,7 +476,8 @@ func registerAPIRouter(router *mux.Router) {
 
 }
 
-// corsHandler handler for CORS (Cross Origin Resource Sharing)
+// corsHandler handler for CORS (Cross Origin Resource Sharing), buckets
+// with a CORS configuration are served according to that configuration.
 func corsHandler(handler http.Handler) http.Handler {
 	commonS3Headers := []string{
 		xhttp.Date,
@@ -478,7 +501,7 @@ func corsHandler(handler http.Handler) http.Handler {
 		"*",
 	}
 
-	return cors.New(cors.Options{
+	defaultHandler := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			for _, allowedOrigin := range globalAPIConfig.getCorsAllowOrigins() {
 				if wildcard.MatchSimple(allowedOrigin, origin) {
@@ -500,4 +523,9 @@ func corsHandler(handler http.Handler) http.Handler {
 		ExposedHeaders:   commonS3Headers,
 		AllowCredentials: true,
 	}).Handler(handler)
+
+	return bucketCorsHandler{
+		handler:        handler,
+		defaultHandler: defaultHandler,
+	}
 }
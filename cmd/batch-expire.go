@@ -0,0 +1,292 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// Types of expire rules.
+const (
+	batchExpireObject  = "object"
+	batchExpireDeleted = "deleted"
+)
+
+// BatchJobSize is a size in bytes, written as 10MiB or 1GB in a job.
+type BatchJobSize int64
+
+// UnmarshalYAML parses a human readable size.
+func (s *BatchJobSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	n, err := humanize.ParseBytes(str)
+	if err != nil {
+		return err
+	}
+	*s = BatchJobSize(n)
+	return nil
+}
+
+// MarshalYAML writes the size in a human readable form.
+func (s BatchJobSize) MarshalYAML() (interface{}, error) {
+	return humanize.IBytes(uint64(s)), nil
+}
+
+// BatchJobSizeFilter selects the objects by their size, a bound of
+// zero is not checked.
+type BatchJobSizeFilter struct {
+	LessThan    BatchJobSize `yaml:"lessThan,omitempty" json:"lessThan"`
+	GreaterThan BatchJobSize `yaml:"greaterThan,omitempty" json:"greaterThan"`
+}
+
+// BatchJobExpirePurge is the versions of an object an object rule
+// keeps whatever they match.
+type BatchJobExpirePurge struct {
+	RetainVersions int `yaml:"retainVersions,omitempty" json:"retainVersions"`
+}
+
+// BatchJobExpireRule selects the versions to delete. An object rule
+// selects object versions, a deleted rule delete markers; name is a
+// pattern matched against the object name.
+type BatchJobExpireRule struct {
+	Type          string              `yaml:"type" json:"type"`
+	Name          string              `yaml:"name,omitempty" json:"name"`
+	OlderThan     time.Duration       `yaml:"olderThan,omitempty" json:"olderThan"`
+	CreatedBefore time.Time           `yaml:"createdBefore,omitempty" json:"createdBefore"`
+	Tags          []BatchJobKV        `yaml:"tags,omitempty" json:"tags"`
+	Metadata      []BatchJobKV        `yaml:"metadata,omitempty" json:"metadata"`
+	Size          BatchJobSizeFilter  `yaml:"size,omitempty" json:"size"`
+	Purge         BatchJobExpirePurge `yaml:"purge,omitempty" json:"purge"`
+}
+
+func (e BatchJobExpireRule) validate() error {
+	switch e.Type {
+	case batchExpireObject:
+	case batchExpireDeleted:
+		if len(e.Tags) > 0 || len(e.Metadata) > 0 || e.Size != (BatchJobSizeFilter{}) || e.Purge.RetainVersions > 0 {
+			return errInvalidBatchJob("delete markers can only be selected by name and age")
+		}
+	default:
+		return errInvalidBatchJob("unknown expire rule type %q", e.Type)
+	}
+	if e.OlderThan < 0 || e.Purge.RetainVersions < 0 {
+		return errInvalidBatchJob("olderThan and retainVersions cannot be negative")
+	}
+	if e.Size.LessThan > 0 && e.Size.GreaterThan >= e.Size.LessThan {
+		return errInvalidBatchJob("no object is smaller than %d and larger than %d bytes", e.Size.LessThan, e.Size.GreaterThan)
+	}
+	for _, kv := range append(append([]BatchJobKV(nil), e.Tags...), e.Metadata...) {
+		if err := kv.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// match returns true if the rule selects the version, versionIdx is the
+// position of an object version among the versions of its object, the
+// latest version being 0.
+func (e BatchJobExpireRule) match(oi ObjectInfo, versionIdx int, now time.Time) bool {
+	if oi.DeleteMarker != (e.Type == batchExpireDeleted) {
+		return false
+	}
+	if e.Name != "" && !wildcard.Match(e.Name, oi.Name) {
+		return false
+	}
+	if e.OlderThan > 0 && now.Sub(oi.ModTime) < e.OlderThan {
+		return false
+	}
+	if !e.CreatedBefore.IsZero() && !oi.ModTime.Before(e.CreatedBefore) {
+		return false
+	}
+	if oi.DeleteMarker {
+		return true
+	}
+	if versionIdx < e.Purge.RetainVersions {
+		return false
+	}
+	size, err := oi.GetActualSize()
+	if err != nil {
+		return false
+	}
+	if e.Size.LessThan > 0 && size >= int64(e.Size.LessThan) {
+		return false
+	}
+	if e.Size.GreaterThan > 0 && size <= int64(e.Size.GreaterThan) {
+		return false
+	}
+	if len(e.Tags) > 0 {
+		t := batchJobObjectTags(oi)
+		for _, kv := range e.Tags {
+			if !kv.matchTags(t) {
+				return false
+			}
+		}
+	}
+	for _, kv := range e.Metadata {
+		if !kv.matchMetadata(oi.UserDefined) {
+			return false
+		}
+	}
+	return true
+}
+
+// BatchJobExpireV1 deletes for good the versions of the objects of a
+// bucket, and the delete markers, matching any of its rules.
+type BatchJobExpireV1 struct {
+	APIVersion string               `yaml:"apiVersion" json:"apiVersion"`
+	Bucket     string               `yaml:"bucket" json:"bucket"`
+	Prefix     string               `yaml:"prefix,omitempty" json:"prefix"`
+	Rules      []BatchJobExpireRule `yaml:"rules" json:"rules"`
+	Notify     BatchJobNotification `yaml:"notify,omitempty" json:"notify"`
+	Retry      BatchJobRetry        `yaml:"retry,omitempty" json:"retry"`
+}
+
+func (r *BatchJobExpireV1) bucket() string {
+	return r.Bucket
+}
+
+func (r *BatchJobExpireV1) notification() BatchJobNotification {
+	return r.Notify
+}
+
+func (r *BatchJobExpireV1) validate(ctx context.Context, o ObjectLayer) error {
+	if r.APIVersion != batchJobAPIVersionV1 {
+		return errInvalidBatchJob("unsupported apiVersion %q", r.APIVersion)
+	}
+	if r.Bucket == "" {
+		return errInvalidBatchJob("bucket is missing")
+	}
+	if _, err := o.GetBucketInfo(ctx, r.Bucket); err != nil {
+		return err
+	}
+	if len(r.Rules) == 0 {
+		return errInvalidBatchJob("no expire rule")
+	}
+	for _, rule := range r.Rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+	if err := r.Notify.validate(); err != nil {
+		return err
+	}
+	return r.Retry.validate()
+}
+
+// expire deletes the version, as lifecycle expiry does.
+func (r *BatchJobExpireV1) expire(ctx context.Context, o ObjectLayer, oi ObjectInfo) error {
+	if oi.TransitionStatus == lifecycle.TransitionComplete {
+		// The event is sent once the version is deleted.
+		return deleteTransitionedObject(ctx, o, oi.Bucket, oi.Name, lifecycle.ObjectOpts{
+			Name:             oi.Name,
+			UserTags:         oi.UserTags,
+			ModTime:          oi.ModTime,
+			VersionID:        oi.VersionID,
+			IsLatest:         oi.IsLatest,
+			TransitionStatus: oi.TransitionStatus,
+		}, oi.TransitionedObject, false, false)
+	}
+
+	deleted, err := o.DeleteObject(ctx, oi.Bucket, oi.Name, ObjectOptions{
+		VersionID: oi.VersionID,
+	})
+	if err != nil {
+		return err
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRemovedDelete,
+		BucketName: oi.Bucket,
+		Object:     deleted,
+		Host:       "Internal: [Batch-Expire]",
+	})
+	return nil
+}
+
+func (r *BatchJobExpireV1) run(ctx context.Context, o ObjectLayer, ri *batchJobInfo) error {
+	now := UTCNow()
+	marker, versionIDMarker := ri.marker(), ""
+
+	// Versions are listed newest first, an object is checkpointed
+	// once all its versions are processed.
+	var object string
+	versionIdx := 0
+	for {
+		loi, err := o.ListObjectVersions(ctx, r.Bucket, r.Prefix, marker, versionIDMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range loi.Objects {
+			if oi.Name != object {
+				if object != "" {
+					ri.checkpoint(r.Bucket, object)
+				}
+				object, versionIdx = oi.Name, 0
+			}
+			idx := versionIdx
+			if !oi.DeleteMarker {
+				versionIdx++
+			}
+
+			matched := false
+			for _, rule := range r.Rules {
+				if rule.match(oi, idx, now) {
+					matched = true
+					break
+				}
+			}
+			if !matched || enforceRetentionForDeletion(ctx, oi) {
+				continue
+			}
+
+			size, _ := oi.GetActualSize()
+			err := r.Retry.do(ctx, ri, func() error {
+				return r.expire(ctx, o, oi)
+			})
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			switch {
+			case err == nil:
+				ri.track(size, nil)
+			case isErrObjectNotFound(err), isErrVersionNotFound(err):
+				// Deleted since listed.
+			default:
+				logger.LogIf(ctx, fmt.Errorf("batch job: unable to expire %s/%s (%s): %w", oi.Bucket, oi.Name, oi.VersionID, err))
+				ri.track(size, err)
+			}
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker, versionIDMarker = loi.NextMarker, loi.NextVersionIDMarker
+	}
+	if object != "" {
+		ri.checkpoint(r.Bucket, object)
+	}
+	return nil
+}
@@ -0,0 +1,971 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/wildcard"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Prefix in .minio.sys of the batch jobs, each job is saved with
+	// its progress as <id>.json.
+	batchJobPrefix = "batch-jobs"
+
+	batchJobStateVersionV1 = 1
+	batchJobStateVersion   = batchJobStateVersionV1
+
+	// The only version of the job descriptions.
+	batchJobAPIVersionV1 = "v1"
+
+	// How often the progress of a running job is saved.
+	batchJobSaveInterval = 10 * time.Second
+
+	// How often, and how far apart, an object failing is retried
+	// when the job does not say otherwise.
+	batchJobDefaultRetryAttempts = 3
+	batchJobDefaultRetryDelay    = 250 * time.Millisecond
+
+	// How long the webhook of a job has to acknowledge the report.
+	batchJobNotifyTimeout = 30 * time.Second
+
+	// Replaces the secrets of a job when it is described.
+	batchJobRedacted = "*REDACTED*"
+)
+
+var (
+	errBatchJobNotFound = AdminError{
+		Code:       "XMinioAdminNoSuchJob",
+		Message:    "no such batch job",
+		StatusCode: http.StatusNotFound,
+	}
+	errBatchJobNotRunning = AdminError{
+		Code:       "XMinioAdminJobNotRunning",
+		Message:    "the batch job is not running",
+		StatusCode: http.StatusBadRequest,
+	}
+
+	// Objects encrypted with SSE-C cannot be read without the key of
+	// their owner.
+	errBatchJobSSEC = errors.New("objects encrypted with SSE-C are not supported")
+)
+
+// errInvalidBatchJob wraps a problem found in a job description.
+func errInvalidBatchJob(format string, args ...interface{}) error {
+	return AdminError{
+		Code:       "XMinioAdminInvalidJob",
+		Message:    fmt.Sprintf(format, args...),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// BatchJobKV is a key and a value matched against the tags or the
+// metadata of an object, the value may hold wildcards and an empty
+// value matches any value.
+type BatchJobKV struct {
+	Key   string `yaml:"key" json:"key"`
+	Value string `yaml:"value" json:"value"`
+}
+
+func (kv BatchJobKV) validate() error {
+	if kv.Key == "" {
+		return errInvalidBatchJob("key is missing in key/value filter")
+	}
+	return nil
+}
+
+func (kv BatchJobKV) matchValue(v string) bool {
+	return kv.Value == "" || wildcard.Match(kv.Value, v)
+}
+
+// matchTags returns true if the object has the tag.
+func (kv BatchJobKV) matchTags(t map[string]string) bool {
+	v, ok := t[kv.Key]
+	return ok && kv.matchValue(v)
+}
+
+// matchMetadata returns true if the object has the metadata, the key is
+// case insensitive and may omit the x-amz-meta- prefix.
+func (kv BatchJobKV) matchMetadata(meta map[string]string) bool {
+	for k, v := range meta {
+		if strings.EqualFold(k, kv.Key) || strings.EqualFold(k, "X-Amz-Meta-"+kv.Key) {
+			if kv.matchValue(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BatchJobNotification is the webhook sent the report of a job when it
+// ends, the token is sent as the Authorization header.
+type BatchJobNotification struct {
+	Endpoint string `yaml:"endpoint,omitempty" json:"endpoint"`
+	Token    string `yaml:"token,omitempty" json:"token"`
+}
+
+func (n BatchJobNotification) validate() error {
+	if n.Endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(n.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidBatchJob("invalid notification endpoint %q", n.Endpoint)
+	}
+	return nil
+}
+
+// notify posts the status of the job to the webhook.
+func (n BatchJobNotification) notify(ctx context.Context, status madmin.BatchJobStatus) error {
+	if n.Endpoint == "" {
+		return nil
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, batchJobNotifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+	if n.Token != "" {
+		req.Header.Set(xhttp.Authorization, n.Token)
+	}
+
+	getRemoteTargetInstanceTransportOnce.Do(func() {
+		getRemoteTargetInstanceTransport = NewRemoteTargetHTTPTransport()
+	})
+	client := &http.Client{Transport: getRemoteTargetInstanceTransport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("batch job %s: notification endpoint %s returned %s", status.ID, n.Endpoint, resp.Status)
+	}
+	return nil
+}
+
+// BatchJobRetry is how often, and how far apart, an object failing is
+// retried before it is counted as failed.
+type BatchJobRetry struct {
+	Attempts int           `yaml:"attempts,omitempty" json:"attempts"`
+	Delay    time.Duration `yaml:"delay,omitempty" json:"delay"`
+}
+
+func (r BatchJobRetry) validate() error {
+	if r.Attempts < 0 || r.Delay < 0 {
+		return errInvalidBatchJob("retry attempts and delay cannot be negative")
+	}
+	return nil
+}
+
+// batchJobRetriable returns false for the errors the next attempt would
+// meet too.
+func batchJobRetriable(err error) bool {
+	switch {
+	case isErrObjectNotFound(err), isErrVersionNotFound(err), isErrPreconditionFailed(err):
+		return false
+	case errors.Is(err, errBatchJobSSEC):
+		return false
+	}
+	return true
+}
+
+// do calls fn until it succeeds or fails for good.
+func (r BatchJobRetry) do(ctx context.Context, ri *batchJobInfo, fn func() error) error {
+	attempts := r.Attempts
+	if attempts == 0 {
+		attempts = batchJobDefaultRetryAttempts
+	}
+	delay := r.Delay
+	if delay == 0 {
+		delay = batchJobDefaultRetryDelay
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			ri.retried()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		if err = fn(); err == nil || !batchJobRetriable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// BatchJobFilter selects the objects of a job. Ages are relative to the
+// start of the job, all the tags and metadata listed must match.
+type BatchJobFilter struct {
+	NewerThan     time.Duration `yaml:"newerThan,omitempty" json:"newerThan"`
+	OlderThan     time.Duration `yaml:"olderThan,omitempty" json:"olderThan"`
+	CreatedAfter  time.Time     `yaml:"createdAfter,omitempty" json:"createdAfter"`
+	CreatedBefore time.Time     `yaml:"createdBefore,omitempty" json:"createdBefore"`
+	Tags          []BatchJobKV  `yaml:"tags,omitempty" json:"tags"`
+	Metadata      []BatchJobKV  `yaml:"metadata,omitempty" json:"metadata"`
+	KMSKeyID      string        `yaml:"kmsKeyId,omitempty" json:"kmsKeyId"`
+}
+
+func (f BatchJobFilter) validate() error {
+	if f.NewerThan < 0 || f.OlderThan < 0 {
+		return errInvalidBatchJob("newerThan and olderThan cannot be negative")
+	}
+	if f.NewerThan > 0 && f.OlderThan > 0 && f.OlderThan >= f.NewerThan {
+		return errInvalidBatchJob("no object is older than %s and newer than %s", f.OlderThan, f.NewerThan)
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return errInvalidBatchJob("createdAfter must be before createdBefore")
+	}
+	for _, kv := range append(append([]BatchJobKV(nil), f.Tags...), f.Metadata...) {
+		if err := kv.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchJobObjectTags returns the tags of the object.
+func batchJobObjectTags(oi ObjectInfo) map[string]string {
+	t, err := tags.ParseObjectTags(oi.UserTags)
+	if err != nil {
+		return nil
+	}
+	return t.ToMap()
+}
+
+func (f BatchJobFilter) match(oi ObjectInfo, now time.Time) bool {
+	age := now.Sub(oi.ModTime)
+	if f.NewerThan > 0 && age > f.NewerThan {
+		return false
+	}
+	if f.OlderThan > 0 && age < f.OlderThan {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && !oi.ModTime.After(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !oi.ModTime.Before(f.CreatedBefore) {
+		return false
+	}
+	if f.KMSKeyID != "" && oi.UserDefined[crypto.MetaKeyID] != f.KMSKeyID {
+		return false
+	}
+	if len(f.Tags) > 0 {
+		t := batchJobObjectTags(oi)
+		for _, kv := range f.Tags {
+			if !kv.matchTags(t) {
+				return false
+			}
+		}
+	}
+	for _, kv := range f.Metadata {
+		if !kv.matchMetadata(oi.UserDefined) {
+			return false
+		}
+	}
+	return true
+}
+
+// BatchJobFlags are the settings of a replicate or a keyrotate job.
+type BatchJobFlags struct {
+	Filter BatchJobFilter       `yaml:"filter,omitempty" json:"filter"`
+	Notify BatchJobNotification `yaml:"notify,omitempty" json:"notify"`
+	Retry  BatchJobRetry        `yaml:"retry,omitempty" json:"retry"`
+}
+
+func (f BatchJobFlags) validate() error {
+	if err := f.Filter.validate(); err != nil {
+		return err
+	}
+	if err := f.Notify.validate(); err != nil {
+		return err
+	}
+	return f.Retry.validate()
+}
+
+// batchJob is implemented by every type of job.
+type batchJob interface {
+	// validate checks the job before it is started.
+	validate(ctx context.Context, o ObjectLayer) error
+	// bucket returns the bucket the job works on.
+	bucket() string
+	notification() BatchJobNotification
+	// run processes the objects after the object recorded in ri,
+	// recording its progress in ri.
+	run(ctx context.Context, o ObjectLayer, ri *batchJobInfo) error
+}
+
+// BatchJobRequest is a batch job as submitted, exactly one of the
+// types of job is set.
+type BatchJobRequest struct {
+	ID        string               `yaml:"-" json:"id"`
+	User      string               `yaml:"-" json:"user"`
+	Started   time.Time            `yaml:"-" json:"started"`
+	Location  string               `yaml:"-" json:"location"`
+	Replicate *BatchJobReplicateV1 `yaml:"replicate,omitempty" json:"replicate,omitempty"`
+	Expire    *BatchJobExpireV1    `yaml:"expire,omitempty" json:"expire,omitempty"`
+	KeyRotate *BatchJobKeyRotateV1 `yaml:"keyrotate,omitempty" json:"keyrotate,omitempty"`
+}
+
+// parseBatchJobRequest decodes a job description, JSON is accepted as
+// it is valid YAML.
+func parseBatchJobRequest(data []byte) (*BatchJobRequest, error) {
+	var req BatchJobRequest
+	if err := yaml.UnmarshalStrict(data, &req); err != nil {
+		return nil, errInvalidBatchJob("unable to parse the job: %v", err)
+	}
+	n := 0
+	for _, set := range []bool{req.Replicate != nil, req.Expire != nil, req.KeyRotate != nil} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, errInvalidBatchJob("a job must be exactly one of %v", madmin.SupportedJobTypes)
+	}
+	return &req, nil
+}
+
+// Type returns the type of the job.
+func (j BatchJobRequest) Type() madmin.BatchJobType {
+	switch {
+	case j.Replicate != nil:
+		return madmin.BatchJobReplicate
+	case j.Expire != nil:
+		return madmin.BatchJobExpire
+	case j.KeyRotate != nil:
+		return madmin.BatchJobKeyRotate
+	}
+	return ""
+}
+
+func (j BatchJobRequest) job() batchJob {
+	switch {
+	case j.Replicate != nil:
+		return j.Replicate
+	case j.Expire != nil:
+		return j.Expire
+	case j.KeyRotate != nil:
+		return j.KeyRotate
+	}
+	return nil
+}
+
+// withoutSecrets returns a copy of the job without its secrets.
+func (j BatchJobRequest) withoutSecrets() *BatchJobRequest {
+	if j.Replicate != nil {
+		r := *j.Replicate
+		r.Target.Credentials.SecretKey = batchJobRedacted
+		if r.Target.Credentials.SessionToken != "" {
+			r.Target.Credentials.SessionToken = batchJobRedacted
+		}
+		j.Replicate = &r
+	}
+	return &j
+}
+
+// secretsRedacted returns true if the job has been saved without
+// its secrets, it cannot be resumed then.
+func (j BatchJobRequest) secretsRedacted() bool {
+	return j.Replicate != nil && j.Replicate.Target.Credentials.SecretKey == batchJobRedacted
+}
+
+// redact returns the description of the job without its secrets.
+func (j BatchJobRequest) redact() ([]byte, error) {
+	return yaml.Marshal(j.withoutSecrets())
+}
+
+// batchJobInfo is the progress of a running job.
+type batchJobInfo struct {
+	mu     sync.RWMutex
+	status madmin.BatchJobStatus
+}
+
+func (ri *batchJobInfo) snapshot() madmin.BatchJobStatus {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return ri.status
+}
+
+// marker returns the last object processed, the job resumes after it.
+func (ri *batchJobInfo) marker() string {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return ri.status.Object
+}
+
+// checkpoint records that the objects up to object are processed.
+func (ri *batchJobInfo) checkpoint(bucket, object string) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	ri.status.Bucket = bucket
+	ri.status.Object = object
+	ri.status.LastUpdate = UTCNow()
+}
+
+// track records an object processed.
+func (ri *batchJobInfo) track(size int64, err error) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	if err != nil {
+		ri.status.ObjectsFailed++
+		ri.status.BytesFailed += size
+	} else {
+		ri.status.Objects++
+		ri.status.BytesTransferred += size
+	}
+	ri.status.LastUpdate = UTCNow()
+}
+
+func (ri *batchJobInfo) retried() {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	ri.status.RetryAttempts++
+}
+
+// finish records how the job ended.
+func (ri *batchJobInfo) finish(canceled bool, err error) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	switch {
+	case canceled:
+		ri.status.Canceled = true
+	case err != nil || ri.status.ObjectsFailed > 0:
+		ri.status.Failed = true
+	default:
+		ri.status.Complete = true
+	}
+	ri.status.LastUpdate = UTCNow()
+}
+
+// batchJobState is a job and its progress, as saved in .minio.sys.
+type batchJobState struct {
+	Version int                   `json:"version"`
+	Request *BatchJobRequest      `json:"request"`
+	Status  madmin.BatchJobStatus `json:"status"`
+}
+
+func (s batchJobState) finished() bool {
+	return s.Status.Complete || s.Status.Failed || s.Status.Canceled
+}
+
+func batchJobPath(id string) string {
+	return pathJoin(batchJobPrefix, id+".json")
+}
+
+func saveBatchJobState(ctx context.Context, o objectIO, s batchJobState) error {
+	// The job may hold the credentials of a remote endpoint, they
+	// are only saved encrypted like the server configuration.
+	if !globalConfigEncrypted {
+		s.Request = s.Request.withoutSecrets()
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, o, batchJobPath(s.Request.ID), data)
+}
+
+func loadBatchJobState(ctx context.Context, o objectIO, id string) (batchJobState, error) {
+	data, err := readConfig(ctx, o, batchJobPath(id))
+	if err != nil {
+		if err == errConfigNotFound {
+			err = errBatchJobNotFound
+		}
+		return batchJobState{}, err
+	}
+	if globalConfigEncrypted && !utf8.Valid(data) {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			if err == madmin.ErrMaliciousData {
+				return batchJobState{}, config.ErrInvalidCredentialsBackendEncrypted(nil)
+			}
+			return batchJobState{}, err
+		}
+	}
+	var s batchJobState
+	if err = json.Unmarshal(data, &s); err != nil {
+		return batchJobState{}, err
+	}
+	if s.Version != batchJobStateVersionV1 {
+		return batchJobState{}, fmt.Errorf("unexpected batch job %s version: %d", id, s.Version)
+	}
+	if s.Request == nil || s.Request.job() == nil {
+		return batchJobState{}, fmt.Errorf("batch job %s is corrupted", id)
+	}
+	return s, nil
+}
+
+// listBatchJobStates returns all the jobs, running and finished.
+func listBatchJobStates(ctx context.Context, o ObjectLayer) ([]batchJobState, error) {
+	var states []batchJobState
+	marker := ""
+	for {
+		res, err := o.ListObjects(ctx, minioMetaBucket, batchJobPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			id := strings.TrimSuffix(strings.TrimPrefix(obj.Name, batchJobPrefix+SlashSeparator), ".json")
+			s, err := loadBatchJobState(ctx, o, id)
+			if err != nil {
+				if err != errBatchJobNotFound {
+					logger.LogIf(ctx, err)
+				}
+				continue
+			}
+			states = append(states, s)
+		}
+		if !res.IsTruncated {
+			return states, nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+// batchJobRun is a job running on this server.
+type batchJobRun struct {
+	req      *BatchJobRequest
+	info     *batchJobInfo
+	cancel   context.CancelFunc
+	canceled bool
+}
+
+// BatchJobPool runs the batch jobs started on this server. A job is run
+// by the server it was submitted to, which resumes it after a restart.
+type BatchJobPool struct {
+	mu       sync.Mutex
+	objLayer ObjectLayer
+	jobs     map[string]*batchJobRun
+}
+
+// NewBatchJobPool returns an empty pool of batch jobs.
+func NewBatchJobPool() *BatchJobPool {
+	return &BatchJobPool{
+		jobs: make(map[string]*batchJobRun),
+	}
+}
+
+// Init resumes the jobs of this server interrupted by a restart.
+func (p *BatchJobPool) Init(ctx context.Context, objAPI ObjectLayer) error {
+	p.mu.Lock()
+	p.objLayer = objAPI
+	p.mu.Unlock()
+
+	states, err := listBatchJobStates(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	for _, s := range states {
+		if s.finished() || s.Request.Location != globalLocalNodeName {
+			continue
+		}
+		if s.Request.secretsRedacted() {
+			// The credentials of the remote endpoint have not been
+			// saved since the backend is not encrypted.
+			logger.LogIf(ctx, fmt.Errorf("batch job %s cannot be resumed without its credentials", s.Request.ID))
+			s.Status.Failed = true
+			s.Status.LastUpdate = UTCNow()
+			if err = saveBatchJobState(ctx, objAPI, s); err != nil {
+				logger.LogIf(ctx, err)
+			}
+			continue
+		}
+		p.start(s.Request, s.Status)
+	}
+	return nil
+}
+
+// submit validates the job, saves it and starts it.
+func (p *BatchJobPool) submit(ctx context.Context, req *BatchJobRequest) (madmin.BatchJobStatus, error) {
+	p.mu.Lock()
+	objAPI := p.objLayer
+	p.mu.Unlock()
+	if objAPI == nil {
+		return madmin.BatchJobStatus{}, errServerNotInitialized
+	}
+
+	if err := req.job().validate(ctx, objAPI); err != nil {
+		return madmin.BatchJobStatus{}, err
+	}
+
+	req.ID = mustGetUUID()
+	req.Started = UTCNow()
+	req.Location = globalLocalNodeName
+	status := madmin.BatchJobStatus{
+		ID:         req.ID,
+		Type:       req.Type(),
+		User:       req.User,
+		Node:       req.Location,
+		Started:    req.Started,
+		LastUpdate: req.Started,
+		Bucket:     req.job().bucket(),
+	}
+	s := batchJobState{
+		Version: batchJobStateVersion,
+		Request: req,
+		Status:  status,
+	}
+	if err := saveBatchJobState(ctx, objAPI, s); err != nil {
+		return madmin.BatchJobStatus{}, err
+	}
+	p.start(req, status)
+	return status, nil
+}
+
+// start runs the job in the background from its last checkpoint.
+func (p *BatchJobPool) start(req *BatchJobRequest, status madmin.BatchJobStatus) {
+	ctx, cancel := context.WithCancel(GlobalContext)
+	r := &batchJobRun{
+		req:    req,
+		info:   &batchJobInfo{status: status},
+		cancel: cancel,
+	}
+	p.mu.Lock()
+	p.jobs[req.ID] = r
+	p.mu.Unlock()
+	go p.run(ctx, r)
+}
+
+// cancel stops the job if it runs on this server.
+func (p *BatchJobPool) cancel(id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.jobs[id]
+	if !ok {
+		return false
+	}
+	r.canceled = true
+	r.cancel()
+	return true
+}
+
+// status returns the progress of the job if it runs on this server.
+func (p *BatchJobPool) status(id string) (madmin.BatchJobStatus, bool) {
+	p.mu.Lock()
+	r, ok := p.jobs[id]
+	p.mu.Unlock()
+	if !ok {
+		return madmin.BatchJobStatus{}, false
+	}
+	return r.info.snapshot(), true
+}
+
+func (p *BatchJobPool) save(ctx context.Context, r *batchJobRun) error {
+	return saveBatchJobState(ctx, p.objLayer, batchJobState{
+		Version: batchJobStateVersion,
+		Request: r.req,
+		Status:  r.info.snapshot(),
+	})
+}
+
+func (p *BatchJobPool) run(ctx context.Context, r *batchJobRun) {
+	defer func() {
+		p.mu.Lock()
+		delete(p.jobs, r.req.ID)
+		p.mu.Unlock()
+		r.cancel()
+	}()
+
+	saveCtx, stopSaving := context.WithCancel(ctx)
+	saveDone := make(chan struct{})
+	go func() {
+		defer close(saveDone)
+		ticker := time.NewTicker(batchJobSaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-saveCtx.Done():
+				return
+			case <-ticker.C:
+				logger.LogIf(ctx, p.save(ctx, r))
+			}
+		}
+	}()
+
+	job := r.req.job()
+	err := job.run(ctx, p.objLayer, r.info)
+	stopSaving()
+	<-saveDone
+
+	p.mu.Lock()
+	canceled := r.canceled
+	p.mu.Unlock()
+	if ctx.Err() != nil && !canceled {
+		// The server is stopping, the job resumes when it restarts.
+		logger.LogIf(GlobalContext, p.save(GlobalContext, r))
+		return
+	}
+	if err != nil && !canceled {
+		logger.LogIf(GlobalContext, fmt.Errorf("batch job %s failed: %w", r.req.ID, err))
+	}
+	r.info.finish(canceled, err)
+	logger.LogIf(GlobalContext, p.save(GlobalContext, r))
+	logger.LogIf(GlobalContext, job.notification().notify(GlobalContext, r.info.snapshot()))
+}
+
+// validateBatchJobReq checks the request, returns the object layer and
+// the credentials of the caller.
+func validateBatchJobReq(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) (ObjectLayer, auth.Credentials) {
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, auth.Credentials{}
+	}
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, action)
+	if objAPI == nil {
+		return nil, cred
+	}
+	if globalBatchJobPool == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return nil, cred
+	}
+	return objAPI, cred
+}
+
+// StartBatchJob - POST /minio/admin/v3/start-job
+//
+// Validates the job description in the body and starts the job.
+func (a adminAPIHandlers) StartBatchJob(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, cred := validateBatchJobReq(ctx, w, r, iampolicy.StartBatchJobAction)
+	if objAPI == nil {
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+	data, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	req, err := parseBatchJobRequest(data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	req.User = cred.AccessKey
+	if cred.ParentUser != "" {
+		req.User = cred.ParentUser
+	}
+
+	status, err := globalBatchJobPool.submit(ctx, req)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err = json.Marshal(madmin.BatchJobResult{
+		ID:      status.ID,
+		Type:    status.Type,
+		User:    status.User,
+		Started: status.Started,
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// batchJobStatus returns the progress of a job, live when it runs on
+// this server, as last saved otherwise.
+func batchJobStatus(s batchJobState) madmin.BatchJobStatus {
+	if status, ok := globalBatchJobPool.status(s.Request.ID); ok {
+		return status
+	}
+	return s.Status
+}
+
+// ListBatchJobs - GET /minio/admin/v3/list-jobs?jobType=replicate
+//
+// Lists the batch jobs, running and finished.
+func (a adminAPIHandlers) ListBatchJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBatchJobs")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateBatchJobReq(ctx, w, r, iampolicy.ListBatchJobsAction)
+	if objAPI == nil {
+		return
+	}
+
+	jobType := r.URL.Query().Get("jobType")
+	states, err := listBatchJobStates(ctx, objAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	res := madmin.ListBatchJobsResult{Jobs: []madmin.BatchJobStatus{}}
+	for _, s := range states {
+		if jobType != "" && string(s.Request.Type()) != jobType {
+			continue
+		}
+		res.Jobs = append(res.Jobs, batchJobStatus(s))
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// DescribeBatchJob - GET /minio/admin/v3/describe-job?jobId=id
+//
+// Returns the YAML description of a job, without its secrets.
+func (a adminAPIHandlers) DescribeBatchJob(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DescribeBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateBatchJobReq(ctx, w, r, iampolicy.DescribeBatchJobAction)
+	if objAPI == nil {
+		return
+	}
+
+	s, err := loadBatchJobState(ctx, objAPI, r.URL.Query().Get("jobId"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := s.Request.redact()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, data, mimeNone)
+}
+
+// BatchJobStatus - GET /minio/admin/v3/status-job?jobId=id
+//
+// Returns the progress of a job.
+func (a adminAPIHandlers) BatchJobStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BatchJobStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateBatchJobReq(ctx, w, r, iampolicy.DescribeBatchJobAction)
+	if objAPI == nil {
+		return
+	}
+
+	s, err := loadBatchJobState(ctx, objAPI, r.URL.Query().Get("jobId"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(batchJobStatus(s))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// CancelBatchJob - DELETE /minio/admin/v3/cancel-job?id=id
+//
+// Stops a running job on whichever server runs it.
+func (a adminAPIHandlers) CancelBatchJob(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI, _ := validateBatchJobReq(ctx, w, r, iampolicy.CancelBatchJobAction)
+	if objAPI == nil {
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	s, err := loadBatchJobState(ctx, objAPI, id)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if s.finished() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errBatchJobNotRunning), r.URL)
+		return
+	}
+
+	if !globalBatchJobPool.cancel(id) {
+		globalNotificationSys.CancelBatchJob(ctx, id)
+		if s.Request.Location != globalLocalNodeName {
+			// The server running the job may be down, make sure it
+			// does not resume the job.
+			s.Status.Canceled = true
+			s.Status.LastUpdate = UTCNow()
+			if err = saveBatchJobState(ctx, objAPI, s); err != nil {
+				writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+				return
+			}
+		}
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
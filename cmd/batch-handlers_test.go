@@ -0,0 +1,618 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseBatchJobRequest(t *testing.T) {
+	testCases := []struct {
+		job     string
+		jobType madmin.BatchJobType
+		success bool
+	}{
+		{
+			job: `
+replicate:
+  apiVersion: v1
+  source:
+    bucket: photos
+    prefix: 2021/
+  target:
+    endpoint: https://play.min.io
+    bucket: backup
+    credentials:
+      accessKey: minio
+      secretKey: minio123
+  flags:
+    filter:
+      newerThan: 168h
+      createdAfter: 2021-01-01T00:00:00Z
+      tags:
+        - key: project
+          value: "alpha*"
+    notify:
+      endpoint: https://notify.example.com
+      token: Bearer xxx
+    retry:
+      attempts: 5
+      delay: 1s
+`,
+			jobType: madmin.BatchJobReplicate,
+			success: true,
+		},
+		{
+			job: `
+expire:
+  apiVersion: v1
+  bucket: logs
+  rules:
+    - type: object
+      name: "*.tmp"
+      olderThan: 720h
+      size:
+        greaterThan: 1MiB
+    - type: deleted
+`,
+			jobType: madmin.BatchJobExpire,
+			success: true,
+		},
+		{
+			job:     `{"keyrotate": {"apiVersion": "v1", "bucket": "secrets", "encryption": {"type": "sse-kms", "key": "my-key"}}}`,
+			jobType: madmin.BatchJobKeyRotate,
+			success: true,
+		},
+		// Two jobs in one.
+		{
+			job: `
+expire:
+  apiVersion: v1
+  bucket: logs
+keyrotate:
+  apiVersion: v1
+  bucket: logs
+`,
+		},
+		// No job.
+		{
+			job: `{}`,
+		},
+		// Unknown field.
+		{
+			job: `
+expire:
+  apiVersion: v1
+  bucket: logs
+  olderThan: 720h
+`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		req, err := parseBatchJobRequest([]byte(testCase.job))
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err != nil {
+			continue
+		}
+		if req.Type() != testCase.jobType {
+			t.Fatalf("Test %d: expected job type %s, got %s", i+1, testCase.jobType, req.Type())
+		}
+	}
+
+	req, err := parseBatchJobRequest([]byte(testCases[0].job))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := req.Replicate
+	if r.Flags.Filter.NewerThan != 168*time.Hour || r.Flags.Retry.Delay != time.Second || r.Flags.Retry.Attempts != 5 {
+		t.Fatalf("unexpected flags %#v", r.Flags)
+	}
+	if !r.Flags.Filter.CreatedAfter.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected createdAfter %s", r.Flags.Filter.CreatedAfter)
+	}
+
+	// The description of a job does not hold its secrets.
+	data, err := req.redact()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("minio123")) {
+		t.Fatalf("secret key is not redacted:\n%s", data)
+	}
+	described, err := parseBatchJobRequest(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if described.Replicate.Target.Credentials.AccessKey != "minio" || described.Replicate.Flags.Filter.Tags[0].Value != "alpha*" ||
+		!described.Replicate.Flags.Filter.CreatedAfter.Equal(r.Flags.Filter.CreatedAfter) {
+		t.Fatalf("unexpected description:\n%s", data)
+	}
+
+	req, err = parseBatchJobRequest([]byte(testCases[1].job))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Expire.Rules[0].Size.GreaterThan != 1<<20 {
+		t.Fatalf("unexpected size %d", req.Expire.Rules[0].Size.GreaterThan)
+	}
+}
+
+func TestBatchJobFilterMatch(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	oi := ObjectInfo{
+		Name:     "photos/2021/a.jpg",
+		ModTime:  now.Add(-48 * time.Hour),
+		UserTags: "project=alpha-1&env=prod",
+		UserDefined: map[string]string{
+			"X-Amz-Meta-Owner": "alice",
+			crypto.MetaKeyID:   "key-1",
+		},
+	}
+
+	testCases := []struct {
+		filter BatchJobFilter
+		match  bool
+	}{
+		{BatchJobFilter{}, true},
+		{BatchJobFilter{NewerThan: 72 * time.Hour}, true},
+		{BatchJobFilter{NewerThan: 24 * time.Hour}, false},
+		{BatchJobFilter{OlderThan: 24 * time.Hour}, true},
+		{BatchJobFilter{OlderThan: 72 * time.Hour}, false},
+		{BatchJobFilter{CreatedAfter: now.Add(-72 * time.Hour), CreatedBefore: now}, true},
+		{BatchJobFilter{CreatedBefore: now.Add(-72 * time.Hour)}, false},
+		{BatchJobFilter{Tags: []BatchJobKV{{Key: "project", Value: "alpha*"}, {Key: "env"}}}, true},
+		{BatchJobFilter{Tags: []BatchJobKV{{Key: "project", Value: "beta*"}}}, false},
+		{BatchJobFilter{Tags: []BatchJobKV{{Key: "owner"}}}, false},
+		{BatchJobFilter{Metadata: []BatchJobKV{{Key: "owner", Value: "alice"}}}, true},
+		{BatchJobFilter{Metadata: []BatchJobKV{{Key: "x-amz-meta-owner", Value: "a*"}}}, true},
+		{BatchJobFilter{Metadata: []BatchJobKV{{Key: "owner", Value: "bob"}}}, false},
+		{BatchJobFilter{KMSKeyID: "key-1"}, true},
+		{BatchJobFilter{KMSKeyID: "key-2"}, false},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.filter.validate(); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if match := testCase.filter.match(oi, now); match != testCase.match {
+			t.Fatalf("Test %d: expected match %v, got %v", i+1, testCase.match, match)
+		}
+	}
+
+	if err := (BatchJobFilter{NewerThan: time.Hour, OlderThan: 2 * time.Hour}).validate(); err == nil {
+		t.Fatal("expected no object to be older than 2h and newer than 1h")
+	}
+}
+
+func TestBatchJobExpireRuleMatch(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	obj := ObjectInfo{
+		Name:     "logs/a.tmp",
+		ModTime:  now.Add(-48 * time.Hour),
+		Size:     2 << 20,
+		UserTags: "env=tmp",
+	}
+	marker := ObjectInfo{
+		Name:         "logs/b.log",
+		ModTime:      now.Add(-48 * time.Hour),
+		DeleteMarker: true,
+	}
+
+	testCases := []struct {
+		rule       BatchJobExpireRule
+		oi         ObjectInfo
+		versionIdx int
+		match      bool
+	}{
+		{BatchJobExpireRule{Type: batchExpireObject}, obj, 0, true},
+		{BatchJobExpireRule{Type: batchExpireObject}, marker, 0, false},
+		{BatchJobExpireRule{Type: batchExpireDeleted}, marker, 0, true},
+		{BatchJobExpireRule{Type: batchExpireDeleted}, obj, 0, false},
+		{BatchJobExpireRule{Type: batchExpireObject, Name: "*.tmp", OlderThan: 24 * time.Hour}, obj, 0, true},
+		{BatchJobExpireRule{Type: batchExpireObject, Name: "*.log"}, obj, 0, false},
+		{BatchJobExpireRule{Type: batchExpireDeleted, OlderThan: 72 * time.Hour}, marker, 0, false},
+		{BatchJobExpireRule{Type: batchExpireObject, CreatedBefore: now.Add(-24 * time.Hour)}, obj, 0, true},
+		{BatchJobExpireRule{Type: batchExpireObject, Tags: []BatchJobKV{{Key: "env", Value: "tmp"}}}, obj, 0, true},
+		{BatchJobExpireRule{Type: batchExpireObject, Tags: []BatchJobKV{{Key: "env", Value: "prod"}}}, obj, 0, false},
+		{BatchJobExpireRule{Type: batchExpireObject, Size: BatchJobSizeFilter{GreaterThan: 1 << 20}}, obj, 0, true},
+		{BatchJobExpireRule{Type: batchExpireObject, Size: BatchJobSizeFilter{LessThan: 1 << 20}}, obj, 0, false},
+		{BatchJobExpireRule{Type: batchExpireObject, Purge: BatchJobExpirePurge{RetainVersions: 1}}, obj, 0, false},
+		{BatchJobExpireRule{Type: batchExpireObject, Purge: BatchJobExpirePurge{RetainVersions: 1}}, obj, 1, true},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.rule.validate(); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if match := testCase.rule.match(testCase.oi, testCase.versionIdx, now); match != testCase.match {
+			t.Fatalf("Test %d: expected match %v, got %v", i+1, testCase.match, match)
+		}
+	}
+
+	if err := (BatchJobExpireRule{Type: batchExpireDeleted, Tags: []BatchJobKV{{Key: "env"}}}).validate(); err == nil {
+		t.Fatal("expected delete markers not to be selected by tags")
+	}
+}
+
+// runBatchJob starts the job and waits for it to end.
+func runBatchJob(ctx context.Context, t *testing.T, job string) madmin.BatchJobStatus {
+	t.Helper()
+
+	req, err := parseBatchJobRequest([]byte(job))
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := globalBatchJobPool.submit(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 300; i++ {
+		if _, ok := globalBatchJobPool.status(status.ID); !ok {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	s, err := loadBatchJobState(ctx, globalBatchJobPool.objLayer, status.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.finished() {
+		t.Fatalf("batch job %s did not end: %#v", status.ID, s.Status)
+	}
+	return s.Status
+}
+
+func TestBatchJobExpire(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+	defer objLayer.Shutdown(context.Background())
+
+	// Listings look up the object layer.
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(objLayer)
+
+	globalBatchJobPool = NewBatchJobPool()
+	defer func() { globalBatchJobPool = nil }()
+	if err = globalBatchJobPool.Init(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "logs"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	objects := map[string]string{
+		"app/a.tmp": "env=tmp",
+		"app/b.log": "env=tmp",
+		"app/c.tmp": "env=prod",
+		"app/d.tmp": "",
+		"web/e.tmp": "env=tmp",
+	}
+	for object, tags := range objects {
+		data := []byte(object)
+		opts := ObjectOptions{UserDefined: map[string]string{xhttp.AmzObjectTagging: tags}}
+		if _, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	status := runBatchJob(ctx, t, `
+expire:
+  apiVersion: v1
+  bucket: logs
+  prefix: app/
+  rules:
+    - type: object
+      name: "*.tmp"
+      tags:
+        - key: env
+          value: tmp
+`)
+	if !status.Complete || status.Objects != 1 || status.ObjectsFailed != 0 {
+		t.Fatalf("unexpected status %#v", status)
+	}
+	if status.Object != "app/d.tmp" {
+		t.Fatalf("expected the job to checkpoint the last object, got %s", status.Object)
+	}
+
+	for object := range objects {
+		_, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if object == "app/a.tmp" {
+			if !isErrObjectNotFound(err) {
+				t.Fatalf("expected %s to be expired, got %v", object, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected %s to be kept, got %v", object, err)
+		}
+	}
+
+	res, err := listBatchJobStates(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].Status.ID != status.ID || res[0].Request.Type() != madmin.BatchJobExpire {
+		t.Fatalf("unexpected jobs %#v", res)
+	}
+}
+
+func TestBatchJobKeyRotate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+	defer objLayer.Shutdown(context.Background())
+
+	// Listings look up the object layer.
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(objLayer)
+
+	os.Setenv("MINIO_KMS_MASTER_KEY", "my-minio-key:6368616e676520746869732070617373776f726420746f206120736563726574")
+	GlobalKMS, err = crypto.NewKMS(crypto.KMSConfig{})
+	os.Setenv("MINIO_KMS_MASTER_KEY", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { GlobalKMS = nil }()
+
+	globalBatchJobPool = NewBatchJobPool()
+	defer func() { globalBatchJobPool = nil }()
+	if err = globalBatchJobPool.Init(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, object := "secrets", "object"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("secret"), 1000)
+	metadata := map[string]string{}
+	reader, _, err := newEncryptReader(bytes.NewReader(data), crypto.S3, "", nil, bucket, object, metadata, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, reader, -1, "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+		t.Fatal(err)
+	}
+
+	oi, err := objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealedKey := oi.UserDefined[crypto.MetaSealedKeyS3]
+
+	// Only the keys of SSE-KMS encrypted objects are rotated.
+	status := runBatchJob(ctx, t, `
+keyrotate:
+  apiVersion: v1
+  bucket: secrets
+  encryption:
+    type: sse-kms
+    key: my-minio-key
+`)
+	if !status.Complete || status.Objects != 0 || status.ObjectsFailed != 0 {
+		t.Fatalf("unexpected status %#v", status)
+	}
+
+	status = runBatchJob(ctx, t, `
+keyrotate:
+  apiVersion: v1
+  bucket: secrets
+  encryption:
+    type: sse-s3
+`)
+	if !status.Complete || status.Objects != 1 || status.ObjectsFailed != 0 {
+		t.Fatalf("unexpected status %#v", status)
+	}
+
+	gr, err := objLayer.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+	if !crypto.S3.IsEncrypted(gr.ObjInfo.UserDefined) {
+		t.Fatalf("expected the object to be encrypted with SSE-S3, got %v", gr.ObjInfo.UserDefined)
+	}
+	if gr.ObjInfo.UserDefined[crypto.MetaSealedKeyS3] == sealedKey {
+		t.Fatal("expected the object key to be sealed again")
+	}
+	got, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("the object cannot be decrypted after the key rotation")
+	}
+}
+
+func TestBatchJobKeyRotateKMSContext(t *testing.T) {
+	os.Setenv("MINIO_KMS_MASTER_KEY", "my-minio-key:6368616e676520746869732070617373776f726420746f206120736563726574")
+	kms, err := crypto.NewKMS(crypto.KMSConfig{})
+	os.Setenv("MINIO_KMS_MASTER_KEY", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	GlobalKMS = kms
+	defer func() { GlobalKMS = nil }()
+
+	bucket, object := "secrets", "object"
+	key, err := kms.GenerateKey("my-minio-key", crypto.Context{bucket: path.Join(bucket, object)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectKey := crypto.GenerateKey(key.Plaintext, rand.Reader)
+	sealedKey := objectKey.Seal(key.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), bucket, object)
+	metadata := crypto.S3KMS.CreateMetadata(nil, key.KeyID, key.Ciphertext, sealedKey)
+
+	r := &BatchJobKeyRotateV1{
+		Encryption: BatchJobKeyRotateEncryption{
+			Type:    batchKeyRotateSSEKMS,
+			Key:     "my-minio-key",
+			Context: base64.StdEncoding.EncodeToString([]byte(`{"project":"alpha"}`)),
+		},
+	}
+	if err = r.rotateKey(bucket, object, metadata); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, kmsCtx, err := crypto.S3KMS.ParseMetadata(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kmsCtx["project"] != "alpha" || kmsCtx[bucket] != path.Join(bucket, object) {
+		t.Fatalf("unexpected KMS context %v", kmsCtx)
+	}
+	got, err := crypto.S3KMS.UnsealObjectKey(kms, metadata, bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != objectKey {
+		t.Fatal("the object key changed after the key rotation")
+	}
+
+	// The key of an SSE-KMS object is not rotated by an SSE-S3 job.
+	r.Encryption = BatchJobKeyRotateEncryption{Type: batchKeyRotateSSES3}
+	if err = r.rotateKey(bucket, object, metadata); !isErrPreconditionFailed(err) {
+		t.Fatalf("expected a precondition failure, got %v", err)
+	}
+}
+
+func TestBatchJobStateSecrets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+	defer objLayer.Shutdown(context.Background())
+
+	// Listings look up the object layer.
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(objLayer)
+
+	defer func(encrypted bool, cred auth.Credentials) {
+		globalConfigEncrypted, globalActiveCred = encrypted, cred
+	}(globalConfigEncrypted, globalActiveCred)
+	globalActiveCred = auth.Credentials{AccessKey: "minioadmin", SecretKey: "minioadmin"}
+
+	req, err := parseBatchJobRequest([]byte(`
+replicate:
+  apiVersion: v1
+  source:
+    bucket: photos
+  target:
+    endpoint: https://play.min.io
+    bucket: backup
+    credentials:
+      accessKey: minio
+      secretKey: remote-secret-key
+      sessionToken: remote-session-token
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Location = globalLocalNodeName
+	s := batchJobState{
+		Version: batchJobStateVersion,
+		Request: req,
+		Status:  madmin.BatchJobStatus{Type: req.Type()},
+	}
+	rawState := func(id string) []byte {
+		t.Helper()
+		data, err := readConfig(ctx, objLayer, batchJobPath(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(data, []byte("remote-secret-key")) || bytes.Contains(data, []byte("remote-session-token")) {
+			t.Fatalf("the credentials are saved in plaintext:\n%s", data)
+		}
+		return data
+	}
+
+	// An encrypted backend saves the credentials encrypted.
+	globalConfigEncrypted = true
+	req.ID = "encrypted"
+	if err = saveBatchJobState(ctx, objLayer, s); err != nil {
+		t.Fatal(err)
+	}
+	if data := rawState(req.ID); utf8.Valid(data) {
+		t.Fatalf("the job is not encrypted:\n%s", data)
+	}
+	loaded, err := loadBatchJobState(ctx, objLayer, req.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds := loaded.Request.Replicate.Target.Credentials; creds.SecretKey != "remote-secret-key" || creds.SessionToken != "remote-session-token" {
+		t.Fatalf("unexpected credentials %+v", creds)
+	}
+	if err = deleteConfig(ctx, objLayer, batchJobPath(req.ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Otherwise the credentials are not saved, the job cannot be resumed.
+	globalConfigEncrypted = false
+	req.ID = "redacted"
+	if err = saveBatchJobState(ctx, objLayer, s); err != nil {
+		t.Fatal(err)
+	}
+	rawState(req.ID)
+	if req.Replicate.Target.Credentials.SecretKey != "remote-secret-key" {
+		t.Fatal("saving the job redacted the running job")
+	}
+	globalBatchJobPool = NewBatchJobPool()
+	defer func() { globalBatchJobPool = nil }()
+	if err = globalBatchJobPool.Init(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalBatchJobPool.status(req.ID); ok {
+		t.Fatal("a job without credentials was resumed")
+	}
+	if loaded, err = loadBatchJobState(ctx, objLayer, req.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Status.Failed || !loaded.Request.secretsRedacted() {
+		t.Fatalf("expected a failed job without credentials, got %+v", loaded.Status)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// BatchJobCredentials are the credentials of a remote S3 endpoint.
+type BatchJobCredentials struct {
+	AccessKey    string `yaml:"accessKey" json:"accessKey"`
+	SecretKey    string `yaml:"secretKey" json:"secretKey"`
+	SessionToken string `yaml:"sessionToken,omitempty" json:"sessionToken"`
+}
+
+// BatchJobReplicateSource is the bucket, and optionally the prefix, of
+// the objects copied.
+type BatchJobReplicateSource struct {
+	Bucket string `yaml:"bucket" json:"bucket"`
+	Prefix string `yaml:"prefix,omitempty" json:"prefix"`
+}
+
+// BatchJobReplicateTarget is the remote bucket the objects are copied
+// to, under the prefix when set.
+type BatchJobReplicateTarget struct {
+	Endpoint    string              `yaml:"endpoint" json:"endpoint"`
+	Region      string              `yaml:"region,omitempty" json:"region"`
+	Bucket      string              `yaml:"bucket" json:"bucket"`
+	Prefix      string              `yaml:"prefix,omitempty" json:"prefix"`
+	Credentials BatchJobCredentials `yaml:"credentials" json:"credentials"`
+}
+
+func (t BatchJobReplicateTarget) client() (*miniogo.Client, error) {
+	u, err := url.Parse(t.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != SlashSeparator) {
+		return nil, errInvalidBatchJob("invalid target endpoint %q", t.Endpoint)
+	}
+
+	getRemoteTargetInstanceTransportOnce.Do(func() {
+		getRemoteTargetInstanceTransport = NewRemoteTargetHTTPTransport()
+	})
+	return miniogo.New(u.Host, &miniogo.Options{
+		Creds:     credentials.NewStaticV4(t.Credentials.AccessKey, t.Credentials.SecretKey, t.Credentials.SessionToken),
+		Secure:    u.Scheme == "https",
+		Region:    t.Region,
+		Transport: getRemoteTargetInstanceTransport,
+	})
+}
+
+// BatchJobReplicateV1 copies the latest version of the objects of a
+// bucket to a bucket of a remote S3 endpoint.
+type BatchJobReplicateV1 struct {
+	APIVersion string                  `yaml:"apiVersion" json:"apiVersion"`
+	Source     BatchJobReplicateSource `yaml:"source" json:"source"`
+	Target     BatchJobReplicateTarget `yaml:"target" json:"target"`
+	Flags      BatchJobFlags           `yaml:"flags,omitempty" json:"flags"`
+}
+
+func (r *BatchJobReplicateV1) bucket() string {
+	return r.Source.Bucket
+}
+
+func (r *BatchJobReplicateV1) notification() BatchJobNotification {
+	return r.Flags.Notify
+}
+
+func (r *BatchJobReplicateV1) validate(ctx context.Context, o ObjectLayer) error {
+	if r.APIVersion != batchJobAPIVersionV1 {
+		return errInvalidBatchJob("unsupported apiVersion %q", r.APIVersion)
+	}
+	if r.Source.Bucket == "" {
+		return errInvalidBatchJob("source bucket is missing")
+	}
+	if _, err := o.GetBucketInfo(ctx, r.Source.Bucket); err != nil {
+		return err
+	}
+	if r.Target.Bucket == "" {
+		return errInvalidBatchJob("target bucket is missing")
+	}
+	if r.Target.Credentials.AccessKey == "" || r.Target.Credentials.SecretKey == "" {
+		return errInvalidBatchJob("target credentials are missing")
+	}
+	if err := r.Flags.validate(); err != nil {
+		return err
+	}
+
+	c, err := r.Target.client()
+	if err != nil {
+		return err
+	}
+	ok, err := c.BucketExists(ctx, r.Target.Bucket)
+	if err != nil {
+		return errInvalidBatchJob("unable to reach the target bucket: %v", err)
+	}
+	if !ok {
+		return errInvalidBatchJob("target bucket %s does not exist", r.Target.Bucket)
+	}
+	return nil
+}
+
+// targetObject returns the name of the copy of the object.
+func (r *BatchJobReplicateV1) targetObject(object string) string {
+	if r.Target.Prefix == "" {
+		return object
+	}
+	return pathJoin(r.Target.Prefix, object)
+}
+
+// batchReplicateOpts returns the options copying the user metadata and
+// the tags of the object.
+func batchReplicateOpts(oi ObjectInfo) miniogo.PutObjectOptions {
+	meta := make(map[string]string)
+	for k, v := range oi.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			meta[k] = v
+		}
+	}
+	opts := miniogo.PutObjectOptions{
+		UserMetadata:    meta,
+		UserTags:        batchJobObjectTags(oi),
+		ContentType:     oi.ContentType,
+		ContentEncoding: oi.ContentEncoding,
+	}
+	lkMap := caseInsensitiveMap(oi.UserDefined)
+	if lang, ok := lkMap.Lookup(xhttp.ContentLanguage); ok {
+		opts.ContentLanguage = lang
+	}
+	if disp, ok := lkMap.Lookup(xhttp.ContentDisposition); ok {
+		opts.ContentDisposition = disp
+	}
+	if cc, ok := lkMap.Lookup(xhttp.CacheControl); ok {
+		opts.CacheControl = cc
+	}
+	return opts
+}
+
+// copyObject copies a version of an object to the target, decrypted
+// and decompressed.
+func (r *BatchJobReplicateV1) copyObject(ctx context.Context, o ObjectLayer, c *miniogo.Client, oi ObjectInfo) error {
+	if crypto.SSEC.IsEncrypted(oi.UserDefined) {
+		return errBatchJobSSEC
+	}
+	gr, err := o.GetObjectNInfo(ctx, oi.Bucket, oi.Name, nil, http.Header{}, readLock, ObjectOptions{VersionID: oi.VersionID})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	size, err := gr.ObjInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+	_, err = c.PutObject(ctx, r.Target.Bucket, r.targetObject(oi.Name), gr, size, batchReplicateOpts(gr.ObjInfo))
+	return err
+}
+
+func (r *BatchJobReplicateV1) run(ctx context.Context, o ObjectLayer, ri *batchJobInfo) error {
+	c, err := r.Target.client()
+	if err != nil {
+		return err
+	}
+
+	now := UTCNow()
+	marker := ri.marker()
+	for {
+		loi, err := o.ListObjects(ctx, r.Source.Bucket, r.Source.Prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range loi.Objects {
+			if r.Flags.Filter.match(oi, now) {
+				size, _ := oi.GetActualSize()
+				err := r.Flags.Retry.do(ctx, ri, func() error {
+					return r.copyObject(ctx, o, c, oi)
+				})
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				switch {
+				case err == nil:
+					ri.track(size, nil)
+				case isErrObjectNotFound(err), isErrVersionNotFound(err):
+					// Deleted since listed.
+				default:
+					logger.LogIf(ctx, fmt.Errorf("batch job: unable to replicate %s/%s: %w", oi.Bucket, oi.Name, err))
+					ri.track(size, err)
+				}
+			}
+			ri.checkpoint(r.Source.Bucket, oi.Name)
+		}
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker = loi.NextMarker
+	}
+}
@@ -0,0 +1,258 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// Types of encryption of the objects whose keys are rotated.
+const (
+	batchKeyRotateSSES3  = "sse-s3"
+	batchKeyRotateSSEKMS = "sse-kms"
+)
+
+// BatchJobKeyRotateEncryption selects the objects whose keys are rotated,
+// either SSE-S3 or SSE-KMS encrypted ones, and the KMS master key they
+// are sealed with: the default key when empty for SSE-S3, and for SSE-KMS
+// the base64 encoded JSON context bound to the new object keys.
+type BatchJobKeyRotateEncryption struct {
+	Type    string `yaml:"type" json:"type"`
+	Key     string `yaml:"key,omitempty" json:"key"`
+	Context string `yaml:"context,omitempty" json:"context"`
+}
+
+func (e BatchJobKeyRotateEncryption) kmsContext() (crypto.Context, error) {
+	kmsCtx := crypto.Context{}
+	if e.Context == "" {
+		return kmsCtx, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(e.Context)
+	if err != nil {
+		return nil, errInvalidBatchJob("the KMS context is not base64 encoded")
+	}
+	if err = json.Unmarshal(b, &kmsCtx); err != nil {
+		return nil, errInvalidBatchJob("the KMS context is not a JSON object of strings")
+	}
+	return kmsCtx, nil
+}
+
+func (e BatchJobKeyRotateEncryption) validate() error {
+	switch e.Type {
+	case batchKeyRotateSSES3:
+		if e.Context != "" {
+			return errInvalidBatchJob("a KMS context is only allowed with %s", batchKeyRotateSSEKMS)
+		}
+	case batchKeyRotateSSEKMS:
+		if e.Key == "" {
+			return errInvalidBatchJob("the KMS key is missing")
+		}
+	default:
+		return errInvalidBatchJob("unknown encryption type %q", e.Type)
+	}
+	if _, err := e.kmsContext(); err != nil {
+		return err
+	}
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+	if _, err := GlobalKMS.GenerateKey(e.Key, crypto.Context{}); err != nil {
+		return errInvalidBatchJob("unable to use the KMS key %q: %v", e.Key, err)
+	}
+	return nil
+}
+
+// BatchJobKeyRotateV1 seals again the keys of the objects of a bucket
+// encrypted with SSE-S3 or SSE-KMS with a new KMS key, the objects keep
+// their type of encryption and their data is not rewritten.
+type BatchJobKeyRotateV1 struct {
+	APIVersion string                      `yaml:"apiVersion" json:"apiVersion"`
+	Bucket     string                      `yaml:"bucket" json:"bucket"`
+	Prefix     string                      `yaml:"prefix,omitempty" json:"prefix"`
+	Encryption BatchJobKeyRotateEncryption `yaml:"encryption" json:"encryption"`
+	Flags      BatchJobFlags               `yaml:"flags,omitempty" json:"flags"`
+}
+
+func (r *BatchJobKeyRotateV1) bucket() string {
+	return r.Bucket
+}
+
+func (r *BatchJobKeyRotateV1) notification() BatchJobNotification {
+	return r.Flags.Notify
+}
+
+func (r *BatchJobKeyRotateV1) validate(ctx context.Context, o ObjectLayer) error {
+	if r.APIVersion != batchJobAPIVersionV1 {
+		return errInvalidBatchJob("unsupported apiVersion %q", r.APIVersion)
+	}
+	if r.Bucket == "" {
+		return errInvalidBatchJob("bucket is missing")
+	}
+	if _, err := o.GetBucketInfo(ctx, r.Bucket); err != nil {
+		return err
+	}
+	if err := r.Encryption.validate(); err != nil {
+		return err
+	}
+	return r.Flags.validate()
+}
+
+// encrypted returns true if the object is encrypted with the type of
+// encryption whose keys are rotated.
+func (r *BatchJobKeyRotateV1) encrypted(metadata map[string]string) bool {
+	if r.Encryption.Type == batchKeyRotateSSES3 {
+		return crypto.S3.IsEncrypted(metadata)
+	}
+	return crypto.S3KMS.IsEncrypted(metadata)
+}
+
+// rotateKey unseals the object key held in the metadata and seals it
+// with a key generated by the KMS. All the metadata entries set when the
+// object was encrypted are overwritten, so the metadata can be merged
+// into the one stored.
+func (r *BatchJobKeyRotateV1) rotateKey(bucket, object string, metadata map[string]string) error {
+	if !r.encrypted(metadata) {
+		return PreConditionFailed{}
+	}
+	kmsCtx, err := r.Encryption.kmsContext()
+	if err != nil {
+		return err
+	}
+	if _, ok := kmsCtx[bucket]; !ok {
+		kmsCtx[bucket] = path.Join(bucket, object)
+	}
+
+	if r.Encryption.Type == batchKeyRotateSSES3 {
+		objectKey, err := crypto.S3.UnsealObjectKey(GlobalKMS, metadata, bucket, object)
+		if err != nil {
+			return err
+		}
+		newKey, err := GlobalKMS.GenerateKey(r.Encryption.Key, kmsCtx)
+		if err != nil {
+			return err
+		}
+		sealedKey := objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3.String(), bucket, object)
+		crypto.S3.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey)
+		crypto.SetKeyVersion(metadata, newKey.Version)
+		return nil
+	}
+
+	objectKey, err := crypto.S3KMS.UnsealObjectKey(GlobalKMS, metadata, bucket, object)
+	if err != nil {
+		return err
+	}
+	newKey, err := GlobalKMS.GenerateKey(r.Encryption.Key, kmsCtx)
+	if err != nil {
+		return err
+	}
+	sealedKey := objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), bucket, object)
+	crypto.S3KMS.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey)
+	crypto.SetKeyVersion(metadata, newKey.Version)
+
+	// The context always holds the bucket entry, such that it
+	// replaces the context the object was encrypted with.
+	b, err := kmsCtx.MarshalText()
+	if err != nil {
+		return err
+	}
+	metadata[crypto.MetaContext] = base64.StdEncoding.EncodeToString(b)
+	return nil
+}
+
+// rotate rotates the key of a version of an object, unless the object
+// was overwritten since listed.
+func (r *BatchJobKeyRotateV1) rotate(ctx context.Context, o ObjectLayer, oi ObjectInfo) error {
+	_, err := o.PutObjectMetadata(ctx, oi.Bucket, oi.Name, ObjectOptions{
+		VersionID: oi.VersionID,
+		MTime:     oi.ModTime,
+		EvalMetadataFn: func(cur ObjectInfo, metadata map[string]string) error {
+			if !cur.ModTime.Equal(oi.ModTime) {
+				return PreConditionFailed{}
+			}
+			return r.rotateKey(oi.Bucket, oi.Name, metadata)
+		},
+	})
+	return err
+}
+
+func (r *BatchJobKeyRotateV1) run(ctx context.Context, o ObjectLayer, ri *batchJobInfo) error {
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+
+	now := UTCNow()
+	marker, versionIDMarker := ri.marker(), ""
+
+	// Versions are listed newest first, an object is checkpointed
+	// once all its versions are processed.
+	var object string
+	for {
+		loi, err := o.ListObjectVersions(ctx, r.Bucket, r.Prefix, marker, versionIDMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range loi.Objects {
+			if oi.Name != object {
+				if object != "" {
+					ri.checkpoint(r.Bucket, object)
+				}
+				object = oi.Name
+			}
+			if oi.DeleteMarker || !r.encrypted(oi.UserDefined) {
+				continue
+			}
+			if !r.Flags.Filter.match(oi, now) {
+				continue
+			}
+
+			size, _ := oi.GetActualSize()
+			err := r.Flags.Retry.do(ctx, ri, func() error {
+				return r.rotate(ctx, o, oi)
+			})
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			switch {
+			case err == nil:
+				ri.track(size, nil)
+			case isErrObjectNotFound(err), isErrVersionNotFound(err):
+				// Deleted since listed.
+			case isErrPreconditionFailed(err):
+				// Overwritten since listed, with the current key.
+			default:
+				logger.LogIf(ctx, fmt.Errorf("batch job: unable to rotate the key of %s/%s (%s): %w", oi.Bucket, oi.Name, oi.VersionID, err))
+				ri.track(size, err)
+			}
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker, versionIDMarker = loi.NextMarker, loi.NextVersionIDMarker
+	}
+	if object != "" {
+		ri.checkpoint(r.Bucket, object)
+	}
+	return nil
+}
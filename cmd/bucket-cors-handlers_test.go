@@ -0,0 +1,119 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+// Test S3 Bucket CORS APIs and their enforcement.
+func TestBucketCors(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketCorsHandlers, []string{"GetBucketCors", "PutBucketCors", "DeleteBucketCors"})
+}
+
+// Tests are related and the order is important.
+func testBucketCorsHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	corsConfig := `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedMethod>GET</AllowedMethod><AllowedHeader>Content-*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`
+
+	do := func(method string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, getBucketCorsURL("", bucketName),
+			int64(len(body)), bytes.NewReader(body), creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	preflight := func(origin, method, headers string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodOptions, makeTestTargetURL("", bucketName, "object", nil), nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set(xhttp.Origin, origin)
+		req.Header.Set(xhttp.AccessControlRequestMethod, method)
+		if headers != "" {
+			req.Header.Set(xhttp.AccessControlRequestHeaders, headers)
+		}
+		corsHandler(apiRouter).ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodGet, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec := do(http.MethodPut, []byte(`<CORSConfiguration></CORSConfiguration>`)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec := do(http.MethodPut, []byte(corsConfig)); rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+
+	rec := do(http.MethodGet, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	var config cors.Config
+	if err := xml.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+		t.Fatalf("MinIO %s: unable to parse CORS configuration %v", instanceType, err)
+	}
+	if len(config.CORSRules) != 1 || config.CORSRules[0].MaxAgeSeconds != 3000 {
+		t.Fatalf("MinIO %s: unexpected CORS configuration %#v", instanceType, config)
+	}
+
+	// Allowed preflight request.
+	rec = preflight("https://app.example.com", http.MethodPut, "content-type")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	if got := rec.Header().Get(xhttp.AccessControlAllowOrigin); got != "https://app.example.com" {
+		t.Fatalf("MinIO %s: unexpected allowed origin %q", instanceType, got)
+	}
+	if got := rec.Header().Get(xhttp.AccessControlMaxAge); got != "3000" {
+		t.Fatalf("MinIO %s: unexpected max age %q", instanceType, got)
+	}
+
+	// Denied preflight requests.
+	if rec = preflight("https://app.example.org", http.MethodPut, ""); rec.Code != http.StatusForbidden {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusForbidden, rec.Code)
+	}
+	if rec = preflight("https://app.example.com", http.MethodDelete, ""); rec.Code != http.StatusForbidden {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusForbidden, rec.Code)
+	}
+	if rec = preflight("https://app.example.com", http.MethodPut, "authorization"); rec.Code != http.StatusForbidden {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	if rec = do(http.MethodDelete, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = do(http.MethodGet, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+// getBucketCorsConfig returns the CORS configuration of the bucket
+// addressed by the request, path-style or virtual-host-style.
+func getBucketCorsConfig(r *http.Request) (*cors.Config, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil {
+		return nil, false
+	}
+	bucket, _ := path2BucketObject(resource)
+	if bucket == "" || isMinioReservedBucket(bucket) || isMinioMetaBucketName(bucket) {
+		return nil, false
+	}
+
+	if globalIsGateway {
+		if globalGatewayName != NASBackendGateway {
+			return nil, false
+		}
+		// Looked up by every request on the bucket, including
+		// preflight, use the cached metadata instead of loading it.
+		meta, err := globalBucketMetadataSys.getNASMetadata(bucket)
+		if err != nil || meta.corsConfig == nil {
+			return nil, false
+		}
+		return meta.corsConfig, true
+	}
+
+	// Bucket metadata is loaded for all buckets at startup and
+	// kept in sync by peers, never load it for unknown buckets here.
+	meta, err := globalBucketMetadataSys.Get(bucket)
+	if err != nil || meta.corsConfig == nil {
+		return nil, false
+	}
+	return meta.corsConfig, true
+}
+
+// setCorsAllowOriginHeaders sets the response headers of a request
+// from origin allowed by a bucket CORS rule.
+func setCorsAllowOriginHeaders(h http.Header, rule cors.Rule, origin string) {
+	if rule.AllowsAnyOrigin() {
+		h.Set(xhttp.AccessControlAllowOrigin, "*")
+	} else {
+		h.Set(xhttp.AccessControlAllowOrigin, origin)
+		h.Set(xhttp.AccessControlAllowCredentials, "true")
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		h.Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+}
+
+// bucketCorsHandler enforces the CORS configuration of buckets, requests
+// to buckets without CORS configuration are served by defaultHandler.
+type bucketCorsHandler struct {
+	handler        http.Handler
+	defaultHandler http.Handler
+}
+
+func (h bucketCorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get(xhttp.Origin)
+	if origin == "" {
+		h.defaultHandler.ServeHTTP(w, r)
+		return
+	}
+
+	config, ok := getBucketCorsConfig(r)
+	if !ok {
+		h.defaultHandler.ServeHTTP(w, r)
+		return
+	}
+
+	header := w.Header()
+	header.Add(xhttp.Vary, xhttp.Origin)
+
+	// Preflight request.
+	if r.Method == http.MethodOptions && r.Header.Get(xhttp.AccessControlRequestMethod) != "" {
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		var reqHeaders []string
+		for _, reqHeader := range strings.Split(r.Header.Get(xhttp.AccessControlRequestHeaders), ",") {
+			if reqHeader = strings.TrimSpace(reqHeader); reqHeader != "" {
+				reqHeaders = append(reqHeaders, reqHeader)
+			}
+		}
+
+		rule, ok := config.Match(origin, r.Header.Get(xhttp.AccessControlRequestMethod), reqHeaders)
+		if !ok {
+			ctx := newContext(r, w, "PreflightBucketCors")
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrCORSForbidden), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		setCorsAllowOriginHeaders(header, rule, origin)
+		header.Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+		if len(reqHeaders) > 0 {
+			header.Set(xhttp.AccessControlAllowHeaders, strings.Join(reqHeaders, ", "))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			header.Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Actual request, CORS headers are only set when a rule
+	// matches, the request itself is served regardless.
+	if rule, ok := config.Match(origin, r.Method, nil); ok {
+		setCorsAllowOriginHeaders(header, rule, origin)
+	}
+	h.handler.ServeHTTP(w, r)
+}
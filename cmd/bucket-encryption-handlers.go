@@ -25,6 +25,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 const (
@@ -82,6 +83,14 @@ func (api objectAPIHandlers) PutBucketEncryptionHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Verify that the KMS master key of an SSE-KMS configuration exists,
+	// or create it if auto-creation of bucket keys is enabled.
+	if err = verifyBucketSSEKey(bucket, encConfig); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrKMSKeyNotFound), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	configData, err := xml.Marshal(encConfig)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -94,6 +103,12 @@ func (api objectAPIHandlers) PutBucketEncryptionHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Call site replication hook.
+	if err = srHookBucketMeta(ctx, madmin.SRBucketMetaTypeSSEConfig, bucket, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	writeSuccessResponseHeadersOnly(w)
 }
 
@@ -174,5 +189,11 @@ func (api objectAPIHandlers) DeleteBucketEncryptionHandler(w http.ResponseWriter
 		return
 	}
 
+	// Call site replication hook.
+	if err = srHookBucketMeta(ctx, madmin.SRBucketMetaTypeSSEConfig, bucket, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	writeSuccessNoContent(w)
 }
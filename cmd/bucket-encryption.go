@@ -19,8 +19,12 @@ package cmd
 import (
 	"errors"
 	"io"
+	"net/http"
 
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
+	"github.com/minio/minio/pkg/kms"
 )
 
 // BucketSSEConfigSys - in-memory cache of bucket encryption config
@@ -45,6 +49,57 @@ func (sys *BucketSSEConfigSys) Get(bucket string) (*bucketsse.BucketSSEConfig, e
 	return globalBucketMetadataSys.GetSSEConfig(bucket)
 }
 
+// Apply sets the SSE headers of a write request without any SSE headers
+// to the default encryption of the bucket, SSE-S3 or SSE-KMS with the
+// bucket's KMS master key. With auto-encryption enabled, requests to
+// buckets without default encryption are encrypted with SSE-S3.
+// It must be called after authorizing the request, such that policy
+// conditions apply to the headers as sent by the client.
+func (sys *BucketSSEConfigSys) Apply(bucket string, h http.Header) {
+	if _, ok := crypto.IsRequested(h); ok {
+		return
+	}
+	config, err := sys.Get(bucket)
+	if err != nil {
+		if globalAutoEncryption {
+			h.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
+		}
+		return
+	}
+	switch config.Algo() {
+	case bucketsse.AES256:
+		h.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
+	case bucketsse.AWSKms:
+		h.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMS)
+		if keyID := config.KeyID(); keyID != "" {
+			h.Set(xhttp.AmzServerSideEncryptionKmsID, keyID)
+		}
+	}
+}
+
+// verifyBucketSSEKey checks that the KMS master key of an SSE-KMS bucket
+// encryption configuration can be used to generate data keys for the
+// bucket. If the key does not exist and auto-creation of bucket keys is
+// enabled, the key is created at the KMS.
+func verifyBucketSSEKey(bucket string, config *bucketsse.BucketSSEConfig) error {
+	if config.Algo() != bucketsse.AWSKms {
+		return nil
+	}
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+	keyID, ctx := config.KeyID(), crypto.Context{bucket: bucket}
+	_, err := GlobalKMS.GenerateKey(keyID, ctx)
+	if err == nil || !globalKMSAutoCreateBucketKey {
+		return err
+	}
+	if err = GlobalKMS.CreateKey(keyID); err != nil && !errors.Is(err, kms.ErrKeyExists) && !errors.Is(err, crypto.ErrKESKeyExists) {
+		return err
+	}
+	_, err = GlobalKMS.GenerateKey(keyID, ctx)
+	return err
+}
+
 // validateBucketSSEConfig parses bucket encryption configuration and validates if it is supported by MinIO.
 func validateBucketSSEConfig(r io.Reader) (*bucketsse.BucketSSEConfig, error) {
 	encConfig, err := bucketsse.ParseBucketSSEConfig(r)
@@ -52,8 +107,11 @@ func validateBucketSSEConfig(r io.Reader) (*bucketsse.BucketSSEConfig, error) {
 		return nil, err
 	}
 
-	if len(encConfig.Rules) == 1 && encConfig.Rules[0].DefaultEncryptionAction.Algorithm == bucketsse.AES256 {
-		return encConfig, nil
+	if len(encConfig.Rules) == 1 {
+		switch encConfig.Algo() {
+		case bucketsse.AES256, bucketsse.AWSKms:
+			return encConfig, nil
+		}
 	}
 
 	return nil, errors.New("Unsupported bucket encryption configuration")
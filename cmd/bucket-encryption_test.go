@@ -18,8 +18,12 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/rand"
 	"errors"
 	"testing"
+
+	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
+	"github.com/minio/minio/pkg/kms"
 )
 
 func TestValidateBucketSSEConfig(t *testing.T) {
@@ -40,7 +44,7 @@ func TestValidateBucketSSEConfig(t *testing.T) {
 			expectedErr: nil,
 			shouldPass:  true,
 		},
-		// Unsupported XML
+		// MinIO supported XML with SSE-KMS
 		{
 			inputXML: `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
 			<Rule>
@@ -50,7 +54,19 @@ func TestValidateBucketSSEConfig(t *testing.T) {
 			</ApplyServerSideEncryptionByDefault>
 			</Rule>
 			</ServerSideEncryptionConfiguration>`,
-			expectedErr: errors.New("Unsupported bucket encryption configuration"),
+			expectedErr: nil,
+			shouldPass:  true,
+		},
+		// Unsupported XML
+		{
+			inputXML: `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+			<Rule>
+			<ApplyServerSideEncryptionByDefault>
+                        <SSEAlgorithm>aws:kms</SSEAlgorithm>
+			</ApplyServerSideEncryptionByDefault>
+			</Rule>
+			</ServerSideEncryptionConfiguration>`,
+			expectedErr: errors.New("MasterKeyID is missing with aws:kms"),
 			shouldPass:  false,
 		},
 	}
@@ -68,3 +84,49 @@ func TestValidateBucketSSEConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyBucketSSEKey(t *testing.T) {
+	masterKey := make([]byte, 32)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatal(err)
+	}
+	store, err := kms.NewKeyStore(t.TempDir(), masterKey, "default-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	GlobalKMS = store
+	defer func() { GlobalKMS = nil }()
+	defer func(flag bool) { globalKMSAutoCreateBucketKey = flag }(globalKMSAutoCreateBucketKey)
+
+	newConfig := func(keyID string) *bucketsse.BucketSSEConfig {
+		config, err := bucketsse.ParseBucketSSEConfig(bytes.NewReader([]byte(`<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+			<Rule>
+			<ApplyServerSideEncryptionByDefault>
+			<SSEAlgorithm>aws:kms</SSEAlgorithm>
+			<KMSMasterKeyID>` + keyID + `</KMSMasterKeyID>
+			</ApplyServerSideEncryptionByDefault>
+			</Rule>
+			</ServerSideEncryptionConfiguration>`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+
+	if err = verifyBucketSSEKey("bucket", newConfig("default-key")); err != nil {
+		t.Fatalf("existing key: %v", err)
+	}
+
+	globalKMSAutoCreateBucketKey = false
+	if err = verifyBucketSSEKey("bucket", newConfig("arn:aws:kms:bucket-key")); err == nil {
+		t.Fatal("expected a non-existing key to be rejected")
+	}
+
+	globalKMSAutoCreateBucketKey = true
+	if err = verifyBucketSSEKey("bucket", newConfig("arn:aws:kms:bucket-key")); err != nil {
+		t.Fatalf("auto-created key: %v", err)
+	}
+	if _, err = store.GenerateKey("bucket-key", nil); err != nil {
+		t.Fatalf("the bucket key was not created: %v", err)
+	}
+}
@@ -0,0 +1,332 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/inventory"
+	"github.com/minio/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+const (
+	// Bucket inventory configurations file name.
+	bucketInventoryConfig = "inventory.xml"
+
+	// Maximum number of inventory configurations listed at once.
+	maxInventoryConfigsList = 100
+)
+
+// listBucketInventoryConfigurationsResult - response of
+// ListBucketInventoryConfigurations.
+type listBucketInventoryConfigurationsResult struct {
+	XMLName               xml.Name           `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListInventoryConfigurationsResult"`
+	Configurations        []inventory.Config `xml:"InventoryConfiguration"`
+	IsTruncated           bool               `xml:"IsTruncated"`
+	ContinuationToken     string             `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string             `xml:"NextContinuationToken,omitempty"`
+}
+
+// getBucketInventoryConfigs - returns the inventory configurations of
+// the bucket, none when inventories are not supported.
+func getBucketInventoryConfigs(bucket string) (*inventory.Configs, error) {
+	if globalIsGateway && globalGatewayName != NASBackendGateway {
+		return &inventory.Configs{}, nil
+	}
+	configs, err := globalBucketMetadataSys.GetInventoryConfig(bucket)
+	if err != nil {
+		if _, ok := err.(BucketInventoryConfigNotFound); ok {
+			return &inventory.Configs{}, nil
+		}
+		return nil, err
+	}
+	return configs, nil
+}
+
+// updateBucketInventoryConfigs - stores the inventory configurations of
+// the bucket, they are removed when there are none left.
+func updateBucketInventoryConfigs(bucket string, configs *inventory.Configs) error {
+	var configData []byte
+	if len(configs.Configs) > 0 {
+		var err error
+		if configData, err = xml.Marshal(configs); err != nil {
+			return err
+		}
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketInventoryConfig, configData)
+}
+
+// PutBucketInventoryConfigurationHandler - Adds or replaces an inventory
+// configuration of a bucket.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketInventoryConfiguration.html
+func (api objectAPIHandlers) PutBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if globalIsGateway && globalGatewayName != NASBackendGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := inventory.ParseConfig(io.LimitReader(r.Body, maxBucketInventoryConfigSize))
+	if err != nil {
+		apiErr := APIError{
+			Code:           "MalformedXML",
+			Description:    fmt.Sprintf("%s (%s)", errorCodes[ErrMalformedXML].Description, err),
+			HTTPStatusCode: errorCodes[ErrMalformedXML].HTTPStatusCode,
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if id := r.URL.Query().Get("id"); id != config.ID {
+		apiErr := APIError{
+			Code:           "InvalidArgument",
+			Description:    fmt.Sprintf("The inventory configuration Id '%s' does not match the id parameter '%s'", config.ID, id),
+			HTTPStatusCode: http.StatusBadRequest,
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	dest := config.Destination.S3BucketDestination
+	if _, err = objAPI.GetBucketInfo(ctx, dest.BucketName()); err != nil {
+		if _, ok := err.(BucketNotFound); ok {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidInventoryDestination), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Reports are written on behalf of the requester, who
+	// must be allowed to write to the destination bucket.
+	if s3Error := isPutActionAllowed(ctx, getRequestAuthType(r), dest.BucketName(), dest.Prefix, r, iampolicy.PutObjectAction); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Configurations held by the bucket metadata are never modified.
+	updated := &inventory.Configs{Configs: append([]inventory.Config{}, configs.Configs...)}
+	if err = updated.Set(*config); err != nil {
+		apiErr := APIError{
+			Code:           "TooManyConfigurations",
+			Description:    err.Error(),
+			HTTPStatusCode: http.StatusBadRequest,
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = updateBucketInventoryConfigs(bucket, updated); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketInventoryConfigurationHandler - Returns an inventory
+// configuration of a bucket.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketInventoryConfiguration.html
+func (api objectAPIHandlers) GetBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	config, ok := configs.Get(r.URL.Query().Get("id"))
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchInventoryConfiguration), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Write bucket inventory configuration to client
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketInventoryConfigurationHandler - Removes an inventory
+// configuration of a bucket.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketInventoryConfiguration.html
+func (api objectAPIHandlers) DeleteBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	updated := &inventory.Configs{Configs: append([]inventory.Config{}, configs.Configs...)}
+	if !updated.Remove(id) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchInventoryConfiguration), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = updateBucketInventoryConfigs(bucket, updated); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// The schedule of a configuration created again with the same
+	// ID starts over.
+	if err = deleteConfig(ctx, objAPI, inventoryStatePath(bucket, id)); err != nil && err != errConfigNotFound {
+		logger.LogIf(ctx, err)
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// ListBucketInventoryConfigurationsHandler - Lists the inventory
+// configurations of a bucket, sorted by ID.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBucketInventoryConfigurations.html
+func (api objectAPIHandlers) ListBucketInventoryConfigurationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketInventoryConfigurations")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// The continuation token is the ID of the last
+	// configuration of the previous page.
+	result := listBucketInventoryConfigurationsResult{
+		ContinuationToken: r.URL.Query().Get("continuation-token"),
+	}
+	for _, config := range configs.Configs {
+		if config.ID <= result.ContinuationToken {
+			continue
+		}
+		if len(result.Configurations) == maxInventoryConfigsList {
+			result.IsTruncated = true
+			result.NextContinuationToken = result.Configurations[len(result.Configurations)-1].ID
+			break
+		}
+		result.Configurations = append(result.Configurations, config)
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(result))
+}
@@ -0,0 +1,288 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/inventory"
+	jsonfmt "github.com/minio/minio/pkg/s3select/json"
+	"github.com/minio/minio/pkg/s3select/parquet"
+)
+
+// Test S3 Bucket inventory APIs and the generation of reports.
+func TestBucketInventory(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketInventoryHandlers, []string{
+		"GetBucketInventoryConfiguration", "ListBucketInventoryConfigurations",
+		"PutBucketInventoryConfiguration", "DeleteBucketInventoryConfiguration",
+	})
+}
+
+const testInventoryConfig = `<InventoryConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Id>%s</Id>
+  <IsEnabled>true</IsEnabled>
+  <Filter><Prefix>photos/</Prefix></Filter>
+  <Destination>
+    <S3BucketDestination>
+      <Bucket>arn:aws:s3:::%s</Bucket>
+      <Format>%s</Format>
+      <Prefix>reports</Prefix>
+    </S3BucketDestination>
+  </Destination>
+  <Schedule><Frequency>Daily</Frequency></Schedule>
+  <IncludedObjectVersions>Current</IncludedObjectVersions>
+  <OptionalFields><Field>Size</Field><Field>ETag</Field><Field>Tags</Field></OptionalFields>
+</InventoryConfiguration>`
+
+// walkBlockingObjectLayer walks by sending objects without
+// observing the context, closing done once it terminates.
+type walkBlockingObjectLayer struct {
+	ObjectLayer
+	done chan struct{}
+}
+
+func (o walkBlockingObjectLayer) Walk(ctx context.Context, bucket, prefix string, results chan<- ObjectInfo, opts ObjectOptions) error {
+	go func() {
+		defer close(o.done)
+		defer close(results)
+		for i := 0; i < 1000; i++ {
+			results <- ObjectInfo{Bucket: bucket, Name: fmt.Sprintf("photos/%d.jpg", i)}
+		}
+	}()
+	return nil
+}
+
+// Tests that the walker terminates if the generation of a report fails.
+func TestBucketInventoryWalkerTerminates(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	config, err := inventory.ParseConfig(strings.NewReader(fmt.Sprintf(testInventoryConfig, "report1", "bucket", "CSV")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Destination.S3BucketDestination.Format = "ORC"
+
+	walker := walkBlockingObjectLayer{ObjectLayer: obj, done: make(chan struct{})}
+	if err = generateBucketInventory(context.Background(), walker, "bucket", *config, time.Now()); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+	select {
+	case <-walker.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the walker did not terminate")
+	}
+}
+
+// Tests are related and the order is important.
+func testBucketInventoryHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	do := func(method, id string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, getBucketInventoryURL("", bucketName, id),
+			int64(len(body)), bytes.NewReader(body), creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	list := func() listBucketInventoryConfigurationsResult {
+		t.Helper()
+		rec := do(http.MethodGet, "", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+		}
+		var result listBucketInventoryConfigurationsResult
+		if err := xml.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatalf("MinIO %s: unable to parse inventory configurations %v", instanceType, err)
+		}
+		return result
+	}
+
+	if result := list(); len(result.Configurations) != 0 {
+		t.Fatalf("MinIO %s: expected no inventory configurations, got %v", instanceType, result.Configurations)
+	}
+	if rec := do(http.MethodGet, "report1", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec := do(http.MethodPut, "report1", []byte(fmt.Sprintf(testInventoryConfig, "report1", "missing-bucket", "CSV"))); rec.Code != http.StatusBadRequest {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec := do(http.MethodPut, "report2", []byte(fmt.Sprintf(testInventoryConfig, "report1", bucketName, "CSV"))); rec.Code != http.StatusBadRequest {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	for _, id := range []string{"report2", "report1"} {
+		if rec := do(http.MethodPut, id, []byte(fmt.Sprintf(testInventoryConfig, id, bucketName, "CSV"))); rec.Code != http.StatusOK {
+			t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+		}
+	}
+	rec := do(http.MethodGet, "report1", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	config, err := inventory.ParseConfig(rec.Body)
+	if err != nil {
+		t.Fatalf("MinIO %s: unable to parse inventory configuration %v", instanceType, err)
+	}
+	if config.ID != "report1" || config.Prefix() != "photos/" || len(config.OptionalFields) != 3 {
+		t.Fatalf("MinIO %s: unexpected inventory configuration %#v", instanceType, config)
+	}
+	if result := list(); len(result.Configurations) != 2 || result.Configurations[0].ID != "report1" || result.IsTruncated {
+		t.Fatalf("MinIO %s: unexpected inventory configurations %#v", instanceType, result)
+	}
+
+	// Generate the reports of the objects with the prefix.
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("a"), 42)
+	for _, object := range []string{"photos/1.jpg", "photos/2.jpg", "videos/1.mp4"} {
+		opts := ObjectOptions{UserDefined: map[string]string{"X-Amz-Tagging": "album=holidays"}}
+		if _, err = obj.PutObject(ctx, bucketName, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatalf("MinIO %s: %v", instanceType, err)
+		}
+	}
+	now := time.Date(2021, time.May, 1, 10, 30, 0, 0, time.UTC)
+	for _, format := range []inventory.Format{inventory.FormatCSV, inventory.FormatJSON, inventory.FormatParquet} {
+		config.Destination.S3BucketDestination.Format = format
+		if err = generateBucketInventory(ctx, obj, bucketName, *config, now); err != nil {
+			t.Fatalf("MinIO %s: unable to generate %s inventory %v", instanceType, format, err)
+		}
+
+		readObject := func(object string) []byte {
+			t.Helper()
+			gr, err := obj.GetObjectNInfo(ctx, bucketName, object, nil, nil, readLock, ObjectOptions{})
+			if err != nil {
+				t.Fatalf("MinIO %s: unable to read %s: %v", instanceType, object, err)
+			}
+			defer gr.Close()
+			b, err := ioutil.ReadAll(gr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return b
+		}
+		var manifest inventoryManifest
+		if err = json.Unmarshal(readObject(path.Join("reports", bucketName, "report1", "2021-05-01T10-30Z", "manifest.json")), &manifest); err != nil {
+			t.Fatalf("MinIO %s: unable to parse the manifest %v", instanceType, err)
+		}
+		if manifest.FileFormat != format || manifest.FileSchema != "Bucket, Key, Size, ETag, Tags" || len(manifest.Files) != 1 {
+			t.Fatalf("MinIO %s: unexpected manifest %#v", instanceType, manifest)
+		}
+		file := readObject(manifest.Files[0].Key)
+		if int64(len(file)) != manifest.Files[0].Size {
+			t.Fatalf("MinIO %s: expected data file of %d bytes, got %d", instanceType, manifest.Files[0].Size, len(file))
+		}
+
+		var records [][]string
+		switch format {
+		case inventory.FormatCSV:
+			gz, err := gzip.NewReader(bytes.NewReader(file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if records, err = csv.NewReader(gz).ReadAll(); err != nil {
+				t.Fatal(err)
+			}
+		case inventory.FormatJSON:
+			gz, err := gzip.NewReader(bytes.NewReader(file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dec := json.NewDecoder(gz)
+			for {
+				var rec map[string]interface{}
+				if err = dec.Decode(&rec); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatal(err)
+				}
+				records = append(records, []string{fmt.Sprint(rec["Bucket"]), fmt.Sprint(rec["Key"]), fmt.Sprint(rec["Size"]), fmt.Sprint(rec["ETag"]), fmt.Sprint(rec["Tags"])})
+			}
+		case inventory.FormatParquet:
+			r, err := parquet.NewReader(func(offset, length int64) (io.ReadCloser, error) {
+				if offset < 0 {
+					offset = int64(len(file)) + offset
+				}
+				return ioutil.NopCloser(bytes.NewReader(file[offset : offset+length])), nil
+			}, &parquet.ReaderArgs{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for {
+				rec, err := r.Read(nil)
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatal(err)
+				}
+				var fields []string
+				for _, kv := range rec.(*jsonfmt.Record).KVS {
+					fields = append(fields, fmt.Sprint(kv.Value))
+				}
+				records = append(records, fields)
+			}
+		}
+		if len(records) != 2 {
+			t.Fatalf("MinIO %s: expected 2 %s records, got %v", instanceType, format, records)
+		}
+		for i, rec := range records {
+			if rec[0] != bucketName || rec[1] != fmt.Sprintf("photos/%d.jpg", i+1) || rec[2] != "42" ||
+				strings.Trim(rec[3], `"`) == "" || rec[4] != "album=holidays" {
+				t.Fatalf("MinIO %s: unexpected %s record %v", instanceType, format, rec)
+			}
+		}
+	}
+
+	// Scheduled reports are generated once per day.
+	for i, expected := range []bool{true, false} {
+		reportTime := now.Add(time.Duration(i+1) * time.Hour)
+		generateDueBucketInventories(ctx, obj, reportTime)
+		manifest := path.Join("reports", bucketName, "report2", reportTime.Format("2006-01-02T15-04Z"), "manifest.json")
+		if _, err = obj.GetObjectInfo(ctx, bucketName, manifest, ObjectOptions{}); (err == nil) != expected {
+			t.Fatalf("MinIO %s: expected report at %s %v, got %v", instanceType, reportTime, expected, err)
+		}
+	}
+
+	if rec = do(http.MethodDelete, "report1", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = do(http.MethodDelete, "report1", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if result := list(); len(result.Configurations) != 1 || result.Configurations[0].ID != "report2" {
+		t.Fatalf("MinIO %s: unexpected inventory configurations %#v", instanceType, result)
+	}
+}
@@ -0,0 +1,572 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/inventory"
+	xhash "github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/s3select/parquet"
+)
+
+const (
+	// Interval between two checks for due inventory reports.
+	inventoryCheckInterval = time.Hour
+
+	// Maximum number of records of an inventory data file.
+	inventoryMaxFileRecords = 1000000
+
+	// Version of the inventory manifest format.
+	inventoryManifestVersion = "2016-11-30"
+)
+
+var inventoryLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// inventoryState - state of the reports of an inventory configuration.
+type inventoryState struct {
+	LastReport time.Time `json:"lastReport"`
+}
+
+func inventoryStatePath(bucket, id string) string {
+	return path.Join(bucketMetaPrefix, bucket, "inventory", id+".json")
+}
+
+func loadInventoryState(ctx context.Context, objAPI ObjectLayer, bucket, id string) (inventoryState, error) {
+	var state inventoryState
+	data, err := readConfig(ctx, objAPI, inventoryStatePath(bucket, id))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return state, nil
+		}
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+func saveInventoryState(ctx context.Context, objAPI ObjectLayer, bucket, id string, state inventoryState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, inventoryStatePath(bucket, id), data)
+}
+
+// initBucketInventory - starts the generation of scheduled inventory
+// reports of buckets.
+func initBucketInventory(ctx context.Context, objAPI ObjectLayer) {
+	go runBucketInventory(ctx, objAPI)
+}
+
+// runBucketInventory generates the due inventory reports of all buckets,
+// there should only ever be one generator running per cluster.
+func runBucketInventory(ctx context.Context, objAPI ObjectLayer) {
+	var err error
+	locker := objAPI.NewNSLock(minioMetaBucket, "runBucketInventory.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		ctx, err = locker.GetLock(ctx, inventoryLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(inventoryCheckInterval)))
+			continue
+		}
+		break
+		// No unlock for "leader" lock.
+	}
+
+	timer := time.NewTimer(inventoryCheckInterval)
+	defer timer.Stop()
+
+	for {
+		generateDueBucketInventories(ctx, objAPI, UTCNow())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			timer.Reset(inventoryCheckInterval)
+		}
+	}
+}
+
+// generateDueBucketInventories - generates the reports of all enabled
+// inventory configurations whose last report is older than their
+// schedule.
+func generateDueBucketInventories(ctx context.Context, objAPI ObjectLayer, now time.Time) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, bucket := range buckets {
+		configs, err := globalBucketMetadataSys.GetInventoryConfig(bucket.Name)
+		if err != nil {
+			continue
+		}
+		for _, config := range configs.Configs {
+			if ctx.Err() != nil {
+				return
+			}
+			if !config.IsEnabled {
+				continue
+			}
+			state, err := loadInventoryState(ctx, objAPI, bucket.Name, config.ID)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			if now.Sub(state.LastReport) < config.Schedule.Frequency.Interval() {
+				continue
+			}
+			if err = generateBucketInventory(ctx, objAPI, bucket.Name, config, now); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("unable to generate the inventory %s of bucket %s: %w", config.ID, bucket.Name, err))
+				continue
+			}
+			state.LastReport = now
+			logger.LogIf(ctx, saveInventoryState(ctx, objAPI, bucket.Name, config.ID, state))
+		}
+	}
+}
+
+// inventoryColumn - column of the records of an inventory report,
+// value returns nil when the column does not apply to an object.
+type inventoryColumn struct {
+	name  string
+	typ   parquet.ColumnType
+	value func(oi ObjectInfo) interface{}
+}
+
+func inventoryColumns(config inventory.Config) []inventoryColumn {
+	columns := []inventoryColumn{
+		{"Bucket", parquet.ColumnString, func(oi ObjectInfo) interface{} { return oi.Bucket }},
+		{"Key", parquet.ColumnString, func(oi ObjectInfo) interface{} { return oi.Name }},
+	}
+	if config.AllVersions() {
+		columns = append(columns,
+			inventoryColumn{"VersionId", parquet.ColumnString, func(oi ObjectInfo) interface{} {
+				if oi.VersionID == "" {
+					return nil
+				}
+				return oi.VersionID
+			}},
+			inventoryColumn{"IsLatest", parquet.ColumnBool, func(oi ObjectInfo) interface{} { return oi.IsLatest }},
+			inventoryColumn{"IsDeleteMarker", parquet.ColumnBool, func(oi ObjectInfo) interface{} { return oi.DeleteMarker }},
+		)
+	}
+
+	for _, field := range config.OptionalFields {
+		column := inventoryColumn{name: string(field), typ: parquet.ColumnString}
+		switch field {
+		case inventory.FieldSize:
+			column.typ = parquet.ColumnInt64
+			column.value = func(oi ObjectInfo) interface{} {
+				if oi.DeleteMarker {
+					return nil
+				}
+				size, err := oi.GetActualSize()
+				if err != nil {
+					return oi.Size
+				}
+				return size
+			}
+		case inventory.FieldLastModifiedDate:
+			column.value = func(oi ObjectInfo) interface{} { return oi.ModTime.UTC().Format(time.RFC3339Nano) }
+		case inventory.FieldStorageClass:
+			column.value = func(oi ObjectInfo) interface{} {
+				if oi.DeleteMarker {
+					return nil
+				}
+				if oi.StorageClass == "" {
+					return globalMinioDefaultStorageClass
+				}
+				return oi.StorageClass
+			}
+		case inventory.FieldETag:
+			column.value = func(oi ObjectInfo) interface{} {
+				if oi.DeleteMarker {
+					return nil
+				}
+				return oi.ETag
+			}
+		case inventory.FieldIsMultipartUploaded:
+			column.typ = parquet.ColumnBool
+			column.value = func(oi ObjectInfo) interface{} {
+				if oi.DeleteMarker {
+					return nil
+				}
+				return strings.Contains(oi.ETag, "-")
+			}
+		case inventory.FieldReplicationStatus:
+			column.value = func(oi ObjectInfo) interface{} {
+				if oi.ReplicationStatus == "" {
+					return nil
+				}
+				return oi.ReplicationStatus.String()
+			}
+		case inventory.FieldEncryptionStatus:
+			column.value = func(oi ObjectInfo) interface{} {
+				if oi.DeleteMarker {
+					return nil
+				}
+				switch kind, _ := crypto.IsEncrypted(oi.UserDefined); kind {
+				case crypto.S3:
+					return "SSE-S3"
+				case crypto.S3KMS:
+					return "SSE-KMS"
+				case crypto.SSEC:
+					return "SSE-C"
+				}
+				return "NOT-SSE"
+			}
+		case inventory.FieldTags:
+			column.value = func(oi ObjectInfo) interface{} {
+				if oi.UserTags == "" {
+					return nil
+				}
+				return oi.UserTags
+			}
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// inventoryUpload - streams an inventory data file into the
+// destination bucket, computing its size and MD5 checksum.
+type inventoryUpload struct {
+	pw    *io.PipeWriter
+	md5   hash.Hash
+	size  int64
+	errCh chan error
+}
+
+func newInventoryUpload(ctx context.Context, objAPI ObjectLayer, bucket, object, contentType string) *inventoryUpload {
+	pr, pw := io.Pipe()
+	u := &inventoryUpload{
+		pw:    pw,
+		md5:   md5.New(),
+		errCh: make(chan error, 1),
+	}
+	go func() {
+		u.errCh <- putInventoryObject(ctx, objAPI, bucket, object, contentType, pr, -1)
+	}()
+	return u
+}
+
+func (u *inventoryUpload) Write(p []byte) (int, error) {
+	n, err := u.pw.Write(p)
+	u.md5.Write(p[:n])
+	u.size += int64(n)
+	return n, err
+}
+
+func (u *inventoryUpload) Close() error {
+	u.pw.Close()
+	return <-u.errCh
+}
+
+func putInventoryObject(ctx context.Context, objAPI ObjectLayer, bucket, object, contentType string, r io.Reader, size int64) error {
+	pr, ok := r.(*io.PipeReader)
+	hr, err := xhash.NewReader(r, size, "", "", size)
+	if err != nil {
+		if ok {
+			pr.CloseWithError(err)
+		}
+		return err
+	}
+	opts := ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: contentType},
+		Versioned:        globalBucketVersioningSys.Enabled(bucket),
+		VersionSuspended: globalBucketVersioningSys.Suspended(bucket),
+	}
+	_, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hr), opts)
+	if ok {
+		// Unblock the writer if the upload failed.
+		pr.CloseWithError(err)
+	}
+	return err
+}
+
+// inventoryRecordWriter - writes the records of an inventory data file.
+type inventoryRecordWriter interface {
+	write(values []interface{}) error
+	Close() error
+}
+
+type inventoryCSVWriter struct {
+	gz  *gzip.Writer
+	w   *csv.Writer
+	wc  io.WriteCloser
+	rec []string
+}
+
+func (w *inventoryCSVWriter) write(values []interface{}) error {
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+			w.rec[i] = ""
+		case string:
+			w.rec[i] = v
+		case int64:
+			w.rec[i] = strconv.FormatInt(v, 10)
+		case bool:
+			w.rec[i] = strconv.FormatBool(v)
+		}
+	}
+	return w.w.Write(w.rec)
+}
+
+func (w *inventoryCSVWriter) Close() error {
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		w.wc.Close()
+		return err
+	}
+	if err := w.gz.Close(); err != nil {
+		w.wc.Close()
+		return err
+	}
+	return w.wc.Close()
+}
+
+type inventoryJSONWriter struct {
+	gz      *gzip.Writer
+	enc     *json.Encoder
+	wc      io.WriteCloser
+	columns []inventoryColumn
+}
+
+func (w *inventoryJSONWriter) write(values []interface{}) error {
+	rec := make(map[string]interface{}, len(values))
+	for i, v := range values {
+		if v != nil {
+			rec[w.columns[i].name] = v
+		}
+	}
+	return w.enc.Encode(rec)
+}
+
+func (w *inventoryJSONWriter) Close() error {
+	if err := w.gz.Close(); err != nil {
+		w.wc.Close()
+		return err
+	}
+	return w.wc.Close()
+}
+
+type inventoryParquetWriter struct {
+	w *parquet.Writer
+}
+
+func (w *inventoryParquetWriter) write(values []interface{}) error {
+	return w.w.Write(values...)
+}
+
+func (w *inventoryParquetWriter) Close() error {
+	return w.w.Close()
+}
+
+func newInventoryRecordWriter(format inventory.Format, wc io.WriteCloser, columns []inventoryColumn) (inventoryRecordWriter, error) {
+	switch format {
+	case inventory.FormatCSV:
+		gz := gzip.NewWriter(wc)
+		return &inventoryCSVWriter{gz: gz, w: csv.NewWriter(gz), wc: wc, rec: make([]string, len(columns))}, nil
+	case inventory.FormatJSON:
+		gz := gzip.NewWriter(wc)
+		return &inventoryJSONWriter{gz: gz, enc: json.NewEncoder(gz), wc: wc, columns: columns}, nil
+	case inventory.FormatParquet:
+		pcolumns := make([]parquet.Column, len(columns))
+		for i, c := range columns {
+			pcolumns[i] = parquet.Column{Name: c.name, Type: c.typ}
+		}
+		w, err := parquet.NewWriter(wc, pcolumns)
+		if err != nil {
+			return nil, err
+		}
+		return &inventoryParquetWriter{w: w}, nil
+	}
+	return nil, fmt.Errorf("unsupported inventory format %s", format)
+}
+
+func inventoryFileExtension(format inventory.Format) string {
+	switch format {
+	case inventory.FormatCSV:
+		return ".csv.gz"
+	case inventory.FormatJSON:
+		return ".json.gz"
+	}
+	return ".parquet"
+}
+
+func inventoryContentType(format inventory.Format) string {
+	if format == inventory.FormatParquet {
+		return "application/octet-stream"
+	}
+	return "application/gzip"
+}
+
+// inventoryManifestFile - data file of an inventory report.
+type inventoryManifestFile struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	MD5Checksum string `json:"MD5checksum"`
+}
+
+// inventoryManifest - lists the data files of an inventory report.
+type inventoryManifest struct {
+	SourceBucket      string                  `json:"sourceBucket"`
+	DestinationBucket string                  `json:"destinationBucket"`
+	Version           string                  `json:"version"`
+	CreationTimestamp string                  `json:"creationTimestamp"`
+	FileFormat        inventory.Format        `json:"fileFormat"`
+	FileSchema        string                  `json:"fileSchema"`
+	Files             []inventoryManifestFile `json:"files"`
+}
+
+// generateBucketInventory - walks the objects of the bucket and writes
+// an inventory report into the destination bucket of the configuration:
+// data files named `<prefix>/<bucket>/<id>/data/<uuid>.<format>` and a
+// manifest `<prefix>/<bucket>/<id>/<YYYY-MM-DDTHH-MMZ>/manifest.json`
+// listing them.
+func generateBucketInventory(ctx context.Context, objAPI ObjectLayer, bucket string, config inventory.Config, now time.Time) error {
+	dest := config.Destination.S3BucketDestination
+	destBucket := dest.BucketName()
+	base := path.Join(dest.Prefix, bucket, config.ID)
+	columns := inventoryColumns(config)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan ObjectInfo, 100)
+	if err := objAPI.Walk(ctx, bucket, config.Prefix(), results, ObjectOptions{WalkVersions: config.AllVersions()}); err != nil {
+		return err
+	}
+	// The walker blocks on sending results, cancel and drain it on
+	// early returns such that it terminates.
+	defer func() {
+		cancel()
+		for range results {
+		}
+	}()
+
+	manifest := inventoryManifest{
+		SourceBucket:      bucket,
+		DestinationBucket: dest.Bucket,
+		Version:           inventoryManifestVersion,
+		CreationTimestamp: strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10),
+		FileFormat:        dest.Format,
+	}
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	manifest.FileSchema = strings.Join(names, ", ")
+
+	var (
+		upload  *inventoryUpload
+		w       inventoryRecordWriter
+		records int
+		key     string
+	)
+	closeFile := func() error {
+		err := w.Close()
+		w = nil
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, inventoryManifestFile{
+			Key:         key,
+			Size:        upload.size,
+			MD5Checksum: hex.EncodeToString(upload.md5.Sum(nil)),
+		})
+		return nil
+	}
+	defer func() {
+		if w != nil {
+			upload.pw.CloseWithError(errors.New("inventory generation aborted"))
+			w.Close()
+		}
+	}()
+
+	values := make([]interface{}, len(columns))
+	for oi := range results {
+		if w == nil {
+			var err error
+			key = path.Join(base, "data", mustGetUUID()+inventoryFileExtension(dest.Format))
+			upload = newInventoryUpload(ctx, objAPI, destBucket, key, inventoryContentType(dest.Format))
+			if w, err = newInventoryRecordWriter(dest.Format, upload, columns); err != nil {
+				upload.pw.CloseWithError(err)
+				<-upload.errCh
+				w = nil
+				return err
+			}
+		}
+		for i, c := range columns {
+			values[i] = c.value(oi)
+		}
+		if err := w.write(values); err != nil {
+			return err
+		}
+		if records++; records == inventoryMaxFileRecords {
+			if err := closeFile(); err != nil {
+				return err
+			}
+			records = 0
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if w != nil {
+		if err := closeFile(); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	manifestDir := path.Join(base, now.UTC().Format("2006-01-02T15-04Z"))
+	if err = putInventoryObject(ctx, objAPI, destBucket, path.Join(manifestDir, "manifest.json"),
+		"application/json", bytes.NewReader(data), int64(len(data))); err != nil {
+		return err
+	}
+	sum := md5.Sum(data)
+	checksum := []byte(hex.EncodeToString(sum[:]))
+	return putInventoryObject(ctx, objAPI, destBucket, path.Join(manifestDir, "manifest.checksum"),
+		"text/plain", bytes.NewReader(checksum), int64(len(checksum)))
+}
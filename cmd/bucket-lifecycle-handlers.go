@@ -26,6 +26,7 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 const (
@@ -95,6 +96,12 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Call site replication hook.
+	if err = srHookBucketMeta(ctx, madmin.SRBucketMetaTypeLifecycle, bucket, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Success.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -172,6 +179,12 @@ func (api objectAPIHandlers) DeleteBucketLifecycleHandler(w http.ResponseWriter,
 		return
 	}
 
+	// Call site replication hook.
+	if err := srHookBucketMeta(ctx, madmin.SRBucketMetaTypeLifecycle, bucket, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Success.
 	writeSuccessNoContent(w)
 }
@@ -44,6 +44,48 @@ const (
 	Disabled = "Disabled"
 )
 
+const (
+	// transitionTierKey holds the remote tier an object version was
+	// transitioned to.
+	transitionTierKey = ReservedMetadataPrefixLower + "transition-tier"
+	// transitionedObjNameKey holds the name of the object version in the
+	// remote tier.
+	transitionedObjNameKey = ReservedMetadataPrefixLower + "transitioned-object"
+)
+
+// transitionedObject identifies the copy of an object version in a remote
+// tier, it is empty for objects transitioned to a remote bucket target.
+type transitionedObject struct {
+	Tier string
+	Name string
+}
+
+// metadata returns the internal metadata recording the transitioned object.
+func (t transitionedObject) metadata() map[string]string {
+	if t.Tier == "" {
+		return nil
+	}
+	return map[string]string{
+		transitionTierKey:      t.Tier,
+		transitionedObjNameKey: t.Name,
+	}
+}
+
+// newTransitionedObject returns the transitionedObject recorded in metadata.
+func newTransitionedObject(metadata map[string]string) transitionedObject {
+	return transitionedObject{
+		Tier: metadata[transitionTierKey],
+		Name: metadata[transitionedObjNameKey],
+	}
+}
+
+// genTransitionObjName generates a unique name for an object version
+// transitioned to a remote tier, spread over two levels of prefixes.
+func genTransitionObjName() string {
+	us := mustGetUUID()
+	return fmt.Sprintf("%s/%s/%s", us[0:2], us[2:4], us)
+}
+
 // LifecycleSys - Bucket lifecycle subsystem.
 type LifecycleSys struct{}
 
@@ -170,6 +212,9 @@ func initBackgroundTransition(ctx context.Context, objectAPI ObjectLayer) {
 func validateLifecycleTransition(ctx context.Context, bucket string, lfc *lifecycle.Lifecycle) error {
 	for _, rule := range lfc.Rules {
 		if rule.Transition.StorageClass != "" {
+			if globalTierConfigMgr.IsTierValid(rule.Transition.StorageClass) {
+				continue
+			}
 			sameTarget, destbucket, err := validateTransitionDestination(ctx, bucket, rule.Transition.StorageClass)
 			if err != nil {
 				return err
@@ -297,22 +342,10 @@ func putTransitionOpts(objInfo ObjectInfo) (putOpts miniogo.PutObjectOptions, er
 // 1. temporarily restored copies of objects (restored with the PostRestoreObject API) expired.
 // 2. life cycle expiry date is met on the object.
 // 3. Object is removed through DELETE api call
-func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, bucket, object string, lcOpts lifecycle.ObjectOpts, restoredObject, isDeleteTierOnly bool) error {
+func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, bucket, object string, lcOpts lifecycle.ObjectOpts, tobj transitionedObject, restoredObject, isDeleteTierOnly bool) error {
 	if lcOpts.TransitionStatus == "" && !isDeleteTierOnly {
 		return nil
 	}
-	lc, err := globalLifecycleSys.Get(bucket)
-	if err != nil {
-		return err
-	}
-	arn := getLifecycleTransitionTargetArn(ctx, lc, bucket, lcOpts)
-	if arn == nil {
-		return fmt.Errorf("remote target not configured")
-	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn.String())
-	if tgt == nil {
-		return fmt.Errorf("remote target not configured")
-	}
 
 	var opts ObjectOptions
 	opts.Versioned = globalBucketVersioningSys.Enabled(bucket)
@@ -322,14 +355,37 @@ func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, bucket
 		// from the source, while leaving metadata behind. The data on
 		// transitioned tier lies untouched and still accessible
 		opts.TransitionStatus = lcOpts.TransitionStatus
-		_, err = objectAPI.DeleteObject(ctx, bucket, object, opts)
+		opts.TransitionedObject = tobj
+		_, err := objectAPI.DeleteObject(ctx, bucket, object, opts)
 		return err
 	}
 
 	// When an object is past expiry, delete the data from transitioned tier and
 	// metadata from source
-	if err := tgt.RemoveObject(context.Background(), arn.Bucket, object, miniogo.RemoveObjectOptions{VersionID: lcOpts.VersionID}); err != nil {
-		logger.LogIf(ctx, err)
+	if tobj.Tier != "" {
+		w, err := globalTierConfigMgr.getDriver(ctx, tobj.Tier)
+		if err != nil {
+			return err
+		}
+		if err = w.Remove(ctx, tobj.Name); err != nil {
+			logger.LogIf(ctx, err)
+		}
+	} else {
+		lc, err := globalLifecycleSys.Get(bucket)
+		if err != nil {
+			return err
+		}
+		arn := getLifecycleTransitionTargetArn(ctx, lc, bucket, lcOpts)
+		if arn == nil {
+			return fmt.Errorf("remote target not configured")
+		}
+		tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn.String())
+		if tgt == nil {
+			return fmt.Errorf("remote target not configured")
+		}
+		if err := tgt.RemoveObject(context.Background(), arn.Bucket, object, miniogo.RemoveObjectOptions{VersionID: lcOpts.VersionID}); err != nil {
+			logger.LogIf(ctx, err)
+		}
 	}
 
 	if isDeleteTierOnly {
@@ -369,6 +425,9 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, objInfo Object
 		Name:     objInfo.Name,
 		UserTags: objInfo.UserTags,
 	}
+	if sc := getLifecycleTransitionSC(lc, lcOpts); globalTierConfigMgr.IsTierValid(sc) {
+		return transitionObjectToTier(ctx, objectAPI, objInfo, sc)
+	}
 	arn := getLifecycleTransitionTargetArn(ctx, lc, objInfo.Bucket, lcOpts)
 	if arn == nil {
 		return fmt.Errorf("remote target not configured")
@@ -425,18 +484,87 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, objInfo Object
 	return err
 }
 
-// getLifecycleTransitionTargetArn returns transition ARN for storage class specified in the config.
-func getLifecycleTransitionTargetArn(ctx context.Context, lc *lifecycle.Lifecycle, bucket string, obj lifecycle.ObjectOpts) *madmin.ARN {
+// transitionObjectToTier moves the data of objInfo to the remote tier tierName.
+func transitionObjectToTier(ctx context.Context, objectAPI ObjectLayer, objInfo ObjectInfo, tierName string) error {
+	w, err := globalTierConfigMgr.getDriver(ctx, tierName)
+	if err != nil {
+		return err
+	}
+
+	gr, err := objectAPI.GetObjectNInfo(ctx, objInfo.Bucket, objInfo.Name, nil, http.Header{}, readLock, ObjectOptions{
+		VersionID:        objInfo.VersionID,
+		TransitionStatus: lifecycle.TransitionPending,
+	})
+	if err != nil {
+		return err
+	}
+	oi := gr.ObjInfo
+	if oi.TransitionStatus == lifecycle.TransitionComplete {
+		gr.Close()
+		return nil
+	}
+
+	tobj := transitionedObject{
+		Tier: tierName,
+		Name: genTransitionObjName(),
+	}
+	if err = w.Put(ctx, tobj.Name, gr, oi.Size); err != nil {
+		gr.Close()
+		return err
+	}
+	gr.Close()
+
+	var opts ObjectOptions
+	opts.Versioned = globalBucketVersioningSys.Enabled(oi.Bucket)
+	opts.VersionID = oi.VersionID
+	opts.TransitionStatus = lifecycle.TransitionComplete
+	opts.TransitionedObject = tobj
+	eventName := event.ObjectTransitionComplete
+
+	objInfo, err = objectAPI.DeleteObject(ctx, oi.Bucket, oi.Name, opts)
+	if err != nil {
+		eventName = event.ObjectTransitionFailed
+		// The object was not updated to point to the remote tier,
+		// clean up the now orphaned copy.
+		logger.LogIf(ctx, w.Remove(ctx, tobj.Name))
+	}
+
+	// Notify object deleted event.
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: objInfo.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [ILM-Transition]",
+	})
+
+	return err
+}
+
+// getLifecycleTransitionSC returns the storage class of the first
+// actionable transition rule applying to obj.
+func getLifecycleTransitionSC(lc *lifecycle.Lifecycle, obj lifecycle.ObjectOpts) string {
 	for _, rule := range lc.FilterActionableRules(obj) {
 		if rule.Transition.StorageClass != "" {
-			return globalBucketTargetSys.GetRemoteArnWithLabel(ctx, bucket, rule.Transition.StorageClass)
+			return rule.Transition.StorageClass
 		}
 	}
+	return ""
+}
+
+// getLifecycleTransitionTargetArn returns transition ARN for storage class specified in the config.
+func getLifecycleTransitionTargetArn(ctx context.Context, lc *lifecycle.Lifecycle, bucket string, obj lifecycle.ObjectOpts) *madmin.ARN {
+	if sc := getLifecycleTransitionSC(lc, obj); sc != "" {
+		return globalBucketTargetSys.GetRemoteArnWithLabel(ctx, bucket, sc)
+	}
 	return nil
 }
 
 // getTransitionedObjectReader returns a reader from the transitioned tier.
 func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, oi ObjectInfo, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	if oi.TransitionedObject.Tier != "" {
+		return getTransitionedObjectReaderFromTier(ctx, bucket, object, rs, h, oi, opts)
+	}
+
 	var lc *lifecycle.Lifecycle
 	lc, err = globalLifecycleSys.Get(bucket)
 	if err != nil {
@@ -480,6 +608,34 @@ func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs
 	return fn(reader, h, opts.CheckPrecondFn, closeReader)
 }
 
+// getTransitionedObjectReaderFromTier returns a reader streaming the data of
+// oi from the remote tier it was transitioned to.
+func getTransitionedObjectReaderFromTier(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, oi ObjectInfo, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	w, err := globalTierConfigMgr.getDriver(ctx, oi.TransitionedObject.Tier)
+	if err != nil {
+		return nil, err
+	}
+	fn, off, length, err := NewGetObjectReader(rs, oi, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// get correct offsets for encrypted object
+	var gopts WarmBackendGetOpts
+	if off >= 0 && length >= 0 {
+		gopts.startOffset = off
+		gopts.length = length
+	}
+
+	reader, err := w.Get(ctx, oi.TransitionedObject.Name, gopts)
+	if err != nil {
+		return nil, err
+	}
+	closeReader := func() { reader.Close() }
+
+	return fn(reader, h, opts.CheckPrecondFn, closeReader)
+}
+
 // RestoreRequestType represents type of restore.
 type RestoreRequestType string
 
@@ -636,6 +792,11 @@ func putRestoreOpts(bucket, object string, rreq *RestoreObjectRequest, objInfo O
 	for k, v := range objInfo.UserDefined {
 		meta[k] = v
 	}
+	// Keep the restored copy marked as transitioned, so that it is
+	// removed once the restore expires.
+	if objInfo.TransitionStatus != "" {
+		meta[ReservedMetadataPrefixLower+"transition-status"] = objInfo.TransitionStatus
+	}
 	if len(objInfo.UserTags) != 0 {
 		meta[xhttp.AmzObjectTagging] = objInfo.UserTags
 	}
@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/logging"
+)
+
+// Test S3 Bucket logging APIs and the delivery of access logs.
+func TestBucketLogging(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLoggingHandlers, []string{"GetBucketLogging", "PutBucketLogging"})
+}
+
+// Tests are related and the order is important.
+func testBucketLoggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	do := func(method string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, getBucketLoggingURL("", bucketName),
+			int64(len(body)), bytes.NewReader(body), creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	getStatus := func() logging.Config {
+		t.Helper()
+		rec := do(http.MethodGet, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+		}
+		var config logging.Config
+		if err := xml.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+			t.Fatalf("MinIO %s: unable to parse logging status %v", instanceType, err)
+		}
+		return config
+	}
+
+	if getStatus().Enabled() {
+		t.Fatalf("MinIO %s: expected logging to be disabled", instanceType)
+	}
+	if rec := do(http.MethodPut, []byte(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>missing-bucket</TargetBucket><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec := do(http.MethodPut, []byte(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>`+bucketName+`</TargetBucket><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`)); rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	if config := getStatus(); !config.Enabled() || config.LoggingEnabled.TargetPrefix != "logs/" {
+		t.Fatalf("MinIO %s: unexpected logging status %#v", instanceType, config)
+	}
+
+	// Deliver the access log of a request.
+	sys := newBucketAccessLogSys(obj)
+	if !sys.Enabled(bucketName) {
+		t.Fatalf("MinIO %s: expected access logging to be enabled", instanceType)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/"+bucketName+"/photo.jpg", nil)
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucketName, ObjectName: "photo.jpg", AccessKey: creds.AccessKey})
+	entry := audit.Entry{RequestID: "16A9E7CB1B08B0F5", RespHeader: map[string]string{"Content-Length": "42"}}
+	entry.API.StatusCode = http.StatusOK
+	sys.Send(ctx, entry, req)
+	sys.flush(context.Background())
+
+	result, err := obj.ListObjects(context.Background(), bucketName, "logs/", "", "", 10)
+	if err != nil {
+		t.Fatalf("MinIO %s: unable to list log objects %v", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("MinIO %s: expected 1 log object, got %d", instanceType, len(result.Objects))
+	}
+	gr, err := obj.GetObjectNInfo(context.Background(), bucketName, result.Objects[0].Name, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("MinIO %s: unable to read log object %v", instanceType, err)
+	}
+	data, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "- " + creds.AccessKey + " 16A9E7CB1B08B0F5 REST.GET.OBJECT photo.jpg \"GET /" + bucketName + "/photo.jpg HTTP/1.1\" 200 - 42 42"
+	if !strings.Contains(string(data), expected) {
+		t.Fatalf("MinIO %s: expected log record to contain %q, got %q", instanceType, expected, data)
+	}
+
+	// Disable logging.
+	if rec := do(http.MethodPut, []byte(`<BucketLoggingStatus></BucketLoggingStatus>`)); rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	if getStatus().Enabled() || sys.Enabled(bucketName) {
+		t.Fatalf("MinIO %s: expected logging to be disabled", instanceType)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// Interval between two deliveries of buffered server access logs.
+	accessLogFlushInterval = 5 * time.Minute
+
+	// Server access logs of a destination are delivered before the
+	// next interval once their buffered size reaches this limit.
+	accessLogMaxBufferSize = 4 * humanize.MiByte
+)
+
+// accessLogDestination - where server access logs are delivered.
+type accessLogDestination struct {
+	bucket string
+	prefix string
+}
+
+// bucketAccessLogSys - builds S3 server access log records from the
+// audit entries of requests, buffers them per destination and
+// periodically writes them as log objects into the target buckets.
+type bucketAccessLogSys struct {
+	sync.Mutex
+	buffers map[accessLogDestination]*bytes.Buffer
+	flushCh chan struct{}
+	objAPI  ObjectLayer
+}
+
+var globalBucketAccessLogSys *bucketAccessLogSys
+
+func newBucketAccessLogSys(objAPI ObjectLayer) *bucketAccessLogSys {
+	return &bucketAccessLogSys{
+		buffers: make(map[accessLogDestination]*bytes.Buffer),
+		flushCh: make(chan struct{}, 1),
+		objAPI:  objAPI,
+	}
+}
+
+// initBucketAccessLog - enables server access logging of buckets and
+// starts the periodic delivery of the logs.
+func initBucketAccessLog(ctx context.Context, objAPI ObjectLayer) {
+	globalBucketAccessLogSys = newBucketAccessLogSys(objAPI)
+	logger.AccessLog = globalBucketAccessLogSys
+	go globalBucketAccessLogSys.run(ctx)
+}
+
+// getBucketLoggingConfig returns the server access logging
+// configuration of the bucket, if logging is enabled.
+func getBucketLoggingConfig(bucket string) (*logging.Config, bool) {
+	if globalBucketMetadataSys == nil || bucket == "" ||
+		isMinioReservedBucket(bucket) || isMinioMetaBucketName(bucket) {
+		return nil, false
+	}
+
+	if globalIsGateway {
+		if globalGatewayName != NASBackendGateway {
+			return nil, false
+		}
+		// Looked up by every request on the bucket, use the
+		// cached metadata instead of loading it each time.
+		meta, err := globalBucketMetadataSys.getNASMetadata(bucket)
+		if err != nil || meta.loggingConfig == nil || !meta.loggingConfig.Enabled() {
+			return nil, false
+		}
+		return meta.loggingConfig, true
+	}
+
+	// Bucket metadata is loaded for all buckets at startup and
+	// kept in sync by peers, never load it for unknown buckets here.
+	meta, err := globalBucketMetadataSys.Get(bucket)
+	if err != nil || meta.loggingConfig == nil || !meta.loggingConfig.Enabled() {
+		return nil, false
+	}
+	return meta.loggingConfig, true
+}
+
+// Enabled - returns true if server access logging is enabled for the bucket.
+func (sys *bucketAccessLogSys) Enabled(bucket string) bool {
+	_, ok := getBucketLoggingConfig(bucket)
+	return ok
+}
+
+// Send - queues the server access log record of a request.
+func (sys *bucketAccessLogSys) Send(ctx context.Context, entry audit.Entry, r *http.Request) {
+	reqInfo := logger.GetReqInfo(ctx)
+	if reqInfo == nil {
+		return
+	}
+	config, ok := getBucketLoggingConfig(reqInfo.BucketName)
+	if !ok {
+		return
+	}
+
+	dest := accessLogDestination{
+		bucket: config.LoggingEnabled.TargetBucket,
+		prefix: config.LoggingEnabled.TargetPrefix,
+	}
+	line := newAccessLogEntry(reqInfo, entry, r).String()
+
+	sys.Lock()
+	buf, ok := sys.buffers[dest]
+	if !ok {
+		buf = &bytes.Buffer{}
+		sys.buffers[dest] = buf
+	}
+	buf.WriteString(line)
+	buf.WriteByte('\n')
+	full := buf.Len() >= accessLogMaxBufferSize
+	sys.Unlock()
+
+	if full {
+		select {
+		case sys.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (sys *bucketAccessLogSys) run(ctx context.Context) {
+	ticker := time.NewTicker(accessLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-sys.flushCh:
+		}
+		sys.flush(ctx)
+	}
+}
+
+// flush - writes all buffered server access logs as log objects named
+// `<TargetPrefix>YYYY-mm-DD-HH-MM-SS-<UniqueString>`.
+func (sys *bucketAccessLogSys) flush(ctx context.Context) {
+	sys.Lock()
+	buffers := sys.buffers
+	sys.buffers = make(map[accessLogDestination]*bytes.Buffer)
+	sys.Unlock()
+
+	for dest, buf := range buffers {
+		uniqueID := strings.ToUpper(strings.ReplaceAll(mustGetUUID(), "-", ""))[:16]
+		object := dest.prefix + UTCNow().Format("2006-01-02-15-04-05") + "-" + uniqueID
+
+		size := int64(buf.Len())
+		hr, err := hash.NewReader(buf, size, "", "", size)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		opts := ObjectOptions{
+			UserDefined:      map[string]string{xhttp.ContentType: "text/plain"},
+			Versioned:        globalBucketVersioningSys.Enabled(dest.bucket),
+			VersionSuspended: globalBucketVersioningSys.Suspended(dest.bucket),
+		}
+		if _, err = sys.objAPI.PutObject(ctx, dest.bucket, object, NewPutObjReader(hr), opts); err != nil {
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
+// newAccessLogEntry - builds the server access log record of a request
+// from its audit entry.
+func newAccessLogEntry(reqInfo *logger.ReqInfo, entry audit.Entry, r *http.Request) logging.Entry {
+	e := logging.Entry{
+		Bucket:     reqInfo.BucketName,
+		Time:       UTCNow(),
+		RemoteIP:   entry.RemoteHost,
+		Requester:  reqInfo.AccessKey,
+		RequestID:  entry.RequestID,
+		Operation:  "REST." + r.Method + ".BUCKET",
+		Key:        reqInfo.ObjectName,
+		RequestURI: r.Method + " " + r.URL.RequestURI() + " " + r.Proto,
+		HTTPStatus: entry.API.StatusCode,
+		BytesSent:  -1,
+		ObjectSize: -1,
+		Referer:    r.Referer(),
+		UserAgent:  entry.UserAgent,
+		VersionID:  entry.RespHeader[xhttp.AmzVersionID],
+		HostID:     entry.DeploymentID,
+		HostHeader: r.Host,
+	}
+	if t, err := time.Parse(time.RFC3339Nano, entry.Time); err == nil {
+		e.Time = t
+	}
+	if reqInfo.ObjectName != "" {
+		e.Operation = "REST." + r.Method + ".OBJECT"
+	}
+	if e.VersionID == "" {
+		e.VersionID = r.URL.Query().Get(xhttp.VersionID)
+	}
+	if d, err := time.ParseDuration(entry.API.TimeToResponse); err == nil {
+		e.TotalTime = d
+	}
+	if d, err := time.ParseDuration(entry.API.TimeToFirstByte); err == nil {
+		e.TurnAroundTime = d
+	}
+	if n, err := strconv.ParseInt(entry.RespHeader[xhttp.ContentLength], 10, 64); err == nil {
+		e.BytesSent = n
+	}
+
+	// Object size of full and ranged reads and of uploads.
+	if reqInfo.ObjectName != "" {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			if cr := entry.RespHeader[xhttp.ContentRange]; cr != "" {
+				if i := strings.LastIndex(cr, "/"); i >= 0 {
+					if n, err := strconv.ParseInt(cr[i+1:], 10, 64); err == nil {
+						e.ObjectSize = n
+					}
+				}
+			} else if e.HTTPStatus == http.StatusOK {
+				e.ObjectSize = e.BytesSent
+			}
+		case http.MethodPut:
+			e.ObjectSize = r.ContentLength
+			if n, err := strconv.ParseInt(r.Header.Get(xhttp.AmzDecodedContentLength), 10, 64); err == nil {
+				e.ObjectSize = n
+			}
+		}
+	}
+
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer:
+		e.SignatureVersion, e.AuthType = "SigV4", "AuthHeader"
+	case authTypePresigned:
+		e.SignatureVersion, e.AuthType = "SigV4", "QueryString"
+	case authTypeSignedV2:
+		e.SignatureVersion, e.AuthType = "SigV2", "AuthHeader"
+	case authTypePresignedV2:
+		e.SignatureVersion, e.AuthType = "SigV2", "QueryString"
+	case authTypePostPolicy:
+		e.SignatureVersion = "SigV4"
+	}
+
+	if r.TLS != nil {
+		e.CipherSuite = tls.CipherSuiteName(r.TLS.CipherSuite)
+		switch r.TLS.Version {
+		case tls.VersionTLS10:
+			e.TLSVersion = "TLSv1"
+		case tls.VersionTLS11:
+			e.TLSVersion = "TLSv1.1"
+		case tls.VersionTLS12:
+			e.TLSVersion = "TLSv1.2"
+		case tls.VersionTLS13:
+			e.TLSVersion = "TLSv1.3"
+		}
+	}
+	return e
+}
@@ -22,30 +22,52 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
+	"github.com/minio/minio/pkg/bucket/inventory"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
 	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/sync/errgroup"
 )
 
+// nasMetadataCacheTTL is the duration the NAS gateway uses the bucket
+// metadata loaded from the backend before loading it again, since the
+// backend may be shared with other gateways.
+const nasMetadataCacheTTL = time.Second
+
+// nasBucketMetadata is the bucket metadata cached by the NAS gateway.
+type nasBucketMetadata struct {
+	meta     BucketMetadata
+	loadedAt time.Time
+}
+
 // BucketMetadataSys captures all bucket metadata for a given cluster.
 type BucketMetadataSys struct {
 	sync.RWMutex
 	metadataMap map[string]BucketMetadata
+
+	// Only used by the NAS gateway.
+	nasMetadataMap map[string]nasBucketMetadata
 }
 
 // Remove bucket metadata from memory.
 func (sys *BucketMetadataSys) Remove(bucket string) {
 	if globalIsGateway {
+		sys.Lock()
+		delete(sys.nasMetadataMap, bucket)
+		sys.Unlock()
 		return
 	}
 	sys.Lock()
@@ -80,6 +102,10 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 	}
 
 	if globalIsGateway {
+		sys.Lock()
+		delete(sys.nasMetadataMap, bucket)
+		sys.Unlock()
+
 		// This code is needed only for gateway implementations.
 		switch configFile {
 		case bucketSSEConfig:
@@ -118,6 +144,60 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 				meta.NotificationConfigXML = configData
 				return meta.Save(GlobalContext, objAPI)
 			}
+		case bucketVersioningConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.VersioningConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
+		case bucketCorsConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.CorsConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
+		case bucketWebsiteConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.WebsiteConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
+		case bucketLoggingConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.LoggingConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
+		case bucketInventoryConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.InventoryConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
+		case objectLockConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.ObjectLockConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
 		case bucketPolicyConfig:
 			if configData == nil {
 				return objAPI.DeleteBucketPolicy(GlobalContext, bucket)
@@ -153,15 +233,17 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 		meta.TaggingConfigXML = configData
 	case bucketQuotaConfigFile:
 		meta.QuotaConfigJSON = configData
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+	case bucketWebsiteConfig:
+		meta.WebsiteConfigXML = configData
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
+	case bucketInventoryConfig:
+		meta.InventoryConfigXML = configData
 	case objectLockConfig:
-		if !globalIsErasure && !globalIsDistErasure {
-			return NotImplemented{}
-		}
 		meta.ObjectLockConfigXML = configData
 	case bucketVersioningConfig:
-		if !globalIsErasure && !globalIsDistErasure {
-			return NotImplemented{}
-		}
 		meta.VersioningConfigXML = configData
 	case bucketReplicationConfig:
 		if !globalIsErasure && !globalIsDistErasure {
@@ -219,6 +301,19 @@ func (sys *BucketMetadataSys) Get(bucket string) (BucketMetadata, error) {
 // GetVersioningConfig returns configured versioning config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetVersioningConfig(bucket string) (*versioning.Versioning, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		return meta.versioningConfig, nil
+	}
+
 	meta, err := sys.GetConfig(bucket)
 	if err != nil {
 		return nil, err
@@ -245,6 +340,22 @@ func (sys *BucketMetadataSys) GetTaggingConfig(bucket string) (*tags.Tags, error
 // GetObjectLockConfig returns configured object lock config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetObjectLockConfig(bucket string) (*objectlock.Config, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if meta.objectLockConfig == nil {
+			return nil, BucketObjectLockConfigNotFound{Bucket: bucket}
+		}
+		return meta.objectLockConfig, nil
+	}
+
 	meta, err := sys.GetConfig(bucket)
 	if err != nil {
 		if errors.Is(err, errConfigNotFound) {
@@ -313,6 +424,134 @@ func (sys *BucketMetadataSys) GetSSEConfig(bucket string) (*bucketsse.BucketSSEC
 	return meta.sseConfig, nil
 }
 
+// GetLoggingConfig returns configured server access logging config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(bucket string) (*logging.Config, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if meta.loggingConfig == nil {
+			return nil, BucketLoggingConfigNotFound{Bucket: bucket}
+		}
+		return meta.loggingConfig, nil
+	}
+
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketLoggingConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.loggingConfig == nil {
+		return nil, BucketLoggingConfigNotFound{Bucket: bucket}
+	}
+	return meta.loggingConfig, nil
+}
+
+// GetInventoryConfig returns configured inventory configs
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetInventoryConfig(bucket string) (*inventory.Configs, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if meta.inventoryConfig == nil {
+			return nil, BucketInventoryConfigNotFound{Bucket: bucket}
+		}
+		return meta.inventoryConfig, nil
+	}
+
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketInventoryConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.inventoryConfig == nil {
+		return nil, BucketInventoryConfigNotFound{Bucket: bucket}
+	}
+	return meta.inventoryConfig, nil
+}
+
+// GetWebsiteConfig returns configured website config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetWebsiteConfig(bucket string) (*website.Config, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if meta.websiteConfig == nil {
+			return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+		}
+		return meta.websiteConfig, nil
+	}
+
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.websiteConfig == nil {
+		return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+	}
+	return meta.websiteConfig, nil
+}
+
+// GetCorsConfig returns configured CORS config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(bucket string) (*cors.Config, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if meta.corsConfig == nil {
+			return nil, BucketCorsConfigNotFound{Bucket: bucket}
+		}
+		return meta.corsConfig, nil
+	}
+
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketCorsConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.corsConfig == nil {
+		return nil, BucketCorsConfigNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, nil
+}
+
 // GetPolicyConfig returns configured bucket policy
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetPolicyConfig(bucket string) (*policy.Policy, error) {
@@ -423,6 +662,39 @@ func (sys *BucketMetadataSys) GetConfig(bucket string) (BucketMetadata, error) {
 	return meta, nil
 }
 
+// getNASMetadata - returns the bucket metadata of the NAS gateway, it
+// is loaded from the backend at most once per nasMetadataCacheTTL.
+func (sys *BucketMetadataSys) getNASMetadata(bucket string) (BucketMetadata, error) {
+	sys.RLock()
+	cached, ok := sys.nasMetadataMap[bucket]
+	sys.RUnlock()
+	if ok && time.Since(cached.loadedAt) < nasMetadataCacheTTL {
+		return cached.meta, nil
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return newBucketMetadata(bucket), errServerNotInitialized
+	}
+	meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+	if err != nil {
+		return meta, err
+	}
+
+	now := time.Now()
+	sys.Lock()
+	// Drop the expired entries, such that requests on
+	// many buckets do not grow the cache unbounded.
+	for b, c := range sys.nasMetadataMap {
+		if now.Sub(c.loadedAt) >= nasMetadataCacheTTL {
+			delete(sys.nasMetadataMap, b)
+		}
+	}
+	sys.nasMetadataMap[bucket] = nasBucketMetadata{meta: meta, loadedAt: now}
+	sys.Unlock()
+	return meta, nil
+}
+
 // Init - initializes bucket metadata system for all buckets.
 func (sys *BucketMetadataSys) Init(ctx context.Context, buckets []BucketInfo, objAPI ObjectLayer) error {
 	if objAPI == nil {
@@ -492,6 +764,7 @@ func (sys *BucketMetadataSys) Reset() {
 // NewBucketMetadataSys - creates new policy system.
 func NewBucketMetadataSys() *BucketMetadataSys {
 	return &BucketMetadataSys{
-		metadataMap: make(map[string]BucketMetadata),
+		metadataMap:    make(map[string]BucketMetadata),
+		nasMetadataMap: make(map[string]nasBucketMetadata),
 	}
 }
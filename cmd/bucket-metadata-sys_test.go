@@ -0,0 +1,106 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// Tests that the NAS gateway caches the bucket metadata loaded from
+// the backend until it is updated.
+func TestBucketMetadataSysNASCache(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	savedObjAPI := newObjectLayerFn()
+	defer setObjectLayer(savedObjAPI)
+	setObjectLayer(obj)
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	savedIsGateway, savedGatewayName := globalIsGateway, globalGatewayName
+	defer func() {
+		globalIsGateway, globalGatewayName = savedIsGateway, savedGatewayName
+	}()
+	globalIsGateway, globalGatewayName = true, NASBackendGateway
+
+	sys := NewBucketMetadataSys()
+	meta, err := sys.getNASMetadata(bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.loggingConfig != nil {
+		t.Fatalf("Expected no logging config, got %v", meta.loggingConfig)
+	}
+
+	loggingXML := []byte(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>` + bucket + `</TargetBucket><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`)
+
+	// Changes of the backend are not seen until the cached metadata expires.
+	stored, err := loadBucketMetadata(ctx, obj, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored.LoggingConfigXML = loggingXML
+	if err = stored.Save(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if meta, err = sys.getNASMetadata(bucket); err != nil {
+		t.Fatal(err)
+	}
+	if meta.loggingConfig != nil {
+		t.Fatalf("Expected the cached metadata, got logging config %v", meta.loggingConfig)
+	}
+
+	// Updates through the gateway invalidate the cached metadata.
+	if err = sys.Update(bucket, bucketLoggingConfig, loggingXML); err != nil {
+		t.Fatal(err)
+	}
+	if meta, err = sys.getNASMetadata(bucket); err != nil {
+		t.Fatal(err)
+	}
+	if meta.loggingConfig == nil || !meta.loggingConfig.Enabled() {
+		t.Fatalf("Expected logging to be enabled, got %v", meta.loggingConfig)
+	}
+
+	// CORS requests, including preflight, use the cached metadata.
+	savedBucketMetadataSys := globalBucketMetadataSys
+	defer func() { globalBucketMetadataSys = savedBucketMetadataSys }()
+	globalBucketMetadataSys = sys
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/"+bucket+"/object", nil)
+	if _, ok := getBucketCorsConfig(preflight); ok {
+		t.Fatal("Expected no CORS config")
+	}
+	corsXML := []byte(`<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`)
+	if err = sys.Update(bucket, bucketCorsConfig, corsXML); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := getBucketCorsConfig(preflight); !ok {
+		t.Fatal("Expected the updated CORS config")
+	}
+}
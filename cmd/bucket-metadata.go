@@ -31,12 +31,16 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
+	"github.com/minio/minio/pkg/bucket/inventory"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
 	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/fips"
 	"github.com/minio/minio/pkg/kms"
@@ -80,6 +84,10 @@ type BucketMetadata struct {
 	ReplicationConfigXML        []byte
 	BucketTargetsConfigJSON     []byte
 	BucketTargetsConfigMetaJSON []byte
+	CorsConfigXML               []byte
+	WebsiteConfigXML            []byte
+	LoggingConfigXML            []byte
+	InventoryConfigXML          []byte
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig           *policy.Policy
@@ -93,6 +101,10 @@ type BucketMetadata struct {
 	replicationConfig      *replication.Config
 	bucketTargetConfig     *madmin.BucketTargets
 	bucketTargetConfigMeta map[string]string
+	corsConfig             *cors.Config
+	websiteConfig          *website.Config
+	loggingConfig          *logging.Config
+	inventoryConfig        *inventory.Configs
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -249,6 +261,42 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	} else {
 		b.bucketTargetConfig = &madmin.BucketTargets{}
 	}
+
+	if len(b.CorsConfigXML) != 0 {
+		b.corsConfig, err = cors.ParseConfig(bytes.NewReader(b.CorsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.corsConfig = nil
+	}
+
+	if len(b.WebsiteConfigXML) != 0 {
+		b.websiteConfig, err = website.ParseConfig(bytes.NewReader(b.WebsiteConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.websiteConfig = nil
+	}
+
+	if len(b.LoggingConfigXML) != 0 {
+		b.loggingConfig, err = logging.ParseConfig(bytes.NewReader(b.LoggingConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.loggingConfig = nil
+	}
+
+	if len(b.InventoryConfigXML) != 0 {
+		b.inventoryConfig, err = inventory.ParseConfigs(bytes.NewReader(b.InventoryConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.inventoryConfig = nil
+	}
 	return nil
 }
 
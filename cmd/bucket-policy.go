@@ -88,7 +88,7 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 	switch authType {
 	case authTypeSignedV2, authTypePresignedV2:
 		signatureVersion = signV2Algorithm
-	case authTypeSigned, authTypePresigned, authTypeStreamingSigned, authTypePostPolicy:
+	case authTypeSigned, authTypePresigned, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer, authTypePostPolicy:
 		signatureVersion = signV4Algorithm
 	}
 
@@ -96,7 +96,7 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 	switch authType {
 	case authTypePresignedV2, authTypePresigned:
 		authtype = "REST-QUERY-STRING"
-	case authTypeSignedV2, authTypeSigned, authTypeStreamingSigned:
+	case authTypeSignedV2, authTypeSigned, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer:
 		authtype = "REST-HEADER"
 	case authTypePostPolicy:
 		authtype = "POST"
@@ -0,0 +1,438 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	replicationResyncStateVersionV1 = 1
+	replicationResyncStateVersion   = replicationResyncStateVersionV1
+
+	replicationResyncFile = "resync.json"
+
+	// A resync checkpoints its progress once this many versions
+	// are replicated.
+	replicationResyncBatchSize = 1000
+)
+
+// replicationResyncState is the progress of the resyncs of the targets
+// of a bucket by target ARN, as saved in .minio.sys.
+type replicationResyncState struct {
+	Version int                                  `json:"version"`
+	Targets map[string]madmin.TargetResyncStatus `json:"targets"`
+}
+
+func replicationResyncPath(bucket string) string {
+	return pathJoin(bucketMetaPrefix, bucket, "replication", replicationResyncFile)
+}
+
+func loadReplicationResyncState(ctx context.Context, o objectIO, bucket string) (replicationResyncState, error) {
+	s := replicationResyncState{
+		Version: replicationResyncStateVersion,
+		Targets: make(map[string]madmin.TargetResyncStatus),
+	}
+	data, err := readConfig(ctx, o, replicationResyncPath(bucket))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return s, nil
+		}
+		return s, err
+	}
+	if err = json.Unmarshal(data, &s); err != nil {
+		return s, err
+	}
+	if s.Version != replicationResyncStateVersionV1 {
+		return s, fmt.Errorf("unexpected replication resync state version of %s: %d", bucket, s.Version)
+	}
+	if s.Targets == nil {
+		s.Targets = make(map[string]madmin.TargetResyncStatus)
+	}
+	return s, nil
+}
+
+func saveReplicationResyncState(ctx context.Context, o objectIO, bucket string, s replicationResyncState) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, o, replicationResyncPath(bucket), data)
+}
+
+// updateReplicationResyncState applies fn to the saved resync state of
+// the bucket. The resyncs of the targets of a bucket may run on
+// different servers, hence the state is updated under a namespace lock.
+func updateReplicationResyncState(ctx context.Context, objAPI ObjectLayer, bucket string, fn func(*replicationResyncState) error) error {
+	lk := objAPI.NewNSLock(minioMetaBucket, replicationResyncPath(bucket)+".lock")
+	ctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	s, err := loadReplicationResyncState(ctx, objAPI, bucket)
+	if err != nil {
+		return err
+	}
+	if err = fn(&s); err != nil {
+		return err
+	}
+	return saveReplicationResyncState(ctx, objAPI, bucket, s)
+}
+
+// targetResync is the progress of a resync running on this server.
+type targetResync struct {
+	bucket string
+	arn    string
+
+	mu     sync.RWMutex
+	status madmin.TargetResyncStatus
+
+	// versions queued and not yet replicated.
+	inflight sync.WaitGroup
+}
+
+func (r *targetResync) snapshot() madmin.TargetResyncStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+// marker returns the last object replicated, the resync resumes after it.
+func (r *targetResync) marker() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status.Object
+}
+
+// checkpoint records that the objects up to object are replicated.
+func (r *targetResync) checkpoint(object string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.Object = object
+	r.status.LastUpdate = UTCNow()
+}
+
+// track records the outcome of the replication of a version queued
+// by the resync, it is called by the replication workers.
+func (r *targetResync) track(oi ObjectInfo, status replication.StatusType) {
+	if r == nil {
+		return
+	}
+	defer r.inflight.Done()
+
+	size, _ := oi.GetActualSize()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if status == replication.Completed {
+		r.status.ReplicatedCount++
+		r.status.ReplicatedSize += size
+	} else {
+		r.status.FailedCount++
+		r.status.FailedSize += size
+	}
+	r.status.LastUpdate = UTCNow()
+}
+
+// wait waits for the versions queued to be replicated.
+func (r *targetResync) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// finish records how the resync ended.
+func (r *targetResync) finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil || r.status.FailedCount > 0 {
+		r.status.Status = madmin.ResyncFailed
+	} else {
+		r.status.Status = madmin.ResyncCompleted
+	}
+	r.status.LastUpdate = UTCNow()
+}
+
+// replicationResyncer runs the resyncs of bucket targets started on this
+// server. A resync is run by the server it was started on, which resumes
+// it after a restart.
+type replicationResyncer struct {
+	mu       sync.Mutex
+	objLayer ObjectLayer
+	resyncs  map[string]*targetResync
+}
+
+func newReplicationResyncer() *replicationResyncer {
+	return &replicationResyncer{
+		resyncs: make(map[string]*targetResync),
+	}
+}
+
+func replicationResyncKey(bucket, arn string) string {
+	return pathJoin(bucket, arn)
+}
+
+// Init resumes the resyncs of this server interrupted by a restart.
+func (p *replicationResyncer) Init(ctx context.Context, objAPI ObjectLayer) error {
+	p.mu.Lock()
+	p.objLayer = objAPI
+	p.mu.Unlock()
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		s, err := loadReplicationResyncState(ctx, objAPI, bucket.Name)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for _, status := range s.Targets {
+			if status.Status != madmin.ResyncStarted || status.Node != globalLocalNodeName {
+				continue
+			}
+			p.mu.Lock()
+			p.start(bucket.Name, status)
+			p.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// resync validates the target, saves the new resync and starts it.
+func (p *replicationResyncer) resync(ctx context.Context, bucket, arn string) (madmin.TargetResyncStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.objLayer == nil {
+		return madmin.TargetResyncStatus{}, errServerNotInitialized
+	}
+
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return madmin.TargetResyncStatus{}, err
+	}
+	if !cfg.HasExistingObjectReplication(arn) {
+		return madmin.TargetResyncStatus{}, BucketReplicationExistingObjectsDisabled{Bucket: bucket}
+	}
+	if tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn); tgt == nil {
+		return madmin.TargetResyncStatus{}, BucketRemoteTargetNotFound{Bucket: bucket}
+	}
+
+	var status madmin.TargetResyncStatus
+	err = updateReplicationResyncState(ctx, p.objLayer, bucket, func(s *replicationResyncState) error {
+		if s.Targets[arn].Status == madmin.ResyncStarted {
+			return BucketReplicationResyncInProgress{Bucket: bucket}
+		}
+		now := UTCNow()
+		status = madmin.TargetResyncStatus{
+			Arn:        arn,
+			ResyncID:   mustGetUUID(),
+			Node:       globalLocalNodeName,
+			StartTime:  now,
+			LastUpdate: now,
+			Status:     madmin.ResyncStarted,
+		}
+		s.Targets[arn] = status
+		return nil
+	})
+	if err != nil {
+		return madmin.TargetResyncStatus{}, err
+	}
+	p.start(bucket, status)
+	return status, nil
+}
+
+// start runs the resync in the background from its last checkpoint,
+// p.mu must be held.
+func (p *replicationResyncer) start(bucket string, status madmin.TargetResyncStatus) {
+	r := &targetResync{
+		bucket: bucket,
+		arn:    status.Arn,
+		status: status,
+	}
+	p.resyncs[replicationResyncKey(bucket, status.Arn)] = r
+	go p.run(GlobalContext, r)
+}
+
+// status returns the progress of the resyncs of the targets of the
+// bucket, those running on this server are up to date.
+func (p *replicationResyncer) status(ctx context.Context, bucket string) (madmin.BucketResyncStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.objLayer == nil {
+		return madmin.BucketResyncStatus{}, errServerNotInitialized
+	}
+
+	s, err := loadReplicationResyncState(ctx, p.objLayer, bucket)
+	if err != nil {
+		return madmin.BucketResyncStatus{}, err
+	}
+	res := madmin.BucketResyncStatus{
+		Bucket:  bucket,
+		Targets: []madmin.TargetResyncStatus{},
+	}
+	for arn, status := range s.Targets {
+		if r, ok := p.resyncs[replicationResyncKey(bucket, arn)]; ok {
+			status = r.snapshot()
+		}
+		res.Targets = append(res.Targets, status)
+	}
+	sort.Slice(res.Targets, func(i, j int) bool {
+		return res.Targets[i].Arn < res.Targets[j].Arn
+	})
+	return res, nil
+}
+
+// save records the progress of the resync.
+func (p *replicationResyncer) save(ctx context.Context, r *targetResync) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.persist(ctx, r)
+}
+
+// persist records the progress of the resync, p.mu must be held.
+func (p *replicationResyncer) persist(ctx context.Context, r *targetResync) error {
+	return updateReplicationResyncState(ctx, p.objLayer, r.bucket, func(s *replicationResyncState) error {
+		s.Targets[r.arn] = r.snapshot()
+		return nil
+	})
+}
+
+func (p *replicationResyncer) run(ctx context.Context, r *targetResync) {
+	err := p.replicate(ctx, r)
+	if ctx.Err() != nil {
+		// The server is stopping, the resync resumes when it restarts.
+		logger.LogIf(GlobalContext, p.save(GlobalContext, r))
+		return
+	}
+	if err != nil {
+		logger.LogIf(GlobalContext, fmt.Errorf("replication resync of %s to %s failed: %w", r.bucket, r.arn, err))
+	}
+	// The outcome is saved before the resync is no longer reported
+	// as running.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r.finish(err)
+	logger.LogIf(GlobalContext, p.persist(GlobalContext, r))
+	delete(p.resyncs, replicationResyncKey(r.bucket, r.arn))
+}
+
+// resyncEligible returns true if the version is replicated by a resync of the target arn.
+func resyncEligible(cfg *replication.Config, arn string, oi ObjectInfo) bool {
+	if oi.DeleteMarker || oi.ReplicationStatus == replication.Replica {
+		return false
+	}
+	return cfg.Replicate(replication.ObjectOpts{
+		Name:           oi.Name,
+		UserTags:       oi.UserTags,
+		VersionID:      oi.VersionID,
+		SSEC:           crypto.SSEC.IsEncrypted(oi.UserDefined),
+		OpType:         replication.ExistingObjectReplicationType,
+		ExistingObject: true,
+		TargetArn:      arn,
+	})
+}
+
+// replicate walks all the versions of the bucket after the checkpoint
+// and queues those eligible into the replication pool. The versions
+// already on the target are not copied again by the workers.
+func (p *replicationResyncer) replicate(ctx context.Context, r *targetResync) error {
+	cfg, err := getReplicationConfig(ctx, r.bucket)
+	if err != nil {
+		return err
+	}
+	if !cfg.HasExistingObjectReplication(r.arn) {
+		return BucketReplicationExistingObjectsDisabled{Bucket: r.bucket}
+	}
+
+	walkCtx, cancel := context.WithCancel(ctx)
+	results := make(chan ObjectInfo, 100)
+	if err = p.objLayer.Walk(walkCtx, r.bucket, "", results, ObjectOptions{WalkVersions: true}); err != nil {
+		cancel()
+		return err
+	}
+	defer func() {
+		cancel()
+		// Unblock the walk.
+		for range results {
+		}
+	}()
+
+	marker := r.marker()
+	var object string
+	var queued int
+	for oi := range results {
+		if marker != "" && oi.Name <= marker {
+			continue
+		}
+		// Versions are listed newest first, an object is checkpointed
+		// once all its versions are replicated.
+		if oi.Name != object {
+			if queued >= replicationResyncBatchSize {
+				if err = r.wait(ctx); err != nil {
+					return err
+				}
+				r.checkpoint(object)
+				logger.LogIf(ctx, p.save(ctx, r))
+				queued = 0
+			}
+			object = oi.Name
+		}
+		if !resyncEligible(cfg, r.arn, oi) {
+			continue
+		}
+		r.inflight.Add(1)
+		err = globalReplicationPool.queueResyncTask(ctx, ReplicateObjectInfo{
+			ObjectInfo: oi,
+			OpType:     replication.ExistingObjectReplicationType,
+			TargetArn:  r.arn,
+			resync:     r,
+		})
+		if err != nil {
+			r.inflight.Done()
+			return err
+		}
+		queued++
+	}
+	if err = r.wait(ctx); err != nil {
+		return err
+	}
+	if object != "" {
+		r.checkpoint(object)
+	}
+	return nil
+}
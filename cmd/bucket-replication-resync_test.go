@@ -0,0 +1,325 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestResyncEligible(t *testing.T) {
+	cfg := &replication.Config{
+		RoleArn: "arn:minio:replication::id:dest",
+		Rules: []replication.Rule{
+			{
+				Status:                    replication.Enabled,
+				DeleteMarkerReplication:   replication.DeleteMarkerReplication{Status: replication.Enabled},
+				DeleteReplication:         replication.DeleteReplication{Status: replication.Enabled},
+				ExistingObjectReplication: replication.ExistingObjectReplication{Status: replication.Enabled},
+				Filter:                    replication.Filter{Prefix: "data/"},
+			},
+		},
+	}
+	testCases := []struct {
+		oi       ObjectInfo
+		eligible bool
+	}{
+		{ObjectInfo{Name: "data/a"}, true},
+		{ObjectInfo{Name: "data/a", ReplicationStatus: replication.Completed}, true},
+		{ObjectInfo{Name: "data/a", ReplicationStatus: replication.Replica}, false},
+		{ObjectInfo{Name: "data/a", DeleteMarker: true}, false},
+		{ObjectInfo{Name: "data/a", UserDefined: map[string]string{crypto.MetaSealedKeySSEC: "key"}}, false},
+		{ObjectInfo{Name: "other/a"}, false},
+	}
+	for i, testCase := range testCases {
+		if eligible := resyncEligible(cfg, cfg.RoleArn, testCase.oi); eligible != testCase.eligible {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.eligible, eligible)
+		}
+	}
+	if resyncEligible(cfg, "arn:minio:replication::id2:dest", ObjectInfo{Name: "data/a"}) {
+		t.Errorf("expected versions not to be resynced to another target")
+	}
+}
+
+// fakeReplicationTarget is a remote target that holds no object and
+// records the objects replicated to it, or denies them when deny is set.
+type fakeReplicationTarget struct {
+	mu      sync.Mutex
+	objects []string
+	deny    bool
+	gate    chan struct{}
+}
+
+func (f *fakeReplicationTarget) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusNotFound)
+	case http.MethodPut:
+		<-f.gate
+		io.Copy(ioutil.Discard, r.Body)
+		f.mu.Lock()
+		if f.deny {
+			f.mu.Unlock()
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		f.objects = append(f.objects, strings.TrimPrefix(r.URL.Path, "/dest/")+"?"+r.URL.Query().Get("versionId"))
+		f.mu.Unlock()
+		w.Header().Set(xhttp.ETag, `"00000000000000000000000000000000"`)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (f *fakeReplicationTarget) setDeny(deny bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deny = deny
+}
+
+func (f *fakeReplicationTarget) replicated() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.objects...)
+}
+
+func TestReplicationResync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+	defer objLayer.Shutdown(context.Background())
+
+	// Listings and bucket metadata look up the object layer.
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(objLayer)
+	defer func(isErasure bool) { globalIsErasure = isErasure }(globalIsErasure)
+	globalIsErasure = true
+
+	target := &fakeReplicationTarget{gate: make(chan struct{})}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalReplicationPool = NewReplicationPool(ctx, objLayer, 2)
+	defer func() { globalReplicationPool = nil }()
+	globalReplicationResyncer = newReplicationResyncer()
+	defer func() { globalReplicationResyncer = nil }()
+	if err = globalReplicationResyncer.Init(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "source"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	for _, object := range []string{"data/a", "data/a", "data/b", "other/c"} {
+		data := []byte(object)
+		oi, err := objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasPrefix(object, "data/") {
+			versions = append(versions, object+"?"+oi.VersionID)
+		}
+	}
+
+	arn := "arn:minio:replication::resync:dest"
+	targets := madmin.BucketTargets{
+		Targets: []madmin.BucketTarget{
+			{
+				SourceBucket: bucket,
+				Endpoint:     u.Host,
+				Credentials:  &auth.Credentials{AccessKey: "minio", SecretKey: "minio123"},
+				TargetBucket: "dest",
+				Arn:          arn,
+				Type:         madmin.ReplicationService,
+				Region:       "us-east-1",
+			},
+		},
+	}
+	data, err := json.Marshal(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketTargetsFile, data); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketTargetSys.UpdateAllTargets(bucket, &targets)
+
+	replicationConfig := func(existingObjects replication.Status) []byte {
+		return []byte(`<ReplicationConfiguration><Role>` + arn + `</Role><Rule><Status>Enabled</Status><Priority>1</Priority>` +
+			`<DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication>` +
+			`<ExistingObjectReplication><Status>` + string(existingObjects) + `</Status></ExistingObjectReplication>` +
+			`<Filter><Prefix>data/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::dest</Bucket></Destination></Rule></ReplicationConfiguration>`)
+	}
+
+	// Existing objects are not replicated.
+	if err = globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, replicationConfig(replication.Disabled)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalReplicationResyncer.resync(ctx, bucket, arn); !errors.As(err, &BucketReplicationExistingObjectsDisabled{}) {
+		t.Fatalf("Expected existing object replication to be disabled, got %v", err)
+	}
+
+	if err = globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, replicationConfig(replication.Enabled)); err != nil {
+		t.Fatal(err)
+	}
+	// Unknown target.
+	if _, err = globalReplicationResyncer.resync(ctx, bucket, "arn:minio:replication::unknown:dest"); !errors.As(err, &BucketReplicationExistingObjectsDisabled{}) {
+		t.Fatalf("Expected existing object replication to be disabled for an unknown target, got %v", err)
+	}
+
+	started, err := globalReplicationResyncer.resync(ctx, bucket, arn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started.Arn != arn || started.Status != madmin.ResyncStarted || started.Node != globalLocalNodeName {
+		t.Fatalf("Unexpected resync %+v", started)
+	}
+	// The target blocks the replication, the resync is in progress.
+	if _, err = globalReplicationResyncer.resync(ctx, bucket, arn); !errors.As(err, &BucketReplicationResyncInProgress{}) {
+		t.Fatalf("Expected the resync to be in progress, got %v", err)
+	}
+	close(target.gate)
+
+	var status madmin.TargetResyncStatus
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		res, err := globalReplicationResyncer.status(ctx, bucket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Targets) != 1 {
+			t.Fatalf("Expected the resync of one target, got %+v", res)
+		}
+		status = res.Targets[0]
+		if status.Status != madmin.ResyncStarted {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Resync did not complete: %+v", status)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if status.Status != madmin.ResyncCompleted || status.ResyncID != started.ResyncID {
+		t.Fatalf("Unexpected resync %+v", status)
+	}
+	if status.ReplicatedCount != 3 || status.FailedCount != 0 || status.Object != "other/c" {
+		t.Fatalf("Unexpected resync progress %+v", status)
+	}
+
+	replicated := target.replicated()
+	if len(replicated) != len(versions) {
+		t.Fatalf("Expected %v to be replicated, got %v", versions, replicated)
+	}
+	for _, version := range versions {
+		found := false
+		for _, r := range replicated {
+			found = found || r == version
+		}
+		if !found {
+			t.Fatalf("Expected %v to be replicated, got %v", versions, replicated)
+		}
+	}
+	oi, err := objLayer.GetObjectInfo(ctx, bucket, "data/b", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.ReplicationStatus != replication.Completed {
+		t.Fatalf("Expected data/b to be replicated, got status %q", oi.ReplicationStatus)
+	}
+
+	// The progress is saved.
+	s, err := loadReplicationResyncState(ctx, objLayer, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved := s.Targets[arn]; saved != status {
+		t.Fatalf("Expected %+v to be saved, got %+v", status, saved)
+	}
+}
+
+func TestUpdateReplicationResyncStateConcurrent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+	defer objLayer.Shutdown(context.Background())
+
+	// The resyncs of the targets of a bucket save their progress at
+	// the same time, none of the updates may be lost.
+	bucket := "source"
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(arn string) {
+			defer wg.Done()
+			errs <- updateReplicationResyncState(ctx, objLayer, bucket, func(s *replicationResyncState) error {
+				s.Targets[arn] = madmin.TargetResyncStatus{Arn: arn, Status: madmin.ResyncStarted}
+				return nil
+			})
+		}(fmt.Sprintf("arn:minio:replication::resync-%d:dest", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := loadReplicationResyncState(ctx, objLayer, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Targets) != 10 {
+		t.Fatalf("Expected 10 saved targets, got %d", len(s.Targets))
+	}
+}
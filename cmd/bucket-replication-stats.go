@@ -53,61 +53,69 @@ func (r *ReplicationStats) Delete(bucket string) {
 
 }
 
-// Update updates in-memory replication statistics with new values.
-func (r *ReplicationStats) Update(bucket string, n int64, status, prevStatus replication.StatusType, opType replication.Type) {
+// Update updates in-memory replication statistics of the target arn with new values,
+// replica statistics are not specific to a target.
+func (r *ReplicationStats) Update(bucket, arn string, n int64, status, prevStatus replication.StatusType, opType replication.Type) {
 	if r == nil {
 		return
 	}
 
-	r.RLock()
+	r.Lock()
 	b, ok := r.Cache[bucket]
 	if !ok {
-		b = &BucketReplicationStats{}
+		b = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+		r.Cache[bucket] = b
+	}
+	if status == replication.Replica {
+		if opType == replication.ObjectReplicationType {
+			atomic.AddUint64(&b.ReplicaSize, uint64(n))
+		}
+		r.Unlock()
+		return
 	}
-	r.RUnlock()
+	st, ok := b.Stats[arn]
+	if !ok {
+		st = &BucketReplicationStat{}
+		b.Stats[arn] = st
+	}
+	r.Unlock()
+
 	switch status {
 	case replication.Pending:
 		if opType == replication.ObjectReplicationType {
-			atomic.AddUint64(&b.PendingSize, uint64(n))
+			atomic.AddUint64(&st.PendingSize, uint64(n))
 		}
-		atomic.AddUint64(&b.PendingCount, 1)
+		atomic.AddUint64(&st.PendingCount, 1)
 	case replication.Completed:
 		switch prevStatus { // adjust counters based on previous state
 		case replication.Pending:
-			atomic.AddUint64(&b.PendingCount, ^uint64(0))
+			atomic.AddUint64(&st.PendingCount, ^uint64(0))
 		case replication.Failed:
-			atomic.AddUint64(&b.FailedCount, ^uint64(0))
+			atomic.AddUint64(&st.FailedCount, ^uint64(0))
 		}
 		if opType == replication.ObjectReplicationType {
-			atomic.AddUint64(&b.ReplicatedSize, uint64(n))
+			atomic.AddUint64(&st.ReplicatedSize, uint64(n))
 			switch prevStatus {
 			case replication.Pending:
-				atomic.AddUint64(&b.PendingSize, ^uint64(n-1))
+				atomic.AddUint64(&st.PendingSize, ^uint64(n-1))
 			case replication.Failed:
-				atomic.AddUint64(&b.FailedSize, ^uint64(n-1))
+				atomic.AddUint64(&st.FailedSize, ^uint64(n-1))
 			}
 		}
 	case replication.Failed:
 		// count failures only once - not on every retry
 		switch prevStatus { // adjust counters based on previous state
 		case replication.Pending:
-			atomic.AddUint64(&b.PendingCount, ^uint64(0))
+			atomic.AddUint64(&st.PendingCount, ^uint64(0))
 		}
 		if opType == replication.ObjectReplicationType {
 			if prevStatus == replication.Pending {
-				atomic.AddUint64(&b.FailedSize, uint64(n))
-				atomic.AddUint64(&b.FailedCount, 1)
-				atomic.AddUint64(&b.PendingSize, ^uint64(n-1))
+				atomic.AddUint64(&st.FailedSize, uint64(n))
+				atomic.AddUint64(&st.FailedCount, 1)
+				atomic.AddUint64(&st.PendingSize, ^uint64(n-1))
 			}
 		}
-	case replication.Replica:
-		if opType == replication.ObjectReplicationType {
-			atomic.AddUint64(&b.ReplicaSize, uint64(n))
-		}
 	}
-	r.Lock()
-	r.Cache[bucket] = b
-	r.Unlock()
 }
 
 // GetInitialUsage get replication metrics available at the time of cluster initialization
@@ -133,7 +141,8 @@ func (r *ReplicationStats) GetInitialUsage(bucket string) BucketReplicationStats
 	}
 }
 
-// Get replication metrics for a bucket from this node since this node came up.
+// Get replication metrics for a bucket from this node since this node came up,
+// the metrics of the bucket are the sum of the metrics of its targets.
 func (r *ReplicationStats) Get(bucket string) BucketReplicationStats {
 	if r == nil {
 		return BucketReplicationStats{}
@@ -147,14 +156,26 @@ func (r *ReplicationStats) Get(bucket string) BucketReplicationStats {
 		return BucketReplicationStats{}
 	}
 
-	return BucketReplicationStats{
-		PendingSize:    atomic.LoadUint64(&st.PendingSize),
-		FailedSize:     atomic.LoadUint64(&st.FailedSize),
-		ReplicatedSize: atomic.LoadUint64(&st.ReplicatedSize),
-		ReplicaSize:    atomic.LoadUint64(&st.ReplicaSize),
-		PendingCount:   atomic.LoadUint64(&st.PendingCount),
-		FailedCount:    atomic.LoadUint64(&st.FailedCount),
+	bs := BucketReplicationStats{
+		Stats:       make(map[string]*BucketReplicationStat, len(st.Stats)),
+		ReplicaSize: atomic.LoadUint64(&st.ReplicaSize),
+	}
+	for arn, tst := range st.Stats {
+		ts := &BucketReplicationStat{
+			PendingSize:    atomic.LoadUint64(&tst.PendingSize),
+			FailedSize:     atomic.LoadUint64(&tst.FailedSize),
+			ReplicatedSize: atomic.LoadUint64(&tst.ReplicatedSize),
+			PendingCount:   atomic.LoadUint64(&tst.PendingCount),
+			FailedCount:    atomic.LoadUint64(&tst.FailedCount),
+		}
+		bs.Stats[arn] = ts
+		bs.PendingSize += ts.PendingSize
+		bs.FailedSize += ts.FailedSize
+		bs.ReplicatedSize += ts.ReplicatedSize
+		bs.PendingCount += ts.PendingCount
+		bs.FailedCount += ts.FailedCount
 	}
+	return bs
 }
 
 // NewReplicationStats initialize in-memory replication statistics
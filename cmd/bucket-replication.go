@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -54,32 +55,50 @@ func getReplicationConfig(ctx context.Context, bucketName string) (rc *replicati
 }
 
 // validateReplicationDestination returns error if replication destination bucket missing or not configured
-// It also returns true if replication destination is same as this server.
+// for any of the targets replicated to. It also returns true if a replication destination is same as this server.
 func validateReplicationDestination(ctx context.Context, bucket string, rCfg *replication.Config) (bool, error) {
-	arn, err := madmin.ParseARN(rCfg.RoleArn)
+	arns := rCfg.TargetArns()
+	if len(arns) == 0 {
+		return false, BucketRemoteArnInvalid{}
+	}
+	var sameTarget bool
+	for _, arnStr := range arns {
+		same, err := validateReplicationTarget(ctx, bucket, arnStr, rCfg.GetTargetDestination(arnStr))
+		if err != nil {
+			return false, err
+		}
+		sameTarget = sameTarget || same
+	}
+	return sameTarget, nil
+}
+
+// validateReplicationTarget returns error if the destination bucket of the target arn is missing or not
+// configured, and true if the target is this server.
+func validateReplicationTarget(ctx context.Context, bucket, arnStr string, dest replication.Destination) (bool, error) {
+	arn, err := madmin.ParseARN(arnStr)
 	if err != nil {
 		return false, BucketRemoteArnInvalid{}
 	}
 	if arn.Type != madmin.ReplicationService {
 		return false, BucketRemoteArnTypeInvalid{}
 	}
-	clnt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rCfg.RoleArn)
+	clnt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arnStr)
 	if clnt == nil {
 		return false, BucketRemoteTargetNotFound{Bucket: bucket}
 	}
-	if found, _ := clnt.BucketExists(ctx, rCfg.GetDestination().Bucket); !found {
-		return false, BucketRemoteDestinationNotFound{Bucket: rCfg.GetDestination().Bucket}
+	if found, _ := clnt.BucketExists(ctx, dest.Bucket); !found {
+		return false, BucketRemoteDestinationNotFound{Bucket: dest.Bucket}
 	}
 	if ret, err := globalBucketObjectLockSys.Get(bucket); err == nil {
 		if ret.LockEnabled {
-			lock, _, _, _, err := clnt.GetObjectLockConfig(ctx, rCfg.GetDestination().Bucket)
+			lock, _, _, _, err := clnt.GetObjectLockConfig(ctx, dest.Bucket)
 			if err != nil || lock != "Enabled" {
-				return false, BucketReplicationDestinationMissingLock{Bucket: rCfg.GetDestination().Bucket}
+				return false, BucketReplicationDestinationMissingLock{Bucket: dest.Bucket}
 			}
 		}
 	}
 	// validate replication ARN against target endpoint
-	c, ok := globalBucketTargetSys.arnRemotesMap[rCfg.RoleArn]
+	c, ok := globalBucketTargetSys.arnRemotesMap[arnStr]
 	if ok {
 		if c.EndpointURL().String() == clnt.EndpointURL().String() {
 			sameTarget, _ := isLocalHost(clnt.EndpointURL().Hostname(), clnt.EndpointURL().Port(), globalMinioPort)
@@ -129,13 +148,16 @@ func mustReplicater(ctx context.Context, bucket, object string, meta map[string]
 	if ok {
 		opts.UserTags = tagStr
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, cfg.RoleArn)
 	// the target online status should not be used here while deciding
-	// whether to replicate as the target could be temporarily down
-	if tgt != nil {
-		return cfg.Replicate(opts), tgt.replicateSync
+	// whether to replicate as the target could be temporarily down,
+	// the replication is synchronous if it is for any of the targets.
+	for _, arn := range cfg.FilterTargetArns(opts) {
+		replicate = true
+		if tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn); tgt != nil && tgt.replicateSync {
+			sync = true
+		}
 	}
-	return cfg.Replicate(opts), false
+	return replicate, sync
 }
 
 // Standard headers that needs to be extracted from User metadata.
@@ -204,13 +226,21 @@ func checkReplicateDelete(ctx context.Context, bucket string, dobj ObjectToDelet
 		// is issued - this still needs to be replicated back to the other target
 		return oi.VersionPurgeStatus == Pending || oi.VersionPurgeStatus == Failed, sync
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rcfg.RoleArn)
 	// the target online status should not be used here while deciding
 	// whether to replicate deletes as the target could be temporarily down
-	if tgt == nil {
+	var found bool
+	for _, arn := range rcfg.FilterTargetArns(opts) {
+		tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+		if tgt == nil {
+			continue
+		}
+		found = true
+		sync = sync || tgt.replicateSync
+	}
+	if !found {
 		return false, false
 	}
-	return replicate, tgt.replicateSync
+	return replicate, sync
 }
 
 // replicate deletes to the designated replication target if replication configuration
@@ -247,9 +277,9 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 		return
 	}
 
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rcfg.RoleArn)
-	if tgt == nil {
-		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, rcfg.RoleArn))
+	arns := replicationDeleteTargets(rcfg, dobj)
+	if len(arns) == 0 {
+		logger.LogIf(ctx, fmt.Errorf("failed to get targets for bucket:%s object:%s", bucket, dobj.ObjectName))
 		sendEvent(eventArgs{
 			BucketName: bucket,
 			Object: ObjectInfo{
@@ -264,26 +294,48 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 		return
 	}
 
-	rmErr := tgt.RemoveObject(ctx, rcfg.GetDestination().Bucket, dobj.ObjectName, miniogo.RemoveObjectOptions{
-		VersionID: versionID,
-		Internal: miniogo.AdvancedRemoveOptions{
-			ReplicationDeleteMarker: dobj.DeleteMarkerVersionID != "",
-			ReplicationMTime:        dobj.DeleteMarkerMTime.Time,
-			ReplicationStatus:       miniogo.ReplicationStatusReplica,
-			ReplicationRequest:      true, // always set this to distinguish between `mc mirror` replication and serverside
-		},
-	})
+	// The delete is replicated to every target, it is retried on all of
+	// them as long as it failed on any.
+	var failed bool
+	for _, arn := range arns {
+		var rmErr error
+		dest := rcfg.GetTargetDestination(arn)
+		if tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn); tgt == nil {
+			rmErr = fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, arn)
+		} else {
+			rmErr = tgt.RemoveObject(ctx, dest.Bucket, dobj.ObjectName, miniogo.RemoveObjectOptions{
+				VersionID: versionID,
+				Internal: miniogo.AdvancedRemoveOptions{
+					ReplicationDeleteMarker: dobj.DeleteMarkerVersionID != "",
+					ReplicationMTime:        dobj.DeleteMarkerMTime.Time,
+					ReplicationStatus:       miniogo.ReplicationStatusReplica,
+					ReplicationRequest:      true, // always set this to distinguish between `mc mirror` replication and serverside
+				},
+			})
+		}
+		currStatus := replication.Completed
+		if rmErr != nil {
+			failed = true
+			currStatus = replication.Failed
+			logger.LogIf(ctx, fmt.Errorf("Unable to replicate delete marker to %s/%s(%s): %s", dest.Bucket, dobj.ObjectName, versionID, rmErr))
+		}
+		prevStatus := replication.StatusType(dobj.DeleteMarkerReplicationStatus)
+		if dobj.VersionID != "" {
+			prevStatus = replication.StatusType(dobj.VersionPurgeStatus)
+		}
+		// to decrement pending count later.
+		globalReplicationStats.Update(dobj.Bucket, arn, 0, currStatus, prevStatus, replication.DeleteReplicationType)
+	}
 
 	replicationStatus := dobj.DeleteMarkerReplicationStatus
 	versionPurgeStatus := dobj.VersionPurgeStatus
 
-	if rmErr != nil {
+	if failed {
 		if dobj.VersionID == "" {
 			replicationStatus = string(replication.Failed)
 		} else {
 			versionPurgeStatus = Failed
 		}
-		logger.LogIf(ctx, fmt.Errorf("Unable to replicate delete marker to %s/%s(%s): %s", rcfg.GetDestination().Bucket, dobj.ObjectName, versionID, rmErr))
 	} else {
 		if dobj.VersionID == "" {
 			replicationStatus = string(replication.Completed)
@@ -291,14 +343,6 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 			versionPurgeStatus = Complete
 		}
 	}
-	prevStatus := dobj.DeleteMarkerReplicationStatus
-	currStatus := replicationStatus
-	if dobj.VersionID != "" {
-		prevStatus = string(dobj.VersionPurgeStatus)
-		currStatus = string(versionPurgeStatus)
-	}
-	// to decrement pending count later.
-	globalReplicationStats.Update(dobj.Bucket, 0, replication.StatusType(currStatus), replication.StatusType(prevStatus), replication.DeleteReplicationType)
 
 	var eventName = event.ObjectReplicationComplete
 	if replicationStatus == string(replication.Failed) || versionPurgeStatus == Failed {
@@ -336,6 +380,23 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 	}
 }
 
+// replicationDeleteTargets returns the ARNs of the targets a delete is replicated
+// to: the ones replicating deletes for the object or, when none does, as for
+// the removal of a delete marker whose creation was replicated, the ones
+// replicating the object.
+func replicationDeleteTargets(rcfg *replication.Config, dobj DeletedObjectVersionInfo) []string {
+	arns := rcfg.FilterTargetArns(replication.ObjectOpts{
+		Name:         dobj.ObjectName,
+		VersionID:    dobj.VersionID,
+		DeleteMarker: dobj.DeleteMarker,
+		OpType:       replication.DeleteReplicationType,
+	})
+	if len(arns) == 0 {
+		arns = rcfg.FilterTargetArns(replication.ObjectOpts{Name: dobj.ObjectName})
+	}
+	return arns
+}
+
 func getCopyObjMetadata(oi ObjectInfo, dest replication.Destination) map[string]string {
 	meta := make(map[string]string, len(oi.UserDefined))
 	for k, v := range oi.UserDefined {
@@ -461,8 +522,13 @@ func putReplicationOpts(ctx context.Context, dest replication.Destination, objIn
 	if lhold, ok := lkMap.Lookup(xhttp.AmzObjectLockLegalHold); ok {
 		putOpts.LegalHold = miniogo.LegalHoldStatus(lhold)
 	}
-	if crypto.S3.IsEncrypted(objInfo.UserDefined) {
+	switch kind, _ := crypto.IsEncrypted(objInfo.UserDefined); kind {
+	case crypto.S3:
 		putOpts.ServerSideEncryption = encrypt.NewSSE()
+	case crypto.S3KMS:
+		// Replicate with the same KMS key such that the target
+		// enforces the same key policy as the source bucket.
+		putOpts.ServerSideEncryption, err = encrypt.NewSSEKMS(objInfo.UserDefined[crypto.MetaKeyID], nil)
 	}
 	return
 }
@@ -569,9 +635,93 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo) replicationActio
 	return replicateNone
 }
 
-// replicateObject replicates the specified version of the object to destination bucket
-// The source object is then updated to reflect the replication status.
-func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI ObjectLayer) {
+// replicationStatusKey holds the replication status of an object version to each
+// of its targets, as "arn1=COMPLETED;arn2=PENDING;". X-Amz-Replication-Status holds
+// their aggregate, a version replicated before targets were tracked only has the
+// latter, which is then the status for all of them.
+const replicationStatusKey = ReservedMetadataPrefixLower + "replication-status"
+
+// targetReplicationStatus is the replication status of an object version by target ARN.
+type targetReplicationStatus map[string]replication.StatusType
+
+// parseTargetReplicationStatus parses the replication status stored in the metadata.
+func parseTargetReplicationStatus(s string) targetReplicationStatus {
+	st := make(targetReplicationStatus)
+	for _, kv := range strings.Split(s, ";") {
+		i := strings.LastIndex(kv, "=")
+		if i <= 0 {
+			continue
+		}
+		st[kv[:i]] = replication.StatusType(kv[i+1:])
+	}
+	return st
+}
+
+// arns returns the ARNs of the targets, sorted.
+func (st targetReplicationStatus) arns() []string {
+	arns := make([]string, 0, len(st))
+	for arn := range st {
+		arns = append(arns, arn)
+	}
+	sort.Strings(arns)
+	return arns
+}
+
+func (st targetReplicationStatus) String() string {
+	var sb strings.Builder
+	for _, arn := range st.arns() {
+		if st[arn].Empty() {
+			continue
+		}
+		sb.WriteString(arn + "=" + st[arn].String() + ";")
+	}
+	return sb.String()
+}
+
+// aggregate returns the replication status of the version to all the targets,
+// pending as long as it is pending for any of them, then failed if it failed
+// for any of them.
+func (st targetReplicationStatus) aggregate() replication.StatusType {
+	var status replication.StatusType
+	for _, s := range st {
+		switch s {
+		case replication.Pending:
+			return replication.Pending
+		case replication.Failed:
+			status = replication.Failed
+		case replication.Completed:
+			if status.Empty() {
+				status = replication.Completed
+			}
+		}
+	}
+	return status
+}
+
+// targetReplicationStatus returns the replication status of the version to each target.
+func (o ObjectInfo) targetReplicationStatus() targetReplicationStatus {
+	return parseTargetReplicationStatus(o.UserDefined[replicationStatusKey])
+}
+
+// setReplicationPending marks an existing version pending replication to all
+// its targets, whatever its replication status to each of them.
+func setReplicationPending(meta map[string]string) {
+	meta[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	meta[replicationStatusKey] = ""
+}
+
+// replicatedTargetInfo is the outcome of the replication of a version to a target.
+type replicatedTargetInfo struct {
+	Arn    string
+	Status replication.StatusType
+	OpType replication.Type
+}
+
+// replicateObject replicates the specified version of the object to the destination bucket
+// of each of its targets concurrently, or only to ri.TargetArn when set. The source object
+// is then updated to reflect the replication status to each target and the aggregated one,
+// which is returned, or the status to ri.TargetArn when set.
+func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI ObjectLayer) replication.StatusType {
 	objInfo := ri.ObjectInfo
 	bucket := objInfo.Bucket
 	object := objInfo.Name
@@ -585,35 +735,39 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			Object:     objInfo,
 			Host:       "Internal: [Replication]",
 		})
-		return
+		return replication.Failed
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, cfg.RoleArn)
-	if tgt == nil {
-		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, cfg.RoleArn))
+
+	// hold write lock for entire transaction, the version is read once per target.
+	lk := objectAPI.NewNSLock(bucket, object)
+	ctx, err = lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
 		sendEvent(eventArgs{
 			EventName:  event.ObjectReplicationNotTracked,
 			BucketName: bucket,
 			Object:     objInfo,
 			Host:       "Internal: [Replication]",
 		})
-		return
+		logger.LogIf(ctx, fmt.Errorf("Unable to update replicate for %s/%s(%s): %w", bucket, object, objInfo.VersionID, err))
+		return replication.Failed
 	}
-	gr, err := objectAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, writeLock, ObjectOptions{
+	defer lk.Unlock()
+
+	objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{
 		VersionID: objInfo.VersionID,
+		NoLock:    true,
 	})
 	if err != nil {
 		sendEvent(eventArgs{
 			EventName:  event.ObjectReplicationNotTracked,
 			BucketName: bucket,
-			Object:     objInfo,
+			Object:     ri.ObjectInfo,
 			Host:       "Internal: [Replication]",
 		})
-		logger.LogIf(ctx, fmt.Errorf("Unable to update replicate for %s/%s(%s): %w", bucket, object, objInfo.VersionID, err))
-		return
+		logger.LogIf(ctx, fmt.Errorf("Unable to update replicate for %s/%s(%s): %w", bucket, object, ri.VersionID, err))
+		return replication.Failed
 	}
-	defer gr.Close() // hold write lock for entire transaction
 
-	objInfo = gr.ObjInfo
 	size, err := objInfo.GetActualSize()
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -623,10 +777,152 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			Object:     objInfo,
 			Host:       "Internal: [Replication]",
 		})
-		return
+		return replication.Failed
+	}
+
+	// The status of the version to each of the targets it is replicated to,
+	// the targets it is no longer replicated to are dropped.
+	prevStatus := objInfo.targetReplicationStatus()
+	tgtStatus := make(targetReplicationStatus)
+	for _, arn := range cfg.FilterTargetArns(replication.ObjectOpts{
+		Name:     object,
+		UserTags: objInfo.UserTags,
+		SSEC:     crypto.SSEC.IsEncrypted(objInfo.UserDefined),
+	}) {
+		if _, ok := prevStatus[arn]; !ok && !objInfo.ReplicationStatus.Empty() {
+			prevStatus[arn] = objInfo.ReplicationStatus
+		}
+		tgtStatus[arn] = prevStatus[arn]
+	}
+
+	var arns []string
+	if ri.TargetArn != "" {
+		arns = append(arns, ri.TargetArn)
+		tgtStatus[ri.TargetArn] = prevStatus[ri.TargetArn]
+	} else {
+		for _, arn := range tgtStatus.arns() {
+			// healing only retries the targets the version is not replicated to.
+			if ri.OpType == replication.HealReplicationType && tgtStatus[arn] == replication.Completed {
+				continue
+			}
+			arns = append(arns, arn)
+		}
+	}
+	if len(arns) == 0 {
+		return objInfo.ReplicationStatus
+	}
+
+	results := make([]replicatedTargetInfo, len(arns))
+	var wg sync.WaitGroup
+	for i, arn := range arns {
+		wg.Add(1)
+		go func(i int, arn string) {
+			defer wg.Done()
+			results[i] = replicateObjectToTarget(ctx, objInfo, size, cfg, arn, objectAPI)
+		}(i, arn)
+	}
+	wg.Wait()
+
+	// Leave metadata in `PENDING` state for the targets inline replication fails to,
+	// to save iops. They are retried once, failures are then counted once.
+	var updated, failed bool
+	for _, rinfo := range results {
+		if rinfo.Status == replication.Failed {
+			failed = true
+			if ri.OpType != replication.HealReplicationType {
+				continue
+			}
+		}
+		tgtStatus[rinfo.Arn] = rinfo.Status
+		updated = true
+		globalReplicationStats.Update(bucket, rinfo.Arn, size, rinfo.Status, prevStatus[rinfo.Arn], rinfo.OpType)
+	}
+
+	// FIXME: add support for missing replication events
+	// - event.ObjectReplicationMissedThreshold
+	// - event.ObjectReplicationReplicatedAfterThreshold
+	var eventName = event.ObjectReplicationComplete
+	if failed {
+		eventName = event.ObjectReplicationFailed
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if updated && ok {
+		objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = tgtStatus.aggregate().String()
+		objInfo.UserDefined[replicationStatusKey] = tgtStatus.String()
+		if objInfo.UserTags != "" {
+			objInfo.UserDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
+		}
+		// This lower level implementation is necessary to avoid write locks from CopyObject.
+		poolIdx, err := z.getPoolIdxExisting(ctx, bucket, object)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w", bucket, objInfo.Name, objInfo.VersionID, err))
+		} else {
+			fi := FileInfo{}
+			fi.VersionID = objInfo.VersionID
+			fi.Metadata = make(map[string]string, len(objInfo.UserDefined))
+			for k, v := range objInfo.UserDefined {
+				fi.Metadata[k] = v
+			}
+			if err = z.serverPools[poolIdx].getHashedSet(object).updateObjectMeta(ctx, bucket, object, fi); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w", bucket, objInfo.Name, objInfo.VersionID, err))
+			}
+		}
+		sendEvent(eventArgs{
+			EventName:  eventName,
+			BucketName: bucket,
+			Object:     objInfo,
+			Host:       "Internal: [Replication]",
+		})
+	}
+
+	// re-queue failures once more - keep a retry count to avoid flooding the queue if
+	// the target site is down. Leave it to scanner to catch up instead.
+	for _, rinfo := range results {
+		if rinfo.Status == replication.Failed && ri.RetryCount < 1 {
+			retry := ri
+			retry.OpType = replication.HealReplicationType
+			retry.RetryCount++
+			retry.TargetArn = rinfo.Arn
+			// The resync reports the outcome of the first attempt only.
+			retry.resync = nil
+			globalReplicationPool.queueReplicaTask(ctx, retry)
+		}
+	}
+
+	if ri.TargetArn != "" {
+		return results[0].Status
+	}
+	if failed {
+		return replication.Failed
+	}
+	return replication.Completed
+}
+
+// replicateObjectToTarget replicates the version of the object to the destination bucket
+// of the target arn, the caller holds the write lock of the object.
+func replicateObjectToTarget(ctx context.Context, objInfo ObjectInfo, size int64, cfg *replication.Config, arn string, objectAPI ObjectLayer) (rinfo replicatedTargetInfo) {
+	bucket := objInfo.Bucket
+	object := objInfo.Name
+
+	rinfo = replicatedTargetInfo{
+		Arn:    arn,
+		Status: replication.Failed,
+		OpType: replication.MetadataReplicationType,
+	}
+	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+	if tgt == nil {
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, arn))
+		sendEvent(eventArgs{
+			EventName:  event.ObjectReplicationNotTracked,
+			BucketName: bucket,
+			Object:     objInfo,
+			Host:       "Internal: [Replication]",
+		})
+		return rinfo
 	}
 
-	dest := cfg.GetDestination()
+	dest := cfg.GetTargetDestination(arn)
 	if dest.Bucket == "" {
 		logger.LogIf(ctx, fmt.Errorf("Unable to replicate object %s(%s), bucket is empty", objInfo.Name, objInfo.VersionID))
 		sendEvent(eventArgs{
@@ -635,7 +931,7 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			Object:     objInfo,
 			Host:       "Internal: [Replication]",
 		})
-		return
+		return rinfo
 	}
 
 	rtype := replicateAll
@@ -649,10 +945,10 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		if rtype == replicateNone {
 			// object with same VersionID already exists, replication kicked off by
 			// PutObject might have completed
-			return
+			rinfo.Status = replication.Completed
+			return rinfo
 		}
 	}
-	replicationStatus := replication.Completed
 	// use core client to avoid doing multipart on PUT
 	c := &miniogo.Core{Client: tgt.Client}
 	if rtype != replicateAll {
@@ -668,115 +964,74 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 				ReplicationRequest: true, // always set this to distinguish between `mc mirror` replication and serverside
 			}}
 		if _, err = c.CopyObject(ctx, dest.Bucket, object, dest.Bucket, object, getCopyObjMetadata(objInfo, dest), srcOpts, dstOpts); err != nil {
-			replicationStatus = replication.Failed
-			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
-		}
-	} else {
-		target, err := globalBucketMetadataSys.GetBucketTarget(bucket, cfg.RoleArn)
-		if err != nil {
-			logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s cfg:%s err:%s", bucket, cfg.RoleArn, err))
-			sendEvent(eventArgs{
-				EventName:  event.ObjectReplicationNotTracked,
-				BucketName: bucket,
-				Object:     objInfo,
-				Host:       "Internal: [Replication]",
-			})
-			return
-		}
-
-		putOpts, err := putReplicationOpts(ctx, dest, objInfo)
-		if err != nil {
-			logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s cfg:%s err:%w", bucket, cfg.RoleArn, err))
-			sendEvent(eventArgs{
-				EventName:  event.ObjectReplicationNotTracked,
-				BucketName: bucket,
-				Object:     objInfo,
-				Host:       "Internal: [Replication]",
-			})
-			return
-		}
-
-		// Setup bandwidth throttling
-		peers, _ := globalEndpoints.peers()
-		totalNodesCount := len(peers)
-		if totalNodesCount == 0 {
-			totalNodesCount = 1 // For standalone erasure coding
-		}
-
-		var headerSize int
-		for k, v := range putOpts.Header() {
-			headerSize += len(k) + len(v)
-		}
-
-		opts := &bandwidth.MonitorReaderOptions{
-			Bucket:               objInfo.Bucket,
-			Object:               objInfo.Name,
-			HeaderSize:           headerSize,
-			BandwidthBytesPerSec: target.BandwidthLimit / int64(totalNodesCount),
-			ClusterBandwidth:     target.BandwidthLimit,
-		}
-
-		r := bandwidth.NewMonitoredReader(ctx, globalBucketMonitor, gr, opts)
-		if _, err = c.PutObject(ctx, dest.Bucket, object, r, size, "", "", putOpts); err != nil {
-			replicationStatus = replication.Failed
-			logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %w", bucket, objInfo.Name, objInfo.VersionID, err))
+			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s) to %s: %s", bucket, objInfo.Name, objInfo.VersionID, arn, err))
+			return rinfo
 		}
+		rinfo.Status = replication.Completed
+		return rinfo
 	}
 
-	prevReplStatus := objInfo.ReplicationStatus
-	objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = replicationStatus.String()
-	if objInfo.UserTags != "" {
-		objInfo.UserDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
-	}
-
-	// FIXME: add support for missing replication events
-	// - event.ObjectReplicationMissedThreshold
-	// - event.ObjectReplicationReplicatedAfterThreshold
-	var eventName = event.ObjectReplicationComplete
-	if replicationStatus == replication.Failed {
-		eventName = event.ObjectReplicationFailed
+	rinfo.OpType = replication.ObjectReplicationType
+	target, err := globalBucketMetadataSys.GetBucketTarget(bucket, arn)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s cfg:%s err:%s", bucket, arn, err))
+		sendEvent(eventArgs{
+			EventName:  event.ObjectReplicationNotTracked,
+			BucketName: bucket,
+			Object:     objInfo,
+			Host:       "Internal: [Replication]",
+		})
+		return rinfo
 	}
 
-	z, ok := objectAPI.(*erasureServerPools)
-	if !ok {
-		return
-	}
-	// Leave metadata in `PENDING` state if inline replication fails to save iops
-	if ri.OpType == replication.HealReplicationType || replicationStatus == replication.Completed {
-		// This lower level implementation is necessary to avoid write locks from CopyObject.
-		poolIdx, err := z.getPoolIdx(ctx, bucket, object, objInfo.Size)
-		if err != nil {
-			logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w", bucket, objInfo.Name, objInfo.VersionID, err))
-		} else {
-			fi := FileInfo{}
-			fi.VersionID = objInfo.VersionID
-			fi.Metadata = make(map[string]string, len(objInfo.UserDefined))
-			for k, v := range objInfo.UserDefined {
-				fi.Metadata[k] = v
-			}
-			if err = z.serverPools[poolIdx].getHashedSet(object).updateObjectMeta(ctx, bucket, object, fi); err != nil {
-				logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w", bucket, objInfo.Name, objInfo.VersionID, err))
-			}
-		}
-		opType := replication.MetadataReplicationType
-		if rtype == replicateAll {
-			opType = replication.ObjectReplicationType
-		}
-		globalReplicationStats.Update(bucket, size, replicationStatus, prevReplStatus, opType)
+	putOpts, err := putReplicationOpts(ctx, dest, objInfo)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s cfg:%s err:%w", bucket, arn, err))
 		sendEvent(eventArgs{
-			EventName:  eventName,
+			EventName:  event.ObjectReplicationNotTracked,
 			BucketName: bucket,
 			Object:     objInfo,
 			Host:       "Internal: [Replication]",
 		})
+		return rinfo
 	}
-	// re-queue failures once more - keep a retry count to avoid flooding the queue if
-	// the target site is down. Leave it to scanner to catch up instead.
-	if replicationStatus == replication.Failed && ri.RetryCount < 1 {
-		ri.OpType = replication.HealReplicationType
-		ri.RetryCount++
-		globalReplicationPool.queueReplicaTask(ctx, ri)
+
+	gr, err := objectAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, noLock, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	})
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to update replicate for %s/%s(%s): %w", bucket, object, objInfo.VersionID, err))
+		return rinfo
+	}
+	defer gr.Close()
+
+	// Setup bandwidth throttling
+	peers, _ := globalEndpoints.peers()
+	totalNodesCount := len(peers)
+	if totalNodesCount == 0 {
+		totalNodesCount = 1 // For standalone erasure coding
+	}
+
+	var headerSize int
+	for k, v := range putOpts.Header() {
+		headerSize += len(k) + len(v)
+	}
+
+	opts := &bandwidth.MonitorReaderOptions{
+		Bucket:               objInfo.Bucket,
+		Object:               objInfo.Name,
+		HeaderSize:           headerSize,
+		BandwidthBytesPerSec: target.BandwidthLimit / int64(totalNodesCount),
+		ClusterBandwidth:     target.BandwidthLimit,
 	}
+
+	r := bandwidth.NewMonitoredReader(ctx, globalBucketMonitor, gr, opts)
+	if _, err = c.PutObject(ctx, dest.Bucket, object, r, size, "", "", putOpts); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s) to %s: %w", bucket, objInfo.Name, objInfo.VersionID, arn, err))
+		return rinfo
+	}
+	rinfo.Status = replication.Completed
+	return rinfo
 }
 
 // filterReplicationStatusMetadata filters replication status metadata for COPY
@@ -799,6 +1054,7 @@ func filterReplicationStatusMetadata(metadata map[string]string) map[string]stri
 	}
 
 	delKey(xhttp.AmzBucketReplicationStatus)
+	delKey(replicationStatusKey)
 	return dst
 }
 
@@ -809,8 +1065,9 @@ type DeletedObjectVersionInfo struct {
 }
 
 var (
-	globalReplicationPool  *ReplicationPool
-	globalReplicationStats *ReplicationStats
+	globalReplicationPool     *ReplicationPool
+	globalReplicationStats    *ReplicationStats
+	globalReplicationResyncer *replicationResyncer
 )
 
 // ReplicationPool describes replication pool
@@ -822,6 +1079,7 @@ type ReplicationPool struct {
 	replicaDeleteCh    chan DeletedObjectVersionInfo
 	mrfReplicaCh       chan ReplicateObjectInfo
 	mrfReplicaDeleteCh chan DeletedObjectVersionInfo
+	resyncCh           chan ReplicateObjectInfo
 	killCh             chan struct{}
 	wg                 sync.WaitGroup
 	ctx                context.Context
@@ -835,6 +1093,7 @@ func NewReplicationPool(ctx context.Context, o ObjectLayer, sz int) *Replication
 		replicaDeleteCh:    make(chan DeletedObjectVersionInfo, 1000),
 		mrfReplicaCh:       make(chan ReplicateObjectInfo, 100000),
 		mrfReplicaDeleteCh: make(chan DeletedObjectVersionInfo, 100000),
+		resyncCh:           make(chan ReplicateObjectInfo),
 		ctx:                ctx,
 		objLayer:           o,
 	}
@@ -882,6 +1141,8 @@ func (p *ReplicationPool) AddWorker() {
 				return
 			}
 			replicateDelete(p.ctx, doi, p.objLayer)
+		case ri := <-p.resyncCh:
+			ri.resync.track(ri.ObjectInfo, replicateObject(p.ctx, ri, p.objLayer))
 		case <-p.killCh:
 			return
 		}
@@ -889,7 +1150,7 @@ func (p *ReplicationPool) AddWorker() {
 
 }
 
-//Resize replication pool to new size
+// Resize replication pool to new size
 func (p *ReplicationPool) Resize(n int) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -922,6 +1183,20 @@ func (p *ReplicationPool) queueReplicaTask(ctx context.Context, ri ReplicateObje
 	}
 }
 
+// queueResyncTask queues a version replicated by the resync of a target,
+// unlike queueReplicaTask it waits for a worker instead of dropping it.
+func (p *ReplicationPool) queueResyncTask(ctx context.Context, ri ReplicateObjectInfo) error {
+	if p == nil {
+		return errServerNotInitialized
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.resyncCh <- ri:
+		return nil
+	}
+}
+
 func (p *ReplicationPool) queueReplicaDeleteTask(ctx context.Context, doi DeletedObjectVersionInfo) {
 	if p == nil {
 		return
@@ -942,6 +1217,7 @@ func (p *ReplicationPool) queueReplicaDeleteTask(ctx context.Context, doi Delete
 func initBackgroundReplication(ctx context.Context, objectAPI ObjectLayer) {
 	globalReplicationPool = NewReplicationPool(ctx, objectAPI, globalAPIConfig.getReplicationWorkers())
 	globalReplicationStats = NewReplicationStats(ctx, objectAPI)
+	logger.LogIf(ctx, globalReplicationResyncer.Init(ctx, objectAPI))
 }
 
 // get Reader from replication target if active-active replication is in place and
@@ -988,13 +1264,18 @@ func proxyGetToReplicationTarget(ctx context.Context, bucket, object string, rs
 }
 
 // isProxyable returns true if replication config found for this bucket
+// replicates to a bucket of the same name, i.e active-active replication.
 func isProxyable(ctx context.Context, bucket string) bool {
 	cfg, err := getReplicationConfig(ctx, bucket)
 	if err != nil {
 		return false
 	}
-	dest := cfg.GetDestination()
-	return dest.Bucket == bucket
+	for _, arn := range cfg.TargetArns() {
+		if cfg.GetTargetDestination(arn).Bucket == bucket {
+			return true
+		}
+	}
+	return false
 }
 
 func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts ObjectOptions) (tgt *TargetClient, oi ObjectInfo, proxy bool, err error) {
@@ -1007,10 +1288,6 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 	if err != nil {
 		return nil, oi, false, err
 	}
-	dest := cfg.GetDestination()
-	if dest.Bucket != bucket { // not active-active
-		return nil, oi, false, err
-	}
 	ssec := false
 	if opts.ServerSideEncryption != nil {
 		ssec = opts.ServerSideEncryption.Type() == encrypt.SSEC
@@ -1019,14 +1296,6 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 		Name: object,
 		SSEC: ssec,
 	}
-	if !cfg.Replicate(ropts) { // no matching rule for object prefix
-		return nil, oi, false, nil
-	}
-	tgt = globalBucketTargetSys.GetRemoteTargetClient(ctx, cfg.RoleArn)
-	if tgt == nil || tgt.isOffline() {
-		return nil, oi, false, fmt.Errorf("target is offline or not configured")
-	}
-
 	gopts := miniogo.GetObjectOptions{
 		VersionID:            opts.VersionID,
 		ServerSideEncryption: opts.ServerSideEncryption,
@@ -1035,8 +1304,23 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 		},
 	}
 
-	objInfo, err := tgt.StatObject(ctx, dest.Bucket, object, gopts)
-	if err != nil {
+	// proxy to the first of the active-active targets which has the object.
+	var objInfo miniogo.ObjectInfo
+	for _, arn := range cfg.FilterTargetArns(ropts) { // no matching rule for object prefix if none
+		if cfg.GetTargetDestination(arn).Bucket != bucket { // not active-active
+			continue
+		}
+		t := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+		if t == nil || t.isOffline() {
+			err = fmt.Errorf("target is offline or not configured")
+			continue
+		}
+		if objInfo, err = t.StatObject(ctx, bucket, object, gopts); err == nil {
+			tgt = t
+			break
+		}
+	}
+	if tgt == nil {
 		return nil, oi, false, err
 	}
 
@@ -1083,12 +1367,27 @@ func scheduleReplication(ctx context.Context, objInfo ObjectInfo, o ObjectLayer,
 	} else {
 		globalReplicationPool.queueReplicaTask(GlobalContext, ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType})
 	}
-	if sz, err := objInfo.GetActualSize(); err == nil {
-		globalReplicationStats.Update(objInfo.Bucket, sz, objInfo.ReplicationStatus, replication.StatusType(""), opType)
+	sz, err := objInfo.GetActualSize()
+	if err != nil {
+		return
+	}
+	cfg, err := getReplicationConfig(ctx, objInfo.Bucket)
+	if err != nil {
+		return
+	}
+	for _, arn := range cfg.FilterTargetArns(replication.ObjectOpts{
+		Name:     objInfo.Name,
+		UserTags: objInfo.UserTags,
+	}) {
+		globalReplicationStats.Update(objInfo.Bucket, arn, sz, objInfo.ReplicationStatus, replication.StatusType(""), opType)
 	}
 }
 
 func scheduleReplicationDelete(ctx context.Context, dv DeletedObjectVersionInfo, o ObjectLayer, sync bool) {
 	globalReplicationPool.queueReplicaDeleteTask(GlobalContext, dv)
-	globalReplicationStats.Update(dv.Bucket, 0, replication.Pending, replication.StatusType(""), replication.DeleteReplicationType)
+	if cfg, err := getReplicationConfig(ctx, dv.Bucket); err == nil {
+		for _, arn := range replicationDeleteTargets(cfg, dv) {
+			globalReplicationStats.Update(dv.Bucket, arn, 0, replication.Pending, replication.StatusType(""), replication.DeleteReplicationType)
+		}
+	}
 }
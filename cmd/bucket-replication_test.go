@@ -0,0 +1,196 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestTargetReplicationStatus(t *testing.T) {
+	testCases := []struct {
+		s         string
+		status    targetReplicationStatus
+		str       string
+		aggregate replication.StatusType
+	}{
+		{"", targetReplicationStatus{}, "", ""},
+		{"arn:minio:replication::id1:b=COMPLETED;", targetReplicationStatus{"arn:minio:replication::id1:b": replication.Completed}, "arn:minio:replication::id1:b=COMPLETED;", replication.Completed},
+		{"arn:minio:replication::id2:b=FAILED;arn:minio:replication::id1:b=COMPLETED;", targetReplicationStatus{"arn:minio:replication::id1:b": replication.Completed, "arn:minio:replication::id2:b": replication.Failed}, "arn:minio:replication::id1:b=COMPLETED;arn:minio:replication::id2:b=FAILED;", replication.Failed},
+		{"arn:minio:replication::id1:b=FAILED;arn:minio:replication::id2:b=PENDING;invalid;", targetReplicationStatus{"arn:minio:replication::id1:b": replication.Failed, "arn:minio:replication::id2:b": replication.Pending}, "arn:minio:replication::id1:b=FAILED;arn:minio:replication::id2:b=PENDING;", replication.Pending},
+	}
+	for i, testCase := range testCases {
+		st := parseTargetReplicationStatus(testCase.s)
+		if !reflect.DeepEqual(st, testCase.status) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.status, st)
+		}
+		if str := st.String(); str != testCase.str {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.str, str)
+		}
+		if aggregate := st.aggregate(); aggregate != testCase.aggregate {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.aggregate, aggregate)
+		}
+	}
+}
+
+func TestReplicateObjectTargets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+	defer objLayer.Shutdown(context.Background())
+
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(objLayer)
+	defer func(isErasure bool) { globalIsErasure = isErasure }(globalIsErasure)
+	globalIsErasure = true
+	defer func(stats *ReplicationStats) { globalReplicationStats = stats }(globalReplicationStats)
+	globalReplicationStats = &ReplicationStats{
+		Cache:      make(map[string]*BucketReplicationStats),
+		UsageCache: make(map[string]*BucketReplicationStats),
+	}
+
+	bucket := "source"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		arns    []string
+		remotes []*fakeReplicationTarget
+		targets madmin.BucketTargets
+	)
+	for _, id := range []string{"site1", "site2"} {
+		remote := &fakeReplicationTarget{gate: make(chan struct{})}
+		close(remote.gate)
+		server := httptest.NewServer(remote)
+		defer server.Close()
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arn := "arn:minio:replication::" + id + ":dest"
+		arns = append(arns, arn)
+		remotes = append(remotes, remote)
+		targets.Targets = append(targets.Targets, madmin.BucketTarget{
+			SourceBucket: bucket,
+			Endpoint:     u.Host,
+			Credentials:  &auth.Credentials{AccessKey: "minio", SecretKey: "minio123"},
+			TargetBucket: "dest",
+			Arn:          arn,
+			Type:         madmin.ReplicationService,
+			Region:       "us-east-1",
+		})
+	}
+	data, err := json.Marshal(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketTargetsFile, data); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketTargetSys.UpdateAllTargets(bucket, &targets)
+
+	var rules string
+	for i, arn := range arns {
+		rules += `<Rule><Status>Enabled</Status><Priority>` + strconv.Itoa(i+1) + `</Priority>` +
+			`<DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication>` +
+			`<Destination><Bucket>` + arn + `</Bucket></Destination></Rule>`
+	}
+	config := []byte(`<ReplicationConfiguration>` + rules + `</ReplicationConfiguration>`)
+	cfg, err := replication.ParseConfig(bytes.NewReader(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.Validate(bucket, false); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, config); err != nil {
+		t.Fatal(err)
+	}
+
+	object := "object"
+	content := []byte("content")
+	oi, err := objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(content), int64(len(content)), "", ""), ObjectOptions{
+		Versioned:   true,
+		UserDefined: map[string]string{xhttp.AmzBucketReplicationStatus: replication.Pending.String()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second target denies the replication.
+	remotes[1].setDeny(true)
+	if status := replicateObject(ctx, ReplicateObjectInfo{ObjectInfo: oi, OpType: replication.HealReplicationType}, objLayer); status != replication.Failed {
+		t.Fatalf("Expected the replication to fail, got %q", status)
+	}
+	oi, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := targetReplicationStatus{arns[0]: replication.Completed, arns[1]: replication.Failed}
+	if st := oi.targetReplicationStatus(); !reflect.DeepEqual(st, expected) || oi.ReplicationStatus != replication.Failed {
+		t.Fatalf("Expected status %v (%s), got %v (%s)", expected, replication.Failed, st, oi.ReplicationStatus)
+	}
+	stats := globalReplicationStats.Get(bucket)
+	if st := stats.Stats[arns[0]]; st == nil || st.ReplicatedSize != uint64(len(content)) {
+		t.Fatalf("Unexpected stats for %s: %+v", arns[0], st)
+	}
+	if st := stats.Stats[arns[1]]; st == nil || st.FailedSize != uint64(len(content)) {
+		t.Fatalf("Unexpected stats for %s: %+v", arns[1], st)
+	}
+	if stats.ReplicatedSize != uint64(len(content)) || stats.FailedSize != uint64(len(content)) {
+		t.Fatalf("Unexpected bucket stats %+v", stats)
+	}
+
+	// Healing only replicates to the target that failed.
+	remotes[1].setDeny(false)
+	if status := replicateObject(ctx, ReplicateObjectInfo{ObjectInfo: oi, OpType: replication.HealReplicationType}, objLayer); status != replication.Completed {
+		t.Fatalf("Expected the replication to complete, got %q", status)
+	}
+	oi, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = targetReplicationStatus{arns[0]: replication.Completed, arns[1]: replication.Completed}
+	if st := oi.targetReplicationStatus(); !reflect.DeepEqual(st, expected) || oi.ReplicationStatus != replication.Completed {
+		t.Fatalf("Expected status %v (%s), got %v (%s)", expected, replication.Completed, st, oi.ReplicationStatus)
+	}
+	for i, remote := range remotes {
+		if replicated := remote.replicated(); len(replicated) != 1 || replicated[0] != object+"?"+oi.VersionID {
+			t.Fatalf("Expected %s to be replicated once to %s, got %v", object, arns[i], replicated)
+		}
+	}
+	if st := globalReplicationStats.Get(bucket).Stats[arns[1]]; st.FailedSize != 0 || st.ReplicatedSize != uint64(len(content)) {
+		t.Fatalf("Unexpected stats for %s: %+v", arns[1], st)
+	}
+}
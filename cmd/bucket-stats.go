@@ -24,8 +24,11 @@ type BucketStats struct {
 }
 
 // BucketReplicationStats represents inline replication statistics
-// such as pending, failed and completed bytes in total for a bucket
+// such as pending, failed and completed bytes in total for a bucket,
+// summed over the replication targets of the bucket
 type BucketReplicationStats struct {
+	// Replication statistics of each target, by ARN
+	Stats map[string]*BucketReplicationStat `json:"stats,omitempty"`
 	// Pending size in bytes
 	PendingSize uint64 `json:"pendingReplicationSize"`
 	// Completed size in bytes
@@ -39,3 +42,18 @@ type BucketReplicationStats struct {
 	// Total number of failed operations including metadata updates
 	FailedCount uint64 `json:"failedReplicationCount"`
 }
+
+// BucketReplicationStat represents inline replication statistics
+// of a bucket for one of its replication targets
+type BucketReplicationStat struct {
+	// Pending size in bytes
+	PendingSize uint64 `json:"pendingReplicationSize"`
+	// Completed size in bytes
+	ReplicatedSize uint64 `json:"completedReplicationSize"`
+	// Failed size in bytes
+	FailedSize uint64 `json:"failedReplicationSize"`
+	// Total number of pending operations including metadata updates
+	PendingCount uint64 `json:"pendingReplicationCount"`
+	// Total number of failed operations including metadata updates
+	FailedCount uint64 `json:"failedReplicationCount"`
+}
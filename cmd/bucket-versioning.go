@@ -41,7 +41,7 @@ func (sys *BucketVersioningSys) Suspended(bucket string) bool {
 
 // Get returns stored bucket policy
 func (sys *BucketVersioningSys) Get(bucket string) (*versioning.Versioning, error) {
-	if globalIsGateway {
+	if globalIsGateway && globalGatewayName != NASBackendGateway {
 		objAPI := newObjectLayerFn()
 		if objAPI == nil {
 			return nil, errServerNotInitialized
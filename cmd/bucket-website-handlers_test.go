@@ -0,0 +1,142 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/website"
+)
+
+// Test S3 Bucket website APIs and the website endpoint.
+func TestBucketWebsite(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketWebsiteHandlers, []string{"GetBucketWebsite", "PutBucketWebsite", "DeleteBucketWebsite"})
+}
+
+// Tests are related and the order is important.
+func testBucketWebsiteHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	websiteConfig := `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>old/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>new/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`
+
+	do := func(method string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, getBucketWebsiteURL("", bucketName),
+			int64(len(body)), bytes.NewReader(body), creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodGet, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec := do(http.MethodPut, []byte(`<WebsiteConfiguration></WebsiteConfiguration>`)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec := do(http.MethodPut, []byte(websiteConfig)); rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+
+	rec := do(http.MethodGet, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	var config website.Config
+	if err := xml.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+		t.Fatalf("MinIO %s: unable to parse website configuration %v", instanceType, err)
+	}
+	if config.IndexDocument == nil || config.IndexDocument.Suffix != "index.html" {
+		t.Fatalf("MinIO %s: unexpected website configuration %#v", instanceType, config)
+	}
+
+	// Website objects must be publicly readable.
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName)
+	if err := globalBucketMetadataSys.Update(bucketName, bucketPolicyConfig, []byte(policy)); err != nil {
+		t.Fatalf("MinIO %s: unable to set bucket policy %v", instanceType, err)
+	}
+	for object, content := range map[string]string{
+		"index.html":      "home",
+		"docs/index.html": "docs",
+		"error.html":      "oops",
+	} {
+		_, err := obj.PutObject(context.Background(), bucketName, object,
+			mustGetPutObjReader(t, bytes.NewReader([]byte(content)), int64(len(content)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("MinIO %s: unable to put object %v", instanceType, err)
+		}
+	}
+
+	globalDomainNames = []string{"example.com"}
+	defer func() { globalDomainNames = nil }()
+	router := mux.NewRouter().SkipClean(true).UseEncodedPath()
+	registerAPIRouter(router)
+
+	testCases := []struct {
+		method     string
+		path       string
+		statusCode int
+		body       string
+		location   string
+	}{
+		{method: http.MethodGet, path: "/", statusCode: http.StatusOK, body: "home"},
+		{method: http.MethodGet, path: "/docs/", statusCode: http.StatusOK, body: "docs"},
+		{method: http.MethodHead, path: "/docs/", statusCode: http.StatusOK},
+		{method: http.MethodGet, path: "/docs", statusCode: http.StatusFound, location: "/docs/"},
+		{method: http.MethodGet, path: "/missing", statusCode: http.StatusNotFound, body: "oops"},
+		{method: http.MethodGet, path: "/old/page.html", statusCode: http.StatusMovedPermanently, location: "http://" + bucketName + ".website.example.com/new/page.html"},
+		{method: http.MethodPut, path: "/index.html", statusCode: http.StatusMethodNotAllowed},
+	}
+	for i, tc := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(tc.method, "http://"+bucketName+".website.example.com"+tc.path, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.statusCode {
+			t.Fatalf("MinIO %s: Test %d: expected %d, got %d", instanceType, i+1, tc.statusCode, rec.Code)
+		}
+		if tc.body != "" {
+			if body, _ := ioutil.ReadAll(rec.Body); string(body) != tc.body {
+				t.Fatalf("MinIO %s: Test %d: expected body %q, got %q", instanceType, i+1, tc.body, body)
+			}
+		}
+		if tc.location != "" && rec.Header().Get(xhttp.Location) != tc.location {
+			t.Fatalf("MinIO %s: Test %d: expected location %q, got %q", instanceType, i+1, tc.location, rec.Header().Get(xhttp.Location))
+		}
+	}
+
+	if rec = do(http.MethodDelete, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = do(http.MethodGet, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("MinIO %s: expected %d, got %d", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
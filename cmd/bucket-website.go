@@ -0,0 +1,218 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+const (
+	// Static website requests are served on `<bucket>.website.<domain>`
+	// for every domain of globalDomainNames.
+	websiteDomainPrefix = "website."
+)
+
+// websiteMethodNotAllowedHandler - website endpoints are read-only.
+func websiteMethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL, guessIsBrowserReq(r))
+}
+
+// isWebsiteObjectAllowed - website requests are always anonymous,
+// objects must be readable by everyone through the bucket policy.
+func isWebsiteObjectAllowed(r *http.Request, bucket, object string) bool {
+	return globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ObjectName:      object,
+		ConditionValues: getConditionValues(r, "", "", nil),
+		IsOwner:         false,
+	})
+}
+
+// websiteObjectExists - returns true if the object can be served.
+func websiteObjectExists(ctx context.Context, r *http.Request, objAPI ObjectLayer, bucket, object string) bool {
+	if !isWebsiteObjectAllowed(r, bucket, object) {
+		return false
+	}
+	_, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	return err == nil
+}
+
+// serveWebsiteObject - writes the object with the given status code,
+// ranges and preconditions are only honored for successful requests.
+// Returns ErrNone once a response was written.
+func serveWebsiteObject(ctx context.Context, w http.ResponseWriter, r *http.Request, objAPI ObjectLayer, bucket, object string, statusCode int) APIErrorCode {
+	if !isWebsiteObjectAllowed(r, bucket, object) {
+		return ErrAccessDenied
+	}
+
+	var opts ObjectOptions
+	var rs *HTTPRangeSpec
+	if statusCode == http.StatusOK {
+		if rangeHeader := r.Header.Get(xhttp.Range); rangeHeader != "" {
+			var err error
+			rs, err = parseRequestRangeSpec(rangeHeader)
+			// Handle only errInvalidRange, like GetObject
+			// other errors are treated as a regular request.
+			if err == errInvalidRange {
+				return ErrInvalidRange
+			}
+			if err != nil {
+				logger.LogIf(ctx, err, logger.Application)
+			}
+		}
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditions(ctx, w, r, oi, opts)
+		}
+	}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, opts)
+	if err != nil {
+		if isErrPreconditionFailed(err) {
+			return ErrNone
+		}
+		return toAPIErrorCode(ctx, err)
+	}
+	defer gr.Close()
+
+	objInfo := gr.ObjInfo
+	if err = setObjectHeaders(w, objInfo, rs, opts); err != nil {
+		return toAPIErrorCode(ctx, err)
+	}
+	if rs != nil {
+		statusCode = http.StatusPartialContent
+	}
+	w.WriteHeader(statusCode)
+
+	eventName := event.ObjectAccessedHead
+	if r.Method != http.MethodHead {
+		eventName = event.ObjectAccessedGet
+		if _, err = io.Copy(w, gr); err != nil && !xnet.IsNetworkOrHostDown(err, true) {
+			logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+		}
+	}
+
+	// Notify object accessed via the website endpoint.
+	sendEvent(eventArgs{
+		EventName:    eventName,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+	return ErrNone
+}
+
+// WebsiteHandler - serves a bucket configured for static website hosting.
+// Directory keys are served with the index document and failed requests
+// with the error document, routing rules may redirect requests before
+// the object lookup or after it failed with a given status code.
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/WebsiteHosting.html
+func (api objectAPIHandlers) WebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Website")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapePath(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err = objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	proto := handlers.GetSourceScheme(r)
+	if proto == "" {
+		proto = getURLScheme(globalIsTLS)
+	}
+
+	if config.RedirectAllRequestsTo != nil {
+		http.Redirect(w, r, config.RedirectAllRequestsTo.Location(proto, object), http.StatusMovedPermanently)
+		return
+	}
+
+	if rule, ok := config.MatchRoutingRule(object, 0); ok {
+		http.Redirect(w, r, rule.Location(proto, r.Host, object), rule.StatusCode())
+		return
+	}
+
+	key := object
+	if indexKey, ok := config.IndexKey(object); ok {
+		key = indexKey
+	}
+
+	s3Error := serveWebsiteObject(ctx, w, r, objAPI, bucket, key, http.StatusOK)
+	if s3Error == ErrNone {
+		return
+	}
+
+	// Like S3, redirect keys without a trailing slash
+	// to the directory if it has an index document.
+	if s3Error == ErrNoSuchKey && key == object {
+		if indexKey, _ := config.IndexKey(object + SlashSeparator); websiteObjectExists(ctx, r, objAPI, bucket, indexKey) {
+			u := url.URL{Path: SlashSeparator + object + SlashSeparator}
+			http.Redirect(w, r, u.EscapedPath(), http.StatusFound)
+			return
+		}
+	}
+
+	apiErr := errorCodes.ToAPIErr(s3Error)
+	if rule, ok := config.MatchRoutingRule(object, apiErr.HTTPStatusCode); ok {
+		http.Redirect(w, r, rule.Location(proto, r.Host, object), rule.StatusCode())
+		return
+	}
+
+	if config.ErrorDocument != nil && apiErr.HTTPStatusCode >= 400 && apiErr.HTTPStatusCode < 500 {
+		if serveWebsiteObject(ctx, w, r, objAPI, bucket, config.ErrorDocument.Key, apiErr.HTTPStatusCode) == ErrNone {
+			return
+		}
+	}
+
+	writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -35,11 +36,14 @@ import (
 	"github.com/minio/minio/cmd/config/policy/opa"
 	"github.com/minio/minio/cmd/config/scanner"
 	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/config/tracing"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/cmd/logger/target/http"
+	"github.com/minio/minio/cmd/logger/target/kafka"
 	"github.com/minio/minio/pkg/env"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -56,10 +60,14 @@ func initHelp() {
 		config.CredentialsSubSys:    config.DefaultCredentialKVS,
 		config.KmsVaultSubSys:       crypto.DefaultVaultKVS,
 		config.KmsKesSubSys:         crypto.DefaultKesKVS,
+		config.KmsKeyStoreSubSys:    crypto.DefaultKeyStoreKVS,
+		config.KmsKMIPSubSys:        crypto.DefaultKMIPKVS,
 		config.LoggerWebhookSubSys:  logger.DefaultKVS,
 		config.AuditWebhookSubSys:   logger.DefaultAuditKVS,
+		config.AuditKafkaSubSys:     logger.DefaultAuditKafkaKVS,
 		config.HealSubSys:           heal.DefaultKVS,
 		config.ScannerSubSys:        scanner.DefaultKVS,
+		config.TracingOTLPSubSys:    tracing.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -88,8 +96,9 @@ func initHelp() {
 			Description: "federate multiple clusters for IAM and Bucket DNS",
 		},
 		config.HelpKV{
-			Key:         config.IdentityOpenIDSubSys,
-			Description: "enable OpenID SSO support",
+			Key:             config.IdentityOpenIDSubSys,
+			Description:     "enable OpenID SSO support",
+			MultipleTargets: true,
 		},
 		config.HelpKV{
 			Key:         config.IdentityLDAPSubSys,
@@ -107,6 +116,14 @@ func initHelp() {
 			Key:         config.KmsKesSubSys,
 			Description: "enable external MinIO key encryption service",
 		},
+		config.HelpKV{
+			Key:         config.KmsKeyStoreSubSys,
+			Description: "enable built-in key store for key management",
+		},
+		config.HelpKV{
+			Key:         config.KmsKMIPSubSys,
+			Description: "enable external KMIP key management server",
+		},
 		config.HelpKV{
 			Key:         config.APISubSys,
 			Description: "manage global HTTP API call specific features, such as throttling, authentication types, etc.",
@@ -119,6 +136,10 @@ func initHelp() {
 			Key:         config.ScannerSubSys,
 			Description: "manage namespace scanning for usage calculation, lifecycle, healing and more",
 		},
+		config.HelpKV{
+			Key:         config.TracingOTLPSubSys,
+			Description: "export distributed traces to an OpenTelemetry collector",
+		},
 		config.HelpKV{
 			Key:             config.LoggerWebhookSubSys,
 			Description:     "send server logs to webhook endpoints",
@@ -129,6 +150,11 @@ func initHelp() {
 			Description:     "send audit logs to webhook endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.AuditKafkaSubSys,
+			Description:     "send audit logs to kafka endpoints",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyWebhookSubSys,
 			Description:     "publish bucket notifications to webhook endpoints",
@@ -179,6 +205,11 @@ func initHelp() {
 			Description:     "publish bucket notifications to Redis datastores",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifySQSSubSys,
+			Description:     "publish bucket notifications to SQS compatible queues",
+			MultipleTargets: true,
+		},
 	}
 
 	if globalIsErasure {
@@ -200,13 +231,17 @@ func initHelp() {
 		config.CompressionSubSys:    compress.Help,
 		config.HealSubSys:           heal.Help,
 		config.ScannerSubSys:        scanner.Help,
+		config.TracingOTLPSubSys:    tracing.Help,
 		config.IdentityOpenIDSubSys: openid.Help,
 		config.IdentityLDAPSubSys:   xldap.Help,
 		config.PolicyOPASubSys:      opa.Help,
 		config.KmsVaultSubSys:       crypto.HelpVault,
 		config.KmsKesSubSys:         crypto.HelpKes,
+		config.KmsKeyStoreSubSys:    crypto.HelpKeyStore,
+		config.KmsKMIPSubSys:        crypto.HelpKMIP,
 		config.LoggerWebhookSubSys:  logger.Help,
 		config.AuditWebhookSubSys:   logger.HelpAudit,
+		config.AuditKafkaSubSys:     logger.HelpAuditKafka,
 		config.NotifyAMQPSubSys:     notify.HelpAMQP,
 		config.NotifyKafkaSubSys:    notify.HelpKafka,
 		config.NotifyMQTTSubSys:     notify.HelpMQTT,
@@ -215,6 +250,7 @@ func initHelp() {
 		config.NotifyMySQLSubSys:    notify.HelpMySQL,
 		config.NotifyPostgresSubSys: notify.HelpPostgres,
 		config.NotifyRedisSubSys:    notify.HelpRedis,
+		config.NotifySQSSubSys:      notify.HelpSQS,
 		config.NotifyWebhookSubSys:  notify.HelpWebhook,
 		config.NotifyESSubSys:       notify.HelpES,
 	}
@@ -281,6 +317,10 @@ func validateConfig(s config.Config, setDriveCounts []int) error {
 		return err
 	}
 
+	if _, err = tracing.LookupConfig(s[config.TracingOTLPSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -315,7 +355,7 @@ func validateConfig(s config.Config, setDriveCounts []int) error {
 		env.SetEnvOff()
 	}
 
-	if _, err := openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	if _, err := openid.LookupConfigs(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody); err != nil {
 		return err
 	}
@@ -491,6 +531,7 @@ func lookupConfigs(s config.Config, setDriveCounts []int) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to setup KMS with current KMS config: %w", err))
 	}
 	globalAutoEncryption = kmsCfg.AutoEncryption // Enable auto-encryption if enabled
+	globalKMSAutoCreateBucketKey = kmsCfg.AutoCreateBucketKey
 
 	if kmsCfg.Vault.Enabled {
 		const deprecationWarning = `Native Hashicorp Vault support is deprecated and will be removed on 2021-10-01. Please migrate to KES + Hashicorp Vault: https://github.com/minio/kes/wiki/Hashicorp-Vault-Keystore
@@ -499,11 +540,15 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 		logger.LogIf(ctx, fmt.Errorf(deprecationWarning))
 	}
 
-	globalOpenIDConfig, err = openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	openIDConfigs, err := openid.LookupConfigs(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OpenID: %w", err))
 	}
+	globalOpenIDConfig = openIDConfigs[config.Default]
+	if globalOpenIDConfig.ClaimName == "" {
+		globalOpenIDConfig.ClaimName = iampolicy.PolicyName
+	}
 
 	opaCfg, err := opa.LookupConfig(s[config.PolicyOPASubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
@@ -511,7 +556,7 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OPA: %w", err))
 	}
 
-	globalOpenIDValidators = getOpenIDValidators(globalOpenIDConfig)
+	globalOpenIDValidators = getOpenIDValidators(openIDConfigs)
 	globalPolicyOPA = opa.New(opaCfg)
 
 	globalLDAPConfig, err = xldap.Lookup(s[config.IdentityLDAPSubSys][config.Default],
@@ -539,6 +584,9 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 					http.WithUserAgent(loggerUserAgent),
 					http.WithLogKind(string(logger.All)),
 					http.WithTransport(NewGatewayHTTPTransport()),
+					http.WithQueueDir(loggerQueueDir(l.QueueDir, "logger-webhook", k)),
+					http.WithQueueLimit(l.QueueLimit),
+					http.WithDoneCh(GlobalContext.Done()),
 				),
 			); err != nil {
 				logger.LogIf(ctx, fmt.Errorf("Unable to initialize console HTTP target: %w", err))
@@ -557,6 +605,9 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 					http.WithUserAgent(loggerUserAgent),
 					http.WithLogKind(string(logger.All)),
 					http.WithTransport(NewGatewayHTTPTransportWithClientCerts(l.ClientCert, l.ClientKey)),
+					http.WithQueueDir(loggerQueueDir(l.QueueDir, "audit-webhook", k)),
+					http.WithQueueLimit(l.QueueLimit),
+					http.WithDoneCh(GlobalContext.Done()),
 				),
 			); err != nil {
 				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit HTTP target: %w", err))
@@ -564,6 +615,19 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 		}
 	}
 
+	for k, l := range loggerCfg.AuditKafka {
+		if l.Enabled {
+			l.TLS.RootCAs = globalRootCAs
+			l.QueueDir = loggerQueueDir(l.QueueDir, "audit-kafka", k)
+			l.LogOnce = logger.LogOnceIf
+			l.DoneCh = GlobalContext.Done()
+			// Enable Kafka audit logging
+			if err = logger.AddAuditTarget(kafka.New(k, l)); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit Kafka target: %w", err))
+			}
+		}
+	}
+
 	globalConfigTargetList, err = notify.GetNotificationTargets(GlobalContext, s, NewGatewayHTTPTransport(), false)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize notification target(s): %w", err))
@@ -615,6 +679,12 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 		return fmt.Errorf("Unable to apply scanner config: %w", err)
 	}
 
+	// OTLP tracing
+	tracingCfg, err := tracing.LookupConfig(s[config.TracingOTLPSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply OTLP tracing config: %w", err)
+	}
+
 	// Apply configurations.
 	// We should not fail after this.
 	globalAPIConfig.init(apiConfig, objAPI.SetDriveCounts())
@@ -631,6 +701,8 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 	scannerCycle.Update(scannerCfg.Cycle)
 	logger.LogIf(ctx, scannerSleeper.Update(scannerCfg.Delay, scannerCfg.MaxWait))
 
+	applyOTLPTracingConfig(tracingCfg)
+
 	// Update all dynamic config values in memory.
 	globalServerConfigMu.Lock()
 	defer globalServerConfigMu.Unlock()
@@ -764,12 +836,23 @@ func loadConfig(objAPI ObjectLayer) error {
 // enabled providers in server config.
 // A new authentication provider is added like below
 // * Add a new provider in pkg/iam/openid package.
-func getOpenIDValidators(cfg openid.Config) *openid.Validators {
+func getOpenIDValidators(cfgs map[string]openid.Config) *openid.Validators {
 	validators := openid.NewValidators()
 
-	if cfg.JWKS.URL != nil {
-		validators.Add(openid.NewJWT(cfg))
+	for _, cfg := range cfgs {
+		if cfg.JWKS.URL != nil {
+			validators.Add(openid.NewJWT(cfg))
+		}
 	}
 
 	return validators
 }
+
+// loggerQueueDir returns the queue directory of a logger target,
+// unique per target such that targets may share the queue_dir.
+func loggerQueueDir(queueDir, kind, name string) string {
+	if queueDir == "" {
+		return ""
+	}
+	return filepath.Join(queueDir, "minio-"+kind+"-"+name)
+}
@@ -235,62 +235,65 @@ func migrateConfigPrefixToEncrypted(objAPI ObjectLayer, activeCredOld auth.Crede
 		return err
 	}
 
-	var marker string
-	for {
-		res, err := objAPI.ListObjects(GlobalContext, minioMetaBucket,
-			minioConfigPrefix, marker, "", maxObjectList)
-		if err != nil {
-			return err
-		}
-		for _, obj := range res.Objects {
-			var (
-				cdata    []byte
-				cencdata []byte
-			)
-
-			cdata, err = readConfig(GlobalContext, objAPI, obj.Name)
+	// The batch jobs hold the credentials of remote endpoints.
+	for _, prefix := range []string{minioConfigPrefix, batchJobPrefix} {
+		marker := ""
+		for {
+			res, err := objAPI.ListObjects(GlobalContext, minioMetaBucket,
+				prefix, marker, "", maxObjectList)
 			if err != nil {
 				return err
 			}
+			for _, obj := range res.Objects {
+				var (
+					cdata    []byte
+					cencdata []byte
+				)
 
-			var data []byte
-			// Is rotating of creds requested?
-			if activeCredOld.IsValid() {
-				data, err = decryptData(cdata, activeCredOld, globalActiveCred)
+				cdata, err = readConfig(GlobalContext, objAPI, obj.Name)
 				if err != nil {
-					if err == madmin.ErrMaliciousData {
-						return config.ErrInvalidRotatingCredentialsBackendEncrypted(nil)
-					}
 					return err
 				}
-			} else {
-				data = cdata
-			}
 
-			if !utf8.Valid(data) {
-				_, err = decryptData(data, globalActiveCred)
-				if err == nil {
-					// Config is already encrypted with right keys
-					continue
+				var data []byte
+				// Is rotating of creds requested?
+				if activeCredOld.IsValid() {
+					data, err = decryptData(cdata, activeCredOld, globalActiveCred)
+					if err != nil {
+						if err == madmin.ErrMaliciousData {
+							return config.ErrInvalidRotatingCredentialsBackendEncrypted(nil)
+						}
+						return err
+					}
+				} else {
+					data = cdata
 				}
-				return fmt.Errorf("Decrypting config failed %w, possibly credentials are incorrect", err)
-			}
 
-			cencdata, err = madmin.EncryptData(globalActiveCred.String(), data)
-			if err != nil {
-				return err
+				if !utf8.Valid(data) {
+					_, err = decryptData(data, globalActiveCred)
+					if err == nil {
+						// Config is already encrypted with right keys
+						continue
+					}
+					return fmt.Errorf("Decrypting config failed %w, possibly credentials are incorrect", err)
+				}
+
+				cencdata, err = madmin.EncryptData(globalActiveCred.String(), data)
+				if err != nil {
+					return err
+				}
+
+				if err = saveConfig(GlobalContext, objAPI, obj.Name, cencdata); err != nil {
+					return err
+				}
 			}
 
-			if err = saveConfig(GlobalContext, objAPI, obj.Name, cencdata); err != nil {
-				return err
+			if !res.IsTruncated {
+				break
 			}
-		}
 
-		if !res.IsTruncated {
-			break
+			marker = res.NextMarker
 		}
-
-		marker = res.NextMarker
 	}
 
 	if encrypted && globalActiveCred.IsValid() && activeCredOld.IsValid() {
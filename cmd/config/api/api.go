@@ -37,6 +37,8 @@ const (
 	apiListQuorum              = "list_quorum"
 	apiExtendListCacheLife     = "extend_list_cache_life"
 	apiReplicationWorkers      = "replication_workers"
+	apiBucketMetrics           = "bucket_metrics"
+	apiBucketMetricsMax        = "bucket_metrics_max"
 
 	EnvAPIRequestsMax             = "MINIO_API_REQUESTS_MAX"
 	EnvAPIRequestsDeadline        = "MINIO_API_REQUESTS_DEADLINE"
@@ -47,6 +49,8 @@ const (
 	EnvAPIExtendListCacheLife     = "MINIO_API_EXTEND_LIST_CACHE_LIFE"
 	EnvAPISecureCiphers           = "MINIO_API_SECURE_CIPHERS"
 	EnvAPIReplicationWorkers      = "MINIO_API_REPLICATION_WORKERS"
+	EnvAPIBucketMetrics           = "MINIO_API_BUCKET_METRICS"
+	EnvAPIBucketMetricsMax        = "MINIO_API_BUCKET_METRICS_MAX"
 )
 
 // Deprecated key and ENVs
@@ -90,6 +94,14 @@ var (
 			Key:   apiReplicationWorkers,
 			Value: "500",
 		},
+		config.KV{
+			Key:   apiBucketMetrics,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiBucketMetricsMax,
+			Value: "100",
+		},
 	}
 )
 
@@ -103,6 +115,8 @@ type Config struct {
 	ListQuorum              string        `json:"list_strict_quorum"`
 	ExtendListLife          time.Duration `json:"extend_list_cache_life"`
 	ReplicationWorkers      int           `json:"replication_workers"`
+	BucketMetrics           bool          `json:"bucket_metrics"`
+	BucketMetricsMax        int           `json:"bucket_metrics_max"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -189,6 +203,20 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		return cfg, config.ErrInvalidReplicationWorkersValue(nil).Msg("Minimum number of replication workers should be 1")
 	}
 
+	bucketMetrics, err := config.ParseBool(env.Get(EnvAPIBucketMetrics, kvs.Get(apiBucketMetrics)))
+	if err != nil {
+		return cfg, err
+	}
+
+	bucketMetricsMax, err := strconv.Atoi(env.Get(EnvAPIBucketMetricsMax, kvs.Get(apiBucketMetricsMax)))
+	if err != nil {
+		return cfg, err
+	}
+
+	if bucketMetricsMax <= 0 {
+		return cfg, errors.New("invalid API bucket metrics max value")
+	}
+
 	return Config{
 		RequestsMax:             requestsMax,
 		RequestsDeadline:        requestsDeadline,
@@ -198,5 +226,7 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		ListQuorum:              listQuorum,
 		ExtendListLife:          listLife,
 		ReplicationWorkers:      replicationWorkers,
+		BucketMetrics:           bucketMetrics,
+		BucketMetricsMax:        bucketMetricsMax,
 	}, nil
 }
@@ -51,5 +51,17 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         apiBucketMetrics,
+			Description: `set to "on" to report the requests, traffic and latency of the S3 API calls per bucket, defaults to "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiBucketMetricsMax,
+			Description: `set the maximum number of buckets reported by the per bucket metrics of each server, defaults to 100`,
+			Optional:    true,
+			Type:        "number",
+		},
 	}
 )
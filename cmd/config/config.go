@@ -74,11 +74,15 @@ const (
 	CompressionSubSys    = "compression"
 	KmsVaultSubSys       = "kms_vault"
 	KmsKesSubSys         = "kms_kes"
+	KmsKeyStoreSubSys    = "kms_keystore"
+	KmsKMIPSubSys        = "kms_kmip"
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
+	AuditKafkaSubSys     = "audit_kafka"
 	HealSubSys           = "heal"
 	ScannerSubSys        = "scanner"
 	CrawlerSubSys        = "crawler"
+	TracingOTLPSubSys    = "tracing_otlp"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -94,6 +98,7 @@ const (
 	NotifyAMQPSubSys     = "notify_amqp"
 	NotifyPostgresSubSys = "notify_postgres"
 	NotifyRedisSubSys    = "notify_redis"
+	NotifySQSSubSys      = "notify_sqs"
 	NotifyWebhookSubSys  = "notify_webhook"
 
 	// Add new constants here if you add new fields to config.
@@ -110,13 +115,17 @@ var SubSystems = set.CreateStringSet(
 	CompressionSubSys,
 	KmsVaultSubSys,
 	KmsKesSubSys,
+	KmsKeyStoreSubSys,
+	KmsKMIPSubSys,
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
+	AuditKafkaSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
 	ScannerSubSys,
 	HealSubSys,
+	TracingOTLPSubSys,
 	NotifyAMQPSubSys,
 	NotifyESSubSys,
 	NotifyKafkaSubSys,
@@ -126,6 +135,7 @@ var SubSystems = set.CreateStringSet(
 	NotifyNSQSubSys,
 	NotifyPostgresSubSys,
 	NotifyRedisSubSys,
+	NotifySQSSubSys,
 	NotifyWebhookSubSys,
 )
 
@@ -135,6 +145,7 @@ var SubSystemsDynamic = set.CreateStringSet(
 	CompressionSubSys,
 	ScannerSubSys,
 	HealSubSys,
+	TracingOTLPSubSys,
 )
 
 // SubSystemsSingleTargets - subsystems which only support single target.
@@ -148,11 +159,13 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	CompressionSubSys,
 	KmsVaultSubSys,
 	KmsKesSubSys,
+	KmsKeyStoreSubSys,
+	KmsKMIPSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
-	IdentityOpenIDSubSys,
 	HealSubSys,
 	ScannerSubSys,
+	TracingOTLPSubSys,
 }...)
 
 // Constant separators
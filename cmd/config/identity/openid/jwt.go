@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,6 +46,8 @@ type Config struct {
 	URL          *xnet.URL `json:"url,omitempty"`
 	ClaimPrefix  string    `json:"claimPrefix,omitempty"`
 	ClaimName    string    `json:"claimName,omitempty"`
+	RolePolicy   string    `json:"-"`
+	Name         string    `json:"-"`
 	DiscoveryDoc DiscoveryDoc
 	ClientID     string
 	publicKeys   map[string]crypto.PublicKey
@@ -53,6 +56,28 @@ type Config struct {
 	mutex        *sync.Mutex
 }
 
+// roleArnPrefix is the prefix of the role ARNs of OpenID providers.
+const roleArnPrefix = "arn:minio:iam:::role/"
+
+// RoleArn returns the role ARN of a provider with a fixed role policy,
+// e.g. "arn:minio:iam:::role/keycloak" for the "identity_openid:keycloak"
+// configuration. Providers with claim based policies have no role ARN.
+func (r *Config) RoleArn() string {
+	if r.RolePolicy == "" {
+		return ""
+	}
+	if r.Name == "" || r.Name == config.Default {
+		return roleArnPrefix + "default"
+	}
+	return roleArnPrefix + r.Name
+}
+
+// PolicyClaimName returns the name of the JWT claim holding the
+// policies of the provider.
+func (r *Config) PolicyClaimName() string {
+	return r.ClaimPrefix + r.ClaimName
+}
+
 // PopulatePublicKey - populates a new publickey from the JWKS URL.
 func (r *Config) PopulatePublicKey() error {
 	r.mutex.Lock()
@@ -205,6 +230,10 @@ func (p *JWT) Validate(token, dsecs string) (map[string]interface{}, error) {
 		return nil, ErrTokenExpired
 	}
 
+	if err = p.validateIssuerAudience(claims); err != nil {
+		return nil, err
+	}
+
 	if err = updateClaimsExpiry(dsecs, claims); err != nil {
 		return nil, err
 	}
@@ -214,7 +243,56 @@ func (p *JWT) Validate(token, dsecs string) (map[string]interface{}, error) {
 
 // ID returns the provider name and authentication type.
 func (p *JWT) ID() ID {
-	return "jwt"
+	if p.Name == "" || p.Name == config.Default {
+		return "jwt"
+	}
+	return ID("jwt" + config.SubSystemSeparator + p.Name)
+}
+
+// Issuer returns the issuer of the provider as advertised by its
+// discovery document. It is empty if no discovery document is
+// configured.
+func (p *JWT) Issuer() string {
+	return p.DiscoveryDoc.Issuer
+}
+
+// validateIssuerAudience verifies that the token was issued by the
+// issuer of this provider and for its client ID. Providers with a role
+// policy always require both, since any token they accept is granted
+// the role policy.
+func (p *JWT) validateIssuerAudience(claims jwtgo.MapClaims) error {
+	issuer := p.Issuer()
+	if p.RolePolicy != "" && (issuer == "" || p.ClientID == "") {
+		return fmt.Errorf("OpenID provider %s with role policy requires an issuer and a client ID", p.ID())
+	}
+	if issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != issuer {
+			return ErrTokenIssuer
+		}
+	}
+	if p.ClientID != "" && !hasAudience(claims, p.ClientID) {
+		return ErrTokenAudience
+	}
+	return nil
+}
+
+// hasAudience returns true if the audience (aud) or authorized party
+// (azp) claim contains the given client ID.
+func hasAudience(claims jwtgo.MapClaims, clientID string) bool {
+	if azp, ok := claims["azp"].(string); ok && azp == clientID {
+		return true
+	}
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, v := range aud {
+			if s, ok := v.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // OpenID keys and envs.
@@ -225,6 +303,7 @@ const (
 	ClaimPrefix = "claim_prefix"
 	ClientID    = "client_id"
 	Scopes      = "scopes"
+	RolePolicy  = "role_policy"
 
 	EnvIdentityOpenIDClientID    = "MINIO_IDENTITY_OPENID_CLIENT_ID"
 	EnvIdentityOpenIDJWKSURL     = "MINIO_IDENTITY_OPENID_JWKS_URL"
@@ -232,6 +311,7 @@ const (
 	EnvIdentityOpenIDClaimName   = "MINIO_IDENTITY_OPENID_CLAIM_NAME"
 	EnvIdentityOpenIDClaimPrefix = "MINIO_IDENTITY_OPENID_CLAIM_PREFIX"
 	EnvIdentityOpenIDScopes      = "MINIO_IDENTITY_OPENID_SCOPES"
+	EnvIdentityOpenIDRolePolicy  = "MINIO_IDENTITY_OPENID_ROLE_POLICY"
 )
 
 // DiscoveryDoc - parses the output from openid-configuration
@@ -304,6 +384,10 @@ var (
 			Key:   JwksURL,
 			Value: "",
 		},
+		config.KV{
+			Key:   RolePolicy,
+			Value: "",
+		},
 	}
 )
 
@@ -314,26 +398,93 @@ func Enabled(kvs config.KVS) bool {
 
 // LookupConfig lookup jwks from config, override with any ENVs.
 func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (c Config, err error) {
+	return lookupConfig(config.Default, kvs, transport, closeRespFn)
+}
+
+// LookupConfigs looks up the configurations of all OpenID providers,
+// the default one and the named ones, and returns them indexed by
+// their configuration target name. Named providers are configured
+// as "identity_openid:<name>" or by ENVs with the "_<NAME>" suffix.
+// Providers that fail to be looked up are left out, the first error
+// is returned along with the remaining providers.
+func LookupConfigs(kvsMap map[string]config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (configs map[string]Config, err error) {
+	targets := map[string]config.KVS{config.Default: kvsMap[config.Default]}
+	for _, envName := range []string{EnvIdentityOpenIDURL, EnvIdentityOpenIDJWKSURL} {
+		for _, e := range env.List(envName + config.Default) {
+			targets[strings.TrimPrefix(e, envName+config.Default)] = DefaultKVS
+		}
+	}
+	for tgt, kvs := range kvsMap {
+		targets[tgt] = kvs
+	}
+
+	names := make([]string, 0, len(targets))
+	for tgt := range targets {
+		names = append(names, tgt)
+	}
+	sort.Strings(names)
+
+	configs = make(map[string]Config, len(targets))
+	roleArns := make(map[string]string, len(targets))
+	for _, tgt := range names {
+		c, lerr := lookupConfig(tgt, targets[tgt], transport, closeRespFn)
+		if lerr != nil {
+			if tgt != config.Default {
+				lerr = config.Errorf("identity_openid:%s: %v", tgt, lerr)
+			}
+			if err == nil {
+				err = lerr
+			}
+			continue
+		}
+		if arn := c.RoleArn(); arn != "" {
+			if other, ok := roleArns[arn]; ok {
+				if err == nil {
+					err = config.Errorf("identity_openid targets '%s' and '%s' have the same role ARN %s", other, tgt, arn)
+				}
+				continue
+			}
+			roleArns[arn] = tgt
+		}
+		configs[tgt] = c
+	}
+	return configs, err
+}
+
+func lookupConfig(name string, kvs config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (c Config, err error) {
 	if err = config.CheckValidKeys(config.IdentityOpenIDSubSys, kvs, DefaultKVS); err != nil {
 		return c, err
 	}
 
-	jwksURL := env.Get(EnvIamJwksURL, "") // Legacy
+	// envName returns the ENV of the key for the named provider.
+	envName := func(key string) string {
+		if name == config.Default {
+			return key
+		}
+		return key + config.Default + name
+	}
+
+	var jwksURL string
+	if name == config.Default {
+		jwksURL = env.Get(EnvIamJwksURL, "") // Legacy
+	}
 	if jwksURL == "" {
-		jwksURL = env.Get(EnvIdentityOpenIDJWKSURL, kvs.Get(JwksURL))
+		jwksURL = env.Get(envName(EnvIdentityOpenIDJWKSURL), kvs.Get(JwksURL))
 	}
 
 	c = Config{
-		ClaimName:   env.Get(EnvIdentityOpenIDClaimName, kvs.Get(ClaimName)),
-		ClaimPrefix: env.Get(EnvIdentityOpenIDClaimPrefix, kvs.Get(ClaimPrefix)),
+		ClaimName:   env.Get(envName(EnvIdentityOpenIDClaimName), kvs.Get(ClaimName)),
+		ClaimPrefix: env.Get(envName(EnvIdentityOpenIDClaimPrefix), kvs.Get(ClaimPrefix)),
+		RolePolicy:  env.Get(envName(EnvIdentityOpenIDRolePolicy), kvs.Get(RolePolicy)),
+		Name:        name,
 		publicKeys:  make(map[string]crypto.PublicKey),
-		ClientID:    env.Get(EnvIdentityOpenIDClientID, kvs.Get(ClientID)),
+		ClientID:    env.Get(envName(EnvIdentityOpenIDClientID), kvs.Get(ClientID)),
 		transport:   transport,
 		closeRespFn: closeRespFn,
 		mutex:       &sync.Mutex{}, // allocate for copying
 	}
 
-	configURL := env.Get(EnvIdentityOpenIDURL, kvs.Get(ConfigURL))
+	configURL := env.Get(envName(EnvIdentityOpenIDURL), kvs.Get(ConfigURL))
 	if configURL != "" {
 		c.URL, err = xnet.ParseHTTPURL(configURL)
 		if err != nil {
@@ -345,7 +496,7 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 		}
 	}
 
-	if scopeList := env.Get(EnvIdentityOpenIDScopes, kvs.Get(Scopes)); scopeList != "" {
+	if scopeList := env.Get(envName(EnvIdentityOpenIDScopes), kvs.Get(Scopes)); scopeList != "" {
 		var scopes []string
 		for _, scope := range strings.Split(scopeList, ",") {
 			scope = strings.TrimSpace(scope)
@@ -362,6 +513,12 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 		c.ClaimName = iampolicy.PolicyName
 	}
 
+	// Every token accepted by a provider with a role policy is granted
+	// this policy, so tokens must be checked for issuer and audience.
+	if c.RolePolicy != "" && (c.DiscoveryDoc.Issuer == "" || c.ClientID == "") {
+		return c, config.Errorf("'%s' requires '%s' and '%s' to be set", RolePolicy, ConfigURL, ClientID)
+	}
+
 	if jwksURL == "" {
 		// Fallback to discovery document jwksURL
 		jwksURL = c.DiscoveryDoc.JwksURI
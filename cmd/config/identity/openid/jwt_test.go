@@ -18,12 +18,20 @@ package openid
 
 import (
 	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
 	"time"
 
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/minio/cmd/config"
 	xnet "github.com/minio/minio/pkg/net"
 )
 
@@ -199,3 +207,120 @@ func TestDefaultExpiryDuration(t *testing.T) {
 		}
 	}
 }
+
+func TestLookupConfigs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"issuer": "https://idp.example.com"}`)
+	}))
+	defer ts.Close()
+	transport := ts.Client().Transport.(*http.Transport)
+	closeRespFn := func(rc io.ReadCloser) { rc.Close() }
+
+	kvsMap := map[string]config.KVS{
+		config.Default: DefaultKVS,
+		"idp2": {
+			config.KV{Key: ClaimName, Value: "groups"},
+			config.KV{Key: ClaimPrefix, Value: "minio/"},
+			config.KV{Key: ClientID, Value: "minio"},
+			config.KV{Key: RolePolicy, Value: ""},
+		},
+		"apps": {
+			config.KV{Key: ConfigURL, Value: ts.URL},
+			config.KV{Key: ClientID, Value: "apps"},
+			config.KV{Key: RolePolicy, Value: "readonly"},
+		},
+	}
+	configs, err := LookupConfigs(kvsMap, transport, closeRespFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 3 {
+		t.Fatalf("got %d configs, want 3", len(configs))
+	}
+	if c := configs[config.Default]; c.PolicyClaimName() != "policy" || c.RoleArn() != "" {
+		t.Fatalf("unexpected default config: claim %s, role %s", c.PolicyClaimName(), c.RoleArn())
+	}
+	if c := configs["idp2"]; c.PolicyClaimName() != "minio/groups" || c.ClientID != "minio" {
+		t.Fatalf("unexpected idp2 config: claim %s, client ID %s", c.PolicyClaimName(), c.ClientID)
+	}
+	if c := configs["apps"]; c.RoleArn() != "arn:minio:iam:::role/apps" || c.RolePolicy != "readonly" || c.DiscoveryDoc.Issuer != "https://idp.example.com" {
+		t.Fatalf("unexpected apps config: role %s, policy %s, issuer %s", c.RoleArn(), c.RolePolicy, c.DiscoveryDoc.Issuer)
+	}
+
+	// A role policy requires an issuer and a client ID.
+	for _, kvs := range []config.KVS{
+		{config.KV{Key: ClientID, Value: "apps"}, config.KV{Key: RolePolicy, Value: "readonly"}},
+		{config.KV{Key: ConfigURL, Value: ts.URL}, config.KV{Key: RolePolicy, Value: "readonly"}},
+	} {
+		if _, err = LookupConfigs(map[string]config.KVS{"apps": kvs}, transport, closeRespFn); err == nil {
+			t.Fatalf("expected an error for role policy config %v", kvs)
+		}
+	}
+
+	roleKVS := config.KVS{
+		config.KV{Key: ConfigURL, Value: ts.URL},
+		config.KV{Key: ClientID, Value: "apps"},
+	}
+	kvsMap["default"] = append(roleKVS, config.KV{Key: RolePolicy, Value: "readwrite"})
+	kvsMap[config.Default] = append(roleKVS, config.KV{Key: RolePolicy, Value: "readonly"})
+	if _, err = LookupConfigs(kvsMap, transport, closeRespFn); err == nil {
+		t.Fatal("expected an error for two providers with the same role ARN")
+	}
+}
+
+func TestJWTValidateIssuerAudience(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newToken := func(claims jwtgo.MapClaims) string {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, claims)
+		token.Header["kid"] = "key"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	newProvider := func(issuer, clientID, rolePolicy string) *JWT {
+		cfg := Config{ClientID: clientID, RolePolicy: rolePolicy}
+		cfg.mutex = &sync.Mutex{}
+		cfg.DiscoveryDoc.Issuer = issuer
+		cfg.publicKeys = map[string]crypto.PublicKey{"key": &key.PublicKey}
+		return NewJWT(cfg)
+	}
+
+	const issuer = "https://idp.example.com"
+	testCases := []struct {
+		provider *JWT
+		claims   jwtgo.MapClaims
+		err      error
+	}{
+		{newProvider(issuer, "apps", "readonly"), jwtgo.MapClaims{"iss": issuer, "aud": "apps"}, nil},
+		{newProvider(issuer, "apps", "readonly"), jwtgo.MapClaims{"iss": issuer, "aud": []string{"other", "apps"}}, nil},
+		{newProvider(issuer, "apps", "readonly"), jwtgo.MapClaims{"iss": issuer, "azp": "apps"}, nil},
+		// Token issued to another client of the same issuer.
+		{newProvider(issuer, "apps", "readonly"), jwtgo.MapClaims{"iss": issuer, "aud": "other"}, ErrTokenAudience},
+		{newProvider(issuer, "apps", "readonly"), jwtgo.MapClaims{"iss": issuer}, ErrTokenAudience},
+		// Token issued by another issuer sharing the keys.
+		{newProvider(issuer, "apps", "readonly"), jwtgo.MapClaims{"iss": "https://other.example.com", "aud": "apps"}, ErrTokenIssuer},
+		{newProvider(issuer, "apps", "readonly"), jwtgo.MapClaims{"aud": "apps"}, ErrTokenIssuer},
+		{newProvider(issuer, "minio", ""), jwtgo.MapClaims{"iss": issuer, "aud": "apps"}, ErrTokenAudience},
+		// Without a client ID and issuer, only the signature is verified.
+		{newProvider("", "", ""), jwtgo.MapClaims{"iss": "https://other.example.com", "aud": "other"}, nil},
+	}
+	for i, testCase := range testCases {
+		_, err := testCase.provider.Validate(newToken(testCase.claims), "")
+		if err != testCase.err {
+			t.Fatalf("Test %d: got error %v, want %v", i+1, err, testCase.err)
+		}
+	}
+
+	// A role policy without an issuer or client ID is never accepted.
+	for _, p := range []*JWT{newProvider("", "apps", "readonly"), newProvider(issuer, "", "readonly")} {
+		if _, err := p.Validate(newToken(jwtgo.MapClaims{"iss": issuer, "aud": "apps"}), ""); err == nil {
+			t.Fatal("expected a role policy provider without issuer or client ID to reject the token")
+		}
+	}
+}
@@ -19,7 +19,10 @@ package openid
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+
+	jwtgo "github.com/golang-jwt/jwt/v4"
 )
 
 // ID - holds identification name authentication validator target.
@@ -39,6 +42,15 @@ type Validator interface {
 // ErrTokenExpired - error token expired
 var (
 	ErrTokenExpired = errors.New("token expired")
+
+	// ErrNoProvider - no OpenID provider matches the role ARN or token issuer
+	ErrNoProvider = errors.New("no OpenID provider matches the role ARN or token issuer")
+
+	// ErrTokenIssuer - token was not issued by the issuer of the provider
+	ErrTokenIssuer = errors.New("token issuer does not match the OpenID provider")
+
+	// ErrTokenAudience - token audience does not contain the client ID of the provider
+	ErrTokenAudience = errors.New("token audience does not match the OpenID provider client ID")
 )
 
 // Validators - holds list of providers indexed by provider id.
@@ -85,6 +97,69 @@ func (list *Validators) Get(id ID) (p Validator, err error) {
 	return p, nil
 }
 
+// GetJWT - returns the JWT provider for a web identity token. A non-empty
+// roleArn selects the provider with this role ARN. Otherwise the provider
+// is selected by the issuer of the token and, for providers with a client
+// ID, the token audience, and falls back to the default provider, or the
+// only one. The selected provider still verifies the issuer and audience
+// of the token in Validate.
+func (list *Validators) GetJWT(roleArn, token string) (*JWT, error) {
+	list.RLock()
+	defer list.RUnlock()
+
+	var providers []*JWT
+	for _, v := range list.providers {
+		if p, ok := v.(*JWT); ok {
+			providers = append(providers, p)
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].ID() < providers[j].ID() })
+
+	if roleArn != "" {
+		for _, p := range providers {
+			if p.RoleArn() == roleArn {
+				return p, nil
+			}
+		}
+		return nil, ErrNoProvider
+	}
+
+	// The token is verified by the selected provider.
+	var claims jwtgo.MapClaims
+	if _, _, err := new(jwtgo.Parser).ParseUnverified(token, &claims); err == nil {
+		if iss, ok := claims["iss"].(string); ok && iss != "" {
+			var match *JWT
+			for _, p := range providers {
+				if p.Issuer() != iss {
+					continue
+				}
+				if p.ClientID == "" {
+					if match == nil {
+						match = p
+					}
+					continue
+				}
+				if hasAudience(claims, p.ClientID) {
+					return p, nil
+				}
+			}
+			if match != nil {
+				return match, nil
+			}
+		}
+	}
+
+	for _, p := range providers {
+		if p.ID() == "jwt" {
+			return p, nil
+		}
+	}
+	if len(providers) == 1 {
+		return providers[0], nil
+	}
+	return nil, ErrNoProvider
+}
+
 // NewValidators - creates Validators.
 func NewValidators() *Validators {
 	return &Validators{providers: make(map[ID]Validator)}
@@ -21,6 +21,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/minio/cmd/config"
 	xnet "github.com/minio/minio/pkg/net"
 )
 
@@ -102,3 +104,74 @@ func TestValidators(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestValidatorsGetJWT(t *testing.T) {
+	newProvider := func(name, issuer, clientID, rolePolicy string) *JWT {
+		cfg := Config{Name: name, ClientID: clientID, RolePolicy: rolePolicy}
+		cfg.DiscoveryDoc.Issuer = issuer
+		return NewJWT(cfg)
+	}
+	newToken := func(claims jwtgo.MapClaims) string {
+		token, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	vrs := NewValidators()
+	for _, p := range []*JWT{
+		newProvider(config.Default, "https://idp1.example.com", "", ""),
+		newProvider("idp2", "https://idp2.example.com", "minio", ""),
+		newProvider("idp2-apps", "https://idp2.example.com", "apps", "readonly"),
+	} {
+		if err := vrs.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		roleArn string
+		token   string
+		id      ID
+		wantErr bool
+	}{
+		{roleArn: "arn:minio:iam:::role/idp2-apps", id: "jwt:idp2-apps"},
+		{roleArn: "arn:minio:iam:::role/idp2", wantErr: true},
+		{token: newToken(jwtgo.MapClaims{"iss": "https://idp1.example.com"}), id: "jwt"},
+		{token: newToken(jwtgo.MapClaims{"iss": "https://idp2.example.com", "aud": "minio"}), id: "jwt:idp2"},
+		{token: newToken(jwtgo.MapClaims{"iss": "https://idp2.example.com", "aud": []string{"other", "apps"}}), id: "jwt:idp2-apps"},
+		{token: newToken(jwtgo.MapClaims{"iss": "https://idp2.example.com", "azp": "apps"}), id: "jwt:idp2-apps"},
+		{token: newToken(jwtgo.MapClaims{"iss": "https://idp2.example.com"}), id: "jwt"},
+		{token: newToken(jwtgo.MapClaims{"iss": "https://idp2.example.com", "aud": "other"}), id: "jwt"},
+		{token: newToken(jwtgo.MapClaims{"iss": "https://unknown.example.com"}), id: "jwt"},
+		{token: "invalid", id: "jwt"},
+	}
+	for i, testCase := range testCases {
+		p, err := vrs.GetJWT(testCase.roleArn, testCase.token)
+		if testCase.wantErr {
+			if err == nil {
+				t.Fatalf("Test %d: expected an error, got provider %s", i, p.ID())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %v", i, err)
+		}
+		if p.ID() != testCase.id {
+			t.Fatalf("Test %d: got provider %s, want %s", i, p.ID(), testCase.id)
+		}
+	}
+
+	// Without a default provider, unknown issuers are rejected
+	// unless there is only one provider.
+	vrs = NewValidators()
+	vrs.Add(newProvider("idp1", "https://idp1.example.com", "", ""))
+	if _, err := vrs.GetJWT("", "invalid"); err != nil {
+		t.Fatalf("expected the only provider to be selected: %v", err)
+	}
+	vrs.Add(newProvider("idp2", "https://idp2.example.com", "", ""))
+	if _, err := vrs.GetJWT("", "invalid"); err != ErrNoProvider {
+		t.Fatalf("got error %v, want %v", err, ErrNoProvider)
+	}
+}
@@ -73,6 +73,61 @@ var (
 		},
 	}
 
+	HelpSQS = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.SQSQueueURL,
+			Description: "SQS queue URL e.g. https://sqs.us-east-1.amazonaws.com/123456789012/events",
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         target.SQSRegion,
+			Description: "region of the queue used to sign requests e.g. us-east-1",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSAccessKey,
+			Description: "access key used to sign requests, requests are unsigned if not set",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSSecretKey,
+			Description: "secret key used to sign requests",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSSessionToken,
+			Description: "session token for temporary credentials",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSBatchSize,
+			Description: "maximum number of queued events sent with a single SendMessageBatch request, between 1 and 10",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
 	HelpAMQP = config.HelpKVS{
 		config.HelpKV{
 			Key:         target.AmqpURL,
@@ -159,6 +159,11 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		return nil, err
 	}
 
+	sqsTargets, err := GetNotifySQS(cfg[config.NotifySQSSubSys], transport)
+	if err != nil {
+		return nil, err
+	}
+
 	webhookTargets, err := GetNotifyWebhook(cfg[config.NotifyWebhookSubSys], transport)
 	if err != nil {
 		return nil, err
@@ -350,6 +355,26 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		}
 	}
 
+	for id, args := range sqsTargets {
+		if !args.Enable {
+			continue
+		}
+		newTarget, err := target.NewSQSTarget(ctx, id, args, logger.LogOnceIf, transport, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
 	for id, args := range webhookTargets {
 		if !args.Enable {
 			continue
@@ -388,6 +413,7 @@ var (
 		config.NotifyNSQSubSys:      DefaultNSQKVS,
 		config.NotifyPostgresSubSys: DefaultPostgresKVS,
 		config.NotifyRedisSubSys:    DefaultRedisKVS,
+		config.NotifySQSSubSys:      DefaultSQSKVS,
 		config.NotifyWebhookSubSys:  DefaultWebhookKVS,
 		config.NotifyESSubSys:       DefaultESKVS,
 	}
@@ -1540,6 +1566,129 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 	return webhookTargets, nil
 }
 
+// DefaultSQSKVS - default KV for SQS config
+var (
+	DefaultSQSKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.SQSQueueURL,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSRegion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSAccessKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSSecretKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSSessionToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSBatchSize,
+			Value: "10",
+		},
+		config.KV{
+			Key:   target.SQSQueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.SQSQueueDir,
+			Value: "",
+		},
+	}
+)
+
+// GetNotifySQS - returns a map of registered notification 'sqs' targets
+func GetNotifySQS(sqsKVS map[string]config.KVS, transport *http.Transport) (
+	map[string]target.SQSArgs, error) {
+	sqsTargets := make(map[string]target.SQSArgs)
+	for k, kv := range mergeTargets(sqsKVS, target.EnvSQSEnable, DefaultSQSKVS) {
+		enableEnv := target.EnvSQSEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+		queueURLEnv := target.EnvSQSQueueURL
+		if k != config.Default {
+			queueURLEnv = queueURLEnv + config.Default + k
+		}
+		queueURL, err := xnet.ParseHTTPURL(env.Get(queueURLEnv, kv.Get(target.SQSQueueURL)))
+		if err != nil {
+			return nil, err
+		}
+		regionEnv := target.EnvSQSRegion
+		if k != config.Default {
+			regionEnv = regionEnv + config.Default + k
+		}
+		accessKeyEnv := target.EnvSQSAccessKey
+		if k != config.Default {
+			accessKeyEnv = accessKeyEnv + config.Default + k
+		}
+		secretKeyEnv := target.EnvSQSSecretKey
+		if k != config.Default {
+			secretKeyEnv = secretKeyEnv + config.Default + k
+		}
+		sessionTokenEnv := target.EnvSQSSessionToken
+		if k != config.Default {
+			sessionTokenEnv = sessionTokenEnv + config.Default + k
+		}
+		batchSizeEnv := target.EnvSQSBatchSize
+		if k != config.Default {
+			batchSizeEnv = batchSizeEnv + config.Default + k
+		}
+		batchSize, err := strconv.Atoi(env.Get(batchSizeEnv, kv.Get(target.SQSBatchSize)))
+		if err != nil {
+			return nil, err
+		}
+		queueLimitEnv := target.EnvSQSQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.Atoi(env.Get(queueLimitEnv, kv.Get(target.SQSQueueLimit)))
+		if err != nil {
+			return nil, err
+		}
+		queueDirEnv := target.EnvSQSQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+
+		sqsArgs := target.SQSArgs{
+			Enable:       enabled,
+			QueueURL:     *queueURL,
+			Region:       env.Get(regionEnv, kv.Get(target.SQSRegion)),
+			AccessKey:    env.Get(accessKeyEnv, kv.Get(target.SQSAccessKey)),
+			SecretKey:    env.Get(secretKeyEnv, kv.Get(target.SQSSecretKey)),
+			SessionToken: env.Get(sessionTokenEnv, kv.Get(target.SQSSessionToken)),
+			BatchSize:    batchSize,
+			Transport:    transport,
+			QueueDir:     env.Get(queueDirEnv, kv.Get(target.SQSQueueDir)),
+			QueueLimit:   uint64(queueLimit),
+		}
+		if err = sqsArgs.Validate(); err != nil {
+			return nil, err
+		}
+		sqsTargets[k] = sqsArgs
+	}
+	return sqsTargets, nil
+}
+
 // DefaultESKVS - default KV config for Elasticsearch target
 var (
 	DefaultESKVS = config.KVS{
@@ -0,0 +1,49 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import "github.com/minio/minio/cmd/config"
+
+// Help template for OTLP tracing feature.
+var (
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `OTLP/HTTP traces endpoint of the collector e.g. "http://localhost:4318/v1/traces"`,
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         AuthToken,
+			Description: `opaque string or JWT authorization token sent to the collector`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SampleRatio,
+			Description: `ratio of the traces started by the server which are exported, between 0 and 1 e.g. "0.1"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
@@ -0,0 +1,104 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// OTLP tracing environment variables
+const (
+	Endpoint    = "endpoint"
+	AuthToken   = "auth_token"
+	SampleRatio = "sample_ratio"
+
+	EnvEnable      = "MINIO_TRACING_OTLP_ENABLE"
+	EnvEndpoint    = "MINIO_TRACING_OTLP_ENDPOINT"
+	EnvAuthToken   = "MINIO_TRACING_OTLP_AUTH_TOKEN"
+	EnvSampleRatio = "MINIO_TRACING_OTLP_SAMPLE_RATIO"
+
+	// DefaultEndpoint - traces endpoint of a local collector.
+	DefaultEndpoint = "http://localhost:4318/v1/traces"
+)
+
+// Config represents the OTLP tracing settings.
+type Config struct {
+	Enabled   bool   `json:"enabled"`
+	Endpoint  string `json:"endpoint"`
+	AuthToken string `json:"authToken"`
+	// Ratio of the traces started by this server which are exported.
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+var (
+	// DefaultKVS - default KV config for OTLP tracing settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: DefaultEndpoint,
+		},
+		config.KV{
+			Key:   AuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   SampleRatio,
+			Value: "1",
+		},
+	}
+)
+
+// LookupConfig - lookup OTLP tracing config and override with valid
+// environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.TracingOTLPSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		// Parsing failures happen due to empty KVS, ignore it.
+		if kvs.Empty() {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+
+	cfg.Endpoint = env.Get(EnvEndpoint, kvs.Get(Endpoint))
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return cfg, fmt.Errorf("'tracing_otlp:endpoint' value invalid: %q is not an http(s) URL", cfg.Endpoint)
+	}
+	cfg.AuthToken = env.Get(EnvAuthToken, kvs.Get(AuthToken))
+	cfg.SampleRatio, err = strconv.ParseFloat(env.Get(EnvSampleRatio, kvs.Get(SampleRatio)), 64)
+	if err != nil || cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return cfg, fmt.Errorf("'tracing_otlp:sample_ratio' value invalid: must be a number between 0 and 1")
+	}
+	return cfg, nil
+}
@@ -16,7 +16,9 @@ package crypto
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"reflect"
@@ -31,9 +33,12 @@ import (
 
 // KMSConfig has the KMS config for hashicorp vault
 type KMSConfig struct {
-	AutoEncryption bool        `json:"-"`
-	Vault          VaultConfig `json:"vault"`
-	Kes            KesConfig   `json:"kes"`
+	AutoEncryption      bool           `json:"-"`
+	AutoCreateBucketKey bool           `json:"-"`
+	Vault               VaultConfig    `json:"vault"`
+	Kes                 KesConfig      `json:"kes"`
+	KeyStore            KeyStoreConfig `json:"-"`
+	KMIP                KMIPConfig     `json:"-"`
 }
 
 // KMS Vault constants.
@@ -57,6 +62,22 @@ const (
 	KMSKesKeyName  = "key_name"
 )
 
+// KMS key store constants.
+const (
+	KMSKeyStoreDir           = "dir"
+	KMSKeyStoreMasterKeyFile = "master_key_file"
+	KMSKeyStoreKeyName       = "key_name"
+)
+
+// KMS KMIP constants.
+const (
+	KMSKMIPEndpoint = "endpoint"
+	KMSKMIPKeyFile  = "key_file"
+	KMSKMIPCertFile = "cert_file"
+	KMSKMIPCAPath   = "capath"
+	KMSKMIPKeyName  = "key_name"
+)
+
 // DefaultKVS - default KV crypto config
 var (
 	DefaultVaultKVS = config.KVS{
@@ -116,6 +137,44 @@ var (
 			Value: "",
 		},
 	}
+
+	DefaultKeyStoreKVS = config.KVS{
+		config.KV{
+			Key:   KMSKeyStoreDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   KMSKeyStoreMasterKeyFile,
+			Value: "",
+		},
+		config.KV{
+			Key:   KMSKeyStoreKeyName,
+			Value: "",
+		},
+	}
+
+	DefaultKMIPKVS = config.KVS{
+		config.KV{
+			Key:   KMSKMIPEndpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   KMSKMIPKeyName,
+			Value: "",
+		},
+		config.KV{
+			Key:   KMSKMIPCertFile,
+			Value: "",
+		},
+		config.KV{
+			Key:   KMSKMIPKeyFile,
+			Value: "",
+		},
+		config.KV{
+			Key:   KMSKMIPCAPath,
+			Value: "",
+		},
+	}
 )
 
 const (
@@ -130,6 +189,14 @@ const (
 	// request into an SSE-S3 request.
 	// If present EnvAutoEncryption must be either "on" or "off".
 	EnvKMSAutoEncryption = "MINIO_KMS_AUTO_ENCRYPTION"
+
+	// EnvKMSAutoCreateBucketKey is the environment variable used to
+	// en/disable the automatic creation of the KMS master key referenced
+	// by a bucket SSE-KMS default encryption configuration. If enabled,
+	// setting a bucket encryption configuration with a non-existing key
+	// creates the key at the KMS instead of rejecting the configuration.
+	// If present EnvKMSAutoCreateBucketKey must be either "on" or "off".
+	EnvKMSAutoCreateBucketKey = "MINIO_KMS_AUTO_CREATE_BUCKET_KEY"
 )
 
 const (
@@ -196,6 +263,49 @@ const (
 	EnvKMSKesKeyName = "MINIO_KMS_KES_KEY_NAME"
 )
 
+const (
+	// EnvKMSKeyStoreDir is the environment variable used to specify
+	// the directory of the built-in key store.
+	EnvKMSKeyStoreDir = "MINIO_KMS_KEYSTORE_DIR"
+
+	// EnvKMSKeyStoreMasterKeyFile is the environment variable used to
+	// specify the file containing the base64 encoded 256 bit master key
+	// that encrypts the keys of the key store at rest.
+	EnvKMSKeyStoreMasterKeyFile = "MINIO_KMS_KEYSTORE_MASTER_KEY_FILE"
+
+	// EnvKMSKeyStoreKeyName is the environment variable used to specify
+	// the (default) key of the key store. In the S3 context it's
+	// referred as customer master key ID (CMK-ID).
+	EnvKMSKeyStoreKeyName = "MINIO_KMS_KEYSTORE_KEY_NAME"
+)
+
+const (
+	// EnvKMSKMIPEndpoint is the environment variable used to specify
+	// one or multiple KMIP server endpoints as host:port. The individual
+	// endpoints should be separated by ','.
+	EnvKMSKMIPEndpoint = "MINIO_KMS_KMIP_ENDPOINT"
+
+	// EnvKMSKMIPKeyFile is the environment variable used to specify
+	// the TLS private key used by MinIO to authenticate to the KMIP
+	// server.
+	EnvKMSKMIPKeyFile = "MINIO_KMS_KMIP_KEY_FILE"
+
+	// EnvKMSKMIPCertFile is the environment variable used to specify
+	// the TLS certificate used by MinIO to authenticate to the KMIP
+	// server.
+	EnvKMSKMIPCertFile = "MINIO_KMS_KMIP_CERT_FILE"
+
+	// EnvKMSKMIPCAPath is the environment variable used to specify
+	// the TLS root certificates used by MinIO to verify the certificate
+	// presented by the KMIP server.
+	EnvKMSKMIPCAPath = "MINIO_KMS_KMIP_CA_PATH"
+
+	// EnvKMSKMIPKeyName is the environment variable used to specify
+	// the (default) key at the KMIP server. In the S3 context it's
+	// referred as customer master key ID (CMK-ID).
+	EnvKMSKMIPKeyName = "MINIO_KMS_KMIP_KEY_NAME"
+)
+
 var defaultVaultCfg = VaultConfig{
 	Auth: VaultAuth{
 		Type: "approle",
@@ -204,6 +314,8 @@ var defaultVaultCfg = VaultConfig{
 
 var defaultKesCfg = KesConfig{}
 
+var defaultKeyStoreCfg = KeyStoreConfig{}
+
 // EnabledVault returns true if HashiCorp Vault is enabled.
 func EnabledVault(kvs config.KVS) bool {
 	endpoint := kvs.Get(KMSVaultEndpoint)
@@ -268,6 +380,79 @@ func LookupKesConfig(kvs config.KVS) (KesConfig, error) {
 	return kesCfg, nil
 }
 
+// EnabledKeyStore returns true if the built-in key store is enabled.
+func EnabledKeyStore(kvs config.KVS) bool {
+	dir := kvs.Get(KMSKeyStoreDir)
+	return dir != ""
+}
+
+// EnabledKMIP returns true if KMIP as KMS is enabled.
+func EnabledKMIP(kvs config.KVS) bool {
+	endpoint := kvs.Get(KMSKMIPEndpoint)
+	return endpoint != ""
+}
+
+// LookupKeyStoreConfig lookup the built-in key store configuration.
+func LookupKeyStoreConfig(kvs config.KVS) (KeyStoreConfig, error) {
+	if err := config.CheckValidKeys(config.KmsKeyStoreSubSys, kvs, DefaultKeyStoreKVS); err != nil {
+		return KeyStoreConfig{}, err
+	}
+	keyStoreCfg := KeyStoreConfig{
+		Dir:           env.Get(EnvKMSKeyStoreDir, kvs.Get(KMSKeyStoreDir)),
+		MasterKeyFile: env.Get(EnvKMSKeyStoreMasterKeyFile, kvs.Get(KMSKeyStoreMasterKeyFile)),
+		DefaultKeyID:  env.Get(EnvKMSKeyStoreKeyName, kvs.Get(KMSKeyStoreKeyName)),
+	}
+	if keyStoreCfg == defaultKeyStoreCfg {
+		return keyStoreCfg, nil
+	}
+
+	// Verify all the proper settings.
+	if err := keyStoreCfg.Verify(); err != nil {
+		return keyStoreCfg, err
+	}
+	keyStoreCfg.Enabled = true
+	return keyStoreCfg, nil
+}
+
+// LookupKMIPConfig lookup KMIP server configuration.
+func LookupKMIPConfig(kvs config.KVS) (KMIPConfig, error) {
+	if err := config.CheckValidKeys(config.KmsKMIPSubSys, kvs, DefaultKMIPKVS); err != nil {
+		return KMIPConfig{}, err
+	}
+	kmipCfg := KMIPConfig{}
+
+	endpointStr := env.Get(EnvKMSKMIPEndpoint, kvs.Get(KMSKMIPEndpoint))
+	for _, endpoint := range strings.Split(endpointStr, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint == "" {
+			continue
+		}
+		host := endpoint
+		if h, _, err := net.SplitHostPort(endpoint); err == nil {
+			host = h
+		}
+		if host == "" || strings.Contains(host, "/") {
+			return kmipCfg, Errorf("crypto: invalid kmip endpoint '%s'", endpoint)
+		}
+		kmipCfg.Endpoint = append(kmipCfg.Endpoint, endpoint)
+	}
+	kmipCfg.KeyFile = env.Get(EnvKMSKMIPKeyFile, kvs.Get(KMSKMIPKeyFile))
+	kmipCfg.CertFile = env.Get(EnvKMSKMIPCertFile, kvs.Get(KMSKMIPCertFile))
+	kmipCfg.CAPath = env.Get(EnvKMSKMIPCAPath, kvs.Get(KMSKMIPCAPath))
+	kmipCfg.DefaultKeyID = env.Get(EnvKMSKMIPKeyName, kvs.Get(KMSKMIPKeyName))
+
+	if len(kmipCfg.Endpoint) == 0 && kmipCfg.KeyFile == "" && kmipCfg.CertFile == "" &&
+		kmipCfg.CAPath == "" && kmipCfg.DefaultKeyID == "" {
+		return kmipCfg, nil
+	}
+
+	// Verify all the proper settings.
+	if err := kmipCfg.Verify(); err != nil {
+		return kmipCfg, err
+	}
+	kmipCfg.Enabled = true
+	return kmipCfg, nil
+}
+
 func lookupAutoEncryption() (bool, error) {
 	autoBool, err := config.ParseBool(env.Get(EnvAutoEncryptionLegacy, config.EnableOff))
 	if err != nil {
@@ -297,14 +482,37 @@ func LookupConfig(c config.Config, defaultRootCAsDir string, transport *http.Tra
 	if kesCfg.Enabled && kesCfg.CAPath == "" {
 		kesCfg.CAPath = defaultRootCAsDir
 	}
+	keyStoreCfg, err := LookupKeyStoreConfig(c[config.KmsKeyStoreSubSys][config.Default])
+	if err != nil {
+		return KMSConfig{}, err
+	}
+	kmipCfg, err := LookupKMIPConfig(c[config.KmsKMIPSubSys][config.Default])
+	if err != nil {
+		return KMSConfig{}, err
+	}
+	if kmipCfg.Enabled {
+		if transport != nil && transport.TLSClientConfig != nil {
+			kmipCfg.RootCAs = transport.TLSClientConfig.RootCAs
+		}
+		if kmipCfg.CAPath == "" {
+			kmipCfg.CAPath = defaultRootCAsDir
+		}
+	}
 	autoEncrypt, err := lookupAutoEncryption()
 	if err != nil {
 		return KMSConfig{}, err
 	}
+	autoCreateKey, err := config.ParseBool(env.Get(EnvKMSAutoCreateBucketKey, config.EnableOff))
+	if err != nil {
+		return KMSConfig{}, err
+	}
 	kmsCfg := KMSConfig{
-		AutoEncryption: autoEncrypt,
-		Vault:          vcfg,
-		Kes:            kesCfg,
+		AutoEncryption:      autoEncrypt,
+		AutoCreateBucketKey: autoCreateKey,
+		Vault:               vcfg,
+		Kes:                 kesCfg,
+		KeyStore:            keyStoreCfg,
+		KMIP:                kmipCfg,
 	}
 	return kmsCfg, nil
 }
@@ -383,33 +591,47 @@ func LookupVaultConfig(kvs config.KVS) (VaultConfig, error) {
 	return vcfg, nil
 }
 
+// enabled returns the names of all configured KMS backends.
+func (cfg KMSConfig) enabled() []string {
+	var names []string
+	if cfg.Vault.Enabled {
+		names = append(names, "vault")
+	}
+	if cfg.Kes.Enabled {
+		names = append(names, "kes")
+	}
+	if cfg.KeyStore.Enabled {
+		names = append(names, "key store")
+	}
+	if cfg.KMIP.Enabled {
+		names = append(names, "kmip")
+	}
+	return names
+}
+
 // NewKMS - initialize a new KMS.
 func NewKMS(cfg KMSConfig) (kms KMS, err error) {
+	enabled := cfg.enabled()
+
 	// Lookup KMS master kes - only available through ENV.
 	if masterKeyLegacy := env.Get(EnvKMSMasterKeyLegacy, ""); len(masterKeyLegacy) != 0 {
-		if cfg.Vault.Enabled { // Vault and KMS master key provided
-			return kms, errors.New("Ambiguous KMS configuration: vault configuration and a master key are provided at the same time")
-		}
-		if cfg.Kes.Enabled {
-			return kms, errors.New("Ambiguous KMS configuration: kes configuration and a master key are provided at the same time")
+		if len(enabled) > 0 { // KMS and KMS master key provided
+			return kms, fmt.Errorf("Ambiguous KMS configuration: %s configuration and a master key are provided at the same time", enabled[0])
 		}
 		kms, err = ParseMasterKey(masterKeyLegacy)
 		if err != nil {
 			return kms, err
 		}
 	} else if masterKey := env.Get(EnvKMSMasterKey, ""); len(masterKey) != 0 {
-		if cfg.Vault.Enabled { // Vault and KMS master key provided
-			return kms, errors.New("Ambiguous KMS configuration: vault configuration and a master key are provided at the same time")
-		}
-		if cfg.Kes.Enabled {
-			return kms, errors.New("Ambiguous KMS configuration: kes configuration and a master key are provided at the same time")
+		if len(enabled) > 0 { // KMS and KMS master key provided
+			return kms, fmt.Errorf("Ambiguous KMS configuration: %s configuration and a master key are provided at the same time", enabled[0])
 		}
 		kms, err = ParseMasterKey(masterKey)
 		if err != nil {
 			return kms, err
 		}
-	} else if cfg.Vault.Enabled && cfg.Kes.Enabled {
-		return kms, errors.New("Ambiguous KMS configuration: vault configuration and kes configuration are provided at the same time")
+	} else if len(enabled) > 1 {
+		return kms, fmt.Errorf("Ambiguous KMS configuration: %s configuration and %s configuration are provided at the same time", enabled[0], enabled[1])
 	} else if cfg.Vault.Enabled {
 		if v, ok := os.LookupEnv("MINIO_KMS_VAULT_DEPRECATION"); !ok || v != "off" { // TODO(aead): Remove once Vault support has been removed
 			return kms, errors.New("Hashicorp Vault is deprecated and will be removed Oct. 2021. To temporarily enable Hashicorp Vault support, set MINIO_KMS_VAULT_DEPRECATION=off")
@@ -423,6 +645,16 @@ func NewKMS(cfg KMSConfig) (kms KMS, err error) {
 		if err != nil {
 			return kms, err
 		}
+	} else if cfg.KeyStore.Enabled {
+		kms, err = NewKeyStore(cfg.KeyStore)
+		if err != nil {
+			return kms, err
+		}
+	} else if cfg.KMIP.Enabled {
+		kms, err = NewKMIP(cfg.KMIP)
+		if err != nil {
+			return kms, err
+		}
 	}
 
 	if cfg.AutoEncryption && kms == nil {
@@ -73,6 +73,10 @@ var (
 	// ErrIncompatibleEncryptionMethod indicates that both SSE-C headers and SSE-S3 headers were specified, and are incompatible
 	// The client needs to remove the SSE-S3 header or the SSE-C headers
 	ErrIncompatibleEncryptionMethod = Errorf("Server side encryption specified with both SSE-C and SSE-S3 headers")
+
+	// ErrInvalidEncryptionContext indicates that the SSE-KMS context is not a
+	// JSON object of string values, either plain or base64-encoded.
+	ErrInvalidEncryptionContext = Errorf("The SSE-KMS encryption context is not a valid JSON object")
 )
 
 var (
@@ -106,4 +106,63 @@ var (
 			Type:        "sentence",
 		},
 	}
+
+	HelpKeyStore = config.HelpKVS{
+		config.HelpKV{
+			Key:         KMSKeyStoreDir,
+			Description: `directory that stores the encrypted keys - e.g. "/etc/minio/keys"`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         KMSKeyStoreMasterKeyFile,
+			Description: `path to the file containing the base64 encoded 256 bit master key - e.g. /etc/minio/master.key`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         KMSKeyStoreKeyName,
+			Description: `unique default key name, created if it does not exist - e.g. "my-minio-key"`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpKMIP = config.HelpKVS{
+		config.HelpKV{
+			Key:         KMSKMIPEndpoint,
+			Description: `comma separated list of KMIP server endpoints - e.g. "kmip-server:5696"`,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         KMSKMIPKeyName,
+			Description: `unique key name - e.g. "my-minio-key"`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         KMSKMIPCertFile,
+			Description: `path to client certificate for TLS auth - e.g. /etc/keys/public.crt`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         KMSKMIPKeyFile,
+			Description: `path to client private key for TLS auth - e.g. /etc/keys/private.key`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         KMSKMIPCAPath,
+			Description: `path to PEM-encoded cert(s) to verify KMIP server cert - e.g. /etc/keys/CAs`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
 )
@@ -167,6 +167,22 @@ func (kes *kesService) Stat() (kms.Status, error) {
 // CreateKey tries to create a new master key with the given keyID.
 func (kes *kesService) CreateKey(keyID string) error { return kes.client.CreateKey(keyID) }
 
+// ListKeys returns the names of all master keys at the kes server.
+func (kes *kesService) ListKeys() ([]string, error) { return kes.client.ListKeys("*") }
+
+// KeyStatus returns the status of the master key. The kes server
+// does not version keys, so the status never contains versions.
+func (kes *kesService) KeyStatus(keyID string) (kms.KeyStatus, error) {
+	if keyID == "" {
+		keyID = kes.defaultKeyID
+	}
+	return kms.KeyStatus{KeyID: keyID}, nil
+}
+
+// RotateKey returns kms.ErrNotSupported since kes does not
+// version keys. A new key has to be created instead.
+func (kes *kesService) RotateKey(keyID string) error { return kms.ErrNotSupported }
+
 // GenerateKey returns a new plaintext key, generated by the KMS,
 // and a sealed version of this plaintext key encrypted using the
 // named key referenced by keyID. It also binds the generated key
@@ -209,6 +225,7 @@ func (kes *kesService) DecryptKey(keyID string, ciphertext []byte, ctx Context)
 // kesClient implements the bare minimum functionality needed for
 // MinIO to talk to a KES server. In particular, it implements
 //   • CreateKey       (API: /v1/key/create/)
+//   • ListKeys        (API: /v1/key/list/)
 //   • GenerateDataKey (API: /v1/key/generate/)
 //   • DecryptDataKey  (API: /v1/key/decrypt/)
 type kesClient struct {
@@ -231,6 +248,35 @@ func (c *kesClient) CreateKey(name string) error {
 	return nil
 }
 
+// ListKeys returns the names of all keys matching the pattern.
+// The KES server responds with a stream of JSON objects, one per
+// key.
+func (c *kesClient) ListKeys(pattern string) ([]string, error) {
+	type Response struct {
+		Name string `json:"name"`
+	}
+
+	const limit = 16 << 20 // Key names are small. 16 MiB are enough for a lot of keys
+	path := fmt.Sprintf("/v1/key/list/%s", url.PathEscape(pattern))
+	resp, err := c.getRetry(path, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	decoder := json.NewDecoder(resp)
+	for {
+		var response Response
+		if err = decoder.Decode(&response); err != nil {
+			if errors.Is(err, io.EOF) {
+				return names, nil
+			}
+			return nil, err
+		}
+		names = append(names, response.Name)
+	}
+}
+
 // GenerateDataKey requests a new data key from the KES server.
 // On success, the KES server will respond with the plaintext key
 // and the ciphertext key as the plaintext key encrypted with
@@ -364,15 +410,17 @@ func parseErrorResponse(resp *http.Response) error {
 	return NewKESError(resp.StatusCode, sb.String())
 }
 
-func (c *kesClient) post(url string, body io.Reader, limit int64) (io.Reader, error) {
+func (c *kesClient) do(method, url string, body io.Reader, limit int64) (io.Reader, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -394,13 +442,23 @@ func (c *kesClient) post(url string, body io.Reader, limit int64) (io.Reader, er
 }
 
 func (c *kesClient) postRetry(path string, body io.ReadSeeker, limit int64) (io.Reader, error) {
+	return c.doRetry(http.MethodPost, path, body, limit)
+}
+
+func (c *kesClient) getRetry(path string, limit int64) (io.Reader, error) {
+	return c.doRetry(http.MethodGet, path, nil, limit)
+}
+
+func (c *kesClient) doRetry(method, path string, body io.ReadSeeker, limit int64) (io.Reader, error) {
 	retryMax := 1 + len(c.endpoints)
 	for i := 0; ; i++ {
+		var reqBody io.Reader
 		if body != nil {
 			body.Seek(0, io.SeekStart) // seek to the beginning of the body.
+			reqBody = body
 		}
 
-		response, err := c.post(c.endpoints[i%len(c.endpoints)]+path, body, limit)
+		response, err := c.do(method, c.endpoints[i%len(c.endpoints)]+path, reqBody, limit)
 		if err == nil {
 			return response, nil
 		}
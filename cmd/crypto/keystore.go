@@ -0,0 +1,69 @@
+// MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+
+	"github.com/minio/minio/pkg/kms"
+)
+
+// KeyStoreConfig contains the configuration of the
+// built-in key store.
+type KeyStoreConfig struct {
+	Enabled bool
+
+	// The directory that contains the keys.
+	Dir string
+
+	// The path to the file containing the base64
+	// encoded 256 bit master key that encrypts
+	// all keys at rest.
+	MasterKeyFile string
+
+	// The name of the default key. It gets
+	// created if it does not exist.
+	DefaultKeyID string
+}
+
+// Verify verifies if the key store configuration is correct
+func (k KeyStoreConfig) Verify() (err error) {
+	switch {
+	case k.Dir == "":
+		err = Errorf("crypto: missing key store directory")
+	case k.MasterKeyFile == "":
+		err = Errorf("crypto: missing master key file")
+	case k.DefaultKeyID == "":
+		err = Errorf("crypto: missing default key id")
+	}
+	return err
+}
+
+// NewKeyStore returns a new KMS that stores its keys in
+// the configured directory, encrypted by the master key
+// read from the master key file.
+func NewKeyStore(cfg KeyStoreConfig) (KMS, error) {
+	data, err := ioutil.ReadFile(cfg.MasterKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	masterKey, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, Errorf("crypto: invalid master key file '%s': %v", cfg.MasterKeyFile, err)
+	}
+	return kms.NewKeyStore(cfg.Dir, masterKey, cfg.DefaultKeyID)
+}
@@ -0,0 +1,349 @@
+// MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// KMIP messages are encoded as TTLV (tag-type-length-value)
+// items. Each item starts with a 3 byte tag, a 1 byte type
+// and a 4 byte length followed by the value padded to a
+// multiple of 8 bytes. A structure contains a sequence of
+// nested items.
+
+// kmipTag identifies a KMIP item.
+type kmipTag uint32
+
+// KMIP tags used by the KMIP client.
+const (
+	kmipTagAttribute              kmipTag = 0x420008
+	kmipTagAttributeName          kmipTag = 0x42000A
+	kmipTagAttributeValue         kmipTag = 0x42000B
+	kmipTagBatchCount             kmipTag = 0x42000D
+	kmipTagBatchItem              kmipTag = 0x42000F
+	kmipTagCryptographicAlgorithm kmipTag = 0x420028
+	kmipTagCryptographicLength    kmipTag = 0x42002A
+	kmipTagCryptographicUsageMask kmipTag = 0x42002C
+	kmipTagKey                    kmipTag = 0x42003F
+	kmipTagKeyBlock               kmipTag = 0x420040
+	kmipTagKeyFormatType          kmipTag = 0x420042
+	kmipTagKeyMaterial            kmipTag = 0x420043
+	kmipTagKeyValue               kmipTag = 0x420045
+	kmipTagName                   kmipTag = 0x420053
+	kmipTagNameType               kmipTag = 0x420054
+	kmipTagNameValue              kmipTag = 0x420055
+	kmipTagObjectType             kmipTag = 0x420057
+	kmipTagOperation              kmipTag = 0x42005C
+	kmipTagProtocolVersion        kmipTag = 0x420069
+	kmipTagProtocolVersionMajor   kmipTag = 0x42006A
+	kmipTagProtocolVersionMinor   kmipTag = 0x42006B
+	kmipTagRequestHeader          kmipTag = 0x420077
+	kmipTagRequestMessage         kmipTag = 0x420078
+	kmipTagRequestPayload         kmipTag = 0x420079
+	kmipTagResponseHeader         kmipTag = 0x42007A
+	kmipTagResponseMessage        kmipTag = 0x42007B
+	kmipTagResponsePayload        kmipTag = 0x42007C
+	kmipTagResultMessage          kmipTag = 0x42007D
+	kmipTagResultReason           kmipTag = 0x42007E
+	kmipTagResultStatus           kmipTag = 0x42007F
+	kmipTagSymmetricKey           kmipTag = 0x42008F
+	kmipTagTemplateAttribute      kmipTag = 0x420091
+	kmipTagUniqueIdentifier       kmipTag = 0x420094
+)
+
+// kmipType is the type of a KMIP item value.
+type kmipType byte
+
+// KMIP item types.
+const (
+	kmipTypeStructure   kmipType = 0x01
+	kmipTypeInteger     kmipType = 0x02
+	kmipTypeLongInteger kmipType = 0x03
+	kmipTypeBigInteger  kmipType = 0x04
+	kmipTypeEnumeration kmipType = 0x05
+	kmipTypeBoolean     kmipType = 0x06
+	kmipTypeTextString  kmipType = 0x07
+	kmipTypeByteString  kmipType = 0x08
+	kmipTypeDateTime    kmipType = 0x09
+	kmipTypeInterval    kmipType = 0x0A
+)
+
+// kmipMaxMessageSize limits the size of a KMIP response.
+const kmipMaxMessageSize = 1 << 20
+
+// kmipItem is a single TTLV item. The Go type of its value
+// depends on the item type:
+//   - Structure:         []kmipItem
+//   - Integer, Interval: int32
+//   - Enumeration:       uint32
+//   - LongInteger:       int64
+//   - Boolean:           bool
+//   - TextString:        string
+//   - ByteString:        []byte
+//   - BigInteger:        []byte
+//   - DateTime:          time.Time
+type kmipItem struct {
+	Tag   kmipTag
+	Type  kmipType
+	Value interface{}
+}
+
+func kmipStructure(tag kmipTag, items ...kmipItem) kmipItem {
+	return kmipItem{Tag: tag, Type: kmipTypeStructure, Value: items}
+}
+
+func kmipInteger(tag kmipTag, v int32) kmipItem {
+	return kmipItem{Tag: tag, Type: kmipTypeInteger, Value: v}
+}
+
+func kmipEnum(tag kmipTag, v uint32) kmipItem {
+	return kmipItem{Tag: tag, Type: kmipTypeEnumeration, Value: v}
+}
+
+func kmipText(tag kmipTag, v string) kmipItem {
+	return kmipItem{Tag: tag, Type: kmipTypeTextString, Value: v}
+}
+
+func kmipBytes(tag kmipTag, v []byte) kmipItem {
+	return kmipItem{Tag: tag, Type: kmipTypeByteString, Value: v}
+}
+
+// kmipAttribute returns a KMIP attribute with the given name and value.
+func kmipAttribute(name string, value kmipItem) kmipItem {
+	value.Tag = kmipTagAttributeValue
+	return kmipStructure(kmipTagAttribute,
+		kmipText(kmipTagAttributeName, name),
+		value,
+	)
+}
+
+// Child returns the first nested item with the given
+// tag, if the item is a structure.
+func (i kmipItem) Child(tag kmipTag) (kmipItem, bool) {
+	items, _ := i.Value.([]kmipItem)
+	for _, item := range items {
+		if item.Tag == tag {
+			return item, true
+		}
+	}
+	return kmipItem{}, false
+}
+
+// Children returns all nested items with the given
+// tag, if the item is a structure.
+func (i kmipItem) Children(tag kmipTag) []kmipItem {
+	items, _ := i.Value.([]kmipItem)
+	var children []kmipItem
+	for _, item := range items {
+		if item.Tag == tag {
+			children = append(children, item)
+		}
+	}
+	return children
+}
+
+// Find follows the path of tags through nested
+// structures and returns the item at its end.
+func (i kmipItem) Find(path ...kmipTag) (kmipItem, bool) {
+	for _, tag := range path {
+		var ok bool
+		if i, ok = i.Child(tag); !ok {
+			return kmipItem{}, false
+		}
+	}
+	return i, true
+}
+
+// MarshalBinary encodes the item and all its nested items.
+func (i kmipItem) MarshalBinary() ([]byte, error) {
+	return i.appendTo(nil)
+}
+
+func (i kmipItem) appendTo(b []byte) ([]byte, error) {
+	var value []byte
+	switch v := i.Value.(type) {
+	case []kmipItem:
+		if i.Type != kmipTypeStructure {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		var err error
+		for _, item := range v {
+			if value, err = item.appendTo(value); err != nil {
+				return nil, err
+			}
+		}
+	case int32:
+		if i.Type != kmipTypeInteger && i.Type != kmipTypeInterval {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(v))
+	case uint32:
+		if i.Type != kmipTypeEnumeration {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, v)
+	case int64:
+		if i.Type != kmipTypeLongInteger {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(v))
+	case bool:
+		if i.Type != kmipTypeBoolean {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		value = make([]byte, 8)
+		if v {
+			value[7] = 1
+		}
+	case string:
+		if i.Type != kmipTypeTextString {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		value = []byte(v)
+	case []byte:
+		if i.Type != kmipTypeByteString && i.Type != kmipTypeBigInteger {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		value = v
+	case time.Time:
+		if i.Type != kmipTypeDateTime {
+			return nil, fmt.Errorf("kmip: invalid value for type %#x", i.Type)
+		}
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(v.Unix()))
+	default:
+		return nil, fmt.Errorf("kmip: unsupported value %T", i.Value)
+	}
+
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(i.Tag)<<8|uint32(i.Type))
+	binary.BigEndian.PutUint32(header[4:], uint32(len(value)))
+	b = append(b, header[:]...)
+	b = append(b, value...)
+	if n := len(value) % 8; n != 0 {
+		b = append(b, make([]byte, 8-n)...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes b as a single item. It returns an
+// error if b contains trailing data.
+func (i *kmipItem) UnmarshalBinary(b []byte) error {
+	n, err := i.decode(b)
+	if err != nil {
+		return err
+	}
+	if n != len(b) {
+		return errors.New("kmip: trailing data after item")
+	}
+	return nil
+}
+
+// decode decodes the first item in b and returns the
+// number of bytes consumed, including padding.
+func (i *kmipItem) decode(b []byte) (int, error) {
+	if len(b) < 8 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	header := binary.BigEndian.Uint32(b[:4])
+	length := int(binary.BigEndian.Uint32(b[4:8]))
+	padded := length
+	if n := length % 8; n != 0 {
+		padded += 8 - n
+	}
+	if len(b)-8 < padded {
+		return 0, io.ErrUnexpectedEOF
+	}
+	value := b[8 : 8+length]
+
+	i.Tag, i.Type = kmipTag(header>>8), kmipType(header&0xff)
+	switch i.Type {
+	case kmipTypeStructure:
+		var items []kmipItem
+		for len(value) > 0 {
+			var item kmipItem
+			n, err := item.decode(value)
+			if err != nil {
+				return 0, err
+			}
+			items = append(items, item)
+			value = value[n:]
+		}
+		i.Value = items
+	case kmipTypeInteger, kmipTypeInterval, kmipTypeEnumeration:
+		if length != 4 {
+			return 0, fmt.Errorf("kmip: invalid length %d for type %#x", length, i.Type)
+		}
+		if i.Type == kmipTypeEnumeration {
+			i.Value = binary.BigEndian.Uint32(value)
+		} else {
+			i.Value = int32(binary.BigEndian.Uint32(value))
+		}
+	case kmipTypeLongInteger, kmipTypeBoolean, kmipTypeDateTime:
+		if length != 8 {
+			return 0, fmt.Errorf("kmip: invalid length %d for type %#x", length, i.Type)
+		}
+		v := binary.BigEndian.Uint64(value)
+		switch i.Type {
+		case kmipTypeLongInteger:
+			i.Value = int64(v)
+		case kmipTypeBoolean:
+			i.Value = v != 0
+		default:
+			i.Value = time.Unix(int64(v), 0).UTC()
+		}
+	case kmipTypeTextString:
+		i.Value = string(value)
+	case kmipTypeByteString, kmipTypeBigInteger:
+		i.Value = append([]byte(nil), value...)
+	default:
+		return 0, fmt.Errorf("kmip: unsupported type %#x", i.Type)
+	}
+	return 8 + padded, nil
+}
+
+// readKMIPItem reads a single, top-level item from r.
+func readKMIPItem(r io.Reader) (kmipItem, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return kmipItem{}, err
+	}
+	length := binary.BigEndian.Uint32(header[4:])
+	if length > kmipMaxMessageSize {
+		return kmipItem{}, errors.New("kmip: message too large")
+	}
+	if n := length % 8; n != 0 {
+		length += 8 - n
+	}
+	b := make([]byte, 8+int(length))
+	copy(b, header[:])
+	if _, err := io.ReadFull(r, b[8:]); err != nil {
+		return kmipItem{}, err
+	}
+
+	var item kmipItem
+	if err := item.UnmarshalBinary(b); err != nil {
+		return kmipItem{}, err
+	}
+	return item, nil
+}
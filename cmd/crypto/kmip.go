@@ -0,0 +1,486 @@
+// MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/kms"
+)
+
+// KMIPConfig contains the configuration required
+// to initialize and connect to a KMIP server.
+type KMIPConfig struct {
+	Enabled bool
+
+	// The KMIP server endpoints as host:port.
+	// The port defaults to 5696.
+	Endpoint []string
+
+	// The path to the TLS private key used
+	// by MinIO to authenticate to the KMIP
+	// server during the TLS handshake.
+	KeyFile string
+
+	// The path to the TLS certificate used
+	// by MinIO to authenticate to the KMIP
+	// server during the TLS handshake.
+	CertFile string
+
+	// Path to a file or directory containing
+	// the CA certificate(s) that issued the
+	// certificate of the KMIP server.
+	CAPath string
+
+	// The name of the default key. The key
+	// is used when no explicit key ID is
+	// specified.
+	DefaultKeyID string
+
+	// The root CAs trusted in addition to
+	// the CA certificate(s) at CAPath.
+	RootCAs *x509.CertPool
+}
+
+// Verify verifies if the KMIP configuration is correct
+func (k KMIPConfig) Verify() (err error) {
+	switch {
+	case len(k.Endpoint) == 0:
+		err = Errorf("crypto: missing kmip endpoint")
+	case k.CertFile == "":
+		err = Errorf("crypto: missing cert file")
+	case k.KeyFile == "":
+		err = Errorf("crypto: missing key file")
+	case k.DefaultKeyID == "":
+		err = Errorf("crypto: missing default key id")
+	}
+	return err
+}
+
+// KMIP operations, object types and
+// enumeration values used by the client.
+const (
+	kmipOperationCreate   = 0x01
+	kmipOperationLocate   = 0x08
+	kmipOperationGet      = 0x0A
+	kmipOperationGetAttrs = 0x0B
+	kmipOperationActivate = 0x12
+
+	kmipObjectTypeSymmetricKey = 0x02
+	kmipAlgorithmAES           = 0x03
+	kmipKeyFormatRaw           = 0x01
+	kmipNameTypeText           = 0x01
+	kmipUsageEncryptDecrypt    = 0x04 | 0x08
+
+	kmipResultSuccess = 0x00
+)
+
+// kmipService is a KMS implementation that stores its
+// master keys as AES-256 keys on a KMIP server. DEKs are
+// derived locally from the key material fetched from the
+// KMIP server.
+type kmipService struct {
+	client *kmipClient
+
+	endpoints    []string
+	defaultKeyID string
+
+	lock sync.RWMutex
+	keys map[string]kms.KMS // cache of fetched master keys
+}
+
+// NewKMIP returns a new KMS client for the KMIP servers
+// at the configured endpoints. The client uses the X.509
+// certificate to authenticate itself to the KMIP server.
+func NewKMIP(cfg KMIPConfig) (KMS, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCAs := cfg.RootCAs
+	if rootCAs == nil {
+		rootCAs, _ = x509.SystemCertPool()
+		if rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+	}
+	if err = loadCACertificates(cfg.CAPath, rootCAs); err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]string, 0, len(cfg.Endpoint))
+	for _, endpoint := range cfg.Endpoint {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			endpoint = net.JoinHostPort(endpoint, "5696")
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return &kmipService{
+		client: &kmipClient{
+			endpoints: endpoints,
+			dial: func(addr string) (net.Conn, error) {
+				dialer := &net.Dialer{Timeout: 10 * time.Second}
+				return tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{
+					MinVersion:   tls.VersionTLS12,
+					RootCAs:      rootCAs,
+					Certificates: []tls.Certificate{cert},
+				})
+			},
+		},
+		endpoints:    endpoints,
+		defaultKeyID: cfg.DefaultKeyID,
+		keys:         map[string]kms.KMS{},
+	}, nil
+}
+
+// Stat returns the KMIP status. It returns an
+// error if no KMIP server is reachable.
+func (k *kmipService) Stat() (kms.Status, error) {
+	conn, err := k.client.connect()
+	if err != nil {
+		return kms.Status{}, err
+	}
+	conn.Close()
+	return kms.Status{
+		Name:       "KMIP",
+		Endpoints:  k.endpoints,
+		DefaultKey: k.defaultKeyID,
+	}, nil
+}
+
+// CreateKey creates and activates a new AES-256 key with
+// the key ID as name at the KMIP server.
+func (k *kmipService) CreateKey(keyID string) error {
+	if keyID == "" {
+		return Errorf("crypto: invalid key ID %q", keyID)
+	}
+	if _, err := k.client.Locate(keyID); err == nil {
+		return kms.ErrKeyExists
+	} else if !errors.Is(err, kms.ErrKeyNotFound) {
+		return err
+	}
+	uid, err := k.client.Create(keyID)
+	if err != nil {
+		return err
+	}
+	return k.client.Activate(uid)
+}
+
+// ListKeys returns the names of all symmetric keys the
+// KMIP client has access to.
+func (k *kmipService) ListKeys() ([]string, error) {
+	uids, err := k.client.locate(kmipAttribute("Object Type", kmipEnum(0, kmipObjectTypeSymmetricKey)))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		name, err := k.client.GetName(uid)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// KeyStatus returns the status of the master key. KMIP
+// keys are not versioned, so the status never contains
+// versions.
+func (k *kmipService) KeyStatus(keyID string) (kms.KeyStatus, error) {
+	if keyID == "" {
+		keyID = k.defaultKeyID
+	}
+	if _, err := k.client.Locate(keyID); err != nil {
+		return kms.KeyStatus{}, err
+	}
+	return kms.KeyStatus{KeyID: keyID}, nil
+}
+
+// RotateKey returns kms.ErrNotSupported. A new key has
+// to be created instead.
+func (k *kmipService) RotateKey(string) error { return kms.ErrNotSupported }
+
+// GenerateKey generates a new DEK and seals it with the
+// master key referenced by keyID.
+func (k *kmipService) GenerateKey(keyID string, ctx Context) (kms.DEK, error) {
+	if keyID == "" {
+		keyID = k.defaultKeyID
+	}
+	key, err := k.masterKey(keyID)
+	if err != nil {
+		return kms.DEK{}, err
+	}
+	return key.GenerateKey(keyID, ctx)
+}
+
+// DecryptKey decrypts the ciphertext with the master key
+// referenced by keyID.
+func (k *kmipService) DecryptKey(keyID string, ciphertext []byte, ctx Context) ([]byte, error) {
+	key, err := k.masterKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return key.DecryptKey(keyID, ciphertext, ctx)
+}
+
+// masterKey returns the master key with the given name.
+// It fetches the key from the KMIP server once and keeps
+// it in memory afterwards.
+func (k *kmipService) masterKey(keyID string) (kms.KMS, error) {
+	k.lock.RLock()
+	key, ok := k.keys[keyID]
+	k.lock.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	uid, err := k.client.Locate(keyID)
+	if err != nil {
+		return nil, err
+	}
+	material, err := k.client.Get(uid)
+	if err != nil {
+		return nil, err
+	}
+	if key, err = kms.New(keyID, material); err != nil {
+		return nil, err
+	}
+
+	k.lock.Lock()
+	k.keys[keyID] = key
+	k.lock.Unlock()
+	return key, nil
+}
+
+// kmipError is an error returned by a KMIP server.
+type kmipError struct {
+	Status  uint32
+	Reason  uint32
+	Message string
+}
+
+func (e kmipError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("kmip: operation failed: %s (status %d, reason %d)", e.Message, e.Status, e.Reason)
+	}
+	return fmt.Sprintf("kmip: operation failed (status %d, reason %d)", e.Status, e.Reason)
+}
+
+// kmipClient sends KMIP requests to the first reachable
+// endpoint. It uses one connection per request.
+type kmipClient struct {
+	endpoints []string
+	dial      func(addr string) (net.Conn, error)
+}
+
+// Create creates a new AES-256 key with the given name
+// and returns its unique identifier.
+func (c *kmipClient) Create(name string) (string, error) {
+	payload, err := c.do(kmipOperationCreate,
+		kmipEnum(kmipTagObjectType, kmipObjectTypeSymmetricKey),
+		kmipStructure(kmipTagTemplateAttribute,
+			kmipAttribute("Cryptographic Algorithm", kmipEnum(0, kmipAlgorithmAES)),
+			kmipAttribute("Cryptographic Length", kmipInteger(0, 256)),
+			kmipAttribute("Cryptographic Usage Mask", kmipInteger(0, kmipUsageEncryptDecrypt)),
+			kmipAttribute("Name", kmipName(name)),
+		),
+	)
+	if err != nil {
+		return "", err
+	}
+	uid, ok := payload.Child(kmipTagUniqueIdentifier)
+	if !ok {
+		return "", errors.New("kmip: create response contains no unique identifier")
+	}
+	if uid, ok := uid.Value.(string); ok {
+		return uid, nil
+	}
+	return "", errors.New("kmip: create response contains an invalid unique identifier")
+}
+
+// Activate activates the key with the given unique identifier.
+func (c *kmipClient) Activate(uid string) error {
+	_, err := c.do(kmipOperationActivate, kmipText(kmipTagUniqueIdentifier, uid))
+	return err
+}
+
+// Locate returns the unique identifier of the key with
+// the given name or kms.ErrKeyNotFound.
+func (c *kmipClient) Locate(name string) (string, error) {
+	uids, err := c.locate(kmipAttribute("Name", kmipName(name)))
+	if err != nil {
+		return "", err
+	}
+	if len(uids) == 0 {
+		return "", kms.ErrKeyNotFound
+	}
+	return uids[0], nil
+}
+
+// locate returns the unique identifiers of all objects
+// matching the attributes.
+func (c *kmipClient) locate(attributes ...kmipItem) ([]string, error) {
+	payload, err := c.do(kmipOperationLocate, attributes...)
+	if err != nil {
+		return nil, err
+	}
+	var uids []string
+	for _, item := range payload.Children(kmipTagUniqueIdentifier) {
+		uid, ok := item.Value.(string)
+		if !ok {
+			return nil, errors.New("kmip: locate response contains an invalid unique identifier")
+		}
+		uids = append(uids, uid)
+	}
+	return uids, nil
+}
+
+// GetName returns the name of the object with the given
+// unique identifier or an empty string if it has no name.
+func (c *kmipClient) GetName(uid string) (string, error) {
+	payload, err := c.do(kmipOperationGetAttrs,
+		kmipText(kmipTagUniqueIdentifier, uid),
+		kmipText(kmipTagAttributeName, "Name"),
+	)
+	if err != nil {
+		return "", err
+	}
+	for _, attribute := range payload.Children(kmipTagAttribute) {
+		if name, _ := attribute.Child(kmipTagAttributeName); name.Value != "Name" {
+			continue
+		}
+		if value, ok := attribute.Find(kmipTagAttributeValue, kmipTagNameValue); ok {
+			name, _ := value.Value.(string)
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// Get returns the raw key material of the symmetric key
+// with the given unique identifier.
+func (c *kmipClient) Get(uid string) ([]byte, error) {
+	payload, err := c.do(kmipOperationGet,
+		kmipText(kmipTagUniqueIdentifier, uid),
+		kmipEnum(kmipTagKeyFormatType, kmipKeyFormatRaw),
+	)
+	if err != nil {
+		return nil, err
+	}
+	material, ok := payload.Find(kmipTagSymmetricKey, kmipTagKeyBlock, kmipTagKeyValue, kmipTagKeyMaterial)
+	if !ok {
+		return nil, errors.New("kmip: get response contains no key material")
+	}
+	if material.Type == kmipTypeStructure { // transparent symmetric key
+		if material, ok = material.Child(kmipTagKey); !ok {
+			return nil, errors.New("kmip: get response contains no key material")
+		}
+	}
+	key, ok := material.Value.([]byte)
+	if !ok {
+		return nil, errors.New("kmip: get response contains invalid key material")
+	}
+	return key, nil
+}
+
+// connect returns a connection to the first reachable endpoint.
+func (c *kmipClient) connect() (conn net.Conn, err error) {
+	for _, endpoint := range c.endpoints {
+		if conn, err = c.dial(endpoint); err == nil {
+			return conn, nil
+		}
+	}
+	if err == nil {
+		err = errors.New("kmip: no endpoint configured")
+	}
+	return nil, err
+}
+
+// do sends a request with a single batch item for the
+// given operation and returns the response payload.
+func (c *kmipClient) do(operation uint32, payload ...kmipItem) (kmipItem, error) {
+	request, err := kmipStructure(kmipTagRequestMessage,
+		kmipStructure(kmipTagRequestHeader,
+			kmipStructure(kmipTagProtocolVersion,
+				kmipInteger(kmipTagProtocolVersionMajor, 1),
+				kmipInteger(kmipTagProtocolVersionMinor, 2),
+			),
+			kmipInteger(kmipTagBatchCount, 1),
+		),
+		kmipStructure(kmipTagBatchItem,
+			kmipEnum(kmipTagOperation, operation),
+			kmipStructure(kmipTagRequestPayload, payload...),
+		),
+	).MarshalBinary()
+	if err != nil {
+		return kmipItem{}, err
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return kmipItem{}, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	if _, err = conn.Write(request); err != nil {
+		return kmipItem{}, err
+	}
+	response, err := readKMIPItem(conn)
+	if err != nil {
+		return kmipItem{}, err
+	}
+	if response.Tag != kmipTagResponseMessage {
+		return kmipItem{}, errors.New("kmip: invalid response message")
+	}
+	batchItem, ok := response.Child(kmipTagBatchItem)
+	if !ok {
+		return kmipItem{}, errors.New("kmip: response contains no batch item")
+	}
+	status, ok := batchItem.Child(kmipTagResultStatus)
+	if !ok {
+		return kmipItem{}, errors.New("kmip: response contains no result status")
+	}
+	if code, _ := status.Value.(uint32); code != kmipResultSuccess {
+		kErr := kmipError{Status: code}
+		if reason, ok := batchItem.Child(kmipTagResultReason); ok {
+			kErr.Reason, _ = reason.Value.(uint32)
+		}
+		if message, ok := batchItem.Child(kmipTagResultMessage); ok {
+			kErr.Message, _ = message.Value.(string)
+		}
+		return kmipItem{}, kErr
+	}
+	payloadItem, _ := batchItem.Child(kmipTagResponsePayload)
+	return payloadItem, nil
+}
+
+// kmipName returns a KMIP name attribute value.
+func kmipName(name string) kmipItem {
+	return kmipStructure(0,
+		kmipText(kmipTagNameValue, name),
+		kmipEnum(kmipTagNameType, kmipNameTypeText),
+	)
+}
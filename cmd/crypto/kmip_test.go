@@ -0,0 +1,271 @@
+// MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio/pkg/kms"
+)
+
+func TestKMIPItemRoundtrip(t *testing.T) {
+	item := kmipStructure(kmipTagRequestMessage,
+		kmipInteger(kmipTagBatchCount, 1),
+		kmipEnum(kmipTagOperation, kmipOperationGet),
+		kmipText(kmipTagUniqueIdentifier, "1234"),
+		kmipBytes(kmipTagKeyMaterial, []byte("0123456789")),
+		kmipItem{Tag: kmipTagKey, Type: kmipTypeLongInteger, Value: int64(-1)},
+		kmipItem{Tag: kmipTagKey, Type: kmipTypeBoolean, Value: true},
+		kmipStructure(kmipTagAttribute),
+	)
+	b, err := item.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to encode item: %v", err)
+	}
+	if len(b)%8 != 0 {
+		t.Fatalf("Encoded item is not padded: length %d", len(b))
+	}
+	// The encoding of an Integer is defined by the KMIP specification.
+	if want := []byte{0x42, 0x00, 0x0D, 0x02, 0, 0, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0}; !bytes.Equal(b[8:24], want) {
+		t.Fatalf("Invalid integer encoding: got %x - want %x", b[8:24], want)
+	}
+
+	var decoded kmipItem
+	if err = decoded.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Failed to decode item: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, item) {
+		t.Fatalf("Decoded item does not match: got %v - want %v", decoded, item)
+	}
+	if err = decoded.UnmarshalBinary(b[:len(b)-8]); err == nil {
+		t.Fatal("Decoding a truncated item should fail")
+	}
+	if err = decoded.UnmarshalBinary(append(b, make([]byte, 8)...)); err == nil {
+		t.Fatal("Decoding an item with trailing data should fail")
+	}
+	invalid := kmipItem{Tag: kmipTagKey, Type: kmipTypeTextString, Value: int32(1)}
+	if _, err = invalid.MarshalBinary(); err == nil {
+		t.Fatal("Encoding an item with a mismatching value should fail")
+	}
+}
+
+func TestKMIPService(t *testing.T) {
+	server := newFakeKMIPServer()
+	KMS := &kmipService{
+		client: &kmipClient{
+			endpoints: []string{"unreachable:5696", "kmip:5696"},
+			dial:      server.dial,
+		},
+		defaultKeyID: "my-key",
+		keys:         map[string]kms.KMS{},
+	}
+
+	if _, err := KMS.GenerateKey("", Context{}); !errors.Is(err, kms.ErrKeyNotFound) {
+		t.Fatalf("Generating a key with a non-existing key should fail with %v: got %v", kms.ErrKeyNotFound, err)
+	}
+	if err := KMS.CreateKey("my-key"); err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	if err := KMS.CreateKey("my-key"); !errors.Is(err, kms.ErrKeyExists) {
+		t.Fatalf("Creating an existing key should fail with %v: got %v", kms.ErrKeyExists, err)
+	}
+	if !server.active["1"] {
+		t.Fatal("Created key has not been activated")
+	}
+
+	key, err := KMS.GenerateKey("", Context{"bucket": "object"})
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if key.KeyID != "my-key" {
+		t.Fatalf("Generated key has wrong key ID: got %q - want %q", key.KeyID, "my-key")
+	}
+
+	// A new client without cached keys must fetch the
+	// key from the server to decrypt the key.
+	other := &kmipService{client: KMS.client, keys: map[string]kms.KMS{}}
+	plaintext, err := other.DecryptKey(key.KeyID, key.Ciphertext, Context{"bucket": "object"})
+	if err != nil {
+		t.Fatalf("Failed to decrypt key: %v", err)
+	}
+	if !bytes.Equal(plaintext, key.Plaintext) {
+		t.Fatalf("Decrypted key does not match generated one: got %x - want %x", plaintext, key.Plaintext)
+	}
+	if _, err = other.DecryptKey(key.KeyID, key.Ciphertext, Context{}); err == nil {
+		t.Fatal("Decryption with a different context should fail")
+	}
+
+	if err = KMS.CreateKey("other-key"); err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	keys, err := KMS.ListKeys()
+	if err != nil {
+		t.Fatalf("Failed to list keys: %v", err)
+	}
+	sort.Strings(keys)
+	if want := []string{"my-key", "other-key"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("Listed keys do not match: got %v - want %v", keys, want)
+	}
+	if status, err := KMS.KeyStatus(""); err != nil || status.KeyID != "my-key" {
+		t.Fatalf("Invalid key status: got %v - %v", status, err)
+	}
+	if _, err = KMS.KeyStatus("unknown-key"); !errors.Is(err, kms.ErrKeyNotFound) {
+		t.Fatalf("Key status of a non-existing key should fail with %v: got %v", kms.ErrKeyNotFound, err)
+	}
+
+	var kErr kmipError
+	if err = KMS.client.Activate("unknown"); !errors.As(err, &kErr) || kErr.Reason != 1 {
+		t.Fatalf("Activating an unknown key should fail with a KMIP error: got %v", err)
+	}
+}
+
+// fakeKMIPServer is a minimal, in-memory KMIP server that
+// supports the operations used by the KMIP client.
+type fakeKMIPServer struct {
+	lock   sync.Mutex
+	names  map[string]string // name -> unique identifier
+	keys   map[string][]byte // unique identifier -> key material
+	active map[string]bool
+}
+
+func newFakeKMIPServer() *fakeKMIPServer {
+	return &fakeKMIPServer{
+		names:  map[string]string{},
+		keys:   map[string][]byte{},
+		active: map[string]bool{},
+	}
+}
+
+func (s *fakeKMIPServer) dial(addr string) (net.Conn, error) {
+	if addr != "kmip:5696" {
+		return nil, fmt.Errorf("dial %s: connection refused", addr)
+	}
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+		request, err := readKMIPItem(server)
+		if err != nil {
+			return
+		}
+		response, err := s.handle(request).MarshalBinary()
+		if err != nil {
+			return
+		}
+		server.Write(response)
+	}()
+	return client, nil
+}
+
+func (s *fakeKMIPServer) handle(request kmipItem) kmipItem {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	operation, _ := request.Find(kmipTagBatchItem, kmipTagOperation)
+	payload, _ := request.Find(kmipTagBatchItem, kmipTagRequestPayload)
+	uidOf := func() string {
+		uid, _ := payload.Child(kmipTagUniqueIdentifier)
+		v, _ := uid.Value.(string)
+		return v
+	}
+	nameOf := func(attributes kmipItem) string {
+		for _, attribute := range attributes.Children(kmipTagAttribute) {
+			if name, _ := attribute.Child(kmipTagAttributeName); name.Value == "Name" {
+				value, _ := attribute.Find(kmipTagAttributeValue, kmipTagNameValue)
+				v, _ := value.Value.(string)
+				return v
+			}
+		}
+		return ""
+	}
+
+	var result []kmipItem
+	switch operation.Value {
+	case uint32(kmipOperationCreate):
+		template, _ := payload.Child(kmipTagTemplateAttribute)
+		uid := fmt.Sprint(len(s.keys) + 1)
+		key := make([]byte, 32)
+		rand.Read(key)
+		s.names[nameOf(template)], s.keys[uid] = uid, key
+		result = append(result, kmipText(kmipTagUniqueIdentifier, uid))
+	case uint32(kmipOperationLocate):
+		name := nameOf(payload)
+		for n, uid := range s.names {
+			if name == "" || name == n {
+				result = append(result, kmipText(kmipTagUniqueIdentifier, uid))
+			}
+		}
+	case uint32(kmipOperationGetAttrs):
+		uid := uidOf()
+		if _, ok := s.keys[uid]; !ok {
+			return fakeKMIPError(operation)
+		}
+		result = append(result, kmipText(kmipTagUniqueIdentifier, uid))
+		for n, id := range s.names {
+			if id == uid {
+				result = append(result, kmipAttribute("Name", kmipName(n)))
+			}
+		}
+	case uint32(kmipOperationActivate):
+		uid := uidOf()
+		if _, ok := s.keys[uid]; !ok {
+			return fakeKMIPError(operation)
+		}
+		s.active[uid] = true
+		result = append(result, kmipText(kmipTagUniqueIdentifier, uid))
+	case uint32(kmipOperationGet):
+		key, ok := s.keys[uidOf()]
+		if !ok {
+			return fakeKMIPError(operation)
+		}
+		result = append(result, kmipStructure(kmipTagSymmetricKey,
+			kmipStructure(kmipTagKeyBlock,
+				kmipEnum(kmipTagKeyFormatType, kmipKeyFormatRaw),
+				kmipStructure(kmipTagKeyValue,
+					kmipBytes(kmipTagKeyMaterial, key),
+				),
+			),
+		))
+	default:
+		return fakeKMIPError(operation)
+	}
+	return kmipStructure(kmipTagResponseMessage,
+		kmipStructure(kmipTagResponseHeader),
+		kmipStructure(kmipTagBatchItem,
+			operation,
+			kmipEnum(kmipTagResultStatus, kmipResultSuccess),
+			kmipStructure(kmipTagResponsePayload, result...),
+		),
+	)
+}
+
+func fakeKMIPError(operation kmipItem) kmipItem {
+	return kmipStructure(kmipTagResponseMessage,
+		kmipStructure(kmipTagResponseHeader),
+		kmipStructure(kmipTagBatchItem,
+			operation,
+			kmipEnum(kmipTagResultStatus, 1),
+			kmipEnum(kmipTagResultReason, 1),
+			kmipText(kmipTagResultMessage, "item not found"),
+		),
+	)
+}
@@ -60,6 +60,21 @@ func (m *masterKeyKMS) CreateKey(keyID string) error {
 	return errors.New("crypto: creating keys is not supported by a static master key")
 }
 
+func (m *masterKeyKMS) ListKeys() ([]string, error) {
+	return []string{m.keyID}, nil
+}
+
+func (m *masterKeyKMS) KeyStatus(keyID string) (kms.KeyStatus, error) {
+	if keyID == "" {
+		keyID = m.keyID
+	}
+	return kms.KeyStatus{KeyID: keyID}, nil
+}
+
+func (m *masterKeyKMS) RotateKey(keyID string) error {
+	return kms.ErrNotSupported
+}
+
 func (m *masterKeyKMS) GenerateKey(keyID string, ctx Context) (kms.DEK, error) {
 	if keyID == "" {
 		keyID = m.keyID
@@ -15,6 +15,8 @@
 package crypto
 
 import (
+	"strconv"
+
 	xhttp "github.com/minio/minio/cmd/http"
 )
 
@@ -44,6 +46,10 @@ const (
 	// MetaDataEncryptionKey is the sealed data encryption key (DEK) received from
 	// the KMS.
 	MetaDataEncryptionKey = "X-Minio-Internal-Server-Side-Encryption-S3-Kms-Sealed-Key"
+	// MetaKeyVersion is the version of the KMS master key used to generate
+	// the data encryption key (DEK). It is not present if the KMS does not
+	// version keys.
+	MetaKeyVersion = "X-Minio-Internal-Server-Side-Encryption-S3-Kms-Key-Version"
 
 	// MetaContext is the KMS context provided by a client when encrypting an
 	// object with SSE-KMS. A client may not send a context in which case the
@@ -100,6 +106,29 @@ func RemoveInternalEntries(metadata map[string]string) {
 	delete(metadata, MetaSealedKeyKMS)
 	delete(metadata, MetaKeyID)
 	delete(metadata, MetaDataEncryptionKey)
+	delete(metadata, MetaKeyVersion)
+}
+
+// SetKeyVersion stores the version of the KMS master key used to
+// generate the data encryption key in the metadata. It removes the
+// entry if the version is 0 - i.e. the KMS does not version keys.
+func SetKeyVersion(metadata map[string]string, version int) {
+	if version == 0 {
+		delete(metadata, MetaKeyVersion)
+		return
+	}
+	metadata[MetaKeyVersion] = strconv.Itoa(version)
+}
+
+// KeyVersion returns the version of the KMS master key used to
+// generate the data encryption key or 0 if the metadata contains
+// no valid version.
+func KeyVersion(metadata map[string]string) int {
+	version, err := strconv.Atoi(metadata[MetaKeyVersion])
+	if err != nil || version < 0 {
+		return 0
+	}
+	return version
 }
 
 // IsSourceEncrypted returns true if the source is encrypted
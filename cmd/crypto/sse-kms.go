@@ -68,9 +68,15 @@ func (ssekms) ParseHTTP(h http.Header) (string, Context, error) {
 
 	var ctx Context
 	if context, ok := h[xhttp.AmzServerSideEncryptionKmsContext]; ok {
+		// S3 clients send the context as base64-encoded JSON while
+		// older MinIO clients send plain JSON.
+		b := []byte(context[0])
+		if decoded, err := base64.StdEncoding.DecodeString(context[0]); err == nil {
+			b = decoded
+		}
 		var json = jsoniter.ConfigCompatibleWithStandardLibrary
-		if err := json.Unmarshal([]byte(context[0]), &ctx); err != nil {
-			return "", nil, err
+		if err := json.Unmarshal(b, &ctx); err != nil {
+			return "", nil, ErrInvalidEncryptionContext
 		}
 	}
 	return h.Get(xhttp.AmzServerSideEncryptionKmsID), ctx, nil
@@ -135,6 +141,7 @@ func (ssekms) CreateMetadata(metadata map[string]string, keyID string, kmsKey []
 		metadata[MetaKeyID] = keyID
 		metadata[MetaDataEncryptionKey] = base64.StdEncoding.EncodeToString(kmsKey)
 	}
+	delete(metadata, MetaKeyVersion) // The version belongs to the previous data key, if any
 	return metadata
 }
 
@@ -197,11 +204,15 @@ func (ssekms) ParseMetadata(metadata map[string]string) (keyID string, kmsKey []
 			return keyID, kmsKey, sealedKey, ctx, Errorf("The internal KMS context is not base64-encoded")
 		}
 		var json = jsoniter.ConfigCompatibleWithStandardLibrary
-		if err = json.Unmarshal(b, ctx); err != nil {
+		if err = json.Unmarshal(b, &ctx); err != nil {
 			return keyID, kmsKey, sealedKey, ctx, Errorf("The internal sealed KMS context is invalid")
 		}
 	}
 
+	if ctx == nil {
+		ctx = Context{}
+	}
+
 	sealedKey.Algorithm = algorithm
 	copy(sealedKey.IV[:], iv)
 	copy(sealedKey.Key[:], encryptedKey)
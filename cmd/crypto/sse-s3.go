@@ -112,6 +112,7 @@ func (sses3) CreateMetadata(metadata map[string]string, keyID string, kmsKey []b
 		metadata[MetaKeyID] = keyID
 		metadata[MetaDataEncryptionKey] = base64.StdEncoding.EncodeToString(kmsKey)
 	}
+	delete(metadata, MetaKeyVersion) // The version belongs to the previous data key, if any
 	return metadata
 }
 
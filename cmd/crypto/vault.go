@@ -18,6 +18,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -212,6 +214,64 @@ func (v *vaultService) CreateKey(keyID string) error {
 	return errors.New("crypto: creating keys is not supported by Vault")
 }
 
+// ListKeys returns the names of all transit keys.
+func (v *vaultService) ListKeys() ([]string, error) {
+	s, err := v.client.Logical().List("/transit/keys")
+	if err != nil {
+		return nil, Errorf("crypto: client error %w", err)
+	}
+	if s == nil {
+		return nil, nil
+	}
+	keys, ok := s.Data["keys"].([]interface{})
+	if !ok {
+		return nil, Errorf("crypto: incorrect 'keys' type %v", s.Data["keys"])
+	}
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if name, ok := key.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// KeyStatus returns the versions of the transit key.
+func (v *vaultService) KeyStatus(keyID string) (kms.KeyStatus, error) {
+	if keyID == "" {
+		keyID = v.config.Key.Name
+	}
+	s, err := v.client.Logical().Read(fmt.Sprintf("/transit/keys/%s", keyID))
+	if err != nil {
+		return kms.KeyStatus{}, Errorf("crypto: client error %w", err)
+	}
+	if s == nil {
+		return kms.KeyStatus{}, kms.ErrKeyNotFound
+	}
+	keys, ok := s.Data["keys"].(map[string]interface{})
+	if !ok {
+		return kms.KeyStatus{}, Errorf("crypto: incorrect 'keys' type %v", s.Data["keys"])
+	}
+	status := kms.KeyStatus{KeyID: keyID}
+	for version := range keys {
+		n, err := strconv.Atoi(version)
+		if err != nil {
+			return kms.KeyStatus{}, Errorf("crypto: invalid key version %q", version)
+		}
+		status.Versions = append(status.Versions, n)
+	}
+	sort.Ints(status.Versions)
+	return status, nil
+}
+
+// RotateKey adds a new version to the transit key.
+func (v *vaultService) RotateKey(keyID string) error {
+	if _, err := v.client.Logical().Write(fmt.Sprintf("/transit/keys/%s/rotate", keyID), nil); err != nil {
+		return Errorf("crypto: client error %w", err)
+	}
+	return nil
+}
+
 // GenerateKey returns a new plaintext key, generated by the KMS,
 // and a sealed version of this plaintext key encrypted using the
 // named key referenced by keyID. It also binds the generated key
@@ -248,6 +308,7 @@ func (v *vaultService) GenerateKey(keyID string, ctx Context) (kms.DEK, error) {
 	}
 	return kms.DEK{
 		KeyID:      keyID,
+		Version:    vaultCiphertextVersion(sealKey),
 		Plaintext:  plainKey,
 		Ciphertext: []byte(sealKey),
 	}, nil
@@ -287,3 +348,18 @@ func (v *vaultService) DecryptKey(keyID string, sealedKey []byte, ctx Context) (
 	}
 	return plainKey, nil
 }
+
+// vaultCiphertextVersion returns the key version encoded in
+// a Vault transit ciphertext of the form "vault:v<N>:..." or
+// 0 if the ciphertext has no such prefix.
+func vaultCiphertextVersion(ciphertext string) int {
+	v := strings.SplitN(ciphertext, ":", 3)
+	if len(v) != 3 || v[0] != "vault" || !strings.HasPrefix(v[1], "v") {
+		return 0
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(v[1], "v"))
+	if err != nil {
+		return 0
+	}
+	return version
+}
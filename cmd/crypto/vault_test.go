@@ -98,3 +98,24 @@ func TestVerifyVaultConfig(t *testing.T) {
 		})
 	}
 }
+
+var vaultCiphertextVersionTests = []struct {
+	Ciphertext string
+	Version    int
+}{
+	{Ciphertext: "vault:v1:Zm9vYmFy", Version: 1},   // 0
+	{Ciphertext: "vault:v12:Zm9vYmFy", Version: 12}, // 1
+	{Ciphertext: "vault:1:Zm9vYmFy", Version: 0},    // 2
+	{Ciphertext: "vault:vX:Zm9vYmFy", Version: 0},   // 3
+	{Ciphertext: "Zm9vYmFy", Version: 0},            // 4
+	{Ciphertext: "other:v1:Zm9vYmFy", Version: 0},   // 5
+	{Ciphertext: "vault:v3:Zm9v:YmFy", Version: 3},  // 6
+}
+
+func TestVaultCiphertextVersion(t *testing.T) {
+	for i, test := range vaultCiphertextVersionTests {
+		if version := vaultCiphertextVersion(test.Ciphertext); version != test.Version {
+			t.Errorf("Test %d: version mismatch: got %d - want %d", i, version, test.Version)
+		}
+	}
+}
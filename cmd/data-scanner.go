@@ -932,6 +932,7 @@ func (i *scannerItem) applyLifecycle(ctx context.Context, o ObjectLayer, meta ac
 }
 
 // applyActions will apply lifecycle checks on to a scanned item.
+// Object keys of surviving versions are re-wrapped per the KMS re-wrap rules.
 // The resulting size on disk will always be returned.
 // The metadata will be compared to consensus on the object layer before any changes are applied.
 // If no metadata is supplied, -1 is returned if no action is taken.
@@ -943,6 +944,9 @@ func (i *scannerItem) applyActions(ctx context.Context, o ObjectLayer, meta acti
 	if !applied && i.heal {
 		size = i.applyHealing(ctx, o, meta)
 	}
+	if !applied {
+		i.applyKeyRewrap(ctx, o, meta)
+	}
 	return size
 }
 
@@ -1029,7 +1033,7 @@ func applyExpiryOnTransitionedObject(ctx context.Context, objLayer ObjectLayer,
 		TransitionStatus: obj.TransitionStatus,
 	}
 
-	if err := deleteTransitionedObject(ctx, objLayer, obj.Bucket, obj.Name, lcOpts, restoredObject, false); err != nil {
+	if err := deleteTransitionedObject(ctx, objLayer, obj.Bucket, obj.Name, lcOpts, obj.TransitionedObject, restoredObject, false); err != nil {
 		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 			return false
 		}
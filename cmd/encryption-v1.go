@@ -22,6 +22,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
@@ -90,7 +91,7 @@ func isEncryptedMultipart(objInfo ObjectInfo) bool {
 // ParseSSECopyCustomerRequest parses the SSE-C header fields of the provided request.
 // It returns the client provided key on success.
 func ParseSSECopyCustomerRequest(h http.Header, metadata map[string]string) (key []byte, err error) {
-	if crypto.S3.IsEncrypted(metadata) && crypto.SSECopy.IsRequested(h) {
+	if (crypto.S3.IsEncrypted(metadata) || crypto.S3KMS.IsEncrypted(metadata)) && crypto.SSECopy.IsRequested(h) {
 		return nil, crypto.ErrIncompatibleEncryptionMethod
 	}
 	k, err := crypto.SSECopy.ParseHTTP(h)
@@ -162,13 +163,20 @@ func rotateKey(oldKey []byte, newKey []byte, bucket, object string, metadata map
 		}
 		sealedKey = objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3.String(), bucket, object)
 		crypto.S3.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey)
+		crypto.SetKeyVersion(metadata, newKey.Version)
 		return nil
 	}
 }
 
-func newEncryptMetadata(key []byte, bucket, object string, metadata map[string]string, sseS3 bool) (crypto.ObjectKey, error) {
+// newEncryptMetadata generates a new object key and seals it into the
+// metadata. The object key is sealed with the client key for SSE-C, or
+// with a data key generated by the KMS for SSE-S3 and SSE-KMS. For SSE-KMS
+// the data key is generated with the master key keyID, or the default
+// key if empty, and bound to kmsCtx.
+func newEncryptMetadata(kind crypto.Type, keyID string, key []byte, bucket, object string, metadata map[string]string, kmsCtx crypto.Context) (crypto.ObjectKey, error) {
 	var sealedKey crypto.SealedKey
-	if sseS3 {
+	switch kind {
+	case crypto.S3:
 		if GlobalKMS == nil {
 			return crypto.ObjectKey{}, errKMSNotConfigured
 		}
@@ -180,6 +188,33 @@ func newEncryptMetadata(key []byte, bucket, object string, metadata map[string]s
 		objectKey := crypto.GenerateKey(key.Plaintext, rand.Reader)
 		sealedKey = objectKey.Seal(key.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3.String(), bucket, object)
 		crypto.S3.CreateMetadata(metadata, key.KeyID, key.Ciphertext, sealedKey)
+		crypto.SetKeyVersion(metadata, key.Version)
+		return objectKey, nil
+	case crypto.S3KMS:
+		if GlobalKMS == nil {
+			return crypto.ObjectKey{}, errKMSNotConfigured
+		}
+		ctx := make(crypto.Context, len(kmsCtx)+1)
+		for k, v := range kmsCtx {
+			ctx[k] = v
+		}
+		// The object binding is always part of the context. A client
+		// cannot replace it by its own context value.
+		ctx[bucket] = path.Join(bucket, object)
+		key, err := GlobalKMS.GenerateKey(keyID, ctx)
+		if err != nil {
+			return crypto.ObjectKey{}, err
+		}
+		b, err := ctx.MarshalText()
+		if err != nil {
+			return crypto.ObjectKey{}, err
+		}
+
+		objectKey := crypto.GenerateKey(key.Plaintext, rand.Reader)
+		sealedKey = objectKey.Seal(key.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), bucket, object)
+		crypto.S3KMS.CreateMetadata(metadata, key.KeyID, key.Ciphertext, sealedKey)
+		crypto.SetKeyVersion(metadata, key.Version)
+		metadata[crypto.MetaContext] = base64.StdEncoding.EncodeToString(b)
 		return objectKey, nil
 	}
 	objectKey := crypto.GenerateKey(key, rand.Reader)
@@ -188,8 +223,8 @@ func newEncryptMetadata(key []byte, bucket, object string, metadata map[string]s
 	return objectKey, nil
 }
 
-func newEncryptReader(content io.Reader, key []byte, bucket, object string, metadata map[string]string, sseS3 bool) (io.Reader, crypto.ObjectKey, error) {
-	objectEncryptionKey, err := newEncryptMetadata(key, bucket, object, metadata, sseS3)
+func newEncryptReader(content io.Reader, kind crypto.Type, keyID string, key []byte, bucket, object string, metadata map[string]string, kmsCtx crypto.Context) (io.Reader, crypto.ObjectKey, error) {
+	objectEncryptionKey, err := newEncryptMetadata(kind, keyID, key, bucket, object, metadata, kmsCtx)
 	if err != nil {
 		return nil, crypto.ObjectKey{}, err
 	}
@@ -202,19 +237,30 @@ func newEncryptReader(content io.Reader, key []byte, bucket, object string, meta
 	return reader, objectEncryptionKey, nil
 }
 
+// parseEncryptionRequest returns the SSE type requested by the headers
+// along with the SSE-C client key or the SSE-KMS key ID and context.
+func parseEncryptionRequest(r *http.Request) (kind crypto.Type, keyID string, key []byte, kmsCtx crypto.Context, err error) {
+	if crypto.SSEC.IsRequested(r.Header) && (crypto.S3.IsRequested(r.Header) || crypto.S3KMS.IsRequested(r.Header)) {
+		return nil, "", nil, nil, crypto.ErrIncompatibleEncryptionMethod
+	}
+	kind, _ = crypto.IsRequested(r.Header)
+	switch kind {
+	case crypto.SSEC:
+		key, err = ParseSSECustomerRequest(r)
+	case crypto.S3KMS:
+		keyID, kmsCtx, err = crypto.S3KMS.ParseHTTP(r.Header)
+	}
+	return kind, keyID, key, kmsCtx, err
+}
+
 // set new encryption metadata from http request headers for SSE-C and generated key from KMS in the case of
-// SSE-S3
+// SSE-S3 and SSE-KMS
 func setEncryptionMetadata(r *http.Request, bucket, object string, metadata map[string]string) (err error) {
-	var (
-		key []byte
-	)
-	if crypto.SSEC.IsRequested(r.Header) {
-		key, err = ParseSSECustomerRequest(r)
-		if err != nil {
-			return
-		}
+	kind, keyID, key, kmsCtx, err := parseEncryptionRequest(r)
+	if err != nil {
+		return err
 	}
-	_, err = newEncryptMetadata(key, bucket, object, metadata, crypto.S3.IsRequested(r.Header))
+	_, err = newEncryptMetadata(kind, keyID, key, bucket, object, metadata, kmsCtx)
 	return
 }
 
@@ -222,24 +268,16 @@ func setEncryptionMetadata(r *http.Request, bucket, object string, metadata map[
 // with the client provided key. It also marks the object as client-side-encrypted
 // and sets the correct headers.
 func EncryptRequest(content io.Reader, r *http.Request, bucket, object string, metadata map[string]string) (io.Reader, crypto.ObjectKey, error) {
-	if crypto.S3.IsRequested(r.Header) && crypto.SSEC.IsRequested(r.Header) {
-		return nil, crypto.ObjectKey{}, crypto.ErrIncompatibleEncryptionMethod
+	kind, keyID, key, kmsCtx, err := parseEncryptionRequest(r)
+	if err != nil {
+		return nil, crypto.ObjectKey{}, err
 	}
 	if r.ContentLength > encryptBufferThreshold {
 		// The encryption reads in blocks of 64KB.
 		// We add a buffer on bigger files to reduce the number of syscalls upstream.
 		content = bufio.NewReaderSize(content, encryptBufferSize)
 	}
-
-	var key []byte
-	if crypto.SSEC.IsRequested(r.Header) {
-		var err error
-		key, err = ParseSSECustomerRequest(r)
-		if err != nil {
-			return nil, crypto.ObjectKey{}, err
-		}
-	}
-	return newEncryptReader(content, key, bucket, object, metadata, crypto.S3.IsRequested(r.Header))
+	return newEncryptReader(content, kind, keyID, key, bucket, object, metadata, kmsCtx)
 }
 
 func decryptObjectInfo(key []byte, bucket, object string, metadata map[string]string) ([]byte, error) {
@@ -286,7 +324,7 @@ func decryptObjectInfo(key []byte, bucket, object string, metadata map[string]st
 // DecryptRequestWithSequenceNumberR - same as
 // DecryptRequestWithSequenceNumber but with a reader
 func DecryptRequestWithSequenceNumberR(client io.Reader, h http.Header, bucket, object string, seqNumber uint32, metadata map[string]string) (io.Reader, error) {
-	if crypto.S3.IsEncrypted(metadata) {
+	if crypto.S3.IsEncrypted(metadata) || crypto.S3KMS.IsEncrypted(metadata) {
 		return newDecryptReader(client, nil, bucket, object, seqNumber, metadata)
 	}
 
@@ -790,7 +828,7 @@ func DecryptObjectInfo(info *ObjectInfo, r *http.Request) (encrypted bool, err e
 			}
 		}
 
-		if crypto.S3.IsEncrypted(info.UserDefined) && r.Header.Get(xhttp.AmzCopySource) == "" {
+		if (crypto.S3.IsEncrypted(info.UserDefined) || crypto.S3KMS.IsEncrypted(info.UserDefined)) && r.Header.Get(xhttp.AmzCopySource) == "" {
 			if crypto.SSEC.IsRequested(headers) || crypto.SSECopy.IsRequested(headers) {
 				return encrypted, errEncryptedObject
 			}
@@ -18,9 +18,11 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"testing"
@@ -30,6 +32,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/kms"
 	"github.com/minio/sio"
 )
 
@@ -83,6 +86,65 @@ func TestEncryptRequest(t *testing.T) {
 	}
 }
 
+func TestEncryptRequestSSEKMS(t *testing.T) {
+	masterKey := make([]byte, 32)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatal(err)
+	}
+	store, err := kms.NewKeyStore(t.TempDir(), masterKey, "default-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.CreateKey("bucket-key"); err != nil {
+		t.Fatal(err)
+	}
+	GlobalKMS = store
+	defer func() { GlobalKMS = nil }()
+
+	const bucket, object = "bucket", "object"
+	req := &http.Request{Header: http.Header{}}
+	req.Header.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMS)
+	req.Header.Set(xhttp.AmzServerSideEncryptionKmsID, "bucket-key")
+	// The client must not be able to replace the object binding.
+	req.Header.Set(xhttp.AmzServerSideEncryptionKmsContext, base64.StdEncoding.EncodeToString([]byte(`{"project":"alpha","bucket":"other/object"}`)))
+
+	data := []byte("SSE-KMS encrypted content")
+	metadata := map[string]string{}
+	reader, _, err := EncryptRequest(bytes.NewReader(data), req, bucket, object, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !crypto.S3KMS.IsEncrypted(metadata) {
+		t.Fatalf("the metadata does not indicate SSE-KMS: %v", metadata)
+	}
+	if keyID := metadata[crypto.MetaKeyID]; keyID != "bucket-key" {
+		t.Fatalf("got key ID %q, want bucket-key", keyID)
+	}
+	_, _, _, ctx, err := crypto.S3KMS.ParseMetadata(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx["project"] != "alpha" || ctx[bucket] != bucket+"/"+object {
+		t.Fatalf("unexpected encryption context: %v", ctx)
+	}
+
+	plaintext, err := DecryptRequestWithSequenceNumberR(bytes.NewReader(ciphertext), http.Header{}, bucket, object, 0, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("the decrypted content does not match")
+	}
+}
+
 var decryptObjectInfoTests = []struct {
 	info    ObjectInfo
 	request *http.Request
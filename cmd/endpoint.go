@@ -202,11 +202,23 @@ type PoolEndpoints struct {
 	SetCount     int
 	DrivesPerSet int
 	Endpoints    Endpoints
+	CmdLine      string
 }
 
 // EndpointServerPools - list of list of endpoints
 type EndpointServerPools []PoolEndpoints
 
+// GetPoolIdx returns the index of the pool specified by cmdLine on the
+// command line, -1 if there is no such pool.
+func (l EndpointServerPools) GetPoolIdx(cmdLine string) int {
+	for i, zep := range l {
+		if zep.CmdLine == cmdLine {
+			return i
+		}
+	}
+	return -1
+}
+
 // GetLocalPoolIdx returns the pool which endpoint belongs to locally.
 // if ep is remote this code will return -1 poolIndex
 func (l EndpointServerPools) GetLocalPoolIdx(ep Endpoint) int {
@@ -31,10 +31,16 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/mimedb"
 	"github.com/minio/minio/pkg/sync/errgroup"
+	"github.com/minio/minio/pkg/trace/otlp"
 )
 
+// multipartObjectKey records the object of a multipart upload in its
+// metadata, the upload ID path is a hash of it.
+const multipartObjectKey = ReservedMetadataPrefixLower + "multipart-object"
+
 func (er erasureObjects) getUploadIDDir(bucket, object, uploadID string) string {
 	return pathJoin(er.getMultipartSHADir(bucket, object), uploadID)
 }
@@ -325,10 +331,13 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 
 	onlineDisks, partsMetadata = shuffleDisksAndPartsMetadata(onlineDisks, partsMetadata, fi)
 
+	metadata := cloneMSS(opts.UserDefined)
+	metadata[multipartObjectKey] = pathJoin(bucket, object)
+
 	// Fill all the necessary metadata.
 	// Update `xl.meta` content on each disks.
 	for index := range partsMetadata {
-		partsMetadata[index].Metadata = opts.UserDefined
+		partsMetadata[index].Metadata = metadata
 		partsMetadata[index].ModTime = modTime
 	}
 
@@ -349,7 +358,11 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 // subsequent request each UUID is unique.
 //
 // Implements S3 compatible initiate multipart API.
-func (er erasureObjects) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (string, error) {
+func (er erasureObjects) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.NewMultipartUpload", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	// No metadata is set, allocate a new one.
 	if opts.UserDefined == nil {
 		opts.UserDefined = make(map[string]string)
@@ -363,7 +376,12 @@ func (er erasureObjects) NewMultipartUpload(ctx context.Context, bucket, object
 //
 // Implements S3 compatible Upload Part Copy API.
 func (er erasureObjects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject, uploadID string, partID int, startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (pi PartInfo, e error) {
-	partInfo, err := er.PutObjectPart(ctx, dstBucket, dstObject, uploadID, partID, NewPutObjReader(srcInfo.Reader), dstOpts)
+	pReader := NewPutObjReader(srcInfo.Reader)
+	if srcInfo.PutObjReader != nil {
+		// Keep the checksum computed on the uncompressed, unencrypted content.
+		pReader.WithChecksum(srcInfo.PutObjReader.checksumReader)
+	}
+	partInfo, err := er.PutObjectPart(ctx, dstBucket, dstObject, uploadID, partID, pReader, dstOpts)
 	if err != nil {
 		return pi, toObjectErr(err, dstBucket, dstObject)
 	}
@@ -378,6 +396,10 @@ func (er erasureObjects) CopyObjectPart(ctx context.Context, srcBucket, srcObjec
 //
 // Implements S3 compatible Upload Part API.
 func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, r *PutObjReader, opts ObjectOptions) (pi PartInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.PutObjectPart", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	uploadIDLock := er.NewNSLock(bucket, pathJoin(object, uploadID))
 	ctx, err = uploadIDLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
@@ -547,8 +569,13 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 
 	md5hex := r.MD5CurrentHexString()
 
-	// Add the current part.
-	fi.AddObjectPart(partID, md5hex, n, data.ActualSize())
+	// Add the current part along with its content checksum, if any.
+	checksum := r.ContentChecksum()
+	var checksumValue string
+	if checksum != nil {
+		checksumValue = checksum.String()
+	}
+	fi.AddObjectPart(partID, md5hex, n, data.ActualSize(), checksumValue)
 
 	for i, disk := range onlineDisks {
 		if disk == OfflineDisk {
@@ -578,6 +605,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 		LastModified: fi.ModTime,
 		Size:         fi.Size,
 		ActualSize:   data.ActualSize(),
+		Checksum:     checksum,
 	}, nil
 }
 
@@ -710,6 +738,7 @@ func (er erasureObjects) ListObjectParts(ctx context.Context, bucket, object, up
 			ETag:         part.ETag,
 			LastModified: fi.ModTime,
 			Size:         part.Size,
+			Checksum:     part.ContentChecksum(),
 		})
 		count--
 		if count == 0 {
@@ -734,6 +763,10 @@ func (er erasureObjects) ListObjectParts(ctx context.Context, bucket, object, up
 //
 // Implements S3 compatible Complete multipart API.
 func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket string, object string, uploadID string, parts []CompletePart, opts ObjectOptions) (oi ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.CompleteMultipartUpload", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	// Hold read-locks to verify uploaded parts, also disallows
 	// parallel part uploads as well.
 	uploadIDLock := er.NewNSLock(bucket, pathJoin(object, uploadID))
@@ -843,9 +876,18 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 			Number:     part.PartNumber,
 			Size:       currentFI.Parts[partIdx].Size,
 			ActualSize: currentFI.Parts[partIdx].ActualSize,
+			Checksum:   currentFI.Parts[partIdx].Checksum,
 		}
 	}
 
+	// Verify the checksums of the parts and compose the object checksum.
+	checksum, err := completeMultipartChecksum(currentFI.Metadata, parts, func(part CompletePart) *hash.Checksum {
+		return currentFI.Parts[objectPartIndex(currentFI.Parts, part.PartNumber)].ContentChecksum()
+	})
+	if err != nil {
+		return oi, err
+	}
+
 	// Save the final object size and modtime.
 	fi.Size = objectSize
 	fi.ModTime = opts.MTime
@@ -861,6 +903,13 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+	delete(fi.Metadata, multipartObjectKey)
+
+	// Save the object checksum, composed of the checksums of the parts.
+	delete(fi.Metadata, uploadChecksumAlgoKey)
+	if checksum != nil {
+		fi.Metadata[objectChecksumKey] = checksum.String()
+	}
 
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
@@ -935,6 +984,10 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 // would be removed from the system, rollback is not possible on this
 // operation.
 func (er erasureObjects) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) (err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.AbortMultipartUpload", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	lk := er.NewNSLock(bucket, pathJoin(object, uploadID))
 	ctx, err = lk.GetLock(ctx, globalOperationTimeout)
 	if err != nil {
@@ -36,6 +36,7 @@ import (
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/mimedb"
 	"github.com/minio/minio/pkg/sync/errgroup"
+	"github.com/minio/minio/pkg/trace/otlp"
 )
 
 // list all errors which can be ignored in object operations.
@@ -56,6 +57,11 @@ func countOnlineDisks(onlineDisks []StorageAPI) (online int) {
 // if source object and destination object are same we only
 // update metadata.
 func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.CopyObject", dstBucket, dstObject,
+		otlp.String("src_bucket", srcBucket), otlp.String("src_object", srcObject),
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	// This call shouldn't be used for anything other than metadata updates or adding self referential versions.
 	if !srcInfo.metadataOnly {
 		return oi, NotImplemented{}
@@ -133,6 +139,10 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 // GetObjectNInfo - returns object info and an object
 // Read(Closer). When err != nil, the returned reader is always nil.
 func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.GetObjectNInfo", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	var unlockOnDefer bool
 	var nsUnlocker = func() {}
 	defer func() {
@@ -374,6 +384,10 @@ func (er erasureObjects) getObject(ctx context.Context, bucket, object string, s
 
 // GetObjectInfo - reads object metadata and replies back ObjectInfo.
 func (er erasureObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (info ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.GetObjectInfo", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	if !opts.NoLock {
 		// Lock the object before reading.
 		lk := er.NewNSLock(bucket, object)
@@ -461,7 +475,9 @@ func (er erasureObjects) getObjectInfo(ctx context.Context, bucket, object strin
 	objInfo = fi.ToObjectInfo(bucket, object)
 	if objInfo.TransitionStatus == lifecycle.TransitionComplete {
 		// overlay storage class for transitioned objects with transition tier SC Label
-		if sc := transitionSC(ctx, bucket); sc != "" {
+		if tier := objInfo.TransitionedObject.Tier; tier != "" {
+			objInfo.StorageClass = tier
+		} else if sc := transitionSC(ctx, bucket); sc != "" {
 			objInfo.StorageClass = sc
 		}
 	}
@@ -588,6 +604,10 @@ func rename(ctx context.Context, disks []StorageAPI, srcBucket, srcEntry, dstBuc
 // writes `xl.meta` which carries the necessary metadata for future
 // object operations.
 func (er erasureObjects) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.PutObject", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	return er.putObject(ctx, bucket, object, data, opts)
 }
 
@@ -758,7 +778,7 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		} else {
 			partsMetadata[i].Data = nil
 		}
-		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize())
+		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize(), "")
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: 1,
 			Algorithm:  DefaultBitrotAlgorithm,
@@ -768,6 +788,9 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	if opts.UserDefined["etag"] == "" {
 		opts.UserDefined["etag"] = r.MD5CurrentHexString()
 	}
+	if cs := r.ContentChecksum(); cs != nil {
+		opts.UserDefined[objectChecksumKey] = cs.String()
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
@@ -1036,6 +1059,10 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 // any error as it is not necessary for the handler to reply back a
 // response to the client request.
 func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "erasureSet.DeleteObject", bucket, object,
+		otlp.Int("pool", int64(er.poolIndex)), otlp.Int("set", int64(er.setIndex)))
+	defer endSpan(&err)
+
 	versionFound := true
 	objInfo = ObjectInfo{VersionID: opts.VersionID} // version id needed in Delete API response.
 	goi, gerr := er.GetObjectInfo(ctx, bucket, object, opts)
@@ -1109,6 +1136,7 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 				}
 			}
 			fi.TransitionStatus = opts.TransitionStatus
+			fi.Metadata = opts.TransitionedObject.metadata()
 
 			// versioning suspended means we add `null`
 			// version as delete marker
@@ -1131,6 +1159,7 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 		DeleteMarkerReplicationStatus: opts.DeleteMarkerReplicationStatus,
 		VersionPurgeStatus:            opts.VersionPurgeStatus,
 		TransitionStatus:              opts.TransitionStatus,
+		Metadata:                      opts.TransitionedObject.metadata(),
 	}, opts.DeleteMarker); err != nil {
 		return objInfo, toObjectErr(err, bucket, object)
 	}
@@ -1199,6 +1228,11 @@ func (er erasureObjects) PutObjectMetadata(ctx context.Context, bucket, object s
 	for k, v := range opts.UserDefined {
 		fi.Metadata[k] = v
 	}
+	if opts.EvalMetadataFn != nil {
+		if err = opts.EvalMetadataFn(fi.ToObjectInfo(bucket, object), fi.Metadata); err != nil {
+			return ObjectInfo{}, err
+		}
+	}
 	fi.ModTime = opts.MTime
 	fi.VersionID = opts.VersionID
 
@@ -0,0 +1,1059 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+const (
+	// Name of the file in .minio.sys tracking the pools and their
+	// decommissioning, saved on every pool.
+	poolMetaName = "pool.json"
+
+	poolMetaVersionV1 = 1
+	poolMetaVersion   = poolMetaVersionV1
+
+	// How often the progress of a running decommission is saved.
+	decomSaveInterval = 30 * time.Second
+
+	// How many times the buckets are listed again once drained, to
+	// move what was written to the pool in the meantime.
+	decomMaxRescans = 3
+)
+
+var (
+	errDecommissionAlreadyRunning = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "a pool is already being decommissioned",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionNotStarted = AdminError{
+		Code:       "XMinioDecommissionNotStarted",
+		Message:    "the pool is not being decommissioned",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionComplete = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "the pool is already decommissioned, remove it from the command line",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionSinglePool = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "decommissioning requires at least two pools",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionNotEnoughSpace = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "the other pools do not have enough free space to hold the objects of the pool",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionInvalidPool = AdminError{
+		Code:       "XMinioDecommissionInvalidPool",
+		Message:    "no such pool on the command line",
+		StatusCode: http.StatusBadRequest,
+	}
+
+	// Multipart uploads created before their object was recorded in
+	// their metadata cannot be moved.
+	errDecommissionUnknownUpload = errors.New("multipart upload does not record its object, complete or abort it")
+)
+
+// PoolDecommissionInfo - the progress of the decommissioning of a pool.
+type PoolDecommissionInfo struct {
+	StartTime time.Time `json:"startTime"`
+	StartSize int64     `json:"startSize"`
+	TotalSize int64     `json:"totalSize"`
+	Complete  bool      `json:"complete"`
+	Failed    bool      `json:"failed"`
+	Canceled  bool      `json:"canceled"`
+
+	// Buckets still to drain and already drained, the configuration
+	// and bucket metadata in .minio.sys are queued as bucket/prefix.
+	QueuedBuckets         []string `json:"queuedBuckets"`
+	DecommissionedBuckets []string `json:"decommissionedBuckets"`
+	// Bucket being drained.
+	Bucket string `json:"bucket"`
+	// Set once the pending multipart uploads were moved.
+	MultipartDone bool `json:"multipartDone"`
+
+	ObjectsDecommissioned     int64 `json:"objectsDecommissioned"`
+	ObjectsDecommissionFailed int64 `json:"objectsDecommissionedFailed"`
+	BytesDone                 int64 `json:"bytesDecommissioned"`
+	BytesFailed               int64 `json:"bytesDecommissionedFailed"`
+}
+
+// isActive returns true when the decommission is in progress.
+func (d *PoolDecommissionInfo) isActive() bool {
+	return d != nil && !d.Complete && !d.Failed && !d.Canceled
+}
+
+// PoolStatus - the status of a pool, identified by its command line
+// argument.
+type PoolStatus struct {
+	ID           int                   `json:"id"`
+	CmdLine      string                `json:"cmdline"`
+	LastUpdate   time.Time             `json:"lastUpdate"`
+	Decommission *PoolDecommissionInfo `json:"decommissionInfo,omitempty"`
+}
+
+// poolMeta - the pools known to the deployment, persisted in
+// .minio.sys so that a decommission survives restarts.
+type poolMeta struct {
+	Version int          `json:"version"`
+	Pools   []PoolStatus `json:"pools"`
+}
+
+// decommissioning returns the index of the pool being decommissioned,
+// -1 if there is none.
+func (p poolMeta) decommissioning() int {
+	for i, pool := range p.Pools {
+		if pool.Decommission.isActive() {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsSuspended returns true if the pool does not accept new objects,
+// either because it is being decommissioned or because it was.
+func (p poolMeta) IsSuspended(idx int) bool {
+	if idx < 0 || idx >= len(p.Pools) {
+		return false
+	}
+	d := p.Pools[idx].Decommission
+	return d != nil && !d.Failed && !d.Canceled
+}
+
+// validate checks the pools remembered against the pools on the command
+// line, a pool can only be removed from the command line once it is
+// decommissioned. Returns true if the remembered pools must be updated.
+func (p poolMeta) validate(pools EndpointServerPools) (bool, error) {
+	current := make(map[string]struct{}, len(pools))
+	for _, pool := range pools {
+		current[pool.CmdLine] = struct{}{}
+	}
+	for _, pool := range p.Pools {
+		if _, ok := current[pool.CmdLine]; ok {
+			continue
+		}
+		if pool.Decommission == nil || !pool.Decommission.Complete {
+			return false, fmt.Errorf("pool(%s) was removed from the command line but it is not decommissioned, add it back and decommission it first", pool.CmdLine)
+		}
+	}
+	if len(p.Pools) != len(pools) {
+		return true, nil
+	}
+	for i, pool := range pools {
+		if p.Pools[i].CmdLine != pool.CmdLine {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// update returns the pools of the command line, with the decommission
+// state of the pools already known.
+func (p poolMeta) update(pools EndpointServerPools) poolMeta {
+	remembered := make(map[string]PoolStatus, len(p.Pools))
+	for _, pool := range p.Pools {
+		remembered[pool.CmdLine] = pool
+	}
+	np := poolMeta{Version: poolMetaVersion}
+	for i, pool := range pools {
+		status, ok := remembered[pool.CmdLine]
+		if !ok {
+			status = PoolStatus{
+				CmdLine:    pool.CmdLine,
+				LastUpdate: UTCNow(),
+			}
+		}
+		status.ID = i
+		np.Pools = append(np.Pools, status)
+	}
+	return np
+}
+
+// clone returns a deep copy of the pools.
+func (p poolMeta) clone() poolMeta {
+	np := poolMeta{Version: p.Version, Pools: make([]PoolStatus, len(p.Pools))}
+	for i, pool := range p.Pools {
+		np.Pools[i] = pool
+		if pool.Decommission != nil {
+			d := *pool.Decommission
+			d.QueuedBuckets = append([]string(nil), d.QueuedBuckets...)
+			d.DecommissionedBuckets = append([]string(nil), d.DecommissionedBuckets...)
+			np.Pools[i].Decommission = &d
+		}
+	}
+	return np
+}
+
+// load reads the pools from the first pool where they are saved.
+func (p *poolMeta) load(ctx context.Context, pools []*erasureSets) error {
+	for _, pool := range pools {
+		data, err := readConfig(ctx, pool, poolMetaName)
+		if err == errConfigNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var meta poolMeta
+		if err = json.Unmarshal(data, &meta); err != nil {
+			return err
+		}
+		if meta.Version != poolMetaVersionV1 {
+			return fmt.Errorf("unexpected %s version: %d", poolMetaName, meta.Version)
+		}
+		*p = meta
+		return nil
+	}
+	return nil
+}
+
+// save writes the pools to every pool.
+func (p poolMeta) save(ctx context.Context, pools []*erasureSets) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		if err = saveConfig(ctx, pool, poolMetaName, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Init loads the pools remembered, checks them against the command
+// line and resumes the decommission interrupted by a restart of this
+// server.
+func (z *erasureServerPools) Init(ctx context.Context) error {
+	var meta poolMeta
+	if err := meta.load(ctx, z.serverPools); err != nil {
+		return err
+	}
+	update, err := meta.validate(z.endpoints)
+	if err != nil {
+		return err
+	}
+	if update {
+		meta = meta.update(z.endpoints)
+	}
+
+	z.poolMetaMutex.Lock()
+	z.poolMeta = meta
+	z.poolMetaMutex.Unlock()
+
+	// The other servers load the pools as they start.
+	if update {
+		if err = meta.save(ctx, z.serverPools); err != nil {
+			return err
+		}
+	}
+
+	if idx := meta.decommissioning(); idx >= 0 && z.isDecommissionLocal(idx) {
+		z.startDecommission(idx)
+	}
+	return nil
+}
+
+// ReloadPoolMeta reads the pools saved by another server, the progress
+// of a decommission running on this server is kept.
+func (z *erasureServerPools) ReloadPoolMeta(ctx context.Context) error {
+	var meta poolMeta
+	if err := meta.load(ctx, z.serverPools); err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	for idx, cancel := range z.decommissionCancelers {
+		if cancel != nil && idx < len(meta.Pools) && idx < len(z.poolMeta.Pools) {
+			meta.Pools[idx] = z.poolMeta.Pools[idx]
+		}
+	}
+	z.poolMeta = meta
+	return nil
+}
+
+// savePoolMeta saves the pools and tells the other servers to reload
+// them.
+func (z *erasureServerPools) savePoolMeta(ctx context.Context) error {
+	z.poolMetaSaveMu.Lock()
+	defer z.poolMetaSaveMu.Unlock()
+
+	z.poolMetaMutex.RLock()
+	meta := z.poolMeta.clone()
+	z.poolMetaMutex.RUnlock()
+
+	if err := meta.save(ctx, z.serverPools); err != nil {
+		return err
+	}
+	if globalNotificationSys != nil {
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+// IsSuspended returns true if the pool does not accept new objects.
+func (z *erasureServerPools) IsSuspended(idx int) bool {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	return z.poolMeta.IsSuspended(idx)
+}
+
+// isDecommissionLocal returns true if the decommission of the pool runs
+// on this server, the server of the first drive of the pool.
+func (z *erasureServerPools) isDecommissionLocal(idx int) bool {
+	endpoints := z.serverPools[idx].endpoints
+	return len(endpoints) > 0 && endpoints[0].IsLocal
+}
+
+// poolSpace returns the capacity and the free space of a pool.
+func (z *erasureServerPools) poolSpace(ctx context.Context, idx int) (total, free int64) {
+	info := z.serverPools[idx].StorageUsageInfo(ctx)
+	for _, disk := range info.Disks {
+		total += int64(disk.TotalSpace)
+		free += int64(disk.TotalSpace - disk.UsedSpace)
+	}
+	return total, free
+}
+
+// Decommission starts moving the objects of a pool to the other pools,
+// or resumes a canceled or failed decommission of the pool. The pool
+// stops accepting new objects, while the objects it holds are read,
+// overwritten and deleted as usual until they are moved.
+func (z *erasureServerPools) Decommission(ctx context.Context, idx int) error {
+	if idx < 0 || idx >= len(z.serverPools) {
+		return errDecommissionInvalidPool
+	}
+	if z.SinglePool() {
+		return errDecommissionSinglePool
+	}
+
+	total, free := z.poolSpace(ctx, idx)
+	var available int64
+	for i := range z.serverPools {
+		if i == idx || z.IsSuspended(i) {
+			continue
+		}
+		_, poolFree := z.poolSpace(ctx, i)
+		available += poolFree
+	}
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	if idx >= len(z.poolMeta.Pools) {
+		z.poolMetaMutex.Unlock()
+		return errDecommissionInvalidPool
+	}
+	if z.poolMeta.decommissioning() >= 0 || z.decommissionCancelers[idx] != nil {
+		z.poolMetaMutex.Unlock()
+		return errDecommissionAlreadyRunning
+	}
+	d := z.poolMeta.Pools[idx].Decommission
+	switch {
+	case d == nil:
+		if total-free > available {
+			z.poolMetaMutex.Unlock()
+			return errDecommissionNotEnoughSpace
+		}
+		d = &PoolDecommissionInfo{
+			StartTime: UTCNow(),
+			StartSize: free,
+			TotalSize: total,
+		}
+		for _, bucket := range buckets {
+			d.QueuedBuckets = append(d.QueuedBuckets, bucket.Name)
+		}
+		d.QueuedBuckets = append(d.QueuedBuckets,
+			pathJoin(minioMetaBucket, minioConfigPrefix),
+			pathJoin(minioMetaBucket, bucketConfigPrefix))
+		z.poolMeta.Pools[idx].Decommission = d
+	case d.Complete:
+		z.poolMetaMutex.Unlock()
+		return errDecommissionComplete
+	case d.Failed:
+		// Go over everything again, what failed is still on the pool.
+		d.QueuedBuckets = append(d.DecommissionedBuckets, d.QueuedBuckets...)
+		d.DecommissionedBuckets = nil
+		d.MultipartDone = false
+	}
+	d.Failed = false
+	d.Canceled = false
+	z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+	z.poolMetaMutex.Unlock()
+
+	if err = z.savePoolMeta(ctx); err != nil {
+		return err
+	}
+	z.startDecommission(idx)
+	return nil
+}
+
+// DecommissionCancel stops the decommission of a pool, the pool accepts
+// new objects again. The progress is kept to resume the decommission.
+func (z *erasureServerPools) DecommissionCancel(ctx context.Context, idx int) error {
+	if idx < 0 || idx >= len(z.serverPools) {
+		return errDecommissionInvalidPool
+	}
+
+	z.poolMetaMutex.Lock()
+	if idx >= len(z.poolMeta.Pools) || !z.poolMeta.Pools[idx].Decommission.isActive() {
+		z.poolMetaMutex.Unlock()
+		return errDecommissionNotStarted
+	}
+	z.poolMeta.Pools[idx].Decommission.Canceled = true
+	z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+	cancel := z.decommissionCancelers[idx]
+	z.poolMetaMutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return z.savePoolMeta(ctx)
+}
+
+// Status returns the status of a pool.
+func (z *erasureServerPools) Status(ctx context.Context, idx int) (madmin.PoolStatus, error) {
+	if idx < 0 || idx >= len(z.serverPools) {
+		return madmin.PoolStatus{}, errDecommissionInvalidPool
+	}
+
+	z.poolMetaMutex.RLock()
+	if idx >= len(z.poolMeta.Pools) {
+		z.poolMetaMutex.RUnlock()
+		return madmin.PoolStatus{}, errDecommissionInvalidPool
+	}
+	pool := z.poolMeta.Pools[idx]
+	status := madmin.PoolStatus{
+		ID:         pool.ID,
+		CmdLine:    pool.CmdLine,
+		LastUpdate: pool.LastUpdate,
+	}
+	if d := pool.Decommission; d != nil {
+		status.Decommission = &madmin.PoolDecommissionInfo{
+			StartTime:                 d.StartTime,
+			StartSize:                 d.StartSize,
+			TotalSize:                 d.TotalSize,
+			Complete:                  d.Complete,
+			Failed:                    d.Failed,
+			Canceled:                  d.Canceled,
+			ObjectsDecommissioned:     d.ObjectsDecommissioned,
+			ObjectsDecommissionFailed: d.ObjectsDecommissionFailed,
+			BytesDone:                 d.BytesDone,
+			BytesFailed:               d.BytesFailed,
+		}
+	}
+	z.poolMetaMutex.RUnlock()
+
+	if status.Decommission != nil {
+		_, status.Decommission.CurrentSize = z.poolSpace(ctx, idx)
+	}
+	return status, nil
+}
+
+// startDecommission runs the decommission of a pool in the background,
+// unless it already runs.
+func (z *erasureServerPools) startDecommission(idx int) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	if z.decommissionCancelers[idx] != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(GlobalContext)
+	z.decommissionCancelers[idx] = cancel
+	go z.decommissionInBackground(ctx, idx)
+}
+
+// decomUpdate updates the decommission of a pool, if it runs.
+func (z *erasureServerPools) decomUpdate(idx int, fn func(d *PoolDecommissionInfo)) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	if d := z.poolMeta.Pools[idx].Decommission; d.isActive() {
+		fn(d)
+		z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+	}
+}
+
+// decomTrack counts the objects moved, or failed to move.
+func (z *erasureServerPools) decomTrack(idx int, size int64, failed bool) {
+	z.decomUpdate(idx, func(d *PoolDecommissionInfo) {
+		if failed {
+			d.ObjectsDecommissionFailed++
+			d.BytesFailed += size
+			return
+		}
+		d.ObjectsDecommissioned++
+		d.BytesDone += size
+	})
+}
+
+// decomNextBucket returns the next bucket to drain.
+func (z *erasureServerPools) decomNextBucket(idx int) (string, bool) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	d := z.poolMeta.Pools[idx].Decommission
+	if !d.isActive() || len(d.QueuedBuckets) == 0 {
+		return "", false
+	}
+	d.Bucket = d.QueuedBuckets[0]
+	return d.Bucket, true
+}
+
+func (z *erasureServerPools) decommissionInBackground(ctx context.Context, idx int) {
+	defer func() {
+		z.poolMetaMutex.Lock()
+		z.decommissionCancelers[idx]()
+		z.decommissionCancelers[idx] = nil
+		z.poolMetaMutex.Unlock()
+	}()
+
+	saveCtx, stopSaving := context.WithCancel(ctx)
+	defer stopSaving()
+	go func() {
+		ticker := time.NewTicker(decomSaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-saveCtx.Done():
+				return
+			case <-ticker.C:
+				logger.LogIf(ctx, z.savePoolMeta(ctx))
+			}
+		}
+	}()
+
+	z.poolMetaMutex.RLock()
+	multipartDone := z.poolMeta.Pools[idx].Decommission.MultipartDone
+	z.poolMetaMutex.RUnlock()
+
+	var failed, uploadsFailed bool
+	if !multipartDone {
+		_, nfailed := z.decommissionMultipartUploads(ctx, idx)
+		if ctx.Err() != nil {
+			return
+		}
+		uploadsFailed = nfailed > 0
+		z.decomUpdate(idx, func(d *PoolDecommissionInfo) {
+			d.MultipartDone = true
+		})
+	}
+
+	for {
+		bucket, ok := z.decomNextBucket(idx)
+		if !ok {
+			break
+		}
+		_, nfailed := z.decommissionBucket(ctx, idx, bucket)
+		if ctx.Err() != nil {
+			return
+		}
+		failed = failed || nfailed > 0
+		z.decomUpdate(idx, func(d *PoolDecommissionInfo) {
+			d.QueuedBuckets = d.QueuedBuckets[1:]
+			d.DecommissionedBuckets = append(d.DecommissionedBuckets, bucket)
+			d.Bucket = ""
+		})
+	}
+
+	// Objects may have been written to the pool while it was drained,
+	// overwriting an object not yet moved for instance.
+	z.poolMetaMutex.RLock()
+	buckets := append([]string(nil), z.poolMeta.Pools[idx].Decommission.DecommissionedBuckets...)
+	z.poolMetaMutex.RUnlock()
+	for i := 0; i < decomMaxRescans; i++ {
+		var moved, nfailed int
+		for _, bucket := range buckets {
+			m, f := z.decommissionBucket(ctx, idx, bucket)
+			if ctx.Err() != nil {
+				return
+			}
+			moved += m
+			nfailed += f
+		}
+		failed = nfailed > 0
+		if moved == 0 && nfailed == 0 {
+			break
+		}
+	}
+	failed = failed || uploadsFailed
+
+	z.decomUpdate(idx, func(d *PoolDecommissionInfo) {
+		if failed {
+			d.Failed = true
+		} else {
+			d.Complete = true
+		}
+	})
+	if failed {
+		logger.LogIf(ctx, fmt.Errorf("decommission of pool(%s) failed, some objects could not be moved", z.endpoints[idx].CmdLine))
+	}
+	logger.LogIf(ctx, z.savePoolMeta(ctx))
+}
+
+// decomBucketPath splits an entry of the decommission queue in a bucket
+// and a prefix.
+func decomBucketPath(entry string) (bucket, prefix string) {
+	if strings.HasPrefix(entry, minioMetaBucket+SlashSeparator) {
+		return minioMetaBucket, strings.TrimPrefix(entry, minioMetaBucket+SlashSeparator)
+	}
+	return entry, ""
+}
+
+// decomSkipMeta returns true for the entries of .minio.sys which are not
+// moved, they are specific to each erasure set or regenerated.
+func decomSkipMeta(name string) bool {
+	if wildcard.Match("buckets/*/.metacache/*", name) {
+		return true
+	}
+	switch path.Base(name) {
+	case dataUsageObjName, dataUsageCacheName, dataUsageBloomName, healingTrackerFilename:
+		return true
+	}
+	return false
+}
+
+// decomListSets calls fn for each object of every erasure set of a pool,
+// the sets are listed in parallel.
+func (z *erasureServerPools) decomListSets(ctx context.Context, idx int, bucket, prefix string, fn func(set *erasureObjects, entry metaCacheEntry)) {
+	var wg sync.WaitGroup
+	for _, set := range z.serverPools[idx].sets {
+		set := set
+		disks, _ := set.getOnlineDisksWithHealing()
+		if len(disks) == 0 {
+			logger.LogIf(ctx, fmt.Errorf("decommission: no online drives found on a set of pool(%s)", z.endpoints[idx].CmdLine))
+			continue
+		}
+
+		resolver := metadataResolutionParams{
+			dirQuorum: 1,
+			objQuorum: 1,
+			bucket:    bucket,
+		}
+		listEntry := func(entry metaCacheEntry) {
+			if entry.isDir() {
+				return
+			}
+			fn(set, entry)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := listPathRaw(ctx, listPathRawOptions{
+				disks:          disks,
+				bucket:         bucket,
+				path:           prefix,
+				recursive:      true,
+				minDisks:       1,
+				reportNotFound: false,
+				agreed:         listEntry,
+				partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+					if entry, ok := entries.resolve(&resolver); ok {
+						listEntry(*entry)
+					}
+				},
+			})
+			if err != nil && ctx.Err() == nil && !errors.Is(err, errVolumeNotFound) && !errors.Is(err, errFileNotFound) {
+				logger.LogIf(ctx, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// decommissionBucket moves the objects of a bucket out of a pool,
+// returns the number of objects moved and failed to move.
+func (z *erasureServerPools) decommissionBucket(ctx context.Context, idx int, entry string) (moved, failed int) {
+	bucket, prefix := decomBucketPath(entry)
+
+	var mu sync.Mutex
+	z.decomListSets(ctx, idx, bucket, prefix, func(set *erasureObjects, entry metaCacheEntry) {
+		if bucket == minioMetaBucket && decomSkipMeta(entry.name) {
+			return
+		}
+		fivs, err := entry.fileInfoVersions(bucket)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		size, err := z.decommissionObject(ctx, idx, set, bucket, fivs)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("decommission: unable to move %s/%s: %w", bucket, fivs.Name, err))
+		}
+		z.decomTrack(idx, size, err != nil)
+
+		mu.Lock()
+		if err != nil {
+			failed++
+		} else {
+			moved++
+		}
+		mu.Unlock()
+	})
+	return moved, failed
+}
+
+// decomTargetPoolIdx returns the pool the object is moved to, the pool
+// which already has it or else a pool with enough free space.
+func (z *erasureServerPools) decomTargetPoolIdx(ctx context.Context, idx int, bucket, object string, size int64) (int, error) {
+	for i, pool := range z.serverPools {
+		if i == idx || z.IsSuspended(i) {
+			continue
+		}
+		oi, err := pool.GetObjectInfo(ctx, bucket, object, ObjectOptions{NoLock: true})
+		if err == nil || (isErrObjectNotFound(err) && oi.DeleteMarker && oi.Name != "") {
+			return i, nil
+		}
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return -1, err
+		}
+	}
+
+	// We multiply the size by 2 to account for erasure coding.
+	i := z.getAvailablePoolIdx(ctx, size*2)
+	if i < 0 {
+		return -1, toObjectErr(errDiskFull)
+	}
+	return i, nil
+}
+
+// decommissionObject moves all the versions of an object from an erasure
+// set of the pool being decommissioned to another pool, the oldest
+// version first, then removes them from the set. Returns the size of the
+// versions.
+func (z *erasureServerPools) decommissionObject(ctx context.Context, idx int, set *erasureObjects, bucket string, fivs FileInfoVersions) (int64, error) {
+	object := fivs.Name
+
+	var size int64
+	for _, version := range fivs.Versions {
+		size += version.Size
+	}
+
+	// Writes to the object go to this set while it is here.
+	lk := set.NewNSLock(bucket, object)
+	ctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return size, err
+	}
+	defer lk.Unlock()
+
+	targetIdx, err := z.decomTargetPoolIdx(ctx, idx, bucket, object, size)
+	if err != nil {
+		return size, err
+	}
+	target := z.serverPools[targetIdx].getHashedSet(object)
+
+	var moved []FileInfo
+	for i := len(fivs.Versions) - 1; i >= 0; i-- {
+		versionID := fivs.Versions[i].VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+		fi, metaArr, onlineDisks, err := set.getObjectFileInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID}, true)
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			// Deleted since listed.
+			continue
+		}
+		if err != nil {
+			return size, err
+		}
+
+		switch {
+		case fi.Deleted:
+			err = target.deleteObjectVersion(ctx, bucket, object, len(target.getDisks())/2+1, FileInfo{
+				Name:                          object,
+				VersionID:                     fi.VersionID,
+				Deleted:                       true,
+				ModTime:                       fi.ModTime,
+				DeleteMarkerReplicationStatus: fi.DeleteMarkerReplicationStatus,
+				VersionPurgeStatus:            fi.VersionPurgeStatus,
+			}, true)
+			if err != nil {
+				err = toObjectErr(err, bucket, object)
+			}
+		case fi.TransitionStatus == lifecycle.TransitionComplete:
+			// The data is on the remote tier.
+			err = target.putDecomVersion(ctx, bucket, object, fi, nil)
+		default:
+			err = target.putDecomVersion(ctx, bucket, object, fi, set.decomPartReader(ctx, bucket, object, fi, metaArr, onlineDisks))
+		}
+		if err != nil {
+			return size, err
+		}
+		moved = append(moved, fi)
+	}
+
+	// The latest version is removed last, the object stays readable
+	// from this set until then.
+	writeQuorum := len(set.getDisks())/2 + 1
+	for _, fi := range moved {
+		if err = set.deleteObjectVersion(ctx, bucket, object, writeQuorum, FileInfo{
+			Name:      object,
+			VersionID: fi.VersionID,
+		}, false); err != nil {
+			return size, toObjectErr(err, bucket, object)
+		}
+	}
+	return size, nil
+}
+
+// decommissionMultipartUploads moves the pending multipart uploads out
+// of a pool, returns the number of uploads moved and failed to move.
+func (z *erasureServerPools) decommissionMultipartUploads(ctx context.Context, idx int) (moved, failed int) {
+	var mu sync.Mutex
+	z.decomListSets(ctx, idx, minioMetaMultipartBucket, "", func(set *erasureObjects, entry metaCacheEntry) {
+		fi, err := entry.fileInfo(minioMetaMultipartBucket)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		err = z.decommissionMultipartUpload(ctx, idx, set, entry.name, fi.Metadata[multipartObjectKey])
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("decommission: unable to move multipart upload %s: %w", entry.name, err))
+		}
+		z.decomTrack(idx, fi.Size, err != nil)
+
+		mu.Lock()
+		if err != nil {
+			failed++
+		} else {
+			moved++
+		}
+		mu.Unlock()
+	})
+	return moved, failed
+}
+
+// decommissionMultipartUpload moves a pending multipart upload, keeping
+// its upload ID, to the pool with the other uploads of the object or
+// else a pool with enough free space.
+func (z *erasureServerPools) decommissionMultipartUpload(ctx context.Context, idx int, set *erasureObjects, uploadIDPath, bucketObject string) error {
+	if bucketObject == "" {
+		return errDecommissionUnknownUpload
+	}
+	bucket, object := path2BucketObject(bucketObject)
+	uploadID := path.Base(uploadIDPath)
+
+	lk := set.NewNSLock(bucket, pathJoin(object, uploadID))
+	ctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	fi, metaArr, onlineDisks, err := set.getObjectFileInfo(ctx, minioMetaMultipartBucket, uploadIDPath, ObjectOptions{}, false)
+	if isErrObjectNotFound(err) {
+		// Completed or aborted since listed.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	targetIdx := -1
+	for i, pool := range z.serverPools {
+		if i == idx || z.IsSuspended(i) {
+			continue
+		}
+		result, err := pool.ListMultipartUploads(ctx, bucket, object, "", "", "", maxUploadsList)
+		if err != nil {
+			return err
+		}
+		if len(result.Uploads) != 0 {
+			targetIdx = i
+			break
+		}
+	}
+	if targetIdx < 0 {
+		// We multiply the size by 2 to account for erasure coding.
+		if targetIdx = z.getAvailablePoolIdx(ctx, (1<<30)*2); targetIdx < 0 {
+			return toObjectErr(errDiskFull)
+		}
+	}
+	target := z.serverPools[targetIdx].getHashedSet(object)
+
+	// The size of an upload is not the size of its parts.
+	readFi := fi
+	readFi.Size = 0
+	for _, part := range fi.Parts {
+		readFi.Size += part.Size
+	}
+	if err = target.putDecomVersion(ctx, minioMetaMultipartBucket, uploadIDPath, fi,
+		set.decomPartReader(ctx, minioMetaMultipartBucket, uploadIDPath, readFi, metaArr, onlineDisks)); err != nil {
+		return err
+	}
+	return set.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, len(set.getDisks())/2+1)
+}
+
+// decomPartReader returns a function reading a part of a version, as
+// stored, from the erasure set.
+func (er erasureObjects) decomPartReader(ctx context.Context, bucket, object string, fi FileInfo, metaArr []FileInfo, onlineDisks []StorageAPI) func(partIndex int) io.ReadCloser {
+	return func(partIndex int) io.ReadCloser {
+		var offset int64
+		for _, part := range fi.Parts[:partIndex] {
+			offset += part.Size
+		}
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(er.getObjectWithFileInfo(ctx, bucket, object, offset, fi.Parts[partIndex].Size, pw, fi, metaArr, onlineDisks))
+		}()
+		return pr
+	}
+}
+
+// putDecomVersion writes a version moved out of a pool being
+// decommissioned, keeping its version ID, modification time, metadata
+// and parts. The data of each part is read as stored, compressed or
+// encrypted, with readPart; versions transitioned to a remote tier have
+// no data here and no readPart. The caller holds the object lock.
+func (er erasureObjects) putDecomVersion(ctx context.Context, bucket, object string, fi FileInfo, readPart func(partIndex int) io.ReadCloser) error {
+	storageDisks := er.getDisks()
+
+	parityDrives := globalStorageClass.GetParityForSC(fi.Metadata[xhttp.AmzStorageClass])
+	if parityDrives <= 0 {
+		parityDrives = er.defaultParityCount
+	}
+	if fi.Erasure.DataBlocks == fi.Erasure.ParityBlocks {
+		// Written with the maximum parity, configuration for instance.
+		parityDrives = len(storageDisks) / 2
+	}
+	dataDrives := len(storageDisks) - parityDrives
+
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum++
+	}
+
+	nfi := newFileInfo(pathJoin(bucket, object), dataDrives, parityDrives)
+	nfi.VersionID = fi.VersionID
+	nfi.Size = fi.Size
+	nfi.Metadata = cloneMSS(fi.Metadata)
+	if fi.TransitionStatus != "" {
+		nfi.Metadata[ReservedMetadataPrefixLower+"transition-status"] = fi.TransitionStatus
+	}
+	if readPart != nil {
+		nfi.DataDir = mustGetUUID()
+	}
+
+	partsMetadata := make([]FileInfo, len(storageDisks))
+	for index := range partsMetadata {
+		partsMetadata[index] = nfi
+	}
+	onlineDisks, partsMetadata := shuffleDisksAndPartsMetadata(storageDisks, partsMetadata, nfi)
+	for index := range partsMetadata {
+		partsMetadata[index].ModTime = fi.ModTime
+	}
+
+	if readPart == nil {
+		for index := range partsMetadata {
+			partsMetadata[index].Parts = fi.Parts
+		}
+		if _, err := writeUniqueFileInfo(ctx, onlineDisks, bucket, object, partsMetadata, writeQuorum); err != nil {
+			return toObjectErr(err, bucket, object)
+		}
+		return nil
+	}
+
+	erasure, err := NewErasure(ctx, nfi.Erasure.DataBlocks, nfi.Erasure.ParityBlocks, nfi.Erasure.BlockSize)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	buffer := er.bp.Get()
+	defer er.bp.Put(buffer)
+	if len(buffer) > int(nfi.Erasure.BlockSize) {
+		buffer = buffer[:nfi.Erasure.BlockSize]
+	}
+
+	tempObj := mustGetUUID()
+	var online int
+	defer func() {
+		if online != len(onlineDisks) {
+			er.deleteObject(context.Background(), minioMetaTmpBucket, tempObj, writeQuorum)
+		}
+	}()
+
+	for partIndex, part := range fi.Parts {
+		tempErasureObj := pathJoin(tempObj, nfi.DataDir, fmt.Sprintf("part.%d", part.Number))
+		writers := make([]io.Writer, len(onlineDisks))
+		for i, disk := range onlineDisks {
+			if disk == nil {
+				continue
+			}
+			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj,
+				erasure.ShardFileSize(part.Size), DefaultBitrotAlgorithm, erasure.ShardSize(), false)
+		}
+
+		r := readPart(partIndex)
+		n, err := erasure.Encode(ctx, r, writers, buffer, writeQuorum)
+		r.Close()
+		closeBitrotWriters(writers)
+		if err != nil {
+			return toObjectErr(err, bucket, object)
+		}
+		if n != part.Size {
+			return IncompleteBody{Bucket: bucket, Object: object}
+		}
+
+		for i, w := range writers {
+			if w == nil {
+				onlineDisks[i] = nil
+				continue
+			}
+			partsMetadata[i].AddObjectPart(part.Number, part.ETag, part.Size, part.ActualSize, part.Checksum)
+			partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
+				PartNumber: part.Number,
+				Algorithm:  DefaultBitrotAlgorithm,
+				Hash:       bitrotWriterSum(w),
+			})
+		}
+	}
+
+	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaTmpBucket, tempObj, partsMetadata, bucket, object, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	online = countOnlineDisks(onlineDisks)
+	return nil
+}
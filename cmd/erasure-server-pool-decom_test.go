@@ -0,0 +1,301 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestPoolMetaValidate(t *testing.T) {
+	meta := poolMeta{
+		Version: poolMetaVersion,
+		Pools: []PoolStatus{
+			{ID: 0, CmdLine: "pool-0", Decommission: &PoolDecommissionInfo{Complete: true}},
+			{ID: 1, CmdLine: "pool-1", Decommission: &PoolDecommissionInfo{}},
+			{ID: 2, CmdLine: "pool-2"},
+		},
+	}
+
+	testCases := []struct {
+		pools      []string
+		update     bool
+		shouldFail bool
+	}{
+		{[]string{"pool-0", "pool-1", "pool-2"}, false, false},
+		// Pool added.
+		{[]string{"pool-0", "pool-1", "pool-2", "pool-3"}, true, false},
+		// Decommissioned pool removed.
+		{[]string{"pool-1", "pool-2"}, true, false},
+		// Pool being decommissioned removed.
+		{[]string{"pool-0", "pool-2"}, false, true},
+		// Pool removed without decommission.
+		{[]string{"pool-0", "pool-1"}, false, true},
+	}
+	for i, testCase := range testCases {
+		var pools EndpointServerPools
+		for _, cmdLine := range testCase.pools {
+			pools = append(pools, PoolEndpoints{CmdLine: cmdLine})
+		}
+		update, err := meta.validate(pools)
+		if testCase.shouldFail {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if update != testCase.update {
+			t.Errorf("Test %d: expected update %v, got %v", i+1, testCase.update, update)
+		}
+		if !update {
+			continue
+		}
+		updated := meta.update(pools)
+		for idx, pool := range updated.Pools {
+			if pool.ID != idx || pool.CmdLine != testCase.pools[idx] {
+				t.Errorf("Test %d: unexpected pool %d %s", i+1, pool.ID, pool.CmdLine)
+			}
+		}
+		if !updated.IsSuspended(pools.GetPoolIdx("pool-1")) {
+			t.Errorf("Test %d: pool-1 should stay suspended", i+1)
+		}
+	}
+}
+
+func TestDecommissionPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	var pools EndpointServerPools
+	for _, args := range [][]string{disks[:4], disks[4:]} {
+		pools = append(pools, PoolEndpoints{
+			SetCount:     1,
+			DrivesPerSet: len(args),
+			Endpoints:    mustGetNewEndpoints(args...),
+			CmdLine:      strings.Join(args, " "),
+		})
+	}
+	objLayer, _, err := initObjectLayer(ctx, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	z := objLayer.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write everything to the pool to decommission.
+	put := func(pool *erasureSets, object string, data []byte) ObjectInfo {
+		oi, err := pool.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+	v1 := put(z.serverPools[0], "object", []byte("version 1"))
+	v2 := put(z.serverPools[0], "object", bytes.Repeat([]byte("b"), 2*humanize.MiByte))
+	put(z.serverPools[0], "deleted", []byte("deleted"))
+	if _, err = z.serverPools[0].DeleteObject(ctx, bucket, "deleted", ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	part1 := bytes.Repeat([]byte("p"), 5*humanize.MiByte)
+	uploadID, err := z.serverPools[0].NewMultipartUpload(ctx, bucket, "upload", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pi1, err := z.serverPools[0].PutObjectPart(ctx, bucket, "upload", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(part1), int64(len(part1)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = z.DecommissionCancel(ctx, 0); err != errDecommissionNotStarted {
+		t.Fatalf("expected %v, got %v", errDecommissionNotStarted, err)
+	}
+	if err = z.Decommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) {
+		t.Fatal("pool being decommissioned should be suspended")
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		status, err := z.Status(ctx, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Decommission.Failed {
+			t.Fatal("decommission failed")
+		}
+		if status.Decommission.Complete {
+			if status.Decommission.ObjectsDecommissioned == 0 {
+				t.Fatal("expected objects to be decommissioned")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("decommission did not complete")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err = z.Decommission(ctx, 0); err != errDecommissionComplete {
+		t.Fatalf("expected %v, got %v", errDecommissionComplete, err)
+	}
+
+	for _, object := range []string{"object", "deleted"} {
+		if _, err = z.serverPools[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected object not found on the decommissioned pool, got %v", object, err)
+		}
+	}
+
+	// Every version is readable from the other pool.
+	for _, oi := range []ObjectInfo{v1, v2} {
+		r, err := z.GetObjectNInfo(ctx, bucket, "object", nil, http.Header{}, readLock, ObjectOptions{VersionID: oi.VersionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.ObjInfo.ETag != oi.ETag || int64(len(data)) != oi.Size {
+			t.Fatalf("version %s: expected etag %s and size %d, got %s and %d", oi.VersionID, oi.ETag, oi.Size, r.ObjInfo.ETag, len(data))
+		}
+	}
+	if _, err = z.GetObjectInfo(ctx, bucket, "deleted", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected the delete marker to be moved, got %v", err)
+	}
+
+	// The upload goes on on the other pool.
+	part2 := []byte("last part")
+	pi2, err := z.PutObjectPart(ctx, bucket, "upload", uploadID, 2, mustGetPutObjReader(t, bytes.NewReader(part2), int64(len(part2)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.CompleteMultipartUpload(ctx, bucket, "upload", uploadID, []CompletePart{
+		{PartNumber: 1, ETag: pi1.ETag},
+		{PartNumber: 2, ETag: pi2.ETag},
+	}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.serverPools[1].GetObjectInfo(ctx, bucket, "upload", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// New objects are not written to the decommissioned pool.
+	if _, err = z.PutObject(ctx, bucket, "new", mustGetPutObjReader(t, bytes.NewReader([]byte("new")), 3, "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.serverPools[1].GetObjectInfo(ctx, bucket, "new", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Tests that overwrites of objects held by a suspended pool are
+// written to another pool.
+func TestSuspendedPoolOverwrite(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	var pools EndpointServerPools
+	for _, args := range [][]string{disks[:4], disks[4:]} {
+		pools = append(pools, PoolEndpoints{
+			SetCount:     1,
+			DrivesPerSet: len(args),
+			Endpoints:    mustGetNewEndpoints(args...),
+			CmdLine:      strings.Join(args, " "),
+		})
+	}
+	objLayer, _, err := initObjectLayer(ctx, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	z := objLayer.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("version 1")
+	if _, err = z.serverPools[0].PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Suspend the pool without draining it.
+	z.poolMetaMutex.Lock()
+	z.poolMeta = z.poolMeta.clone()
+	z.poolMeta.Pools[0].Decommission = &PoolDecommissionInfo{StartTime: UTCNow()}
+	z.poolMetaMutex.Unlock()
+	if !z.IsSuspended(0) {
+		t.Fatal("pool should be suspended")
+	}
+
+	idx, err := z.getPoolIdx(ctx, bucket, "object", int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx != 1 {
+		t.Fatalf("expected the overwrite to go to pool 1, got pool %d", idx)
+	}
+
+	data = []byte("version 2")
+	if _, err = z.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	oi, err := z.serverPools[1].GetObjectInfo(ctx, bucket, "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.Size != int64(len(data)) {
+		t.Fatalf("expected size %d on pool 1, got %d", len(data), oi.Size)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/sync/errgroup"
+	"github.com/minio/minio/pkg/trace/otlp"
 	"github.com/minio/minio/pkg/wildcard"
 )
 
@@ -42,6 +43,15 @@ type erasureServerPools struct {
 	GatewayUnsupported
 
 	serverPools []*erasureSets
+	endpoints   EndpointServerPools
+
+	// Pools known to the deployment and their decommissioning.
+	poolMetaMutex  sync.RWMutex
+	poolMetaSaveMu sync.Mutex
+	poolMeta       poolMeta
+
+	// Cancels the decommission of a pool running on this server.
+	decommissionCancelers []context.CancelFunc
 
 	// Shut down async operations
 	shutdown context.CancelFunc
@@ -61,7 +71,11 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 
 		formats      = make([]*formatErasureV3, len(endpointServerPools))
 		storageDisks = make([][]StorageAPI, len(endpointServerPools))
-		z            = &erasureServerPools{serverPools: make([]*erasureSets, len(endpointServerPools))}
+		z            = &erasureServerPools{
+			serverPools:           make([]*erasureSets, len(endpointServerPools)),
+			endpoints:             endpointServerPools,
+			decommissionCancelers: make([]context.CancelFunc, len(endpointServerPools)),
+		}
 	)
 
 	var localDrives []string
@@ -236,6 +250,10 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, s
 				available = 0
 			}
 		}
+		// Pools being decommissioned do not take new objects.
+		if z.IsSuspended(i) {
+			available = 0
+		}
 		serverPools[i] = poolAvailableSpace{
 			Index:     i,
 			Available: available,
@@ -253,12 +271,21 @@ func (z *erasureServerPools) getPoolIdxExisting(ctx context.Context, bucket, obj
 	if z.SinglePool() {
 		return 0, nil
 	}
+	return z.findPoolIdxExisting(ctx, bucket, object, false)
+}
 
+// findPoolIdxExisting returns the (first) found object pool index containing an
+// object, pools being decommissioned are not looked at if skipSuspended is set.
+func (z *erasureServerPools) findPoolIdxExisting(ctx context.Context, bucket, object string, skipSuspended bool) (idx int, err error) {
 	errs := make([]error, len(z.serverPools))
 	objInfos := make([]ObjectInfo, len(z.serverPools))
 
 	var wg sync.WaitGroup
 	for i, pool := range z.serverPools {
+		if skipSuspended && z.IsSuspended(i) {
+			errs[i] = toObjectErr(errFileNotFound, bucket, object)
+			continue
+		}
 		wg.Add(1)
 		go func(i int, pool *erasureSets) {
 			defer wg.Done()
@@ -289,41 +316,20 @@ func (z *erasureServerPools) getPoolIdxExisting(ctx context.Context, bucket, obj
 }
 
 // getPoolIdx returns the found previous object and its corresponding pool idx,
-// if none are found falls back to most available space pool.
+// if none are found falls back to most available space pool. Pools being
+// decommissioned are never returned, objects they hold are written elsewhere.
 func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object string, size int64) (idx int, err error) {
 	if z.SinglePool() {
 		return 0, nil
 	}
 
-	errs := make([]error, len(z.serverPools))
-	objInfos := make([]ObjectInfo, len(z.serverPools))
-
-	var wg sync.WaitGroup
-	for i, pool := range z.serverPools {
-		wg.Add(1)
-		go func(i int, pool *erasureSets) {
-			defer wg.Done()
-			objInfos[i], errs[i] = pool.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
-		}(i, pool)
-	}
-	wg.Wait()
-
-	for i, err := range errs {
-		if err != nil && !isErrObjectNotFound(err) {
-			return -1, err
-		}
-		if isErrObjectNotFound(err) {
-			// No object exists or its a delete marker,
-			// check objInfo to confirm.
-			if objInfos[i].DeleteMarker && objInfos[i].Name != "" {
-				return i, nil
-			}
-			// objInfo is not valid, truly the object doesn't
-			// exist proceed to next pool.
-			continue
-		}
+	idx, err = z.findPoolIdxExisting(ctx, bucket, object, true)
+	if err == nil {
 		// object exists at this pool.
-		return i, nil
+		return idx, nil
+	}
+	if !isErrObjectNotFound(err) {
+		return -1, err
 	}
 
 	// We multiply the size by 2 to account for erasure coding.
@@ -591,6 +597,9 @@ func (z *erasureServerPools) MakeBucketWithLocation(ctx context.Context, bucket
 }
 
 func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.GetObjectNInfo", bucket, object)
+	defer endSpan(&err)
+
 	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
 		return nil, err
 	}
@@ -671,6 +680,9 @@ func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object
 }
 
 func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.GetObjectInfo", bucket, object)
+	defer endSpan(&err)
+
 	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
 		return objInfo, err
 	}
@@ -681,13 +693,15 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 		return z.serverPools[0].GetObjectInfo(ctx, bucket, object, opts)
 	}
 
-	// Lock the object before reading.
-	lk := z.NewNSLock(bucket, object)
-	ctx, err = lk.GetRLock(ctx, globalOperationTimeout)
-	if err != nil {
-		return ObjectInfo{}, err
+	if !opts.NoLock {
+		// Lock the object before reading.
+		lk := z.NewNSLock(bucket, object)
+		ctx, err = lk.GetRLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		defer lk.RUnlock()
 	}
-	defer lk.RUnlock()
 
 	errs := make([]error, len(z.serverPools))
 	objInfos := make([]ObjectInfo, len(z.serverPools))
@@ -728,7 +742,10 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 }
 
 // PutObject - writes an object to least used erasure pool.
-func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.PutObject", bucket, object)
+	defer endSpan(&err)
+
 	// Validate put object input args.
 	if err := checkPutObjectArgs(ctx, bucket, object, z); err != nil {
 		return ObjectInfo{}, err
@@ -750,6 +767,9 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 }
 
 func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.DeleteObject", bucket, object)
+	defer endSpan(&err)
+
 	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
 		return objInfo, err
 	}
@@ -830,6 +850,10 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 }
 
 func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.CopyObject", dstBucket, dstObject,
+		otlp.String("src_bucket", srcBucket), otlp.String("src_object", srcObject))
+	defer endSpan(&err)
+
 	srcObject = encodeDirObject(srcObject)
 	dstObject = encodeDirObject(dstObject)
 
@@ -1026,7 +1050,10 @@ func (z *erasureServerPools) ListMultipartUploads(ctx context.Context, bucket, p
 }
 
 // Initiate a new multipart upload on a hashedSet based on object name.
-func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (string, error) {
+func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.NewMultipartUpload", bucket, object)
+	defer endSpan(&err)
+
 	if err := checkNewMultipartArgs(ctx, bucket, object, z); err != nil {
 		return "", err
 	}
@@ -1036,6 +1063,9 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 	}
 
 	for idx, pool := range z.serverPools {
+		if z.IsSuspended(idx) {
+			continue
+		}
 		result, err := pool.ListMultipartUploads(ctx, bucket, object, "", "", "", maxUploadsList)
 		if err != nil {
 			return "", err
@@ -1063,12 +1093,19 @@ func (z *erasureServerPools) CopyObjectPart(ctx context.Context, srcBucket, srcO
 		return PartInfo{}, err
 	}
 
-	return z.PutObjectPart(ctx, destBucket, destObject, uploadID, partID,
-		NewPutObjReader(srcInfo.Reader), dstOpts)
+	pReader := NewPutObjReader(srcInfo.Reader)
+	if srcInfo.PutObjReader != nil {
+		// Keep the checksum computed on the uncompressed, unencrypted content.
+		pReader.WithChecksum(srcInfo.PutObjReader.checksumReader)
+	}
+	return z.PutObjectPart(ctx, destBucket, destObject, uploadID, partID, pReader, dstOpts)
 }
 
 // PutObjectPart - writes part of an object to hashedSet based on the object name.
-func (z *erasureServerPools) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (PartInfo, error) {
+func (z *erasureServerPools) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (pi PartInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.PutObjectPart", bucket, object)
+	defer endSpan(&err)
+
 	if err := checkPutObjectPartArgs(ctx, bucket, object, z); err != nil {
 		return PartInfo{}, err
 	}
@@ -1155,7 +1192,10 @@ func (z *erasureServerPools) ListObjectParts(ctx context.Context, bucket, object
 }
 
 // Aborts an in-progress multipart operation on hashedSet based on the object name.
-func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) error {
+func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) (err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.AbortMultipartUpload", bucket, object)
+	defer endSpan(&err)
+
 	if err := checkAbortMultipartArgs(ctx, bucket, object, z); err != nil {
 		return err
 	}
@@ -1185,6 +1225,9 @@ func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, o
 
 // CompleteMultipartUpload - completes a pending multipart transaction, on hashedSet based on object name.
 func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, endSpan := startObjectSpan(ctx, "objectLayer.CompleteMultipartUpload", bucket, object)
+	defer endSpan(&err)
+
 	if err = checkCompleteMultipartArgs(ctx, bucket, object, z); err != nil {
 		return objInfo, err
 	}
@@ -121,6 +121,10 @@ type fsMetaV1 struct {
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
 	// ModTime keeps track of the last known ModTime
 	ModTime time.Time `json:"modTime,omitempty"`
+	// VersionID of this object version, empty for the null version.
+	VersionID string `json:"versionId,omitempty"`
+	// DeleteMarker is set when this version is a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
 }
 
 // IsValid - tells if the format is sane by validating the version
@@ -174,8 +178,10 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 	}
 
 	objInfo := ObjectInfo{
-		Bucket: bucket,
-		Name:   object,
+		Bucket:       bucket,
+		Name:         object,
+		VersionID:    m.VersionID,
+		DeleteMarker: m.DeleteMarker,
 	}
 
 	// We set file info only if its valid.
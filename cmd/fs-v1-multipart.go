@@ -31,6 +31,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
 	xioutil "github.com/minio/minio/pkg/ioutil"
 	"github.com/minio/minio/pkg/trie"
 )
@@ -50,6 +51,25 @@ func (fs *FSObjects) encodePartFile(partNumber int, etag string, actualSize int6
 	return fmt.Sprintf("%.5d.%s.%d", partNumber, etag, actualSize)
 }
 
+// Returns checksum.partNumber.etag
+func (fs *FSObjects) encodePartChecksumFile(partNumber int, etag string) string {
+	return fmt.Sprintf("checksum.%.5d.%s", partNumber, etag)
+}
+
+// Returns the content checksum of a part, nil if the part was
+// uploaded without one.
+func (fs *FSObjects) readPartChecksum(uploadIDDir string, partNumber int, etag string) *hash.Checksum {
+	buf, err := xioutil.ReadFile(pathJoin(uploadIDDir, fs.encodePartChecksumFile(partNumber, etag)))
+	if err != nil {
+		return nil
+	}
+	cs, err := hash.ParseChecksum(string(buf))
+	if err != nil {
+		return nil
+	}
+	return cs
+}
+
 // Returns partNumber and etag
 func (fs *FSObjects) decodePartFile(name string) (partNumber int, etag string, actualSize int64, err error) {
 	result := strings.Split(name, ".")
@@ -216,6 +236,9 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 	if err := checkNewMultipartArgs(ctx, bucket, object, fs); err != nil {
 		return "", toObjectErr(err, bucket)
 	}
+	if isFSReservedObjectName(object) {
+		return "", ObjectNameInvalid{Bucket: bucket, Object: object}
+	}
 
 	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
 		return "", toObjectErr(err, bucket)
@@ -233,6 +256,13 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 	// Initialize fs.json values.
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = opts.UserDefined
+	// The version ID of the final object is decided upfront.
+	if opts.Versioned {
+		fsMeta.VersionID = opts.VersionID
+		if fsMeta.VersionID == "" {
+			fsMeta.VersionID = mustGetUUID()
+		}
+	}
 
 	fsMetaBytes, err := json.Marshal(fsMeta)
 	if err != nil {
@@ -339,6 +369,20 @@ func (fs *FSObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID
 
 	partPath := pathJoin(uploadIDDir, fs.encodePartFile(partID, etag, data.ActualSize()))
 
+	// Save the content checksum of the part next to it, if any.
+	checksum := r.ContentChecksum()
+	checksumPath := pathJoin(uploadIDDir, fs.encodePartChecksumFile(partID, etag))
+	if checksum != nil {
+		if err = ioutil.WriteFile(checksumPath, []byte(checksum.String()), 0644); err != nil {
+			if os.IsNotExist(err) {
+				return pi, InvalidUploadID{Bucket: bucket, Object: object, UploadID: uploadID}
+			}
+			return pi, toObjectErr(err, minioMetaMultipartBucket, checksumPath)
+		}
+	} else {
+		fsRemoveFile(ctx, checksumPath)
+	}
+
 	// Make sure not to create parent directories if they don't exist - the upload might have been aborted.
 	if err = fsSimpleRenameFile(ctx, tmpPartPath, partPath); err != nil {
 		if err == errFileNotFound || err == errFileAccessDenied {
@@ -359,6 +403,7 @@ func (fs *FSObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID
 		ETag:         etag,
 		Size:         fi.Size(),
 		ActualSize:   data.ActualSize(),
+		Checksum:     checksum,
 	}, nil
 }
 
@@ -500,6 +545,7 @@ func (fs *FSObjects) ListObjectParts(ctx context.Context, bucket, object, upload
 
 	partsCount := 0
 	for partsCount < maxParts && i < len(parts) {
+		parts[i].Checksum = fs.readPartChecksum(uploadIDDir, parts[i].PartNumber, parts[i].ETag)
 		result.Parts = append(result.Parts, parts[i])
 		i++
 		partsCount++
@@ -762,6 +808,35 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	fsMeta.Meta["etag"] = s3MD5
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+
+	// Verify the checksums of the parts and save them with the parts,
+	// along with the composed object checksum.
+	partChecksums := make(map[int]*hash.Checksum, len(parts))
+	checksum, err := completeMultipartChecksum(fsMeta.Meta, parts, func(part CompletePart) *hash.Checksum {
+		cs := fs.readPartChecksum(uploadIDDir, part.PartNumber, part.ETag)
+		partChecksums[part.PartNumber] = cs
+		return cs
+	})
+	if err != nil {
+		return oi, err
+	}
+	for i, part := range parts {
+		if cs := partChecksums[part.PartNumber]; cs != nil {
+			fsMeta.Parts[i].Checksum = cs.String()
+		}
+	}
+	delete(fsMeta.Meta, uploadChecksumAlgoKey)
+	if checksum != nil {
+		fsMeta.Meta[objectChecksumKey] = checksum.String()
+	}
+	// Move away the current version if it has to be kept.
+	versionOpts := ObjectOptions{
+		Versioned: fsMeta.VersionID != "",
+		VersionID: fsMeta.VersionID,
+	}
+	if fsMeta.VersionID, err = fs.prepareVersionedWrite(ctx, bucket, object, metaFile, versionOpts); err != nil {
+		return oi, toObjectErr(err, bucket, object)
+	}
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
@@ -0,0 +1,743 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	xioutil "github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/lock"
+)
+
+// Versioning in FS mode keeps the latest version of an object at its
+// natural path inside the bucket, so that the exported directory stays
+// browsable, with its metadata in the usual `fs.json`. Noncurrent
+// versions and delete markers are moved next to that `fs.json`:
+//
+//   .minio.sys/buckets/<bucket>/<object>/__XLVERSIONS__/<version-id>/fs.json
+//   .minio.sys/buckets/<bucket>/<object>/__XLVERSIONS__/<version-id>/part.1
+//
+// Delete markers have no data file, the null version is stored
+// under the directory name "null". The versions directory shares
+// its parent with the metadata of objects below <object>, so its
+// name is reserved and rejected as an object path component.
+const (
+	// Directory holding noncurrent versions of an object.
+	fsVersionsDir = "__XLVERSIONS__"
+
+	// Data file of a noncurrent version.
+	fsVersionDataFile = "part.1"
+)
+
+// fsVersion is a single version of an object as found on disk.
+type fsVersion struct {
+	meta fsMetaV1
+	// fi is nil for delete markers.
+	fi os.FileInfo
+	// current is set for the version stored at the natural object path.
+	current bool
+}
+
+func (v fsVersion) modTime() time.Time {
+	if v.fi != nil {
+		return v.fi.ModTime()
+	}
+	return v.meta.ModTime
+}
+
+func (v fsVersion) toObjectInfo(bucket, object string) ObjectInfo {
+	oi := v.meta.ToObjectInfo(bucket, object, v.fi)
+	if v.fi == nil {
+		oi.ModTime = v.meta.ModTime
+	}
+	return oi
+}
+
+// isFSReservedObjectName returns true if the object name has the
+// reserved versions directory as a path component.
+func isFSReservedObjectName(object string) bool {
+	for _, elem := range strings.Split(object, SlashSeparator) {
+		if elem == fsVersionsDir {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the directory holding `fs.json` and versions of an object.
+func (fs *FSObjects) getObjectMetaDir(bucket, object string) string {
+	return pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object)
+}
+
+// Returns the directory of a noncurrent version, the
+// null version is identified by an empty versionID.
+func (fs *FSObjects) getVersionDir(bucket, object, versionID string) string {
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	return pathJoin(fs.getObjectMetaDir(bucket, object), fsVersionsDir, versionID)
+}
+
+// Returns the path to the data of a version.
+func (fs *FSObjects) getVersionDataPath(bucket, object string, v fsVersion) string {
+	if v.current {
+		return pathJoin(fs.fsPath, bucket, object)
+	}
+	return pathJoin(fs.getVersionDir(bucket, object, v.meta.VersionID), fsVersionDataFile)
+}
+
+// readFSMetaFile reads and validates the `fs.json` at the given path.
+func readFSMetaFile(fsMetaPath string) (fsMeta fsMetaV1, err error) {
+	fsMetaBuf, err := xioutil.ReadFile(fsMetaPath)
+	if err != nil {
+		return fsMeta, osErrToFileErr(err)
+	}
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err = json.Unmarshal(fsMetaBuf, &fsMeta); err != nil {
+		return fsMeta, err
+	}
+	if !fsMeta.IsValid() {
+		return fsMeta, errCorruptedFormat
+	}
+	return fsMeta, nil
+}
+
+// currentVersion returns the version stored at the natural object
+// path, errFileNotFound is returned when there is none.
+func (fs *FSObjects) currentVersion(ctx context.Context, bucket, object string) (fsVersion, error) {
+	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return fsVersion{}, err
+	}
+	fsMeta, err := readFSMetaFile(pathJoin(fs.getObjectMetaDir(bucket, object), fs.metaJSONFile))
+	if err != nil {
+		// Pre-existing data without `fs.json` is the null version.
+		fsMeta = fs.defaultFsJSON(object)
+	}
+	return fsVersion{meta: fsMeta, fi: fi, current: true}, nil
+}
+
+// noncurrentVersions returns all archived versions and delete
+// markers of an object sorted from the newest to the oldest.
+func (fs *FSObjects) noncurrentVersions(ctx context.Context, bucket, object string) ([]fsVersion, error) {
+	versionsDir := pathJoin(fs.getObjectMetaDir(bucket, object), fsVersionsDir)
+	entries, err := readDir(versionsDir)
+	if err != nil {
+		if err == errFileNotFound || err == errVolumeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	versions := make([]fsVersion, 0, len(entries))
+	for _, entry := range entries {
+		if !HasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		versionDir := pathJoin(versionsDir, entry)
+		fsMeta, err := readFSMetaFile(pathJoin(versionDir, fs.metaJSONFile))
+		if err != nil {
+			// Version is being written or removed.
+			continue
+		}
+		v := fsVersion{meta: fsMeta}
+		if !fsMeta.DeleteMarker {
+			if v.fi, err = fsStatFile(ctx, pathJoin(versionDir, fsVersionDataFile)); err != nil {
+				continue
+			}
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].modTime().After(versions[j].modTime())
+	})
+	return versions, nil
+}
+
+// getVersions returns all versions of an object, latest first.
+func (fs *FSObjects) getVersions(ctx context.Context, bucket, object string) ([]fsVersion, error) {
+	versions, err := fs.noncurrentVersions(ctx, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := fs.currentVersion(ctx, bucket, object)
+	switch err {
+	case nil:
+		// The current version is always the latest one.
+		versions = append([]fsVersion{cur}, versions...)
+	case errFileNotFound:
+	default:
+		return nil, err
+	}
+	return versions, nil
+}
+
+// getVersion returns the version of an object identified by versionID,
+// an empty versionID returns the latest version which may be a delete marker.
+func (fs *FSObjects) getVersion(ctx context.Context, bucket, object, versionID string) (fsVersion, error) {
+	if versionID == "" {
+		versions, err := fs.getVersions(ctx, bucket, object)
+		if err != nil {
+			return fsVersion{}, err
+		}
+		if len(versions) == 0 {
+			return fsVersion{}, errFileNotFound
+		}
+		return versions[0], nil
+	}
+
+	if versionID == nullVersionID {
+		versionID = ""
+	}
+
+	cur, err := fs.currentVersion(ctx, bucket, object)
+	if err == nil && cur.meta.VersionID == versionID {
+		return cur, nil
+	}
+	if err != nil && err != errFileNotFound {
+		return fsVersion{}, err
+	}
+
+	versionDir := fs.getVersionDir(bucket, object, versionID)
+	fsMeta, err := readFSMetaFile(pathJoin(versionDir, fs.metaJSONFile))
+	if err != nil {
+		if err == errFileNotFound {
+			return fsVersion{}, errFileVersionNotFound
+		}
+		return fsVersion{}, err
+	}
+	v := fsVersion{meta: fsMeta}
+	if !fsMeta.DeleteMarker {
+		if v.fi, err = fsStatFile(ctx, pathJoin(versionDir, fsVersionDataFile)); err != nil {
+			if err == errFileNotFound {
+				return fsVersion{}, errFileVersionNotFound
+			}
+			return fsVersion{}, err
+		}
+	}
+	return v, nil
+}
+
+// getVersionInfo returns the object info of a version, delete markers
+// are returned along with errFileNotFound when no version was asked
+// for and with errMethodNotAllowed otherwise.
+func (fs *FSObjects) getVersionInfo(ctx context.Context, bucket, object, versionID string) (fsVersion, ObjectInfo, error) {
+	v, err := fs.getVersion(ctx, bucket, object, versionID)
+	if err != nil {
+		return v, ObjectInfo{}, err
+	}
+	oi := v.toObjectInfo(bucket, object)
+	oi.IsLatest = v.current
+	if v.meta.DeleteMarker {
+		if versionID == "" {
+			oi.IsLatest = true
+			return v, oi, errFileNotFound
+		}
+		return v, oi, errMethodNotAllowed
+	}
+	return v, oi, nil
+}
+
+// getObjectVersionInfoWithLock - reads the metadata of an object version
+// and replies back ObjectInfo.
+func (fs *FSObjects) getObjectVersionInfoWithLock(ctx context.Context, bucket, object, versionID string) (oi ObjectInfo, err error) {
+	// Lock the object before reading.
+	lk := fs.NewNSLock(bucket, object)
+	ctx, err = lk.GetRLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return oi, err
+	}
+	defer lk.RUnlock()
+
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return oi, err
+	}
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return oi, err
+	}
+
+	_, oi, err = fs.getVersionInfo(ctx, bucket, object, versionID)
+	return oi, err
+}
+
+// latestDeleteMarker returns the object info of the latest version of an
+// object without a current version, if that version is a delete marker.
+func (fs *FSObjects) latestDeleteMarker(ctx context.Context, bucket, object string) (ObjectInfo, bool) {
+	if bucket == minioMetaBucket || HasSuffix(object, SlashSeparator) {
+		return ObjectInfo{}, false
+	}
+	versions, err := fs.noncurrentVersions(ctx, bucket, object)
+	if err != nil || len(versions) == 0 || !versions[0].meta.DeleteMarker {
+		return ObjectInfo{}, false
+	}
+	oi := versions[0].toObjectInfo(bucket, object)
+	oi.IsLatest = true
+	return oi, true
+}
+
+// putVersionTags replaces the tags of a noncurrent version, the
+// returned boolean is false if versionID is the current version.
+func (fs *FSObjects) putVersionTags(ctx context.Context, bucket, object, tags, versionID string) (ObjectInfo, bool, error) {
+	lk := fs.NewNSLock(bucket, object)
+	ctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, false, err
+	}
+	defer lk.Unlock()
+
+	v, err := fs.getVersion(ctx, bucket, object, versionID)
+	if err != nil {
+		return ObjectInfo{}, false, err
+	}
+	if v.current {
+		return ObjectInfo{}, false, nil
+	}
+	if v.meta.DeleteMarker {
+		return ObjectInfo{}, true, errMethodNotAllowed
+	}
+
+	if v.meta.Meta == nil {
+		v.meta.Meta = make(map[string]string)
+	}
+	delete(v.meta.Meta, xhttp.AmzObjectTagging)
+	if tags != "" {
+		v.meta.Meta[xhttp.AmzObjectTagging] = tags
+	}
+	if err = fs.writeVersionMeta(ctx, bucket, object, v.meta); err != nil {
+		return ObjectInfo{}, true, err
+	}
+	return v.toObjectInfo(bucket, object), true, nil
+}
+
+// writeVersionMeta saves the `fs.json` of a noncurrent version.
+func (fs *FSObjects) writeVersionMeta(ctx context.Context, bucket, object string, fsMeta fsMetaV1) error {
+	wlk, err := fs.rwPool.Create(pathJoin(fs.getVersionDir(bucket, object, fsMeta.VersionID), fs.metaJSONFile))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer wlk.Close()
+	_, err = fsMeta.WriteTo(wlk)
+	return err
+}
+
+// removeVersion purges a noncurrent version along with its data.
+func (fs *FSObjects) removeVersion(ctx context.Context, bucket, object, versionID string) error {
+	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket)
+	versionDir := fs.getVersionDir(bucket, object, versionID)
+	if err := checkPathLength(versionDir); err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	return deleteFile(bucketMetaDir, versionDir, true)
+}
+
+// checkNotLocked returns ObjectLocked if a version which is about to
+// be replaced in place is protected by a retention period or a legal hold.
+func checkNotLocked(ctx context.Context, bucket, object string, fsMeta fsMetaV1) error {
+	if enforceRetentionForDeletion(ctx, ObjectInfo{UserDefined: fsMeta.Meta}) {
+		return ObjectLocked{Bucket: bucket, Object: object, VersionID: fsMeta.VersionID}
+	}
+	return nil
+}
+
+// removeNullVersion purges the archived null version of an
+// object, unless it is protected by object lock.
+func (fs *FSObjects) removeNullVersion(ctx context.Context, bucket, object string) error {
+	fsMeta, err := readFSMetaFile(pathJoin(fs.getVersionDir(bucket, object, ""), fs.metaJSONFile))
+	if err == nil {
+		if err = checkNotLocked(ctx, bucket, object, fsMeta); err != nil {
+			return err
+		}
+	}
+	if err = fs.removeVersion(ctx, bucket, object, ""); err != nil && err != errFileNotFound {
+		return err
+	}
+	return nil
+}
+
+// archiveCurrentVersion moves the current version of an object
+// to the versions directory, leaving the natural path empty.
+func (fs *FSObjects) archiveCurrentVersion(ctx context.Context, bucket, object string, cur fsVersion) error {
+	fsMeta := cur.meta
+	fsMeta.ModTime = cur.fi.ModTime()
+	if err := fs.writeVersionMeta(ctx, bucket, object, fsMeta); err != nil {
+		return err
+	}
+
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	versionDataPath := pathJoin(fs.getVersionDir(bucket, object, fsMeta.VersionID), fsVersionDataFile)
+	if err := fsRenameFile(ctx, fsObjPath, versionDataPath); err != nil {
+		return err
+	}
+
+	// Cleanup parent directories left empty by the move.
+	deleteFile(pathJoin(fs.fsPath, bucket), path.Dir(fsObjPath), false)
+	return nil
+}
+
+// promoteLatestVersion restores the newest noncurrent version to the
+// natural object path, unless it is a delete marker. It is called
+// after the current version was removed.
+func (fs *FSObjects) promoteLatestVersion(ctx context.Context, bucket, object string) error {
+	versions, err := fs.noncurrentVersions(ctx, bucket, object)
+	if err != nil || len(versions) == 0 {
+		return err
+	}
+
+	latest := versions[0]
+	if latest.meta.DeleteMarker {
+		return nil
+	}
+
+	if fs.parentDirIsObject(ctx, bucket, path.Dir(object)) {
+		return errFileParentIsFile
+	}
+
+	versionDataPath := pathJoin(fs.getVersionDir(bucket, object, latest.meta.VersionID), fsVersionDataFile)
+	if err = fsRenameFile(ctx, versionDataPath, pathJoin(fs.fsPath, bucket, object)); err != nil {
+		return err
+	}
+
+	wlk, err := fs.rwPool.Create(pathJoin(fs.getObjectMetaDir(bucket, object), fs.metaJSONFile))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	_, err = latest.meta.WriteTo(wlk)
+	wlk.Close()
+	if err != nil {
+		return err
+	}
+
+	return fs.removeVersion(ctx, bucket, object, latest.meta.VersionID)
+}
+
+// prepareVersionedWrite is called with the object lock and the lock on
+// the current `fs.json` held, before a new version is written to the
+// natural object path. It archives the current version when needed and
+// returns the version ID of the new version, empty for the null version.
+func (fs *FSObjects) prepareVersionedWrite(ctx context.Context, bucket, object string, wlk *lock.LockedFile, opts ObjectOptions) (string, error) {
+	var versionID string
+	if opts.Versioned {
+		versionID = opts.VersionID
+		if versionID == "" {
+			versionID = mustGetUUID()
+		}
+	}
+
+	if versionID == "" {
+		// The new null version replaces any archived one.
+		if err := fs.removeNullVersion(ctx, bucket, object); err != nil {
+			return "", err
+		}
+	}
+
+	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil && err != errFileNotFound {
+		return "", err
+	}
+	if err == nil {
+		cur := fsVersion{meta: newFSMetaV1(), fi: fi, current: true}
+		if _, rerr := cur.meta.ReadFrom(ctx, wlk); rerr != nil {
+			cur.meta = fs.defaultFsJSON(object)
+		}
+		// A null version is overwritten in place by another null version,
+		// this is the only case for buckets which were never versioned.
+		if cur.meta.VersionID == "" && versionID == "" {
+			return "", checkNotLocked(ctx, bucket, object, cur.meta)
+		}
+		if err = fs.archiveCurrentVersion(ctx, bucket, object, cur); err != nil {
+			return "", err
+		}
+	}
+	return versionID, nil
+}
+
+// deleteObjectVersion implements DeleteObject for versioned and version
+// suspended buckets, and for deletes of an explicit version. It must be
+// called with the object lock held.
+func (fs *FSObjects) deleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket)
+	fsMetaPath := pathJoin(fs.getObjectMetaDir(bucket, object), fs.metaJSONFile)
+
+	if opts.VersionID == "" {
+		// Add a delete marker, a version suspended
+		// bucket gets a `null` delete marker.
+		modTime := opts.MTime
+		if modTime.IsZero() {
+			modTime = UTCNow()
+		}
+		marker := newFSMetaV1()
+		marker.DeleteMarker = true
+		marker.ModTime = modTime
+		if opts.Versioned {
+			marker.VersionID = mustGetUUID()
+		}
+
+		if marker.VersionID == "" {
+			if err := fs.removeNullVersion(ctx, bucket, object); err != nil {
+				return ObjectInfo{}, err
+			}
+		}
+
+		cur, err := fs.currentVersion(ctx, bucket, object)
+		if err != nil && err != errFileNotFound {
+			return ObjectInfo{}, err
+		}
+		if err == nil {
+			if cur.meta.VersionID == "" && marker.VersionID == "" {
+				// The null delete marker replaces the null version.
+				if err = checkNotLocked(ctx, bucket, object, cur.meta); err != nil {
+					return ObjectInfo{}, err
+				}
+				if err = fsDeleteFile(ctx, pathJoin(fs.fsPath, bucket), pathJoin(fs.fsPath, bucket, object)); err != nil {
+					return ObjectInfo{}, err
+				}
+			} else if err = fs.archiveCurrentVersion(ctx, bucket, object, cur); err != nil {
+				return ObjectInfo{}, err
+			}
+		}
+		if err = fs.writeVersionMeta(ctx, bucket, object, marker); err != nil {
+			return ObjectInfo{}, err
+		}
+		if err = fsDeleteFile(ctx, bucketMetaDir, fsMetaPath); err != nil && err != errFileNotFound {
+			return ObjectInfo{}, err
+		}
+		return fsVersion{meta: marker}.toObjectInfo(bucket, object), nil
+	}
+
+	v, err := fs.getVersion(ctx, bucket, object, opts.VersionID)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if v.current {
+		if err = fsDeleteFile(ctx, pathJoin(fs.fsPath, bucket), pathJoin(fs.fsPath, bucket, object)); err != nil {
+			return ObjectInfo{}, err
+		}
+		if err = fsDeleteFile(ctx, bucketMetaDir, fsMetaPath); err != nil && err != errFileNotFound {
+			return ObjectInfo{}, err
+		}
+	} else if err = fs.removeVersion(ctx, bucket, object, v.meta.VersionID); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	// The previous version becomes the latest one
+	// when the object is no longer at its natural path.
+	if _, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object)); err == errFileNotFound {
+		if err = fs.promoteLatestVersion(ctx, bucket, object); err != nil {
+			return ObjectInfo{}, err
+		}
+	}
+
+	return ObjectInfo{
+		Bucket:       bucket,
+		Name:         object,
+		VersionID:    opts.VersionID,
+		DeleteMarker: v.meta.DeleteMarker,
+	}, nil
+}
+
+// bucketHasVersions returns true if the bucket holds any object version,
+// including noncurrent versions and delete markers.
+func (fs *FSObjects) bucketHasVersions(ctx context.Context, bucket string) bool {
+	loi, err := fs.ListObjectVersions(ctx, bucket, "", "", "", "", 1)
+	return err == nil && len(loi.Objects) > 0
+}
+
+// Returns a listDir function listing the bucket namespace merged with
+// objects which only have noncurrent versions or delete markers.
+func (fs *FSObjects) listDirVersionsFactory() ListDirFunc {
+	listDataDir := fs.listDirFactory()
+	return func(bucket, prefixDir, prefixEntry string) (emptyDir bool, entries []string, delayIsLeaf bool) {
+		_, entries, _ = listDataDir(bucket, prefixDir, prefixEntry)
+
+		metaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, prefixDir)
+		metaEntries, err := readDir(metaDir)
+		if err != nil && err != errFileNotFound {
+			logger.LogIf(GlobalContext, err)
+		}
+
+		seen := make(map[string]struct{}, len(entries))
+		for _, entry := range entries {
+			seen[entry] = struct{}{}
+		}
+		add := func(entry string) {
+			if _, ok := seen[entry]; !ok {
+				seen[entry] = struct{}{}
+				entries = append(entries, entry)
+			}
+		}
+		for _, entry := range filterMatchingPrefix(metaEntries, prefixEntry) {
+			if !HasSuffix(entry, SlashSeparator) {
+				// Bucket metadata and `fs.json` files.
+				continue
+			}
+			subEntries, err := readDir(pathJoin(metaDir, entry))
+			if err != nil {
+				continue
+			}
+			for _, subEntry := range subEntries {
+				switch subEntry {
+				case fsVersionsDir + SlashSeparator:
+					add(strings.TrimSuffix(entry, SlashSeparator))
+				case fs.metaJSONFile:
+				default:
+					add(entry)
+				}
+			}
+		}
+
+		if len(entries) == 0 {
+			return true, nil, false
+		}
+		sort.Strings(entries)
+		return false, entries, false
+	}
+}
+
+// ListObjectVersions - lists all object versions at prefix upto maxKeys,
+// optionally delimited by '/'. Objects of buckets which were never
+// versioned are listed with their null version.
+func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (loi ListObjectVersionsInfo, e error) {
+	if marker == "" && versionMarker != "" {
+		return loi, NotImplemented{}
+	}
+	if delimiter != "" && delimiter != SlashSeparator {
+		return loi, NotImplemented{}
+	}
+	if err := checkListObjsArgs(ctx, bucket, prefix, marker, fs); err != nil {
+		return loi, err
+	}
+	if marker != "" && !HasPrefix(marker, prefix) {
+		return loi, nil
+	}
+	if maxKeys == 0 || (delimiter == SlashSeparator && prefix == SlashSeparator) {
+		return loi, nil
+	}
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	atomic.AddInt64(&fs.activeIOCount, 1)
+	defer func() {
+		atomic.AddInt64(&fs.activeIOCount, -1)
+	}()
+
+	objectVersions := func(object string) ([]ObjectInfo, error) {
+		versions, err := fs.getVersions(ctx, bucket, object)
+		if err != nil {
+			return nil, err
+		}
+		objInfos := make([]ObjectInfo, len(versions))
+		for i, v := range versions {
+			objInfos[i] = v.toObjectInfo(bucket, object)
+		}
+		if len(objInfos) > 0 {
+			objInfos[0].IsLatest = true
+		}
+		return objInfos, nil
+	}
+
+	// Entries are listed until one more than requested is found.
+	var entries []ObjectInfo
+
+	// Continue with the versions of the marker object older than versionMarker.
+	if versionMarker != "" {
+		if versionMarker == nullVersionID {
+			versionMarker = ""
+		}
+		objInfos, err := objectVersions(marker)
+		if err != nil {
+			return loi, toObjectErr(err, bucket, marker)
+		}
+		for i, oi := range objInfos {
+			if oi.VersionID == versionMarker {
+				entries = append(entries, objInfos[i+1:]...)
+				break
+			}
+		}
+	}
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+	recursive := delimiter != SlashSeparator
+	walkResultCh := startTreeWalk(ctx, bucket, prefix, marker, recursive, fs.listDirVersionsFactory(), fs.isLeaf, fs.isLeafDir, endWalkCh)
+	for len(entries) <= maxKeys {
+		walkResult, ok := <-walkResultCh
+		if !ok {
+			break
+		}
+		if HasSuffix(walkResult.entry, SlashSeparator) {
+			if delimiter == SlashSeparator {
+				entries = append(entries, ObjectInfo{
+					Bucket: bucket,
+					Name:   walkResult.entry,
+					IsDir:  true,
+				})
+				continue
+			}
+			oi, err := fs.getObjectInfoNoFSLock(ctx, bucket, walkResult.entry)
+			if err != nil {
+				continue
+			}
+			oi.IsLatest = true
+			entries = append(entries, oi)
+			continue
+		}
+		objInfos, err := objectVersions(walkResult.entry)
+		if err != nil {
+			if IsErrIgnored(err, errFileNotFound, errFileVersionNotFound) {
+				continue
+			}
+			return loi, toObjectErr(err, bucket, walkResult.entry)
+		}
+		entries = append(entries, objInfos...)
+	}
+
+	if len(entries) > maxKeys {
+		entries = entries[:maxKeys]
+		loi.IsTruncated = true
+		last := entries[maxKeys-1]
+		loi.NextMarker = last.Name
+		if !last.IsDir || delimiter != SlashSeparator {
+			loi.NextVersionIDMarker = last.VersionID
+			if loi.NextVersionIDMarker == "" {
+				loi.NextVersionIDMarker = nullVersionID
+			}
+		}
+	}
+
+	for _, oi := range entries {
+		if oi.IsDir && delimiter == SlashSeparator {
+			loi.Prefixes = append(loi.Prefixes, oi.Name)
+			continue
+		}
+		loi.Objects = append(loi.Objects, oi)
+	}
+	return loi, nil
+}
@@ -0,0 +1,312 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+)
+
+// TestFSObjectVersions - tests put, get, delete and listing
+// of object versions in FS mode.
+func TestFSObjectVersions(t *testing.T) {
+	obj, disk, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(disk)
+
+	newAllSubsystems()
+	initAllSubsystems(GlobalContext, obj)
+
+	bucket := "bucket"
+	object := "dir/object"
+	if err = obj.MakeBucketWithLocation(GlobalContext, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(content string, opts ObjectOptions) ObjectInfo {
+		t.Helper()
+		oi, err := obj.PutObject(GlobalContext, bucket, object,
+			mustGetPutObjReader(t, bytes.NewReader([]byte(content)), int64(len(content)), "", ""), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+	read := func(versionID string) string {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(GlobalContext, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		data, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// An object written before versioning is the null version.
+	put("null", ObjectOptions{})
+	v1 := put("v1", ObjectOptions{Versioned: true})
+	v2 := put("v2", ObjectOptions{Versioned: true})
+	if v1.VersionID == "" || v2.VersionID == "" || v1.VersionID == v2.VersionID {
+		t.Fatalf("unexpected version IDs %q and %q", v1.VersionID, v2.VersionID)
+	}
+
+	if got := read(""); got != "v2" {
+		t.Fatalf("expected latest version content v2, got %s", got)
+	}
+	if got := read(v1.VersionID); got != "v1" {
+		t.Fatalf("expected version content v1, got %s", got)
+	}
+	if got := read(nullVersionID); got != "null" {
+		t.Fatalf("expected null version content, got %s", got)
+	}
+
+	loi, err := obj.ListObjectVersions(GlobalContext, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 3 {
+		t.Fatalf("expected 3 versions, got %d", len(loi.Objects))
+	}
+	if !loi.Objects[0].IsLatest || loi.Objects[0].VersionID != v2.VersionID {
+		t.Fatalf("expected latest version %s, got %#v", v2.VersionID, loi.Objects[0])
+	}
+
+	// Deleting without a version ID adds a delete marker.
+	dm, err := obj.DeleteObject(GlobalContext, bucket, object, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dm.DeleteMarker || dm.VersionID == "" {
+		t.Fatalf("expected a delete marker, got %#v", dm)
+	}
+	if _, err = obj.GetObjectInfo(GlobalContext, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected ObjectNotFound, got %v", err)
+	}
+	if _, err = obj.GetObjectInfo(GlobalContext, bucket, object, ObjectOptions{VersionID: dm.VersionID}); err == nil {
+		t.Fatal("expected an error reading a delete marker")
+	}
+	result, err := obj.ListObjects(GlobalContext, bucket, "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 0 {
+		t.Fatalf("expected no objects, got %d", len(result.Objects))
+	}
+
+	// Removing the delete marker restores the previous version.
+	if _, err = obj.DeleteObject(GlobalContext, bucket, object, ObjectOptions{VersionID: dm.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(""); got != "v2" {
+		t.Fatalf("expected restored version content v2, got %s", got)
+	}
+
+	// Removing the current version promotes the previous one.
+	if _, err = obj.DeleteObject(GlobalContext, bucket, object, ObjectOptions{VersionID: v2.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(""); got != "v1" {
+		t.Fatalf("expected promoted version content v1, got %s", got)
+	}
+	if _, err = obj.GetObjectInfo(GlobalContext, bucket, object, ObjectOptions{VersionID: v2.VersionID}); !isErrVersionNotFound(err) {
+		t.Fatalf("expected VersionNotFound, got %v", err)
+	}
+
+	// Version suspended writes replace the null version.
+	put("suspended", ObjectOptions{})
+	loi, err = obj.ListObjectVersions(GlobalContext, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(loi.Objects))
+	}
+	if got := read(nullVersionID); got != "suspended" {
+		t.Fatalf("expected null version content suspended, got %s", got)
+	}
+}
+
+// TestFSObjectVersionsNameCollision - tests that noncurrent versions
+// of an object are not overwritten by objects below the object name.
+func TestFSObjectVersionsNameCollision(t *testing.T) {
+	obj, disk, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(disk)
+
+	newAllSubsystems()
+	initAllSubsystems(GlobalContext, obj)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(GlobalContext, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(object, content string) (ObjectInfo, error) {
+		return obj.PutObject(GlobalContext, bucket, object,
+			mustGetPutObjReader(t, bytes.NewReader([]byte(content)), int64(len(content)), "", ""), ObjectOptions{Versioned: true})
+	}
+	read := func(object, versionID string) string {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(GlobalContext, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		data, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	v1, err := put("object", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The delete marker moves v1 to the versions directory and frees
+	// the object path for objects below it.
+	if _, err = obj.DeleteObject(GlobalContext, bucket, "object", ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, object := range []string{
+		"object/versions/" + v1.VersionID,
+		"object/" + fsVersionsDir + "_/" + v1.VersionID,
+	} {
+		if _, err = put(object, "collision"); err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		if got := read(object, ""); got != "collision" {
+			t.Fatalf("%s: expected content collision, got %s", object, got)
+		}
+	}
+	if got := read("object", v1.VersionID); got != "v1" {
+		t.Fatalf("expected version content v1, got %s", got)
+	}
+
+	// The versions directory name is reserved.
+	object := "object/" + fsVersionsDir + "/" + v1.VersionID
+	if _, err = put(object, "collision"); !errors.As(err, &ObjectNameInvalid{}) {
+		t.Fatalf("expected ObjectNameInvalid, got %v", err)
+	}
+	if _, err = obj.NewMultipartUpload(GlobalContext, bucket, object, ObjectOptions{}); !errors.As(err, &ObjectNameInvalid{}) {
+		t.Fatalf("expected ObjectNameInvalid, got %v", err)
+	}
+	if got := read("object", v1.VersionID); got != "v1" {
+		t.Fatalf("expected version content v1, got %s", got)
+	}
+}
+
+// TestFSObjectLock - tests that versions under legal hold or retention
+// are never replaced in place in FS mode.
+func TestFSObjectLock(t *testing.T) {
+	obj, disk, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(disk)
+
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(obj)
+
+	newAllSubsystems()
+	initAllSubsystems(GlobalContext, obj)
+
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(GlobalContext, bucket, BucketOptions{LockEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if rcfg, _ := globalBucketObjectLockSys.Get(bucket); !rcfg.LockEnabled {
+		t.Fatal("expected object lock to be enabled on the bucket")
+	}
+	if vcfg, _ := globalBucketVersioningSys.Get(bucket); !vcfg.Enabled() {
+		t.Fatal("expected versioning to be enabled on the bucket")
+	}
+
+	put := func(opts ObjectOptions) (ObjectInfo, error) {
+		return obj.PutObject(GlobalContext, bucket, object,
+			mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), opts)
+	}
+
+	// A null version put under legal hold.
+	if _, err = put(ObjectOptions{UserDefined: map[string]string{
+		strings.ToLower(xhttp.AmzObjectLockLegalHold): "ON",
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = put(ObjectOptions{}); !errors.As(err, &ObjectLocked{}) {
+		t.Fatalf("expected ObjectLocked, got %v", err)
+	}
+	if _, err = obj.DeleteObject(GlobalContext, bucket, object, ObjectOptions{VersionSuspended: true}); !errors.As(err, &ObjectLocked{}) {
+		t.Fatalf("expected ObjectLocked, got %v", err)
+	}
+
+	// New versions leave the locked version untouched.
+	if _, err = put(ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = put(ObjectOptions{}); !errors.As(err, &ObjectLocked{}) {
+		t.Fatalf("expected ObjectLocked, got %v", err)
+	}
+
+	// Releasing the legal hold allows the null version to be replaced.
+	oi, err := obj.PutObjectMetadata(GlobalContext, bucket, object, ObjectOptions{
+		VersionID: nullVersionID,
+		UserDefined: map[string]string{
+			strings.ToLower(xhttp.AmzObjectLockLegalHold): "OFF",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.IsLatest {
+		t.Fatal("expected the null version to be noncurrent")
+	}
+	if _, err = put(ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Retention in the future protects the version as well.
+	if _, err = obj.PutObjectMetadata(GlobalContext, bucket, object, ObjectOptions{
+		UserDefined: map[string]string{
+			strings.ToLower(xhttp.AmzObjectLockMode):            string(objectlock.RetCompliance),
+			strings.ToLower(xhttp.AmzObjectLockRetainUntilDate): UTCNow().Add(time.Hour).Format(time.RFC3339),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = put(ObjectOptions{}); !errors.As(err, &ObjectLocked{}) {
+		t.Fatalf("expected ObjectLocked, got %v", err)
+	}
+}
@@ -399,10 +399,6 @@ func (fs *FSObjects) statBucketDir(ctx context.Context, bucket string) (os.FileI
 
 // MakeBucketWithLocation - create a new bucket, returns if it already exists.
 func (fs *FSObjects) MakeBucketWithLocation(ctx context.Context, bucket string, opts BucketOptions) error {
-	if opts.LockEnabled || opts.VersioningEnabled {
-		return NotImplemented{}
-	}
-
 	// Verify if bucket is valid.
 	if s3utils.CheckValidBucketNameStrict(bucket) != nil {
 		return BucketNameInvalid{Bucket: bucket}
@@ -424,6 +420,12 @@ func (fs *FSObjects) MakeBucketWithLocation(ctx context.Context, bucket string,
 	}
 
 	meta := newBucketMetadata(bucket)
+	if opts.VersioningEnabled || opts.LockEnabled {
+		meta.VersioningConfigXML = enabledBucketVersioningConfig
+	}
+	if opts.LockEnabled {
+		meta.ObjectLockConfigXML = enabledBucketObjectLockConfig
+	}
 	if err := meta.Save(ctx, fs); err != nil {
 		return toObjectErr(err, bucket)
 	}
@@ -565,6 +567,11 @@ func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string, forceDelet
 	}
 
 	if !forceDelete {
+		// Noncurrent versions and delete markers are not visible in
+		// the bucket directory but still keep the bucket from being empty.
+		if fs.bucketHasVersions(ctx, bucket) {
+			return BucketNotEmpty{Bucket: bucket}
+		}
 		// Attempt to delete regular bucket.
 		if err = fsRemoveDir(ctx, bucketDir); err != nil {
 			return toObjectErr(err, bucket)
@@ -598,12 +605,8 @@ func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string, forceDelet
 // if source object and destination object are same we only
 // update metadata.
 func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, err error) {
-	if srcOpts.VersionID != "" && srcOpts.VersionID != nullVersionID {
-		return oi, VersionNotFound{
-			Bucket:    srcBucket,
-			Object:    srcObject,
-			VersionID: srcOpts.VersionID,
-		}
+	if isFSReservedObjectName(dstObject) {
+		return oi, ObjectNameInvalid{Bucket: dstBucket, Object: dstObject}
 	}
 
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
@@ -627,7 +630,8 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return oi, toObjectErr(err, srcBucket)
 	}
 
-	if cpSrcDstSame && srcInfo.metadataOnly {
+	// Metadata of noncurrent versions is never updated in place.
+	if cpSrcDstSame && srcInfo.metadataOnly && (srcOpts.VersionID == "" || srcInfo.IsLatest) {
 		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, srcBucket, srcObject, fs.metaJSONFile)
 		wlk, err := fs.rwPool.Write(fsMetaPath)
 		if err != nil {
@@ -688,7 +692,12 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return ObjectInfo{}, err
 	}
 
-	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined})
+	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionID:            dstOpts.VersionID,
+	})
 	if err != nil {
 		return oi, toObjectErr(err, dstBucket, dstObject)
 	}
@@ -699,13 +708,6 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 // GetObjectNInfo - returns object info and a reader for object
 // content.
 func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
-	if opts.VersionID != "" && opts.VersionID != nullVersionID {
-		return nil, VersionNotFound{
-			Bucket:    bucket,
-			Object:    object,
-			VersionID: opts.VersionID,
-		}
-	}
 	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
 		return nil, err
 	}
@@ -742,8 +744,24 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 
 	// Otherwise we get the object info
 	var objInfo ObjectInfo
-	if objInfo, err = fs.getObjectInfo(ctx, bucket, object); err != nil {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	current := true
+	if opts.VersionID != "" {
+		var v fsVersion
+		if v, objInfo, err = fs.getVersionInfo(ctx, bucket, object, opts.VersionID); err != nil {
+			nsUnlocker()
+			if v.meta.DeleteMarker {
+				return &GetObjectReader{ObjInfo: objInfo}, toObjectErr(err, bucket, object)
+			}
+			return nil, toObjectErr(err, bucket, object, opts.VersionID)
+		}
+		fsObjPath = fs.getVersionDataPath(bucket, object, v)
+		current = v.current
+	} else if objInfo, err = fs.getObjectInfo(ctx, bucket, object); err != nil {
 		nsUnlocker()
+		if dm, ok := fs.latestDeleteMarker(ctx, bucket, object); ok && err == errFileNotFound {
+			return &GetObjectReader{ObjInfo: dm}, toObjectErr(err, bucket, object)
+		}
 		return nil, toObjectErr(err, bucket, object)
 	}
 	// For a directory, we need to return a reader that returns no bytes.
@@ -754,7 +772,7 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 	}
 	// Take a rwPool lock for NFS gateway type deployment
 	rwPoolUnlocker := func() {}
-	if bucket != minioMetaBucket && lockType != noLock {
+	if bucket != minioMetaBucket && lockType != noLock && current {
 		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 		_, err = fs.rwPool.Open(fsMetaPath)
 		if err != nil && err != errFileNotFound {
@@ -773,7 +791,6 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 	}
 
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
 	readCloser, size, err := fsOpenFile(ctx, fsObjPath, off)
 	if err != nil {
 		rwPoolUnlocker()
@@ -1046,19 +1063,16 @@ func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object s
 
 // GetObjectInfo - reads object metadata and replies back ObjectInfo.
 func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (oi ObjectInfo, e error) {
-	if opts.VersionID != "" && opts.VersionID != nullVersionID {
-		return oi, VersionNotFound{
-			Bucket:    bucket,
-			Object:    object,
-			VersionID: opts.VersionID,
-		}
-	}
-
 	atomic.AddInt64(&fs.activeIOCount, 1)
 	defer func() {
 		atomic.AddInt64(&fs.activeIOCount, -1)
 	}()
 
+	if opts.VersionID != "" {
+		oi, err := fs.getObjectVersionInfoWithLock(ctx, bucket, object, opts.VersionID)
+		return oi, toObjectErr(err, bucket, object, opts.VersionID)
+	}
+
 	oi, err := fs.getObjectInfoWithLock(ctx, bucket, object)
 	if err == errCorruptedFormat || err == io.EOF {
 		lk := fs.NewNSLock(bucket, object)
@@ -1076,6 +1090,11 @@ func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, o
 
 		oi, err = fs.getObjectInfoWithLock(ctx, bucket, object)
 	}
+	if err == errFileNotFound {
+		if dm, ok := fs.latestDeleteMarker(ctx, bucket, object); ok {
+			return dm, toObjectErr(err, bucket, object)
+		}
+	}
 	return oi, toObjectErr(err, bucket, object)
 }
 
@@ -1104,13 +1123,12 @@ func (fs *FSObjects) parentDirIsObject(ctx context.Context, bucket, parent strin
 // Additionally writes `fs.json` which carries the necessary metadata
 // for future object operations.
 func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	if opts.Versioned {
-		return objInfo, NotImplemented{}
-	}
-
 	if err := checkPutObjectArgs(ctx, bucket, object, fs); err != nil {
 		return ObjectInfo{}, err
 	}
+	if isFSReservedObjectName(object) {
+		return ObjectInfo{}, ObjectNameInvalid{Bucket: bucket, Object: object}
+	}
 
 	// Lock the object.
 	lk := fs.NewNSLock(bucket, object)
@@ -1222,6 +1240,9 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	if cs := r.ContentChecksum(); cs != nil {
+		fsMeta.Meta[objectChecksumKey] = cs.String()
+	}
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
@@ -1229,6 +1250,13 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, IncompleteBody{Bucket: bucket, Object: object}
 	}
 
+	if bucket != minioMetaBucket {
+		// Move away the current version if it has to be kept.
+		if fsMeta.VersionID, err = fs.prepareVersionedWrite(ctx, bucket, object, wlk, opts); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+
 	// Entire object was written to the temp location, now it's safe to rename it to the actual location.
 	fsNSObjPath := pathJoin(fs.fsPath, bucket, object)
 	if err = fsRenameFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
@@ -1259,18 +1287,21 @@ func (fs *FSObjects) DeleteObjects(ctx context.Context, bucket string, objects [
 	errs := make([]error, len(objects))
 	dobjects := make([]DeletedObject, len(objects))
 	for idx, object := range objects {
-		if object.VersionID != "" {
-			errs[idx] = VersionNotFound{
-				Bucket:    bucket,
-				Object:    object.ObjectName,
-				VersionID: object.VersionID,
-			}
-			continue
-		}
-		_, errs[idx] = fs.DeleteObject(ctx, bucket, object.ObjectName, opts)
+		objOpts := opts
+		objOpts.VersionID = object.VersionID
+		var objInfo ObjectInfo
+		objInfo, errs[idx] = fs.DeleteObject(ctx, bucket, object.ObjectName, objOpts)
 		if errs[idx] == nil || isErrObjectNotFound(errs[idx]) {
 			dobjects[idx] = DeletedObject{
 				ObjectName: object.ObjectName,
+				VersionID:  object.VersionID,
+			}
+			if objInfo.DeleteMarker {
+				dobjects[idx].DeleteMarker = true
+				if object.VersionID == "" {
+					dobjects[idx].DeleteMarkerVersionID = objInfo.VersionID
+					dobjects[idx].DeleteMarkerMTime = DeleteMarkerMTime{objInfo.ModTime}
+				}
 			}
 			errs[idx] = nil
 		}
@@ -1281,14 +1312,6 @@ func (fs *FSObjects) DeleteObjects(ctx context.Context, bucket string, objects [
 // DeleteObject - deletes an object from a bucket, this operation is destructive
 // and there are no rollbacks supported.
 func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	if opts.VersionID != "" && opts.VersionID != nullVersionID {
-		return objInfo, VersionNotFound{
-			Bucket:    bucket,
-			Object:    object,
-			VersionID: opts.VersionID,
-		}
-	}
-
 	// Acquire a write lock before deleting the object.
 	lk := fs.NewNSLock(bucket, object)
 	ctx, err = lk.GetLock(ctx, globalOperationTimeout)
@@ -1312,6 +1335,12 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string, op
 		return objInfo, toObjectErr(err, bucket)
 	}
 
+	if bucket != minioMetaBucket && (opts.Versioned || opts.VersionSuspended ||
+		(opts.VersionID != "" && opts.VersionID != nullVersionID)) {
+		objInfo, err = fs.deleteObjectVersion(ctx, bucket, object, opts)
+		return objInfo, toObjectErr(err, bucket, object, opts.VersionID)
+	}
+
 	var rwlk *lock.LockedFile
 
 	minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
@@ -1460,11 +1489,6 @@ func (fs *FSObjects) getObjectETag(ctx context.Context, bucket, entry string, lo
 	return extractETag(fsMeta.Meta), nil
 }
 
-// ListObjectVersions not implemented for FS mode.
-func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (loi ListObjectVersionsInfo, e error) {
-	return loi, NotImplemented{}
-}
-
 // ListObjects - list all objects at prefix upto maxKeys., optionally delimited by '/'. Maintains the list pool
 // state for future re-entrant list requests.
 func (fs *FSObjects) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, e error) {
@@ -1479,14 +1503,7 @@ func (fs *FSObjects) ListObjects(ctx context.Context, bucket, prefix, marker, de
 
 // GetObjectTags - get object tags from an existing object
 func (fs *FSObjects) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tags.Tags, error) {
-	if opts.VersionID != "" && opts.VersionID != nullVersionID {
-		return nil, VersionNotFound{
-			Bucket:    bucket,
-			Object:    object,
-			VersionID: opts.VersionID,
-		}
-	}
-	oi, err := fs.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	oi, err := fs.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: opts.VersionID})
 	if err != nil {
 		return nil, err
 	}
@@ -1496,11 +1513,9 @@ func (fs *FSObjects) GetObjectTags(ctx context.Context, bucket, object string, o
 
 // PutObjectTags - replace or add tags to an existing object
 func (fs *FSObjects) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) (ObjectInfo, error) {
-	if opts.VersionID != "" && opts.VersionID != nullVersionID {
-		return ObjectInfo{}, VersionNotFound{
-			Bucket:    bucket,
-			Object:    object,
-			VersionID: opts.VersionID,
+	if opts.VersionID != "" {
+		if oi, ok, err := fs.putVersionTags(ctx, bucket, object, tags, opts.VersionID); ok || err != nil {
+			return oi, toObjectErr(err, bucket, object, opts.VersionID)
 		}
 	}
 
@@ -1549,6 +1564,69 @@ func (fs *FSObjects) DeleteObjectTags(ctx context.Context, bucket, object string
 	return fs.PutObjectTags(ctx, bucket, object, "", opts)
 }
 
+// PutObjectMetadata - replace or add metadata to an existing object/version
+func (fs *FSObjects) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	// Lock the object before updating metadata.
+	lk := fs.NewNSLock(bucket, object)
+	ctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer lk.Unlock()
+
+	v, err := fs.getVersion(ctx, bucket, object, opts.VersionID)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object, opts.VersionID)
+	}
+	if v.meta.DeleteMarker {
+		if opts.VersionID == "" {
+			return ObjectInfo{}, toObjectErr(errFileNotFound, bucket, object)
+		}
+		return ObjectInfo{}, toObjectErr(errMethodNotAllowed, bucket, object)
+	}
+
+	if v.meta.Meta == nil {
+		v.meta.Meta = make(map[string]string)
+	}
+	for k, val := range opts.UserDefined {
+		v.meta.Meta[k] = val
+	}
+	if opts.EvalMetadataFn != nil {
+		oi := v.toObjectInfo(bucket, object)
+		oi.IsLatest = v.current
+		if err = opts.EvalMetadataFn(oi, v.meta.Meta); err != nil {
+			return ObjectInfo{}, err
+		}
+	}
+
+	if !v.current {
+		if err = fs.writeVersionMeta(ctx, bucket, object, v.meta); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+		return v.toObjectInfo(bucket, object), nil
+	}
+
+	fsMetaPath := pathJoin(fs.getObjectMetaDir(bucket, object), fs.metaJSONFile)
+	wlk, err := fs.rwPool.Write(fsMetaPath)
+	if err != nil {
+		wlk, err = fs.rwPool.Create(fsMetaPath)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	if _, err = v.meta.WriteTo(wlk); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	oi := v.toObjectInfo(bucket, object)
+	oi.IsLatest = true
+	return oi, nil
+}
+
 // HealFormat - no-op for fs, Valid only for Erasure.
 func (fs *FSObjects) HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error) {
 	return madmin.HealResultItem{}, NotImplemented{}
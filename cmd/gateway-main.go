@@ -309,6 +309,9 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 			logger.Fatal(err, "Unable to list buckets")
 		}
 		logger.FatalIf(globalNotificationSys.Init(GlobalContext, buckets, newObject), "Unable to initialize notification system")
+
+		// Server access logs are only supported by NAS gateway.
+		initBucketAccessLog(GlobalContext, newObject)
 	}
 
 	if globalEtcdClient != nil {
@@ -103,6 +103,18 @@ const (
 	// Maximum size of default bucket encryption configuration allowed
 	maxBucketSSEConfigSize = 1 * humanize.MiByte
 
+	// Maximum size of bucket CORS configuration allowed
+	maxBucketCorsConfigSize = 64 * humanize.KiByte
+
+	// Maximum size of bucket website configuration allowed
+	maxBucketWebsiteConfigSize = 64 * humanize.KiByte
+
+	// Maximum size of bucket server access logging configuration allowed
+	maxBucketLoggingConfigSize = 16 * humanize.KiByte
+
+	// Maximum size of a bucket inventory configuration allowed
+	maxBucketInventoryConfigSize = 16 * humanize.KiByte
+
 	// diskFillFraction is the fraction of a disk we allow to be filled.
 	diskFillFraction = 0.95
 )
@@ -161,6 +173,9 @@ var (
 	globalLifecycleSys       *LifecycleSys
 	globalBucketSSEConfigSys *BucketSSEConfigSys
 	globalBucketTargetSys    *BucketTargetSys
+	globalTierConfigMgr      *TierConfigMgr
+	globalSiteReplicationSys *SiteReplicationSys
+	globalBatchJobPool       *BatchJobPool
 	// globalAPIConfig controls S3 API requests throttling,
 	// healthcheck readiness deadlines and cors settings.
 	globalAPIConfig = apiConfig{listQuorum: 3}
@@ -205,6 +220,9 @@ var (
 	// Global HTTP request statisitics
 	globalHTTPStats = newHTTPStats()
 
+	// Global HTTP request statistics per bucket
+	globalBucketHTTPStats = newBucketHTTPStats()
+
 	// Time when the server is started
 	globalBootTime = UTCNow()
 
@@ -252,6 +270,10 @@ var (
 	// configuration must be present.
 	globalAutoEncryption bool
 
+	// Auto-creation of the KMS key referenced by a bucket SSE-KMS
+	// default encryption configuration, if it does not exist.
+	globalKMSAutoCreateBucketKey bool
+
 	// Is compression enabled?
 	globalCompressConfigMu sync.Mutex
 	globalCompressConfig   compress.Config
@@ -38,6 +38,8 @@ type apiConfig struct {
 	// total drives per erasure set across pools.
 	totalDriveCount    int
 	replicationWorkers int
+	bucketMetrics      bool
+	bucketMetricsMax   int
 }
 
 func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
@@ -85,6 +87,8 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 		globalReplicationPool.Resize(cfg.ReplicationWorkers)
 	}
 	t.replicationWorkers = cfg.ReplicationWorkers
+	t.bucketMetrics = cfg.BucketMetrics
+	t.bucketMetricsMax = cfg.BucketMetricsMax
 }
 
 func (t *apiConfig) getListQuorum() int {
@@ -171,3 +175,12 @@ func (t *apiConfig) getReplicationWorkers() int {
 
 	return t.replicationWorkers
 }
+
+// getBucketMetrics returns if the S3 API calls are reported per bucket
+// and the maximum number of buckets reported.
+func (t *apiConfig) getBucketMetrics() (bool, int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.bucketMetrics, t.bucketMetricsMax
+}
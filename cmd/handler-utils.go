@@ -31,7 +31,9 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/gorilla/mux"
 	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/http/stats"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/handlers"
@@ -365,26 +367,26 @@ func extractPostPolicyFormValues(ctx context.Context, form *multipart.Form) (fil
 
 // Log headers and body.
 func httpTraceAll(f http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
+	return httpTraceSpan(f, func(w http.ResponseWriter, r *http.Request) {
 		if globalTrace.NumSubscribers() == 0 {
 			f.ServeHTTP(w, r)
 			return
 		}
 		trace := Trace(f, true, w, r)
 		globalTrace.Publish(trace)
-	}
+	})
 }
 
 // Log only the headers.
 func httpTraceHdrs(f http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
+	return httpTraceSpan(f, func(w http.ResponseWriter, r *http.Request) {
 		if globalTrace.NumSubscribers() == 0 {
 			f.ServeHTTP(w, r)
 			return
 		}
 		trace := Trace(f, false, w, r)
 		globalTrace.Publish(trace)
-	}
+	})
 }
 
 func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
@@ -394,9 +396,23 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 
 		statsWriter := logger.NewResponseWriter(w)
 
+		bucketMetrics, bucketMetricsMax := globalAPIConfig.getBucketMetrics()
+		var meteredRequest *stats.IncomingTrafficMeter
+		if bucketMetrics {
+			meteredRequest = &stats.IncomingTrafficMeter{ReadCloser: r.Body}
+			r.Body = meteredRequest
+		}
+
 		f.ServeHTTP(statsWriter, r)
 
 		globalHTTPStats.updateStats(api, r, statsWriter)
+		if bucketMetrics {
+			bucket := mux.Vars(r)["bucket"]
+			if bucket != "" && isBucketHTTPStatsRecorded(bucket, statsWriter.StatusCode) {
+				globalBucketHTTPStats.updateStats(bucket, api, bucketMetricsMax,
+					meteredRequest.BytesCount(), statsWriter)
+			}
+		}
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/minio/minio/cmd/logger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -204,3 +205,114 @@ func (st *HTTPStats) updateStats(api string, r *http.Request, w *logger.Response
 func newHTTPStats() *HTTPStats {
 	return &HTTPStats{}
 }
+
+// BucketHTTPAPIStats holds the statistics of the calls of a
+// given API on a bucket.
+type BucketHTTPAPIStats struct {
+	Requests      uint64
+	Errors        uint64
+	Canceled      uint64
+	ReceivedBytes uint64
+	SentBytes     uint64
+	// Number of requests per bucket of s3TTFBBuckets, the
+	// last one counts the requests above the upper bound.
+	TTFB []uint64
+}
+
+// BucketHTTPStats holds the statistics of the S3 API calls
+// per bucket, the number of buckets is bounded by the limit
+// passed to updateStats.
+type BucketHTTPStats struct {
+	sync.Mutex
+	buckets map[string]map[string]*BucketHTTPAPIStats
+}
+
+// Update statistics of the bucket from http request and response data,
+// the calls are not recorded if limit buckets are already recorded.
+func (st *BucketHTTPStats) updateStats(bucket, api string, limit int, receivedBytes int, w *logger.ResponseWriter) {
+	st.Lock()
+	defer st.Unlock()
+
+	apiStats, ok := st.buckets[bucket]
+	if !ok {
+		if len(st.buckets) >= limit {
+			return
+		}
+		apiStats = make(map[string]*BucketHTTPAPIStats)
+		st.buckets[bucket] = apiStats
+	}
+	stats, ok := apiStats[api]
+	if !ok {
+		stats = &BucketHTTPAPIStats{
+			TTFB: make([]uint64, len(s3TTFBBuckets)+1),
+		}
+		apiStats[api] = stats
+	}
+
+	stats.Requests++
+	switch {
+	case w.StatusCode == 0, w.StatusCode >= 200 && w.StatusCode < 300:
+	case w.StatusCode == 499:
+		// 499 is a good error, shall be counted at canceled.
+		stats.Canceled++
+	default:
+		stats.Errors++
+	}
+	stats.ReceivedBytes += uint64(receivedBytes)
+	stats.SentBytes += uint64(w.Size())
+
+	i := 0
+	for i < len(s3TTFBBuckets) && w.TimeToFirstByte > time.Duration(s3TTFBBuckets[i]*float64(time.Second)) {
+		i++
+	}
+	stats.TTFB[i]++
+}
+
+// isBucketHTTPStatsRecorded returns whether a call on the bucket with the
+// given response status is recorded in the per bucket statistics. Calls on
+// buckets which do not exist and calls which failed authentication are not
+// recorded, such that requests on made up bucket names cannot exhaust the
+// bounded number of recorded buckets.
+func isBucketHTTPStatsRecorded(bucket string, statusCode int) bool {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false
+	}
+	if globalBucketMetadataSys == nil {
+		return false
+	}
+	_, err := globalBucketMetadataSys.Get(bucket)
+	return err == nil
+}
+
+// Load returns a copy of the recorded stats per bucket and API.
+func (st *BucketHTTPStats) Load() map[string]map[string]BucketHTTPAPIStats {
+	st.Lock()
+	defer st.Unlock()
+
+	buckets := make(map[string]map[string]BucketHTTPAPIStats, len(st.buckets))
+	for bucket, apiStats := range st.buckets {
+		buckets[bucket] = make(map[string]BucketHTTPAPIStats, len(apiStats))
+		for api, stats := range apiStats {
+			s := *stats
+			s.TTFB = append([]uint64(nil), stats.TTFB...)
+			buckets[bucket][api] = s
+		}
+	}
+	return buckets
+}
+
+// Delete removes the stats of a deleted bucket.
+func (st *BucketHTTPStats) Delete(bucket string) {
+	st.Lock()
+	defer st.Unlock()
+
+	delete(st.buckets, bucket)
+}
+
+// Prepare new BucketHTTPStats structure
+func newBucketHTTPStats() *BucketHTTPStats {
+	return &BucketHTTPStats{
+		buckets: make(map[string]map[string]*BucketHTTPAPIStats),
+	}
+}
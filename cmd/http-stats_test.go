@@ -0,0 +1,106 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+func TestBucketHTTPStats(t *testing.T) {
+	st := newBucketHTTPStats()
+
+	newWriter := func(statusCode, size int, ttfb time.Duration) *logger.ResponseWriter {
+		w := logger.NewResponseWriter(httptest.NewRecorder())
+		w.WriteHeader(statusCode)
+		w.Write(make([]byte, size))
+		w.TimeToFirstByte = ttfb
+		return w
+	}
+
+	st.updateStats("bucket1", "getobject", 2, 0, newWriter(http.StatusOK, 100, 30*time.Millisecond))
+	st.updateStats("bucket1", "getobject", 2, 0, newWriter(http.StatusNotFound, 10, 300*time.Millisecond))
+	st.updateStats("bucket1", "putobject", 2, 1024, newWriter(499, 0, 20*time.Second))
+	st.updateStats("bucket2", "getobject", 2, 0, newWriter(http.StatusOK, 100, time.Second))
+	// The third bucket exceeds the limit and is not recorded.
+	st.updateStats("bucket3", "getobject", 2, 0, newWriter(http.StatusOK, 100, time.Second))
+
+	stats := st.Load()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(stats))
+	}
+	if _, ok := stats["bucket3"]; ok {
+		t.Fatal("unexpected stats of bucket3")
+	}
+
+	get := stats["bucket1"]["getobject"]
+	if get.Requests != 2 || get.Errors != 1 || get.Canceled != 0 {
+		t.Fatalf("unexpected requests %d, errors %d, canceled %d", get.Requests, get.Errors, get.Canceled)
+	}
+	if get.SentBytes <= 110 {
+		t.Fatalf("expected more than 110 bytes sent, got %d", get.SentBytes)
+	}
+	if get.TTFB[0] != 1 || get.TTFB[3] != 1 {
+		t.Fatalf("unexpected time to first byte distribution %v", get.TTFB)
+	}
+
+	put := stats["bucket1"]["putobject"]
+	if put.Canceled != 1 || put.Errors != 0 || put.ReceivedBytes != 1024 {
+		t.Fatalf("unexpected canceled %d, errors %d, received bytes %d", put.Canceled, put.Errors, put.ReceivedBytes)
+	}
+	if put.TTFB[len(s3TTFBBuckets)] != 1 {
+		t.Fatalf("expected the request above the last bucket, got %v", put.TTFB)
+	}
+
+	// Deleted buckets make room for new ones.
+	st.Delete("bucket2")
+	st.updateStats("bucket3", "getobject", 2, 0, newWriter(http.StatusOK, 100, time.Second))
+	if _, ok := st.Load()["bucket3"]; !ok {
+		t.Fatal("expected stats of bucket3")
+	}
+}
+
+func TestIsBucketHTTPStatsRecorded(t *testing.T) {
+	savedBucketMetadataSys := globalBucketMetadataSys
+	defer func() { globalBucketMetadataSys = savedBucketMetadataSys }()
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalBucketMetadataSys.Set("bucket", newBucketMetadata("bucket"))
+
+	testCases := []struct {
+		bucket     string
+		statusCode int
+		recorded   bool
+	}{
+		{"bucket", http.StatusOK, true},
+		{"bucket", http.StatusNotFound, true},
+		{"bucket", 0, true},
+		{"bucket", http.StatusForbidden, false},
+		{"bucket", http.StatusUnauthorized, false},
+		{"made-up-bucket", http.StatusOK, false},
+		{"made-up-bucket", http.StatusNotFound, false},
+	}
+	for i, testCase := range testCases {
+		if recorded := isBucketHTTPStatsRecorded(testCase.bucket, testCase.statusCode); recorded != testCase.recorded {
+			t.Errorf("Test %d: expected recorded %t, got %t", i+1, testCase.recorded, recorded)
+		}
+	}
+}
@@ -41,6 +41,20 @@ const (
 	Range              = "Range"
 )
 
+// Standard CORS HTTP constants
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+)
+
 // Non standard S3 HTTP response constants
 const (
 	XCache       = "X-Cache"
@@ -99,6 +113,15 @@ const (
 	// Dummy putBucketACL
 	AmzACL = "x-amz-acl"
 
+	// Content checksums
+	AmzChecksumAlgo      = "x-amz-checksum-algorithm"
+	AmzChecksumMode      = "x-amz-checksum-mode"
+	AmzTrailer           = "x-amz-trailer"
+	AmzObjectAttributes  = "x-amz-object-attributes"
+	AmzMaxParts          = "x-amz-max-parts"
+	AmzPartNumberMarker  = "x-amz-part-number-marker"
+	AmzChecksumModeValue = "ENABLED"
+
 	// Signature V4 related contants.
 	AmzContentSha256        = "X-Amz-Content-Sha256"
 	AmzDate                 = "X-Amz-Date"
@@ -165,6 +188,8 @@ const (
 	MinIODeleteReplicationStatus = "X-Minio-Replication-Delete-Status"
 	// Header indicates delete-marker replication status.
 	MinIODeleteMarkerReplicationStatus = "X-Minio-Replication-DeleteMarker-Status"
+	// Header indicates the replication status of an object to each replication target.
+	MinIOReplicationTargetStatus = "X-Minio-Replication-Target-Status"
 	// Header indicates if its a GET/HEAD proxy request for active-active replication
 	MinIOSourceProxyRequest = "X-Minio-Source-Proxy-Request"
 	// Header indicates that this request is a replication request to create a REPLICA
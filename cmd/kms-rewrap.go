@@ -0,0 +1,317 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/console"
+)
+
+// kmsRewrapConfigPath is the path to the KMS re-wrap rules, relative to
+// the minio meta bucket.
+var kmsRewrapConfigPath = path.Join(minioConfigPrefix, "kms", "rewrap.json")
+
+// errKMSRewrapRuleNotFound is returned when removing a re-wrap rule
+// that does not exist.
+var errKMSRewrapRuleNotFound = AdminError{
+	Code:       "XMinioAdminNoSuchKMSRewrapRule",
+	Message:    "no re-wrap rule for the given key ID",
+	StatusCode: http.StatusNotFound,
+}
+
+// kmsRewrapRule re-wraps the data keys of all objects sealed under KeyID.
+// They are sealed under NewKeyID or, if it is empty, under the latest
+// version of KeyID. Only the sealed keys are replaced, the object data
+// is never rewritten.
+type kmsRewrapRule struct {
+	KeyID    string    `json:"keyID"`
+	NewKeyID string    `json:"newKeyID,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+// TargetKeyID returns the key ID objects are re-wrapped under.
+func (r kmsRewrapRule) TargetKeyID() string {
+	if r.NewKeyID != "" {
+		return r.NewKeyID
+	}
+	return r.KeyID
+}
+
+// kmsRewrapConfig is the set of re-wrap rules, at most one per key ID.
+type kmsRewrapConfig struct {
+	Rules []kmsRewrapRule `json:"rules"`
+}
+
+// Set adds the rule, replacing any rule for the same key ID.
+func (c *kmsRewrapConfig) Set(rule kmsRewrapRule) {
+	for i := range c.Rules {
+		if c.Rules[i].KeyID == rule.KeyID {
+			c.Rules[i] = rule
+			return
+		}
+	}
+	c.Rules = append(c.Rules, rule)
+}
+
+// Remove removes the rule for keyID and reports whether there was one.
+func (c *kmsRewrapConfig) Remove(keyID string) bool {
+	for i := range c.Rules {
+		if c.Rules[i].KeyID == keyID {
+			c.Rules = append(c.Rules[:i], c.Rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// loadKMSRewrapConfig loads the re-wrap rules saved in the backend,
+// returning an empty configuration if none were saved.
+func loadKMSRewrapConfig(ctx context.Context, objAPI ObjectLayer) (*kmsRewrapConfig, error) {
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	data, err := readConfig(ctx, objAPI, kmsRewrapConfigPath)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return &kmsRewrapConfig{}, nil
+		}
+		return nil, err
+	}
+
+	config := &kmsRewrapConfig{}
+	if err = json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// saveKMSRewrapConfig saves the re-wrap rules to the backend.
+func saveKMSRewrapConfig(ctx context.Context, objAPI ObjectLayer, config *kmsRewrapConfig) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, kmsRewrapConfigPath, data)
+}
+
+// updateKMSRewrapConfig applies fn to the saved re-wrap rules while
+// holding a cluster wide lock and saves the result.
+func updateKMSRewrapConfig(ctx context.Context, objAPI ObjectLayer, fn func(*kmsRewrapConfig) error) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	lk := objAPI.NewNSLock(minioMetaBucket, kmsRewrapConfigPath+".lock")
+	ctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	config, err := loadKMSRewrapConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if err = fn(config); err != nil {
+		return err
+	}
+	if err = saveKMSRewrapConfig(ctx, objAPI, config); err != nil {
+		return err
+	}
+	globalKMSRewrapCache.update(nil) // Pick up the new rules on this node right away
+	return nil
+}
+
+// kmsRewrapTarget is the key, and its latest version, objects sealed
+// under a particular key ID are re-wrapped under.
+type kmsRewrapTarget struct {
+	KeyID   string
+	Version int
+}
+
+// kmsRewrapTargets maps key IDs to the key their objects are re-wrapped
+// under.
+type kmsRewrapTargets map[string]kmsRewrapTarget
+
+// Target returns the key the object key sealed in metadata has to be
+// re-wrapped under, if any.
+func (t kmsRewrapTargets) Target(metadata map[string]string) (kmsRewrapTarget, bool) {
+	if len(t) == 0 {
+		return kmsRewrapTarget{}, false
+	}
+	if !crypto.S3.IsEncrypted(metadata) && !crypto.S3KMS.IsEncrypted(metadata) {
+		return kmsRewrapTarget{}, false
+	}
+	keyID, ok := metadata[crypto.MetaKeyID]
+	if !ok {
+		return kmsRewrapTarget{}, false
+	}
+	target, ok := t[keyID]
+	if !ok {
+		return kmsRewrapTarget{}, false
+	}
+	if target.KeyID != keyID {
+		return target, true
+	}
+	// Re-wrapping under the same key is only required if the KMS
+	// tracks versions and the object key is sealed under an older one.
+	if target.Version > 0 && crypto.KeyVersion(metadata) < target.Version {
+		return target, true
+	}
+	return kmsRewrapTarget{}, false
+}
+
+// globalKMSRewrapCache caches the re-wrap rules, along with the latest
+// versions of their target keys, for the data scanner. Rules added on
+// other nodes are picked up once the cached value expires.
+var globalKMSRewrapCache = &timedValue{
+	TTL: time.Minute,
+	Update: func() (interface{}, error) {
+		ctx := GlobalContext
+		objAPI := newObjectLayerFn()
+		if objAPI == nil || GlobalKMS == nil {
+			return kmsRewrapTargets{}, nil
+		}
+		config, err := loadKMSRewrapConfig(ctx, objAPI)
+		if err != nil {
+			// Retry once the empty value expires, rather than
+			// reading the config for every scanned object.
+			logger.LogIf(ctx, err)
+			return kmsRewrapTargets{}, nil
+		}
+
+		targets := make(kmsRewrapTargets, len(config.Rules))
+		for _, rule := range config.Rules {
+			status, err := GlobalKMS.KeyStatus(rule.TargetKeyID())
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			targets[rule.KeyID] = kmsRewrapTarget{
+				KeyID:   rule.TargetKeyID(),
+				Version: status.Version(),
+			}
+		}
+		return targets, nil
+	},
+}
+
+// cachedKMSRewrapTargets returns the cached re-wrap targets.
+func cachedKMSRewrapTargets() kmsRewrapTargets {
+	v, err := globalKMSRewrapCache.Get()
+	if err != nil {
+		return nil
+	}
+	targets, _ := v.(kmsRewrapTargets)
+	return targets
+}
+
+// rewrapObjectKey unseals the SSE-S3 or SSE-KMS object key in metadata
+// and seals it again under a data key generated with keyID. The object
+// key, and hence the object data, remains unchanged.
+func rewrapObjectKey(metadata map[string]string, bucket, object, keyID string) error {
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+
+	if crypto.S3.IsEncrypted(metadata) {
+		objectKey, err := crypto.S3.UnsealObjectKey(GlobalKMS, metadata, bucket, object)
+		if err != nil {
+			return err
+		}
+		newKey, err := GlobalKMS.GenerateKey(keyID, crypto.Context{bucket: path.Join(bucket, object)})
+		if err != nil {
+			return err
+		}
+		sealedKey := objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3.String(), bucket, object)
+		crypto.S3.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey)
+		crypto.SetKeyVersion(metadata, newKey.Version)
+		return nil
+	}
+
+	// The object key is sealed under the context it was created with,
+	// so the context stored in the metadata remains valid.
+	_, _, _, kmsCtx, err := crypto.S3KMS.ParseMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	objectKey, err := crypto.S3KMS.UnsealObjectKey(GlobalKMS, metadata, bucket, object)
+	if err != nil {
+		return err
+	}
+	if kmsCtx == nil {
+		kmsCtx = crypto.Context{}
+	}
+	if _, ok := kmsCtx[bucket]; !ok {
+		kmsCtx[bucket] = path.Join(bucket, object)
+	}
+	newKey, err := GlobalKMS.GenerateKey(keyID, kmsCtx)
+	if err != nil {
+		return err
+	}
+	sealedKey := objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), bucket, object)
+	crypto.S3KMS.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey)
+	crypto.SetKeyVersion(metadata, newKey.Version)
+	return nil
+}
+
+// applyKeyRewrap re-wraps the object key of a scanned object version if
+// a re-wrap rule matches the key it is sealed under.
+func (i *scannerItem) applyKeyRewrap(ctx context.Context, o ObjectLayer, meta actionMeta) {
+	if meta.oi.DeleteMarker || GlobalKMS == nil {
+		return
+	}
+	target, ok := cachedKMSRewrapTargets().Target(meta.oi.UserDefined)
+	if !ok {
+		return
+	}
+	if i.debug {
+		console.Debugf(applyActionsLogPrefix+" kms: re-wrapping %v/%v v(%s) under %s\n", i.bucket, i.objectPath(), meta.oi.VersionID, target.KeyID)
+	}
+
+	oi := meta.oi
+	_, err := o.PutObjectMetadata(ctx, oi.Bucket, oi.Name, ObjectOptions{
+		VersionID: oi.VersionID,
+		MTime:     oi.ModTime,
+		EvalMetadataFn: func(cur ObjectInfo, metadata map[string]string) error {
+			if !cur.ModTime.Equal(oi.ModTime) {
+				return PreConditionFailed{}
+			}
+			if _, ok := cachedKMSRewrapTargets().Target(metadata); !ok {
+				return PreConditionFailed{} // Re-wrapped concurrently
+			}
+			return rewrapObjectKey(metadata, oi.Bucket, oi.Name, target.KeyID)
+		},
+	})
+	if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) && !errors.Is(err, PreConditionFailed{}) {
+		logger.LogIf(ctx, err)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"path"
+	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/kms"
+)
+
+func TestKMSRewrapTargets(t *testing.T) {
+	targets := kmsRewrapTargets{
+		"old-key":     {KeyID: "new-key", Version: 0},
+		"rotated-key": {KeyID: "rotated-key", Version: 2},
+		"static-key":  {KeyID: "static-key", Version: 0},
+	}
+	sealed := func(keyID string, version int) map[string]string {
+		metadata := map[string]string{
+			crypto.MetaSealedKeyS3: "sealed",
+			crypto.MetaKeyID:       keyID,
+		}
+		crypto.SetKeyVersion(metadata, version)
+		return metadata
+	}
+
+	testCases := []struct {
+		metadata map[string]string
+		keyID    string
+		ok       bool
+	}{
+		{metadata: map[string]string{}, ok: false},
+		{metadata: map[string]string{crypto.MetaKeyID: "old-key"}, ok: false},
+		{metadata: sealed("unknown-key", 0), ok: false},
+		{metadata: sealed("old-key", 0), keyID: "new-key", ok: true},
+		{metadata: sealed("old-key", 5), keyID: "new-key", ok: true},
+		{metadata: sealed("rotated-key", 0), keyID: "rotated-key", ok: true},
+		{metadata: sealed("rotated-key", 1), keyID: "rotated-key", ok: true},
+		{metadata: sealed("rotated-key", 2), ok: false},
+		{metadata: sealed("static-key", 0), ok: false},
+	}
+	for i, testCase := range testCases {
+		target, ok := targets.Target(testCase.metadata)
+		if ok != testCase.ok {
+			t.Errorf("Test %d: got %v, want %v", i, ok, testCase.ok)
+		}
+		if ok && target.KeyID != testCase.keyID {
+			t.Errorf("Test %d: got key %q, want %q", i, target.KeyID, testCase.keyID)
+		}
+	}
+}
+
+func TestKMSRewrapConfig(t *testing.T) {
+	var config kmsRewrapConfig
+	config.Set(kmsRewrapRule{KeyID: "a"})
+	config.Set(kmsRewrapRule{KeyID: "b", NewKeyID: "c"})
+	config.Set(kmsRewrapRule{KeyID: "a", NewKeyID: "d"})
+	if len(config.Rules) != 2 || config.Rules[0].TargetKeyID() != "d" || config.Rules[1].TargetKeyID() != "c" {
+		t.Fatalf("unexpected rules %v", config.Rules)
+	}
+	if !config.Remove("a") || config.Remove("a") {
+		t.Fatal("expected the rule to be removed exactly once")
+	}
+	if len(config.Rules) != 1 || config.Rules[0].KeyID != "b" {
+		t.Fatalf("unexpected rules %v", config.Rules)
+	}
+}
+
+func TestRewrapObjectKey(t *testing.T) {
+	masterKey := make([]byte, 32)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatal(err)
+	}
+	store, err := kms.NewKeyStore(t.TempDir(), masterKey, "my-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.CreateKey("other-key"); err != nil {
+		t.Fatal(err)
+	}
+	GlobalKMS = store
+	defer func() { GlobalKMS = nil }()
+
+	bucket, object := "secrets", "object"
+	kmsCtx := crypto.Context{"project": "alpha", bucket: path.Join(bucket, object)}
+	key, err := store.GenerateKey("my-key", kmsCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectKey := crypto.GenerateKey(key.Plaintext, rand.Reader)
+	sealedKey := objectKey.Seal(key.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), bucket, object)
+	metadata := crypto.S3KMS.CreateMetadata(nil, key.KeyID, key.Ciphertext, sealedKey)
+	crypto.SetKeyVersion(metadata, key.Version)
+	b, err := kmsCtx.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata[crypto.MetaContext] = base64.StdEncoding.EncodeToString(b)
+
+	if err = store.RotateKey("my-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err = rewrapObjectKey(metadata, bucket, object, "my-key"); err != nil {
+		t.Fatal(err)
+	}
+	if v := crypto.KeyVersion(metadata); v != 2 {
+		t.Fatalf("got key version %d, want 2", v)
+	}
+
+	if err = rewrapObjectKey(metadata, bucket, object, "other-key"); err != nil {
+		t.Fatal(err)
+	}
+	if metadata[crypto.MetaKeyID] != "other-key" {
+		t.Fatalf("got key ID %q, want other-key", metadata[crypto.MetaKeyID])
+	}
+	_, _, _, gotCtx, err := crypto.S3KMS.ParseMetadata(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotCtx["project"] != "alpha" {
+		t.Fatalf("the KMS context was not preserved: %v", gotCtx)
+	}
+	unsealedKey, err := crypto.S3KMS.UnsealObjectKey(GlobalKMS, metadata, bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unsealedKey[:], objectKey[:]) {
+		t.Fatal("the re-wrapped object key does not match")
+	}
+}
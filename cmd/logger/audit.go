@@ -125,8 +125,12 @@ func (lrw *ResponseWriter) Size() int {
 
 // AuditLog - logs audit logs to all audit targets.
 func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqClaims map[string]interface{}, filterKeys ...string) {
+	reqInfo := GetReqInfo(ctx)
+	accessLog := AccessLog != nil && reqInfo != nil && AccessLog.Enabled(reqInfo.BucketName)
+
 	// Fast exit if there is not audit target configured
-	if len(AuditTargets) == 0 {
+	// nor server access logging enabled for the bucket
+	if len(AuditTargets) == 0 && !accessLog {
 		return
 	}
 
@@ -143,7 +147,6 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 		timeToFirstByte = st.TimeToFirstByte
 	}
 
-	reqInfo := GetReqInfo(ctx)
 	if reqInfo == nil {
 		return
 	}
@@ -167,6 +170,10 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 		entry.API.TimeToFirstByte = strconv.FormatInt(timeToFirstByte.Nanoseconds(), 10) + "ns"
 	}
 
+	if accessLog {
+		AccessLog.Send(ctx, entry, r)
+	}
+
 	// Send audit logs only to http targets.
 	for _, t := range AuditTargets {
 		_ = t.Send(entry, string(All))
@@ -17,10 +17,16 @@
 package logger
 
 import (
+	"crypto/tls"
+	"errors"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/logger/target/kafka"
 	"github.com/minio/minio/pkg/env"
+	xnet "github.com/minio/minio/pkg/net"
 )
 
 // Console logger target
@@ -35,13 +41,16 @@ type HTTP struct {
 	AuthToken  string `json:"authToken"`
 	ClientCert string `json:"clientCert"`
 	ClientKey  string `json:"clientKey"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
 }
 
-// Config console and http logger targets
+// Config console, http and kafka logger targets
 type Config struct {
-	Console Console         `json:"console"`
-	HTTP    map[string]HTTP `json:"http"`
-	Audit   map[string]HTTP `json:"audit"`
+	Console    Console                 `json:"console"`
+	HTTP       map[string]HTTP         `json:"http"`
+	Audit      map[string]HTTP         `json:"audit"`
+	AuditKafka map[string]kafka.Config `json:"auditKafka"`
 }
 
 // HTTP endpoint logger
@@ -50,16 +59,54 @@ const (
 	AuthToken  = "auth_token"
 	ClientCert = "client_cert"
 	ClientKey  = "client_key"
+	QueueDir   = "queue_dir"
+	QueueLimit = "queue_limit"
 
-	EnvLoggerWebhookEnable    = "MINIO_LOGGER_WEBHOOK_ENABLE"
-	EnvLoggerWebhookEndpoint  = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
-	EnvLoggerWebhookAuthToken = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
+	EnvLoggerWebhookEnable     = "MINIO_LOGGER_WEBHOOK_ENABLE"
+	EnvLoggerWebhookEndpoint   = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
+	EnvLoggerWebhookAuthToken  = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
+	EnvLoggerWebhookQueueDir   = "MINIO_LOGGER_WEBHOOK_QUEUE_DIR"
+	EnvLoggerWebhookQueueLimit = "MINIO_LOGGER_WEBHOOK_QUEUE_LIMIT"
 
 	EnvAuditWebhookEnable     = "MINIO_AUDIT_WEBHOOK_ENABLE"
 	EnvAuditWebhookEndpoint   = "MINIO_AUDIT_WEBHOOK_ENDPOINT"
 	EnvAuditWebhookAuthToken  = "MINIO_AUDIT_WEBHOOK_AUTH_TOKEN"
 	EnvAuditWebhookClientCert = "MINIO_AUDIT_WEBHOOK_CLIENT_CERT"
 	EnvAuditWebhookClientKey  = "MINIO_AUDIT_WEBHOOK_CLIENT_KEY"
+	EnvAuditWebhookQueueDir   = "MINIO_AUDIT_WEBHOOK_QUEUE_DIR"
+	EnvAuditWebhookQueueLimit = "MINIO_AUDIT_WEBHOOK_QUEUE_LIMIT"
+)
+
+// Kafka audit logger
+const (
+	KafkaBrokers       = "brokers"
+	KafkaTopic         = "topic"
+	KafkaTLS           = "tls"
+	KafkaTLSSkipVerify = "tls_skip_verify"
+	KafkaTLSClientAuth = "tls_client_auth"
+	KafkaSASL          = "sasl"
+	KafkaSASLUsername  = "sasl_username"
+	KafkaSASLPassword  = "sasl_password"
+	KafkaSASLMechanism = "sasl_mechanism"
+	KafkaClientTLSCert = "client_tls_cert"
+	KafkaClientTLSKey  = "client_tls_key"
+	KafkaVersion       = "version"
+
+	EnvKafkaEnable        = "MINIO_AUDIT_KAFKA_ENABLE"
+	EnvKafkaBrokers       = "MINIO_AUDIT_KAFKA_BROKERS"
+	EnvKafkaTopic         = "MINIO_AUDIT_KAFKA_TOPIC"
+	EnvKafkaTLS           = "MINIO_AUDIT_KAFKA_TLS"
+	EnvKafkaTLSSkipVerify = "MINIO_AUDIT_KAFKA_TLS_SKIP_VERIFY"
+	EnvKafkaTLSClientAuth = "MINIO_AUDIT_KAFKA_TLS_CLIENT_AUTH"
+	EnvKafkaSASLEnable    = "MINIO_AUDIT_KAFKA_SASL"
+	EnvKafkaSASLUsername  = "MINIO_AUDIT_KAFKA_SASL_USERNAME"
+	EnvKafkaSASLPassword  = "MINIO_AUDIT_KAFKA_SASL_PASSWORD"
+	EnvKafkaSASLMechanism = "MINIO_AUDIT_KAFKA_SASL_MECHANISM"
+	EnvKafkaClientTLSCert = "MINIO_AUDIT_KAFKA_CLIENT_TLS_CERT"
+	EnvKafkaClientTLSKey  = "MINIO_AUDIT_KAFKA_CLIENT_TLS_KEY"
+	EnvKafkaVersion       = "MINIO_AUDIT_KAFKA_VERSION"
+	EnvKafkaQueueDir      = "MINIO_AUDIT_KAFKA_QUEUE_DIR"
+	EnvKafkaQueueLimit    = "MINIO_AUDIT_KAFKA_QUEUE_LIMIT"
 )
 
 // Default KVS for loggerHTTP and loggerAuditHTTP
@@ -77,6 +124,14 @@ var (
 			Key:   AuthToken,
 			Value: "",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
 	}
 	DefaultAuditKVS = config.KVS{
 		config.KV{
@@ -99,9 +154,89 @@ var (
 			Key:   ClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
+	}
+
+	DefaultAuditKafkaKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   KafkaTopic,
+			Value: "",
+		},
+		config.KV{
+			Key:   KafkaBrokers,
+			Value: "",
+		},
+		config.KV{
+			Key:   KafkaSASLUsername,
+			Value: "",
+		},
+		config.KV{
+			Key:   KafkaSASLPassword,
+			Value: "",
+		},
+		config.KV{
+			Key:   KafkaSASLMechanism,
+			Value: "plain",
+		},
+		config.KV{
+			Key:   KafkaClientTLSCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   KafkaClientTLSKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   KafkaTLSClientAuth,
+			Value: "0",
+		},
+		config.KV{
+			Key:   KafkaSASL,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   KafkaTLS,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   KafkaTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   KafkaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
 	}
 )
 
+// parseQueue - validates the queue directory and parses the
+// maximum number of entries queued.
+func parseQueue(queueDir, queueLimit string) (uint64, error) {
+	if queueDir != "" && !filepath.IsAbs(queueDir) {
+		return 0, errors.New("queue_dir path should be absolute")
+	}
+	return strconv.ParseUint(queueLimit, 10, 64)
+}
+
 // NewConfig - initialize new logger config.
 func NewConfig() Config {
 	cfg := Config{
@@ -109,8 +244,9 @@ func NewConfig() Config {
 		Console: Console{
 			Enabled: true,
 		},
-		HTTP:  make(map[string]HTTP),
-		Audit: make(map[string]HTTP),
+		HTTP:       make(map[string]HTTP),
+		Audit:      make(map[string]HTTP),
+		AuditKafka: make(map[string]kafka.Config),
 	}
 
 	// Create an example HTTP logger
@@ -236,10 +372,24 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if target != config.Default {
 			authTokenEnv = EnvLoggerWebhookAuthToken + config.Default + target
 		}
+		queueDirEnv := EnvLoggerWebhookQueueDir
+		if target != config.Default {
+			queueDirEnv = EnvLoggerWebhookQueueDir + config.Default + target
+		}
+		queueLimitEnv := EnvLoggerWebhookQueueLimit
+		if target != config.Default {
+			queueLimitEnv = EnvLoggerWebhookQueueLimit + config.Default + target
+		}
+		queueLimit, err := parseQueue(env.Get(queueDirEnv, ""), env.Get(queueLimitEnv, "0"))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.HTTP[target] = HTTP{
-			Enabled:   true,
-			Endpoint:  env.Get(endpointEnv, ""),
-			AuthToken: env.Get(authTokenEnv, ""),
+			Enabled:    true,
+			Endpoint:   env.Get(endpointEnv, ""),
+			AuthToken:  env.Get(authTokenEnv, ""),
+			QueueDir:   env.Get(queueDirEnv, ""),
+			QueueLimit: queueLimit,
 		}
 	}
 
@@ -277,12 +427,26 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
+		queueDirEnv := EnvAuditWebhookQueueDir
+		if target != config.Default {
+			queueDirEnv = EnvAuditWebhookQueueDir + config.Default + target
+		}
+		queueLimitEnv := EnvAuditWebhookQueueLimit
+		if target != config.Default {
+			queueLimitEnv = EnvAuditWebhookQueueLimit + config.Default + target
+		}
+		queueLimit, err := parseQueue(env.Get(queueDirEnv, ""), env.Get(queueLimitEnv, "0"))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.Audit[target] = HTTP{
 			Enabled:    true,
 			Endpoint:   env.Get(endpointEnv, ""),
 			AuthToken:  env.Get(authTokenEnv, ""),
 			ClientCert: env.Get(clientCertEnv, ""),
 			ClientKey:  env.Get(clientKeyEnv, ""),
+			QueueDir:   env.Get(queueDirEnv, ""),
+			QueueLimit: queueLimit,
 		}
 	}
 
@@ -307,10 +471,16 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if !enabled {
 			continue
 		}
+		queueLimit, err := parseQueue(kv.Get(QueueDir), kv.Get(QueueLimit))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.HTTP[starget] = HTTP{
-			Enabled:   true,
-			Endpoint:  kv.Get(Endpoint),
-			AuthToken: kv.Get(AuthToken),
+			Enabled:    true,
+			Endpoint:   kv.Get(Endpoint),
+			AuthToken:  kv.Get(AuthToken),
+			QueueDir:   kv.Get(QueueDir),
+			QueueLimit: queueLimit,
 		}
 	}
 
@@ -339,14 +509,115 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
+		queueLimit, err := parseQueue(kv.Get(QueueDir), kv.Get(QueueLimit))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.Audit[starget] = HTTP{
 			Enabled:    true,
 			Endpoint:   kv.Get(Endpoint),
 			AuthToken:  kv.Get(AuthToken),
 			ClientCert: kv.Get(ClientCert),
 			ClientKey:  kv.Get(ClientKey),
+			QueueDir:   kv.Get(QueueDir),
+			QueueLimit: queueLimit,
 		}
 	}
 
+	cfg.AuditKafka, err = lookupAuditKafkaConfig(scfg)
+	if err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
+
+// lookupAuditKafkaConfig - lookup the kafka audit targets of the
+// config and the environment, the environment takes precedence.
+func lookupAuditKafkaConfig(scfg config.Config) (map[string]kafka.Config, error) {
+	kafkaTargets := make(map[string]kafka.Config)
+
+	targets := make(map[string]config.KVS)
+	for _, k := range env.List(EnvKafkaEnable) {
+		target := strings.TrimPrefix(k, EnvKafkaEnable+config.Default)
+		if target == EnvKafkaEnable {
+			target = config.Default
+		}
+		targets[target] = DefaultAuditKafkaKVS
+	}
+	for target, kv := range scfg[config.AuditKafkaSubSys] {
+		subSysTarget := config.AuditKafkaSubSys
+		if target != config.Default {
+			subSysTarget = config.AuditKafkaSubSys + config.SubSystemSeparator + target
+		}
+		if err := config.CheckValidKeys(subSysTarget, kv, DefaultAuditKafkaKVS); err != nil {
+			return nil, err
+		}
+		targets[target] = kv
+	}
+
+	for target, kv := range targets {
+		getEnv := func(envName, key string) string {
+			if target != config.Default {
+				envName = envName + config.Default + target
+			}
+			return env.Get(envName, kv.Get(key))
+		}
+
+		enabled, err := config.ParseBool(getEnv(EnvKafkaEnable, config.Enable))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		var brokers []xnet.Host
+		kafkaBrokers := getEnv(EnvKafkaBrokers, KafkaBrokers)
+		if len(kafkaBrokers) == 0 {
+			return nil, config.Errorf("kafka 'brokers' cannot be empty")
+		}
+		for _, s := range strings.Split(kafkaBrokers, config.ValueSeparator) {
+			host, err := xnet.ParseHost(s)
+			if err != nil {
+				return nil, err
+			}
+			brokers = append(brokers, *host)
+		}
+
+		clientAuth, err := strconv.Atoi(getEnv(EnvKafkaTLSClientAuth, KafkaTLSClientAuth))
+		if err != nil {
+			return nil, err
+		}
+
+		queueDir := getEnv(EnvKafkaQueueDir, QueueDir)
+		queueLimit, err := parseQueue(queueDir, getEnv(EnvKafkaQueueLimit, QueueLimit))
+		if err != nil {
+			return nil, err
+		}
+
+		kafkaArgs := kafka.Config{
+			Enabled:    enabled,
+			Brokers:    brokers,
+			Topic:      getEnv(EnvKafkaTopic, KafkaTopic),
+			Version:    getEnv(EnvKafkaVersion, KafkaVersion),
+			QueueDir:   queueDir,
+			QueueLimit: queueLimit,
+		}
+
+		kafkaArgs.TLS.Enable = getEnv(EnvKafkaTLS, KafkaTLS) == config.EnableOn
+		kafkaArgs.TLS.SkipVerify = getEnv(EnvKafkaTLSSkipVerify, KafkaTLSSkipVerify) == config.EnableOn
+		kafkaArgs.TLS.ClientAuth = tls.ClientAuthType(clientAuth)
+		kafkaArgs.TLS.ClientTLSCert = getEnv(EnvKafkaClientTLSCert, KafkaClientTLSCert)
+		kafkaArgs.TLS.ClientTLSKey = getEnv(EnvKafkaClientTLSKey, KafkaClientTLSKey)
+
+		kafkaArgs.SASL.Enable = getEnv(EnvKafkaSASLEnable, KafkaSASL) == config.EnableOn
+		kafkaArgs.SASL.User = getEnv(EnvKafkaSASLUsername, KafkaSASLUsername)
+		kafkaArgs.SASL.Password = getEnv(EnvKafkaSASLPassword, KafkaSASLPassword)
+		kafkaArgs.SASL.Mechanism = getEnv(EnvKafkaSASLMechanism, KafkaSASLMechanism)
+
+		kafkaTargets[target] = kafkaArgs
+	}
+
+	return kafkaTargets, nil
+}
@@ -23,18 +23,25 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/store"
 )
 
+// Extension of the logs in the queue store.
+const logExt = ".log"
+
 // Target implements logger.Target and sends the json
 // format of a log entry to the configured http endpoint.
 // An internal buffer of logs is maintained but when the
 // buffer is full, new logs are just ignored and an error
-// is returned to the caller.
+// is returned to the caller. If a queue directory is
+// configured the logs are persisted there instead, and
+// sent once the endpoint is reachable.
 type Target struct {
 	// Channel of log entries
 	logCh chan interface{}
@@ -48,6 +55,14 @@ type Target struct {
 	userAgent string
 	logKind   string
 	client    http.Client
+
+	// Directory and maximum number of logs of the queue store
+	queueDir   string
+	queueLimit uint64
+	store      store.Store
+
+	// Closed to stop sending the queued logs.
+	doneCh <-chan struct{}
 }
 
 // Endpoint returns the backend endpoint
@@ -61,6 +76,12 @@ func (h *Target) String() string {
 
 // Validate validate the http target
 func (h *Target) Validate() error {
+	if h.queueDir != "" {
+		// The endpoint may be offline, the logs are
+		// queued until it is reachable again.
+		return h.initQueueStore()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
@@ -100,6 +121,47 @@ func (h *Target) Validate() error {
 	return nil
 }
 
+// send - posts the json encoded log entry to the endpoint.
+func (h *Target) send(logJSON []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		h.endpoint, bytes.NewReader(logJSON))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+
+	// Set user-agent to indicate MinIO release
+	// version to the configured log endpoint
+	req.Header.Set("User-Agent", h.userAgent)
+
+	if h.authToken != "" {
+		req.Header.Set("Authorization", h.authToken)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration",
+			h.endpoint, err)
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusForbidden:
+			return fmt.Errorf("%s returned '%s', please check if your auth token is correctly set",
+				h.endpoint, resp.Status)
+		}
+		return fmt.Errorf("%s returned '%s', please check your endpoint configuration",
+			h.endpoint, resp.Status)
+	}
+	return nil
+}
+
 func (h *Target) startHTTPLogger() {
 	// Create a routine which sends json logs received
 	// from an internal channel.
@@ -110,46 +172,42 @@ func (h *Target) startHTTPLogger() {
 				continue
 			}
 
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-				h.endpoint, bytes.NewReader(logJSON))
-			if err != nil {
-				cancel()
-				continue
+			if err = h.send(logJSON); err != nil {
+				logger.LogOnceIf(context.Background(), err, h.endpoint)
 			}
-			req.Header.Set(xhttp.ContentType, "application/json")
-
-			// Set user-agent to indicate MinIO release
-			// version to the configured log endpoint
-			req.Header.Set("User-Agent", h.userAgent)
+		}
+	}()
+}
 
-			if h.authToken != "" {
-				req.Header.Set("Authorization", h.authToken)
-			}
+// initQueueStore - opens the queue store and starts sending
+// the logs persisted in it.
+func (h *Target) initQueueStore() error {
+	queueStore := store.NewQueueStore(h.queueDir, h.queueLimit, logExt)
+	if err := queueStore.Open(); err != nil {
+		return fmt.Errorf("unable to initialize the queue store of %s: %w", h.name, err)
+	}
+	h.store = queueStore
 
-			resp, err := h.client.Do(req)
-			cancel()
-			if err != nil {
-				logger.LogOnceIf(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration",
-					h.endpoint, err), h.endpoint)
-				continue
-			}
+	go store.Replay(queueStore, h.sendFromStore, h.doneCh, logger.LogOnceIf, h.endpoint)
+	return nil
+}
 
-			// Drain any response.
-			xhttp.DrainBody(resp.Body)
-
-			if resp.StatusCode != http.StatusOK {
-				switch resp.StatusCode {
-				case http.StatusForbidden:
-					logger.LogOnceIf(ctx, fmt.Errorf("%s returned '%s', please check if your auth token is correctly set",
-						h.endpoint, resp.Status), h.endpoint)
-				default:
-					logger.LogOnceIf(ctx, fmt.Errorf("%s returned '%s', please check your endpoint configuration",
-						h.endpoint, resp.Status), h.endpoint)
-				}
-			}
+// sendFromStore - sends a log persisted in the queue store
+// and removes it once delivered.
+func (h *Target) sendFromStore(key string) error {
+	logJSON, err := h.store.Get(key)
+	if err != nil {
+		// The log was already sent.
+		if os.IsNotExist(err) {
+			return nil
 		}
-	}()
+		return err
+	}
+
+	if err = h.send(logJSON); err != nil {
+		return err
+	}
+	return h.store.Del(key)
 }
 
 // Option is a function type that accepts a pointer Target
@@ -199,6 +257,29 @@ func WithTransport(transport *http.Transport) Option {
 	}
 }
 
+// WithQueueDir persists the logs in dir until they are sent.
+func WithQueueDir(dir string) Option {
+	return func(t *Target) {
+		t.queueDir = dir
+	}
+}
+
+// WithDoneCh stops sending the logs persisted in the
+// queue directory once doneCh is closed.
+func WithDoneCh(doneCh <-chan struct{}) Option {
+	return func(t *Target) {
+		t.doneCh = doneCh
+	}
+}
+
+// WithQueueLimit sets the maximum number of logs persisted
+// in the queue directory.
+func WithQueueLimit(limit uint64) Option {
+	return func(t *Target) {
+		t.queueLimit = limit
+	}
+}
+
 // New initializes a new logger target which
 // sends log over http to the specified endpoint
 func New(opts ...Option) *Target {
@@ -223,6 +304,11 @@ func (h *Target) Send(entry interface{}, errKind string) error {
 		return nil
 	}
 
+	if h.store != nil {
+		// The log is sent by the queue store.
+		return h.store.Put(entry)
+	}
+
 	select {
 	case h.logCh <- entry:
 	default:
@@ -0,0 +1,300 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	sarama "github.com/Shopify/sarama"
+	saramatls "github.com/Shopify/sarama/tools/tls"
+
+	"github.com/minio/minio/pkg/event/target"
+	xnet "github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/store"
+)
+
+const (
+	// Extension of the log entries in the queue store.
+	logExt = ".log"
+
+	// Timeout of the connectivity check of a broker.
+	pingTimeout = 2 * time.Second
+)
+
+// Config - Kafka target configuration.
+type Config struct {
+	Enabled bool        `json:"enable"`
+	Brokers []xnet.Host `json:"brokers"`
+	Topic   string      `json:"topic"`
+	Version string      `json:"version"`
+	TLS     struct {
+		Enable        bool               `json:"enable"`
+		RootCAs       *x509.CertPool     `json:"-"`
+		SkipVerify    bool               `json:"skipVerify"`
+		ClientAuth    tls.ClientAuthType `json:"clientAuth"`
+		ClientTLSCert string             `json:"clientTLSCert"`
+		ClientTLSKey  string             `json:"clientTLSKey"`
+	} `json:"tls"`
+	SASL struct {
+		Enable    bool   `json:"enable"`
+		User      string `json:"username"`
+		Password  string `json:"password"`
+		Mechanism string `json:"mechanism"`
+	} `json:"sasl"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+
+	// Custom logger
+	LogOnce store.LogOnce `json:"-"`
+
+	// Closed to stop sending the log entries persisted in the queue store.
+	DoneCh <-chan struct{} `json:"-"`
+}
+
+// Check if atleast one broker in cluster is active
+func (k Config) pingBrokers() bool {
+	for _, broker := range k.Brokers {
+		conn, err := net.DialTimeout("tcp", broker.String(), pingTimeout)
+		if err == nil {
+			conn.Close()
+			return true
+		}
+	}
+	return false
+}
+
+// Target - Kafka target.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	name     string
+	producer sarama.SyncProducer
+	kconfig  Config
+	config   *sarama.Config
+	store    store.Store
+
+	// Set when the last send failed, the brokers are
+	// probed before sending again.
+	offline bool
+}
+
+// New initializes a new logger target which sends the
+// log entries to a Kafka topic.
+func New(name string, config Config) *Target {
+	return &Target{
+		logCh:   make(chan interface{}, 10000),
+		name:    name,
+		kconfig: config,
+	}
+}
+
+// Endpoint returns the backend endpoint
+func (h *Target) Endpoint() string {
+	brokers := make([]string, 0, len(h.kconfig.Brokers))
+	for _, broker := range h.kconfig.Brokers {
+		brokers = append(brokers, broker.String())
+	}
+	return strings.Join(brokers, ",")
+}
+
+func (h *Target) String() string {
+	return h.name
+}
+
+// Validate validates the Kafka configuration, connects
+// to the brokers and starts sending the log entries.
+func (h *Target) Validate() error {
+	if len(h.kconfig.Brokers) == 0 {
+		return errors.New("no broker address found")
+	}
+	for _, b := range h.kconfig.Brokers {
+		if _, err := xnet.ParseHost(b.String()); err != nil {
+			return err
+		}
+	}
+	if h.kconfig.Topic == "" {
+		return errors.New("no topic found")
+	}
+
+	config := sarama.NewConfig()
+	if h.kconfig.Version != "" {
+		kafkaVersion, err := sarama.ParseKafkaVersion(h.kconfig.Version)
+		if err != nil {
+			return err
+		}
+		config.Version = kafkaVersion
+	}
+
+	config.Net.SASL.User = h.kconfig.SASL.User
+	config.Net.SASL.Password = h.kconfig.SASL.Password
+	switch h.kconfig.SASL.Mechanism {
+	case "sha512":
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &target.XDGSCRAMClient{HashGeneratorFcn: target.KafkaSHA512}
+		}
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512)
+	case "sha256":
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &target.XDGSCRAMClient{HashGeneratorFcn: target.KafkaSHA256}
+		}
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA256)
+	default:
+		// default to PLAIN
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(sarama.SASLTypePlaintext)
+	}
+	config.Net.SASL.Enable = h.kconfig.SASL.Enable
+
+	tlsConfig, err := saramatls.NewConfig(h.kconfig.TLS.ClientTLSCert, h.kconfig.TLS.ClientTLSKey)
+	if err != nil {
+		return err
+	}
+
+	config.Net.TLS.Enable = h.kconfig.TLS.Enable
+	config.Net.TLS.Config = tlsConfig
+	config.Net.TLS.Config.InsecureSkipVerify = h.kconfig.TLS.SkipVerify
+	config.Net.TLS.Config.ClientAuth = h.kconfig.TLS.ClientAuth
+	config.Net.TLS.Config.RootCAs = h.kconfig.TLS.RootCAs
+
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 10
+	config.Producer.Return.Successes = true
+
+	h.config = config
+
+	if h.kconfig.QueueDir != "" {
+		queueStore := store.NewQueueStore(h.kconfig.QueueDir, h.kconfig.QueueLimit, logExt)
+		if err = queueStore.Open(); err != nil {
+			return fmt.Errorf("unable to initialize the queue store of %s: %w", h.name, err)
+		}
+		h.store = queueStore
+	}
+
+	if err = h.connect(); err != nil {
+		// The brokers may be offline, the log entries are
+		// queued until they are reachable again.
+		if h.store == nil || !errors.Is(err, sarama.ErrOutOfBrokers) {
+			return err
+		}
+	}
+
+	if h.store != nil {
+		go store.Replay(h.store, h.sendFromStore, h.kconfig.DoneCh, h.logOnce, h.Endpoint())
+	} else {
+		go h.startKafkaLogger()
+	}
+	return nil
+}
+
+// connect - creates the producer if not already connected.
+func (h *Target) connect() (err error) {
+	if h.producer != nil {
+		return nil
+	}
+	brokers := make([]string, 0, len(h.kconfig.Brokers))
+	for _, broker := range h.kconfig.Brokers {
+		brokers = append(brokers, broker.String())
+	}
+	h.producer, err = sarama.NewSyncProducer(brokers, h.config)
+	return err
+}
+
+func (h *Target) logOnce(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+	if h.kconfig.LogOnce != nil {
+		h.kconfig.LogOnce(ctx, err, id, errKind...)
+	}
+}
+
+// send - sends the json encoded log entry to the topic.
+func (h *Target) send(logJSON []byte) (err error) {
+	// Fail fast while the brokers are offline instead of
+	// waiting for the producer retries of every entry.
+	if h.offline && !h.kconfig.pingBrokers() {
+		return errors.New("no Kafka broker is reachable")
+	}
+	defer func() {
+		h.offline = err != nil
+	}()
+	if err = h.connect(); err != nil {
+		return err
+	}
+	msg := sarama.ProducerMessage{
+		Topic: h.kconfig.Topic,
+		Value: sarama.ByteEncoder(logJSON),
+	}
+	_, _, err = h.producer.SendMessage(&msg)
+	return err
+}
+
+func (h *Target) startKafkaLogger() {
+	// Create a routine which sends json logs received
+	// from an internal channel.
+	for entry := range h.logCh {
+		logJSON, err := json.Marshal(&entry)
+		if err != nil {
+			continue
+		}
+		if err = h.send(logJSON); err != nil {
+			h.logOnce(context.Background(), err, h.Endpoint())
+		}
+	}
+}
+
+// sendFromStore - sends a log entry persisted in the queue
+// store and removes it once delivered.
+func (h *Target) sendFromStore(key string) error {
+	logJSON, err := h.store.Get(key)
+	if err != nil {
+		// The log entry was already sent.
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err = h.send(logJSON); err != nil {
+		return err
+	}
+	return h.store.Del(key)
+}
+
+// Send log message 'e' to kafka target.
+func (h *Target) Send(entry interface{}, errKind string) error {
+	if h.store != nil {
+		// The log entry is sent by the queue store.
+		return h.store.Put(entry)
+	}
+
+	select {
+	case h.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+
+	return nil
+}
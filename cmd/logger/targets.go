@@ -16,6 +16,13 @@
 
 package logger
 
+import (
+	"context"
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger/message/audit"
+)
+
 // Target is the entity that we will receive
 // a single log entry and Send it to the log target
 //   e.g. Send the log to a http server
@@ -32,6 +39,16 @@ var Targets = []Target{}
 // AuditTargets is the list of enabled audit loggers
 var AuditTargets = []Target{}
 
+// AccessLogTarget receives the audit entries of requests on buckets
+// with server access logging enabled.
+type AccessLogTarget interface {
+	Enabled(bucket string) bool
+	Send(ctx context.Context, entry audit.Entry, r *http.Request)
+}
+
+// AccessLog is the bucket server access logging target, nil if disabled.
+var AccessLog AccessLogTarget
+
 // AddAuditTarget adds a new audit logger target to the
 // list of enabled loggers
 func AddAuditTarget(t Target) error {
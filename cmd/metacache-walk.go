@@ -271,7 +271,8 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 }
 
 func (p *xlStorageDiskIDCheck) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writer) error {
-	defer p.updateStorageMetrics(storageMetricWalkDir)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricWalkDir, opts.Bucket, opts.BaseDir)
+	defer done()
 	if err := p.checkDiskStale(); err != nil {
 		return err
 	}
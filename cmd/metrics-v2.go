@@ -247,6 +247,7 @@ func GetGeneratorsForPeer() []MetricsGenerator {
 		getMinioVersionMetrics,
 		getNetworkMetrics,
 		getS3TTFBMetric,
+		getBucketHTTPMetrics,
 	}
 	return g
 }
@@ -260,6 +261,7 @@ func GetSingleNodeGenerators() []MetricsGenerator {
 		getNetworkMetrics,
 		getMinioVersionMetrics,
 		getS3TTFBMetric,
+		getBucketHTTPMetrics,
 	}
 	return g
 }
@@ -712,6 +714,60 @@ func getMinIOCommitMD() MetricDescription {
 		Type:      gaugeMetric,
 	}
 }
+func getBucketS3RequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      total,
+		Help:      "Total number of S3 requests on a bucket",
+		Type:      counterMetric,
+	}
+}
+func getBucketS3RequestsErrorsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      errorsTotal,
+		Help:      "Total number of S3 requests with errors on a bucket",
+		Type:      counterMetric,
+	}
+}
+func getBucketS3RequestsCanceledMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      canceledTotal,
+		Help:      "Total number of S3 requests on a bucket that were canceled from the client while processing",
+		Type:      counterMetric,
+	}
+}
+func getBucketS3ReceivedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      receivedBytes,
+		Help:      "Total number of S3 bytes received for a bucket",
+		Type:      counterMetric,
+	}
+}
+func getBucketS3SentBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      sentBytes,
+		Help:      "Total number of S3 bytes sent for a bucket",
+		Type:      counterMetric,
+	}
+}
+func getBucketS3TTFBDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      ttfbDistribution,
+		Help:      "Distribution of the time to first byte across API calls on a bucket.",
+		Type:      gaugeMetric,
+	}
+}
 func getS3TTFBDistributionMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
@@ -976,6 +1032,65 @@ func getS3TTFBMetric() MetricsGroup {
 	}
 }
 
+func getBucketHTTPMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "bucketHTTPMetrics",
+		cachedRead: cachedRead,
+		read: func(ctx context.Context) (metrics []Metric) {
+			if enabled, _ := globalAPIConfig.getBucketMetrics(); !enabled {
+				return
+			}
+
+			for bucket, apiStats := range globalBucketHTTPStats.Load() {
+				for api, stats := range apiStats {
+					labels := map[string]string{"bucket": bucket, "api": api}
+					metrics = append(metrics, Metric{
+						Description:    getBucketS3RequestsTotalMD(),
+						Value:          float64(stats.Requests),
+						VariableLabels: labels,
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketS3RequestsErrorsMD(),
+						Value:          float64(stats.Errors),
+						VariableLabels: labels,
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketS3RequestsCanceledMD(),
+						Value:          float64(stats.Canceled),
+						VariableLabels: labels,
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketS3ReceivedBytesMD(),
+						Value:          float64(stats.ReceivedBytes),
+						VariableLabels: labels,
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketS3SentBytesMD(),
+						Value:          float64(stats.SentBytes),
+						VariableLabels: labels,
+					})
+
+					// Cumulative counts as for the histogram of all buckets.
+					var count uint64
+					for i, n := range stats.TTFB {
+						count += n
+						le := "+Inf"
+						if i < len(s3TTFBBuckets) {
+							le = fmt.Sprintf("%.3f", s3TTFBBuckets[i])
+						}
+						metrics = append(metrics, Metric{
+							Description:    getBucketS3TTFBDistributionMD(),
+							Value:          float64(count),
+							VariableLabels: map[string]string{"bucket": bucket, "api": api, "le": le},
+						})
+					}
+				}
+			}
+			return
+		},
+	}
+}
+
 func getMinioVersionMetrics() MetricsGroup {
 	return MetricsGroup{
 		id:         "MinioVersionMetrics",
@@ -29,11 +29,14 @@ import (
 )
 
 var (
+	// Upper bounds of the buckets of the time to first byte histograms.
+	s3TTFBBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10}
+
 	httpRequestsDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "s3_ttfb_seconds",
 			Help:    "Time taken by requests served by current MinIO server instance",
-			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10},
+			Buckets: s3TTFBBuckets,
 		},
 		[]string{"api"},
 	)
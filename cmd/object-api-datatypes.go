@@ -115,6 +115,10 @@ type ObjectInfo struct {
 	// TransitionStatus indicates if transition is complete/pending
 	TransitionStatus string
 
+	// TransitionedObject identifies the object in the remote tier it was
+	// transitioned to.
+	TransitionedObject transitionedObject
+
 	// RestoreExpires indicates date a restored object expires
 	RestoreExpires time.Time
 
@@ -225,6 +229,11 @@ type ReplicateObjectInfo struct {
 	ObjectInfo
 	OpType     replication.Type
 	RetryCount uint32
+	// TargetArn restricts the replication to this target, the version
+	// is replicated to all its targets when empty.
+	TargetArn string
+	// resync is set when the version is replicated by the resync of a target.
+	resync *targetResync
 }
 
 // MultipartInfo captures metadata information about the uploadId
@@ -449,6 +458,9 @@ type PartInfo struct {
 
 	// Decompressed Size.
 	ActualSize int64
+
+	// Content checksum of the part, if any.
+	Checksum *hash.Checksum
 }
 
 // CompletePart - represents the part that was completed, this is sent by the client
@@ -460,6 +472,9 @@ type CompletePart struct {
 
 	// Entity tag returned when the part was uploaded.
 	ETag string
+
+	// Content checksum returned when the part was uploaded, optional.
+	ContentChecksums
 }
 
 // CompletedParts - is a collection satisfying sort.Interface.
@@ -311,6 +311,13 @@ func (e ObjectExistsAsDirectory) Error() string {
 	return "Object exists on : " + e.Bucket + " as directory " + e.Object
 }
 
+// ObjectLocked object is protected by a retention period or a legal hold.
+type ObjectLocked GenericError
+
+func (e ObjectLocked) Error() string {
+	return "Object is WORM protected and cannot be overwritten: " + e.Bucket + SlashSeparator + e.Object
+}
+
 //PrefixAccessDenied object access is denied.
 type PrefixAccessDenied GenericError
 
@@ -364,6 +371,34 @@ func (e BucketLifecycleNotFound) Error() string {
 	return "No bucket lifecycle configuration found for bucket : " + e.Bucket
 }
 
+// BucketLoggingConfigNotFound - no bucket server access logging configuration found
+type BucketLoggingConfigNotFound GenericError
+
+func (e BucketLoggingConfigNotFound) Error() string {
+	return "The server access logging configuration does not exist: " + e.Bucket
+}
+
+// BucketInventoryConfigNotFound - no bucket inventory configuration found
+type BucketInventoryConfigNotFound GenericError
+
+func (e BucketInventoryConfigNotFound) Error() string {
+	return "The inventory configuration does not exist: " + e.Bucket
+}
+
+// BucketWebsiteConfigNotFound - no bucket website configuration found
+type BucketWebsiteConfigNotFound GenericError
+
+func (e BucketWebsiteConfigNotFound) Error() string {
+	return "The website configuration does not exist: " + e.Bucket
+}
+
+// BucketCorsConfigNotFound - no bucket CORS configuration found
+type BucketCorsConfigNotFound GenericError
+
+func (e BucketCorsConfigNotFound) Error() string {
+	return "The CORS configuration does not exist: " + e.Bucket
+}
+
 // BucketSSEConfigNotFound - no bucket encryption found
 type BucketSSEConfigNotFound GenericError
 
@@ -483,6 +518,20 @@ func (e BucketReplicationSourceNotVersioned) Error() string {
 	return "Replication source does not have versioning enabled: " + e.Bucket
 }
 
+// BucketReplicationResyncInProgress resync of the remote target is already in progress.
+type BucketReplicationResyncInProgress GenericError
+
+func (e BucketReplicationResyncInProgress) Error() string {
+	return "Resync of the remote target is already in progress: " + e.Bucket
+}
+
+// BucketReplicationExistingObjectsDisabled no replication rule of the remote target replicates existing objects.
+type BucketReplicationExistingObjectsDisabled GenericError
+
+func (e BucketReplicationExistingObjectsDisabled) Error() string {
+	return "Existing object replication is not enabled for the remote target: " + e.Bucket
+}
+
 /// Bucket related errors.
 
 // BucketNameInvalid - bucketname provided is invalid.
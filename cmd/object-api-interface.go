@@ -51,16 +51,22 @@ type ObjectOptions struct {
 	DeleteMarkerReplicationStatus string                                                // Is only set in DELETE operations
 	VersionPurgeStatus            VersionPurgeStatusType                                // Is only set in DELETE operations for delete marker version to be permanently deleted.
 	TransitionStatus              string                                                // status of the transition
+	TransitionedObject            transitionedObject                                    // only set in DELETE operations on objects transitioned to a remote tier
 	NoLock                        bool                                                  // indicates to lower layers if the caller is expecting to hold locks.
 	ProxyRequest                  bool                                                  // only set for GET/HEAD in active-active replication scenario
 	ProxyHeaderSet                bool                                                  // only set for GET/HEAD in active-active replication scenario
 	ParentIsObject                func(ctx context.Context, bucket, parent string) bool // Used to verify if parent is an object.
+	EvalMetadataFn                EvalMetadataFn                                        // only set during PutObjectMetadata, updates the metadata under the object lock
 
 	// Use the maximum parity (N/2), used when
 	// saving server configuration files
 	MaxParity bool
 }
 
+// EvalMetadataFn is called by PutObjectMetadata with the current state
+// of the object, it updates the object metadata in place.
+type EvalMetadataFn func(oi ObjectInfo, metadata map[string]string) error
+
 // BucketOptions represents bucket options for ObjectLayer bucket operations
 type BucketOptions struct {
 	Location          string
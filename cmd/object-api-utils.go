@@ -830,9 +830,10 @@ type SealMD5CurrFn func([]byte) []byte
 // PutObjReader is a type that wraps sio.EncryptReader and
 // underlying hash.Reader in a struct
 type PutObjReader struct {
-	*hash.Reader              // actual data stream
-	rawReader    *hash.Reader // original data stream
-	sealMD5Fn    SealMD5CurrFn
+	*hash.Reader                // actual data stream
+	rawReader      *hash.Reader // original data stream
+	checksumReader *hash.Reader // computes the content checksum
+	sealMD5Fn      SealMD5CurrFn
 }
 
 // Size returns the absolute number of bytes the Reader
@@ -882,10 +883,27 @@ func (p *PutObjReader) WithEncryption(encReader *hash.Reader, objEncKey *crypto.
 	return p, nil
 }
 
+// WithChecksum sets the reader computing the content checksum, which
+// is the rawReader by default. It must be set when the rawReader does
+// not read the original content, e.g. when the content is compressed.
+func (p *PutObjReader) WithChecksum(checksumReader *hash.Reader) *PutObjReader {
+	p.checksumReader = checksumReader
+	return p
+}
+
+// ContentChecksum returns the content checksum computed while reading,
+// nil if the content has no checksum.
+func (p *PutObjReader) ContentChecksum() *hash.Checksum {
+	if p.checksumReader == nil {
+		return nil
+	}
+	return p.checksumReader.ContentChecksum()
+}
+
 // NewPutObjReader returns a new PutObjReader. It uses given hash.Reader's
 // MD5Current method to construct md5sum when requested downstream.
 func NewPutObjReader(rawReader *hash.Reader) *PutObjReader {
-	return &PutObjReader{Reader: rawReader, rawReader: rawReader}
+	return &PutObjReader{Reader: rawReader, rawReader: rawReader, checksumReader: rawReader}
 }
 
 func sealETag(encKey crypto.ObjectKey, md5CurrSum []byte) []byte {
@@ -0,0 +1,209 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// objectChecksumKey stores the content checksum of an object in the
+	// "<algorithm>:<value>" form, e.g. "CRC32C:4waSgw==". The value of a
+	// multipart object is composed of the checksums of its parts and has
+	// a "-<parts count>" suffix.
+	objectChecksumKey = ReservedMetadataPrefix + "checksum"
+
+	// uploadChecksumAlgoKey stores the checksum algorithm requested
+	// when a multipart upload is initiated.
+	uploadChecksumAlgoKey = ReservedMetadataPrefix + "checksum-algorithm"
+)
+
+// parseChecksumValue returns the checksum stored in the
+// "<algorithm>:<value>" form, nil if v is empty or invalid.
+func parseChecksumValue(v string) *hash.Checksum {
+	if v == "" {
+		return nil
+	}
+	cs, err := hash.ParseChecksum(v)
+	if err != nil {
+		return nil
+	}
+	return cs
+}
+
+// ContentChecksum returns the content checksum of the object,
+// nil if the object was stored without one.
+func (o ObjectInfo) ContentChecksum() *hash.Checksum {
+	return parseChecksumValue(o.UserDefined[objectChecksumKey])
+}
+
+// PartChecksum returns the content checksum of a part of a multipart
+// object, nil if the part was stored without one.
+func (o ObjectInfo) PartChecksum(partNumber int) *hash.Checksum {
+	if i := objectPartIndex(o.Parts, partNumber); i >= 0 {
+		return o.Parts[i].ContentChecksum()
+	}
+	return nil
+}
+
+// ContentChecksum returns the content checksum of the part,
+// nil if the part was stored without one.
+func (p ObjectPartInfo) ContentChecksum() *hash.Checksum {
+	return parseChecksumValue(p.Checksum)
+}
+
+// copyChecksumMetadata sets the checksum of the destination object of
+// a copy from the metadata of the source object. The checksums of the
+// parts are kept with the parts if only the metadata is copied. The
+// copied content is stored as a single part otherwise, only a checksum
+// of the full content is kept then.
+func copyChecksumMetadata(dst, src map[string]string, metadataOnly bool) {
+	delete(dst, objectChecksumKey)
+	v, ok := src[objectChecksumKey]
+	if !ok || (!metadataOnly && strings.Contains(v, "-")) {
+		return
+	}
+	dst[objectChecksumKey] = v
+}
+
+// completeMultipartChecksum verifies the checksums of the parts sent by
+// the client in a CompleteMultipartUpload request against the stored
+// checksums returned by partChecksum. It returns the checksum of the
+// object, composed of the checksums of all parts, if a checksum
+// algorithm was requested when the upload was initiated.
+func completeMultipartChecksum(uploadMeta map[string]string, parts []CompletePart, partChecksum func(part CompletePart) *hash.Checksum) (*hash.Checksum, error) {
+	algo := hash.NewChecksumType(uploadMeta[uploadChecksumAlgoKey])
+	var checksums []hash.Checksum
+	for _, part := range parts {
+		want, err := part.ContentChecksums.checksum()
+		if err != nil {
+			return nil, InvalidPart{PartNumber: part.PartNumber}
+		}
+		got := partChecksum(part)
+		if want != nil {
+			if got == nil || got.Type.Base() != want.Type.Base() || got.Encoded != want.Encoded {
+				invalidPart := InvalidPart{PartNumber: part.PartNumber, ExpETag: want.Encoded}
+				if got != nil {
+					invalidPart.GotETag = got.Encoded
+				}
+				return nil, invalidPart
+			}
+		}
+		if !algo.IsSet() {
+			continue
+		}
+		if got == nil || got.Type.Base() != algo.Base() {
+			return nil, InvalidPart{PartNumber: part.PartNumber}
+		}
+		checksums = append(checksums, *got)
+	}
+	if !algo.IsSet() {
+		return nil, nil
+	}
+	return hash.ComposeChecksum(algo, checksums)
+}
+
+// ContentChecksums - checksums of an object or a part, at most a single
+// one of them is set.
+type ContentChecksums struct {
+	ChecksumCRC32  string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumSHA1   string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256,omitempty"`
+}
+
+// newContentChecksums returns the response representation of cs.
+func newContentChecksums(cs *hash.Checksum) (c ContentChecksums) {
+	if cs == nil {
+		return c
+	}
+	switch cs.Type.Base() {
+	case hash.ChecksumCRC32:
+		c.ChecksumCRC32 = cs.Encoded
+	case hash.ChecksumCRC32C:
+		c.ChecksumCRC32C = cs.Encoded
+	case hash.ChecksumSHA1:
+		c.ChecksumSHA1 = cs.Encoded
+	case hash.ChecksumSHA256:
+		c.ChecksumSHA256 = cs.Encoded
+	}
+	return c
+}
+
+// checksum returns the checksum set in c, nil if none is set. It
+// returns hash.ErrInvalidChecksum if more than one checksum is set
+// or the checksum is invalid.
+func (c ContentChecksums) checksum() (*hash.Checksum, error) {
+	var cs *hash.Checksum
+	for _, v := range []struct {
+		t     hash.ChecksumType
+		value string
+	}{
+		{hash.ChecksumCRC32, c.ChecksumCRC32},
+		{hash.ChecksumCRC32C, c.ChecksumCRC32C},
+		{hash.ChecksumSHA1, c.ChecksumSHA1},
+		{hash.ChecksumSHA256, c.ChecksumSHA256},
+	} {
+		if v.value == "" {
+			continue
+		}
+		if cs != nil {
+			return nil, hash.ErrInvalidChecksum
+		}
+		cs = &hash.Checksum{Type: v.t, Encoded: v.value}
+		if strings.Contains(v.value, "-") {
+			cs.Type |= hash.ChecksumMultipart
+		}
+		if !cs.Valid() {
+			return nil, hash.ErrInvalidChecksum
+		}
+	}
+	return cs, nil
+}
+
+// setChecksumHeader sets the x-amz-checksum-* response header of cs.
+func setChecksumHeader(h http.Header, cs *hash.Checksum) {
+	if cs == nil || !cs.Type.IsSet() {
+		return
+	}
+	h.Set(cs.Type.Key(), cs.Encoded)
+}
+
+// setObjectChecksumHeader sets the content checksum response header of
+// GET and HEAD object requests if the client enabled the checksum mode.
+// The checksum of a single part is returned if a part number is
+// requested, no checksum is returned for a range of the object.
+func setObjectChecksumHeader(w http.ResponseWriter, r *http.Request, objInfo ObjectInfo, rs *HTTPRangeSpec, partNumber int) {
+	if !isChecksumModeEnabled(r) || rs != nil {
+		return
+	}
+	cs := objInfo.ContentChecksum()
+	if partNumber > 0 && (cs == nil || cs.Type.Is(hash.ChecksumMultipart)) {
+		cs = objInfo.PartChecksum(partNumber)
+	}
+	setChecksumHeader(w.Header(), cs)
+}
+
+// isChecksumModeEnabled returns whether the client requested the content
+// checksum to be returned with GET or HEAD object.
+func isChecksumModeEnabled(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get(xhttp.AmzChecksumMode), xhttp.AmzChecksumModeValue)
+}
@@ -0,0 +1,200 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// Wrapper for calling testObjectChecksum tests for both Erasure and FS.
+func TestObjectChecksum(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectChecksum)
+}
+
+// Tests the content checksums of single part and multipart objects.
+func testObjectChecksum(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "checksum-bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	// Single part object with a checksum sent by the client.
+	data := []byte("123456789")
+	hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	if err = hr.AddChecksumType(hash.ChecksumCRC32C); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	if _, err = obj.PutObject(ctx, bucket, "object", NewPutObjReader(hr), ObjectOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	objInfo, err := obj.GetObjectInfo(ctx, bucket, "object", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	if cs := objInfo.ContentChecksum(); cs == nil || cs.String() != "CRC32C:4waSgw==" {
+		t.Errorf("%s : expected checksum CRC32C:4waSgw==, got %v", instanceType, cs)
+	}
+
+	// Multipart object with a composed checksum.
+	opts := ObjectOptions{UserDefined: map[string]string{uploadChecksumAlgoKey: "SHA256"}}
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, "multipart", opts)
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	parts := [][]byte{bytes.Repeat([]byte("a"), 5*humanize.MiByte), []byte("b")}
+	var completeParts []CompletePart
+	var checksums []hash.Checksum
+	for i, part := range parts {
+		hr, err := hash.NewReader(bytes.NewReader(part), int64(len(part)), "", "", int64(len(part)))
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		if err = hr.AddChecksumType(hash.ChecksumSHA256); err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		pi, err := obj.PutObjectPart(ctx, bucket, "multipart", uploadID, i+1, NewPutObjReader(hr), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		want := hash.NewChecksumFromData(hash.ChecksumSHA256, part)
+		if pi.Checksum == nil || *pi.Checksum != *want {
+			t.Fatalf("%s : part %d: expected checksum %v, got %v", instanceType, i+1, want, pi.Checksum)
+		}
+		checksums = append(checksums, *want)
+		completeParts = append(completeParts, CompletePart{
+			PartNumber:       i + 1,
+			ETag:             pi.ETag,
+			ContentChecksums: newContentChecksums(pi.Checksum),
+		})
+	}
+
+	listParts, err := obj.ListObjectParts(ctx, bucket, "multipart", uploadID, 0, maxPartsList, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	for i, part := range listParts.Parts {
+		if part.Checksum == nil || *part.Checksum != checksums[i] {
+			t.Errorf("%s : list part %d: expected checksum %v, got %v", instanceType, i+1, checksums[i], part.Checksum)
+		}
+	}
+
+	// A mismatching part checksum must be rejected.
+	invalidParts := append([]CompletePart(nil), completeParts...)
+	invalidParts[1].ContentChecksums = newContentChecksums(&checksums[0])
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, invalidParts, ObjectOptions{}); err == nil {
+		t.Fatalf("%s : expected a failure with a mismatching part checksum", instanceType)
+	} else if _, ok := err.(InvalidPart); !ok {
+		t.Fatalf("%s : expected InvalidPart, got %v", instanceType, err)
+	}
+
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, completeParts, ObjectOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	objInfo, err = obj.GetObjectInfo(ctx, bucket, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	want, err := hash.ComposeChecksum(hash.ChecksumSHA256, checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cs := objInfo.ContentChecksum(); cs == nil || *cs != *want {
+		t.Errorf("%s : expected checksum %v, got %v", instanceType, want, cs)
+	}
+	for i := range parts {
+		if cs := objInfo.PartChecksum(i + 1); cs == nil || *cs != checksums[i] {
+			t.Errorf("%s : part %d: expected checksum %v, got %v", instanceType, i+1, checksums[i], cs)
+		}
+	}
+	if _, ok := objInfo.UserDefined[uploadChecksumAlgoKey]; ok {
+		t.Errorf("%s : unexpected upload checksum algorithm in object metadata", instanceType)
+	}
+	// The checksums of the parts are stored with the parts, only the
+	// composed checksum is part of the object metadata.
+	for k := range objInfo.UserDefined {
+		if strings.HasPrefix(k, ReservedMetadataPrefix+"checksum") && k != objectChecksumKey {
+			t.Errorf("%s : unexpected checksum %s in object metadata", instanceType, k)
+		}
+	}
+}
+
+func TestCopyChecksumMetadata(t *testing.T) {
+	src := map[string]string{
+		objectChecksumKey: "CRC32:AAAAAA==-2",
+		"content-type":    "text/plain",
+	}
+	dst := map[string]string{"content-type": "text/plain"}
+	copyChecksumMetadata(dst, src, true)
+	if len(dst) != len(src) || dst[objectChecksumKey] != src[objectChecksumKey] {
+		t.Errorf("expected the composed checksum to be copied, got %v", dst)
+	}
+
+	// A composed checksum is not valid for a copied content.
+	dst = map[string]string{objectChecksumKey: "CRC32:AAAAAA=="}
+	copyChecksumMetadata(dst, src, false)
+	if len(dst) != 0 {
+		t.Errorf("expected no checksums to be copied, got %v", dst)
+	}
+
+	src = map[string]string{objectChecksumKey: "CRC32:AAAAAA=="}
+	dst = map[string]string{}
+	copyChecksumMetadata(dst, src, false)
+	if dst[objectChecksumKey] != src[objectChecksumKey] {
+		t.Errorf("expected the object checksum to be copied, got %v", dst)
+	}
+}
+
+func TestContentChecksums(t *testing.T) {
+	testCases := []struct {
+		checksums   ContentChecksums
+		expected    string
+		expectedErr bool
+	}{
+		{ContentChecksums{}, "", false},
+		{ContentChecksums{ChecksumCRC32C: "4waSgw=="}, "CRC32C:4waSgw==", false},
+		{ContentChecksums{ChecksumSHA1: "98O8HYCOBHMq32eZZczDTKeuNEE="}, "SHA1:98O8HYCOBHMq32eZZczDTKeuNEE=", false},
+		{ContentChecksums{ChecksumCRC32: "AAAAAA==-3"}, "CRC32:AAAAAA==-3", false},
+		{ContentChecksums{ChecksumCRC32: "AAAAAA==", ChecksumCRC32C: "4waSgw=="}, "", true},
+		{ContentChecksums{ChecksumSHA256: "4waSgw=="}, "", true},
+	}
+	for i, testCase := range testCases {
+		cs, err := testCase.checksums.checksum()
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+		var got string
+		if cs != nil {
+			got = cs.String()
+			if newContentChecksums(cs) != testCase.checksums {
+				t.Errorf("Test %d: expected %v, got %v", i+1, testCase.checksums, newContentChecksums(cs))
+			}
+		}
+		if got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
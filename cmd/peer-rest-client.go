@@ -460,6 +460,48 @@ func (client *peerRESTClient) LoadBucketMetadata(bucket string) error {
 	return nil
 }
 
+// LoadTransitionTierConfig - reload the remote tier configuration
+func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadTransitionTierConfig, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ReloadSiteReplicationConfig - reload the site replication configuration
+func (client *peerRESTClient) ReloadSiteReplicationConfig(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadSiteReplicationConfig, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ReloadPoolMeta - reload the pools and their decommissioning
+func (client *peerRESTClient) ReloadPoolMeta(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadPoolMeta, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// CancelBatchJob - cancel the batch job if it runs on the peer
+func (client *peerRESTClient) CancelBatchJob(ctx context.Context, jobID string) error {
+	values := make(url.Values)
+	values.Set(peerRESTJobID, jobID)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodCancelBatchJob, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // DeleteBucketMetadata - Delete bucket metadata
 func (client *peerRESTClient) DeleteBucketMetadata(bucket string) error {
 	values := make(url.Values)
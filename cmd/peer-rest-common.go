@@ -17,49 +17,53 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v14" // Add GetBucketStats API
+	peerRESTVersion       = "v18" // Add CancelBatchJob API
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
 )
 
 const (
-	peerRESTMethodHealth                 = "/health"
-	peerRESTMethodServerInfo             = "/serverinfo"
-	peerRESTMethodDriveInfo              = "/driveinfo"
-	peerRESTMethodNetInfo                = "/netinfo"
-	peerRESTMethodCPUInfo                = "/cpuinfo"
-	peerRESTMethodDiskHwInfo             = "/diskhwinfo"
-	peerRESTMethodOsInfo                 = "/osinfo"
-	peerRESTMethodMemInfo                = "/meminfo"
-	peerRESTMethodProcInfo               = "/procinfo"
-	peerRESTMethodDispatchNetInfo        = "/dispatchnetinfo"
-	peerRESTMethodDeleteBucketMetadata   = "/deletebucketmetadata"
-	peerRESTMethodLoadBucketMetadata     = "/loadbucketmetadata"
-	peerRESTMethodGetBucketStats         = "/getbucketstats"
-	peerRESTMethodServerUpdate           = "/serverupdate"
-	peerRESTMethodSignalService          = "/signalservice"
-	peerRESTMethodBackgroundHealStatus   = "/backgroundhealstatus"
-	peerRESTMethodGetLocks               = "/getlocks"
-	peerRESTMethodLoadUser               = "/loaduser"
-	peerRESTMethodLoadServiceAccount     = "/loadserviceaccount"
-	peerRESTMethodDeleteUser             = "/deleteuser"
-	peerRESTMethodDeleteServiceAccount   = "/deleteserviceaccount"
-	peerRESTMethodLoadPolicy             = "/loadpolicy"
-	peerRESTMethodLoadPolicyMapping      = "/loadpolicymapping"
-	peerRESTMethodDeletePolicy           = "/deletepolicy"
-	peerRESTMethodLoadGroup              = "/loadgroup"
-	peerRESTMethodStartProfiling         = "/startprofiling"
-	peerRESTMethodDownloadProfilingData  = "/downloadprofilingdata"
-	peerRESTMethodCycleBloom             = "/cyclebloom"
-	peerRESTMethodTrace                  = "/trace"
-	peerRESTMethodListen                 = "/listen"
-	peerRESTMethodLog                    = "/log"
-	peerRESTMethodGetLocalDiskIDs        = "/getlocaldiskids"
-	peerRESTMethodGetBandwidth           = "/bandwidth"
-	peerRESTMethodGetMetacacheListing    = "/getmetacache"
-	peerRESTMethodUpdateMetacacheListing = "/updatemetacache"
-	peerRESTMethodGetPeerMetrics         = "/peermetrics"
+	peerRESTMethodHealth                      = "/health"
+	peerRESTMethodServerInfo                  = "/serverinfo"
+	peerRESTMethodDriveInfo                   = "/driveinfo"
+	peerRESTMethodNetInfo                     = "/netinfo"
+	peerRESTMethodCPUInfo                     = "/cpuinfo"
+	peerRESTMethodDiskHwInfo                  = "/diskhwinfo"
+	peerRESTMethodOsInfo                      = "/osinfo"
+	peerRESTMethodMemInfo                     = "/meminfo"
+	peerRESTMethodProcInfo                    = "/procinfo"
+	peerRESTMethodDispatchNetInfo             = "/dispatchnetinfo"
+	peerRESTMethodDeleteBucketMetadata        = "/deletebucketmetadata"
+	peerRESTMethodLoadBucketMetadata          = "/loadbucketmetadata"
+	peerRESTMethodGetBucketStats              = "/getbucketstats"
+	peerRESTMethodServerUpdate                = "/serverupdate"
+	peerRESTMethodSignalService               = "/signalservice"
+	peerRESTMethodBackgroundHealStatus        = "/backgroundhealstatus"
+	peerRESTMethodGetLocks                    = "/getlocks"
+	peerRESTMethodLoadUser                    = "/loaduser"
+	peerRESTMethodLoadServiceAccount          = "/loadserviceaccount"
+	peerRESTMethodDeleteUser                  = "/deleteuser"
+	peerRESTMethodDeleteServiceAccount        = "/deleteserviceaccount"
+	peerRESTMethodLoadPolicy                  = "/loadpolicy"
+	peerRESTMethodLoadPolicyMapping           = "/loadpolicymapping"
+	peerRESTMethodDeletePolicy                = "/deletepolicy"
+	peerRESTMethodLoadGroup                   = "/loadgroup"
+	peerRESTMethodStartProfiling              = "/startprofiling"
+	peerRESTMethodDownloadProfilingData       = "/downloadprofilingdata"
+	peerRESTMethodCycleBloom                  = "/cyclebloom"
+	peerRESTMethodTrace                       = "/trace"
+	peerRESTMethodListen                      = "/listen"
+	peerRESTMethodLog                         = "/log"
+	peerRESTMethodGetLocalDiskIDs             = "/getlocaldiskids"
+	peerRESTMethodGetBandwidth                = "/bandwidth"
+	peerRESTMethodGetMetacacheListing         = "/getmetacache"
+	peerRESTMethodUpdateMetacacheListing      = "/updatemetacache"
+	peerRESTMethodGetPeerMetrics              = "/peermetrics"
+	peerRESTMethodLoadTransitionTierConfig    = "/loadtransitiontierconfig"
+	peerRESTMethodReloadSiteReplicationConfig = "/reloadsitereplicationconfig"
+	peerRESTMethodReloadPoolMeta              = "/reloadpoolmeta"
+	peerRESTMethodCancelBatchJob              = "/cancelbatchjob"
 )
 
 const (
@@ -79,6 +83,7 @@ const (
 	peerRESTTraceS3        = "s3"
 	peerRESTTraceOS        = "os"
 	peerRESTTraceThreshold = "threshold"
+	peerRESTJobID          = "job-id"
 
 	peerRESTListenBucket = "bucket"
 	peerRESTListenPrefix = "prefix"
@@ -31,6 +31,7 @@ import (
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	xnet "github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/trace/otlp"
 )
 
 // DefaultTimeout - default REST timeout is 10 seconds.
@@ -121,12 +122,22 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	if !c.IsOnline() {
 		return nil, &NetworkError{Err: &url.Error{Op: method, URL: c.url.String(), Err: restError("remote server offline")}}
 	}
+	if otlp.Enabled() {
+		var span *otlp.Span
+		ctx, span = otlp.Start(ctx, "rest"+method, otlp.SpanKindClient,
+			otlp.String("net.peer.name", c.url.Host))
+		defer func() {
+			span.SetError(err)
+			span.End()
+		}()
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url.String()+method+querySep+values.Encode(), body)
 	if err != nil {
 		return nil, &NetworkError{err}
 	}
 	req.Header.Set("Authorization", "Bearer "+c.newAuthToken(req.URL.RawQuery))
 	req.Header.Set("X-Minio-Time", time.Now().UTC().Format(time.RFC3339))
+	otlp.Inject(ctx, req.Header)
 	if length > 0 {
 		req.ContentLength = length
 	}
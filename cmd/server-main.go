@@ -242,6 +242,18 @@ func newAllSubsystems() {
 
 	// Create new bucket replication subsytem
 	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new remote tier configuration
+	globalTierConfigMgr = newTierConfigMgr()
+
+	// Create new site replication subsystem
+	globalSiteReplicationSys = NewSiteReplicationSys()
+
+	// Create new batch jobs subsystem
+	globalBatchJobPool = NewBatchJobPool()
+
+	// Create new replication resync subsystem
+	globalReplicationResyncer = newReplicationResyncer()
 }
 
 func configRetriableErrors(err error) bool {
@@ -402,6 +414,39 @@ func initAllSubsystems(ctx context.Context, newObject ObjectLayer) (err error) {
 	// Initialize bucket targets sub-system.
 	globalBucketTargetSys.Init(ctx, buckets, newObject)
 
+	if globalIsErasure {
+		// Initialize remote tier configuration.
+		if err = globalTierConfigMgr.Reload(ctx, newObject); err != nil {
+			if configRetriableErrors(err) {
+				return fmt.Errorf("Unable to initialize remote tier config: %w", err)
+			}
+			logger.LogIf(ctx, fmt.Errorf("Unable to initialize remote tier config, transitions may fail %w", err))
+		}
+
+		// Initialize site replication.
+		if err = globalSiteReplicationSys.Init(ctx, newObject); err != nil {
+			if configRetriableErrors(err) {
+				return fmt.Errorf("Unable to initialize site replication: %w", err)
+			}
+			logger.LogIf(ctx, fmt.Errorf("Unable to initialize site replication, IAM and bucket metadata will not be replicated %w", err))
+		}
+
+		// Initialize the pools and resume their decommissioning.
+		if z, ok := newObject.(*erasureServerPools); ok {
+			if err = z.Init(ctx); err != nil {
+				return fmt.Errorf("Unable to initialize server pools: %w", err)
+			}
+		}
+
+		// Initialize batch jobs and resume those of this server.
+		if err = globalBatchJobPool.Init(ctx, newObject); err != nil {
+			if configRetriableErrors(err) {
+				return fmt.Errorf("Unable to initialize batch jobs: %w", err)
+			}
+			logger.LogIf(ctx, fmt.Errorf("Unable to initialize batch jobs, interrupted jobs are not resumed %w", err))
+		}
+	}
+
 	return nil
 }
 
@@ -522,6 +567,8 @@ func serverMain(ctx *cli.Context) {
 
 	initBackgroundExpiry(GlobalContext, newObject)
 	initDataScanner(GlobalContext, newObject)
+	initBucketAccessLog(GlobalContext, newObject)
+	initBucketInventory(GlobalContext, newObject)
 
 	if err = initServer(GlobalContext, newObject); err != nil {
 		var cerr config.Err
@@ -0,0 +1,1352 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/replication"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	srStatePrefix = minioConfigPrefix + "/site-replication"
+
+	srStateFile = "state.json"
+
+	srStateFormatVersion1 = 1
+
+	// siteReplicatorSvcAcc is the IAM user created on every site to
+	// perform site replication calls and bucket replication.
+	siteReplicatorSvcAcc = "site-replicator-0"
+
+	// siteReplicatorPolicy is the canned policy attached to the
+	// replicator user.
+	siteReplicatorPolicy = "consoleAdmin"
+
+	// srMinSites and srMaxSites are the minimum and maximum number of
+	// sites that can be replicated, every bucket is replicated from each
+	// site to all the others.
+	srMinSites = 2
+	srMaxSites = 4
+)
+
+var (
+	errSRCannotJoin = AdminError{
+		Code:       "XMinioSiteReplicationCannotJoin",
+		Message:    "this site is already configured for site-replication",
+		StatusCode: http.StatusBadRequest,
+	}
+	errSRDuplicateSites = AdminError{
+		Code:       "XMinioSiteReplicationDuplicateSites",
+		Message:    "duplicate sites provided for site-replication",
+		StatusCode: http.StatusBadRequest,
+	}
+	errSRSelfNotFound = AdminError{
+		Code:       "XMinioSiteReplicationSelfNotFound",
+		Message:    "none of the given sites correspond to the current one",
+		StatusCode: http.StatusBadRequest,
+	}
+	errSRNotEnabled = AdminError{
+		Code:       "XMinioSiteReplicationNotEnabled",
+		Message:    "site replication is not enabled",
+		StatusCode: http.StatusBadRequest,
+	}
+	errSRTooManySites = AdminError{
+		Code:       "XMinioSiteReplicationTooManySites",
+		Message:    fmt.Sprintf("site replication requires between %d and %d sites", srMinSites, srMaxSites),
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+func errSRInvalidRequest(err error) AdminError {
+	return AdminError{
+		Code:       "XMinioSiteReplicationInvalidRequest",
+		Message:    err.Error(),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+func errSRPeerResp(err error) AdminError {
+	return AdminError{
+		Code:       "XMinioSiteReplicationPeerResp",
+		Message:    err.Error(),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+func errSRBackendIssue(err error) AdminError {
+	return AdminError{
+		Code:       "XMinioSiteReplicationBackendIssue",
+		Message:    err.Error(),
+		StatusCode: http.StatusServiceUnavailable,
+	}
+}
+
+// srBucketMetaConfigFiles maps the replicated bucket metadata types to the
+// bucket metadata config files they are stored in.
+var srBucketMetaConfigFiles = map[string]string{
+	madmin.SRBucketMetaTypePolicy:           bucketPolicyConfig,
+	madmin.SRBucketMetaTypeTags:             bucketTaggingConfig,
+	madmin.SRBucketMetaTypeLifecycle:        bucketLifecycleConfig,
+	madmin.SRBucketMetaTypeSSEConfig:        bucketSSEConfig,
+	madmin.SRBucketMetaTypeObjectLockConfig: objectLockConfig,
+	madmin.SRBucketMetaTypeQuotaConfig:      bucketQuotaConfigFile,
+	madmin.SRBucketMetaTypeCorsConfig:       bucketCorsConfig,
+	madmin.SRBucketMetaTypeWebsiteConfig:    bucketWebsiteConfig,
+	madmin.SRBucketMetaTypeLoggingConfig:    bucketLoggingConfig,
+}
+
+// srBucketMetaConfigs returns the replicated configurations present in the
+// bucket metadata, keyed by their SRBucketMetaType*.
+func srBucketMetaConfigs(meta BucketMetadata) map[string][]byte {
+	configs := map[string][]byte{
+		madmin.SRBucketMetaTypePolicy:           meta.PolicyConfigJSON,
+		madmin.SRBucketMetaTypeTags:             meta.TaggingConfigXML,
+		madmin.SRBucketMetaTypeLifecycle:        meta.LifecycleConfigXML,
+		madmin.SRBucketMetaTypeSSEConfig:        meta.EncryptionConfigXML,
+		madmin.SRBucketMetaTypeObjectLockConfig: meta.ObjectLockConfigXML,
+		madmin.SRBucketMetaTypeQuotaConfig:      meta.QuotaConfigJSON,
+		madmin.SRBucketMetaTypeCorsConfig:       meta.CorsConfigXML,
+		madmin.SRBucketMetaTypeWebsiteConfig:    meta.WebsiteConfigXML,
+		madmin.SRBucketMetaTypeLoggingConfig:    meta.LoggingConfigXML,
+	}
+	for k, v := range configs {
+		if len(v) == 0 {
+			delete(configs, k)
+		}
+	}
+	return configs
+}
+
+// srState is the persisted site replication configuration of a site.
+type srState struct {
+	Version int `json:"version"`
+
+	// Name of this site.
+	Name string `json:"name"`
+
+	// Peers maps the deployment ID of every replicated site, including
+	// this one, to its information.
+	Peers map[string]madmin.PeerInfo `json:"peers"`
+
+	// ServiceAccountAccessKey is the access key of the IAM user used for
+	// site replication calls and bucket replication between the sites.
+	ServiceAccountAccessKey string `json:"serviceAccountAccessKey"`
+}
+
+// SiteReplicationSys - manages cluster-level replication of IAM and bucket
+// metadata across a set of sites.
+type SiteReplicationSys struct {
+	sync.RWMutex
+
+	enabled bool
+	state   srState
+}
+
+// NewSiteReplicationSys - creates a new site replication subsystem.
+func NewSiteReplicationSys() *SiteReplicationSys {
+	return &SiteReplicationSys{}
+}
+
+// Init - loads the site replication state from the backend.
+func (c *SiteReplicationSys) Init(ctx context.Context, objAPI ObjectLayer) error {
+	if err := c.load(ctx, objAPI); err != nil {
+		return err
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+	if c.enabled {
+		logger.Info("Site replication is enabled (%d sites)", len(c.state.Peers))
+	}
+	return nil
+}
+
+// load reads the state from the backend, replacing the in-memory state.
+func (c *SiteReplicationSys) load(ctx context.Context, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	data, err := readConfig(ctx, objAPI, pathJoin(srStatePrefix, srStateFile))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			c.Lock()
+			c.state = srState{}
+			c.enabled = false
+			c.Unlock()
+			return nil
+		}
+		return err
+	}
+
+	var state srState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	if state.Version != srStateFormatVersion1 {
+		return fmt.Errorf("unexpected site replication state version: %d", state.Version)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.state = state
+	c.enabled = len(state.Peers) != 0
+	return nil
+}
+
+// saveToDisk persists the state to the backend, updates the in-memory state
+// and makes all servers of this site reload it.
+func (c *SiteReplicationSys) saveToDisk(ctx context.Context, state srState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	if err = saveConfig(ctx, objAPI, pathJoin(srStatePrefix, srStateFile), data); err != nil {
+		return err
+	}
+
+	c.Lock()
+	c.state = state
+	c.enabled = len(state.Peers) != 0
+	c.Unlock()
+
+	globalNotificationSys.ReloadSiteReplicationConfig(ctx)
+	return nil
+}
+
+// isEnabled returns true if site replication is configured on this site.
+func (c *SiteReplicationSys) isEnabled() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.enabled
+}
+
+// isReplicatorUser returns true if name is the IAM user used by site
+// replication, which is managed by site replication itself.
+func (c *SiteReplicationSys) isReplicatorUser(name string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.enabled && name == c.state.ServiceAccountAccessKey
+}
+
+// GetClusterInfo - returns the site replication information of this site.
+func (c *SiteReplicationSys) GetClusterInfo(ctx context.Context) (info madmin.SiteReplicationInfo, err error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return info, nil
+	}
+
+	info.Enabled = true
+	info.Name = c.state.Name
+	info.ServiceAccountAccessKey = c.state.ServiceAccountAccessKey
+	for _, peer := range c.state.Peers {
+		info.Sites = append(info.Sites, peer)
+	}
+	sort.Slice(info.Sites, func(i, j int) bool {
+		return info.Sites[i].Name < info.Sites[j].Name
+	})
+	return info, nil
+}
+
+// getAdminClient returns an admin client for the given endpoint URL.
+func getAdminClient(endpoint, accessKey, secretKey string) (*madmin.AdminClient, error) {
+	epURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	client, err := madmin.New(epURL.Host, accessKey, secretKey, epURL.Scheme == "https")
+	if err != nil {
+		return nil, err
+	}
+
+	getRemoteTargetInstanceTransportOnce.Do(func() {
+		getRemoteTargetInstanceTransport = NewRemoteTargetHTTPTransport()
+	})
+	client.SetCustomTransport(getRemoteTargetInstanceTransport)
+	return client, nil
+}
+
+// getS3Client returns a minio-go client for the given endpoint URL.
+func getS3Client(endpoint, accessKey, secretKey string) (*minio.Client, error) {
+	epURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	getRemoteTargetInstanceTransportOnce.Do(func() {
+		getRemoteTargetInstanceTransport = NewRemoteTargetHTTPTransport()
+	})
+	return minio.New(epURL.Host, &minio.Options{
+		Creds:     credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:    epURL.Scheme == "https",
+		Transport: getRemoteTargetInstanceTransport,
+	})
+}
+
+// validateSiteEndpoint checks that the endpoint of a site is an URL of the
+// form http(s)://host[:port].
+func validateSiteEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("endpoint %s must use the http or https scheme", endpoint)
+	}
+	if u.Host == "" || (u.Path != "" && u.Path != SlashSeparator) {
+		return fmt.Errorf("endpoint %s must be of the form scheme://host[:port]", endpoint)
+	}
+	return nil
+}
+
+// getReplicatorCreds returns the credentials of the replicator user.
+func (c *SiteReplicationSys) getReplicatorCreds() (auth.Credentials, error) {
+	c.RLock()
+	accessKey := c.state.ServiceAccountAccessKey
+	c.RUnlock()
+
+	cred, ok := globalIAMSys.GetUser(accessKey)
+	if !ok {
+		return auth.Credentials{}, errSRBackendIssue(fmt.Errorf("site replicator user %s was not found", accessKey))
+	}
+	return cred, nil
+}
+
+// getPeerAdminClient returns an admin client, authenticated as the
+// replicator user, for the peer with the given deployment ID.
+func (c *SiteReplicationSys) getPeerAdminClient(deploymentID string) (*madmin.AdminClient, error) {
+	c.RLock()
+	peer, ok := c.state.Peers[deploymentID]
+	c.RUnlock()
+	if !ok {
+		return nil, errSRInvalidRequest(fmt.Errorf("unknown site %s", deploymentID))
+	}
+
+	cred, err := c.getReplicatorCreds()
+	if err != nil {
+		return nil, err
+	}
+	return getAdminClient(peer.Endpoint, cred.AccessKey, cred.SecretKey)
+}
+
+// remotePeers returns the sites other than this one.
+func (c *SiteReplicationSys) remotePeers() []madmin.PeerInfo {
+	c.RLock()
+	defer c.RUnlock()
+
+	peers := make([]madmin.PeerInfo, 0, len(c.state.Peers))
+	for dID, peer := range c.state.Peers {
+		if dID == globalDeploymentID {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// concDo runs peerFn on all remote sites concurrently, returning an error
+// describing all failed sites.
+func (c *SiteReplicationSys) concDo(peerFn func(peer madmin.PeerInfo, admClient *madmin.AdminClient) error) error {
+	peers := c.remotePeers()
+	errs := make([]error, len(peers))
+
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, peer madmin.PeerInfo) {
+			defer wg.Done()
+			admClient, err := c.getPeerAdminClient(peer.DeploymentID)
+			if err == nil {
+				err = peerFn(peer, admClient)
+			}
+			if err != nil {
+				errs[i] = fmt.Errorf("%s (%s): %w", peer.Name, peer.Endpoint, err)
+			}
+		}(i, peer)
+	}
+	wg.Wait()
+
+	var msgs []string
+	for _, err := range errs {
+		if err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	if len(msgs) > 0 {
+		return errSRPeerResp(fmt.Errorf("site replication failed on peer(s): %s", strings.Join(msgs, "; ")))
+	}
+	return nil
+}
+
+// AddPeerClusters - adds the given sites, one of which must be this site,
+// for site replication. All sites except this one must not have any
+// buckets; the buckets, IAM entities and bucket metadata of this site are
+// then replicated to them.
+func (c *SiteReplicationSys) AddPeerClusters(ctx context.Context, sites []madmin.PeerSite) (madmin.ReplicateAddStatus, error) {
+	if c.isEnabled() {
+		return madmin.ReplicateAddStatus{}, errSRCannotJoin
+	}
+	if len(sites) < srMinSites || len(sites) > srMaxSites {
+		return madmin.ReplicateAddStatus{}, errSRTooManySites
+	}
+
+	peers := make(map[string]madmin.PeerInfo, len(sites))
+	names := make(map[string]struct{}, len(sites))
+	var selfName string
+	for _, site := range sites {
+		if site.Name == "" {
+			return madmin.ReplicateAddStatus{}, errSRInvalidRequest(errors.New("site name cannot be empty"))
+		}
+		if _, ok := names[site.Name]; ok {
+			return madmin.ReplicateAddStatus{}, errSRDuplicateSites
+		}
+		names[site.Name] = struct{}{}
+
+		if err := validateSiteEndpoint(site.Endpoint); err != nil {
+			return madmin.ReplicateAddStatus{}, errSRInvalidRequest(err)
+		}
+
+		admClient, err := getAdminClient(site.Endpoint, site.AccessKey, site.SecretKey)
+		if err != nil {
+			return madmin.ReplicateAddStatus{}, errSRPeerResp(fmt.Errorf("unable to create admin client for %s: %w", site.Name, err))
+		}
+		info, err := admClient.ServerInfo(ctx)
+		if err != nil {
+			return madmin.ReplicateAddStatus{}, errSRPeerResp(fmt.Errorf("unable to fetch server info for %s: %w", site.Name, err))
+		}
+		if info.DeploymentID == "" {
+			return madmin.ReplicateAddStatus{}, errSRPeerResp(fmt.Errorf("%s did not report a deployment ID", site.Name))
+		}
+		if _, ok := peers[info.DeploymentID]; ok {
+			return madmin.ReplicateAddStatus{}, errSRDuplicateSites
+		}
+		peers[info.DeploymentID] = madmin.PeerInfo{
+			Endpoint:     strings.TrimSuffix(site.Endpoint, SlashSeparator),
+			Name:         site.Name,
+			DeploymentID: info.DeploymentID,
+		}
+
+		if info.DeploymentID == globalDeploymentID {
+			selfName = site.Name
+			continue
+		}
+
+		// Peers must not have any buckets, their content is
+		// replaced by the one of this site.
+		s3Client, err := getS3Client(site.Endpoint, site.AccessKey, site.SecretKey)
+		if err != nil {
+			return madmin.ReplicateAddStatus{}, errSRPeerResp(fmt.Errorf("unable to create s3 client for %s: %w", site.Name, err))
+		}
+		buckets, err := s3Client.ListBuckets(ctx)
+		if err != nil {
+			return madmin.ReplicateAddStatus{}, errSRPeerResp(fmt.Errorf("unable to list buckets on %s: %w", site.Name, err))
+		}
+		if len(buckets) > 0 {
+			return madmin.ReplicateAddStatus{}, errSRInvalidRequest(fmt.Errorf("all sites other than this one must be empty, but %s has %d bucket(s)", site.Name, len(buckets)))
+		}
+	}
+	if selfName == "" {
+		return madmin.ReplicateAddStatus{}, errSRSelfNotFound
+	}
+
+	// Create the replicator user on this site; the same credentials are
+	// sent to the peers when they join.
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		return madmin.ReplicateAddStatus{}, errSRBackendIssue(err)
+	}
+	if err = createSiteReplicatorUser(ctx, siteReplicatorSvcAcc, cred.SecretKey); err != nil {
+		return madmin.ReplicateAddStatus{}, errSRBackendIssue(fmt.Errorf("unable to create site replicator user: %w", err))
+	}
+
+	joinReq := madmin.SRPeerJoinReq{
+		SvcAcctAccessKey: siteReplicatorSvcAcc,
+		SvcAcctSecretKey: cred.SecretKey,
+		Peers:            peers,
+	}
+	for _, site := range sites {
+		if peers[globalDeploymentID].Name == site.Name {
+			continue
+		}
+		admClient, err := getAdminClient(site.Endpoint, site.AccessKey, site.SecretKey)
+		if err != nil {
+			return madmin.ReplicateAddStatus{}, errSRPeerResp(err)
+		}
+		if err = admClient.SRPeerJoin(ctx, joinReq); err != nil {
+			return madmin.ReplicateAddStatus{
+				Success:   false,
+				Status:    madmin.ReplicateAddStatusPartial,
+				ErrDetail: fmt.Sprintf("unable to join %s: %v", site.Name, err),
+			}, nil
+		}
+	}
+
+	state := srState{
+		Version:                 srStateFormatVersion1,
+		Name:                    selfName,
+		Peers:                   peers,
+		ServiceAccountAccessKey: siteReplicatorSvcAcc,
+	}
+	if err = c.saveToDisk(ctx, state); err != nil {
+		return madmin.ReplicateAddStatus{
+			Success:   false,
+			Status:    madmin.ReplicateAddStatusPartial,
+			ErrDetail: fmt.Sprintf("unable to save site replication state: %v", err),
+		}, nil
+	}
+
+	result := madmin.ReplicateAddStatus{
+		Success: true,
+		Status:  madmin.ReplicateAddStatusSuccess,
+	}
+	if err = c.syncLocalToPeers(ctx); err != nil {
+		result.InitialSyncErrorMessage = err.Error()
+	}
+	return result, nil
+}
+
+// PeerJoinReq - internal API handler to respond to a peer cluster's request
+// to join site replication.
+func (c *SiteReplicationSys) PeerJoinReq(ctx context.Context, arg madmin.SRPeerJoinReq) error {
+	self, ok := arg.Peers[globalDeploymentID]
+	if !ok {
+		return errSRSelfNotFound
+	}
+	if len(arg.Peers) < srMinSites || len(arg.Peers) > srMaxSites {
+		return errSRTooManySites
+	}
+
+	c.RLock()
+	enabled, state := c.enabled, c.state
+	c.RUnlock()
+	if enabled {
+		// Joining again with the same set of sites is allowed, so that
+		// a partially failed add can be retried.
+		if len(state.Peers) != len(arg.Peers) {
+			return errSRCannotJoin
+		}
+		for dID := range arg.Peers {
+			if _, ok := state.Peers[dID]; !ok {
+				return errSRCannotJoin
+			}
+		}
+	}
+
+	if err := createSiteReplicatorUser(ctx, arg.SvcAcctAccessKey, arg.SvcAcctSecretKey); err != nil {
+		return errSRBackendIssue(fmt.Errorf("unable to create site replicator user: %w", err))
+	}
+
+	return c.saveToDisk(ctx, srState{
+		Version:                 srStateFormatVersion1,
+		Name:                    self.Name,
+		Peers:                   arg.Peers,
+		ServiceAccountAccessKey: arg.SvcAcctAccessKey,
+	})
+}
+
+// createSiteReplicatorUser creates (or updates) the IAM user used for site
+// replication with an administrative policy.
+func createSiteReplicatorUser(ctx context.Context, accessKey, secretKey string) error {
+	err := globalIAMSys.CreateUser(accessKey, madmin.UserInfo{
+		SecretKey:  secretKey,
+		PolicyName: siteReplicatorPolicy,
+		Status:     madmin.AccountEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	srLogNotificationErrs(ctx, globalNotificationSys.LoadUser(accessKey, false))
+	return nil
+}
+
+// srLogNotificationErrs logs errors returned while notifying the servers of
+// this site.
+func srLogNotificationErrs(ctx context.Context, nerrs []NotificationPeerErr) {
+	for _, nerr := range nerrs {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+// MakeBucketHook - called after a bucket is created on this site. It
+// enables versioning on the bucket, creates the bucket on all peers and
+// configures replication between all sites.
+func (c *SiteReplicationSys) MakeBucketHook(ctx context.Context, bucket string, opts BucketOptions) error {
+	if err := globalBucketMetadataSys.Update(bucket, bucketVersioningConfig, enabledBucketVersioningConfig); err != nil {
+		return err
+	}
+
+	makeOpts := map[string]string{
+		"lockEnabled": fmt.Sprintf("%t", opts.LockEnabled),
+		"location":    opts.Location,
+	}
+	err := c.concDo(func(peer madmin.PeerInfo, admClient *madmin.AdminClient) error {
+		return admClient.SRPeerBucketOps(ctx, bucket, madmin.MakeWithVersioningBktOp, makeOpts)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Replication can only be configured once the bucket exists,
+	// versioned, on all sites.
+	if err = c.PeerBucketConfigureReplHandler(ctx, bucket); err != nil {
+		return err
+	}
+	return c.concDo(func(peer madmin.PeerInfo, admClient *madmin.AdminClient) error {
+		return admClient.SRPeerBucketOps(ctx, bucket, madmin.ConfigureReplBktOp, nil)
+	})
+}
+
+// PeerBucketMakeWithVersioningHandler - creates the bucket, if it does not
+// exist yet, and enables versioning on it.
+func (c *SiteReplicationSys) PeerBucketMakeWithVersioningHandler(ctx context.Context, bucket string, opts BucketOptions) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	err := objAPI.MakeBucketWithLocation(ctx, bucket, opts)
+	if err != nil {
+		switch err.(type) {
+		case BucketExists, BucketAlreadyExists, BucketAlreadyOwnedByYou:
+		default:
+			return err
+		}
+	}
+	globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
+
+	return globalBucketMetadataSys.Update(bucket, bucketVersioningConfig, enabledBucketVersioningConfig)
+}
+
+// PeerBucketConfigureReplHandler - configures replication of the bucket
+// from this site to all other sites, using the replicator user. Each
+// site is a replication target of the bucket, with its own rule.
+func (c *SiteReplicationSys) PeerBucketConfigureReplHandler(ctx context.Context, bucket string) error {
+	cred, err := c.getReplicatorCreds()
+	if err != nil {
+		return err
+	}
+
+	peers := c.remotePeers()
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].DeploymentID < peers[j].DeploymentID
+	})
+	var replicationConfig replication.Config
+	for i, peer := range peers {
+		epURL, err := url.Parse(peer.Endpoint)
+		if err != nil {
+			return err
+		}
+
+		target := madmin.BucketTarget{
+			SourceBucket: bucket,
+			Endpoint:     epURL.Host,
+			Credentials: &auth.Credentials{
+				AccessKey: cred.AccessKey,
+				SecretKey: cred.SecretKey,
+			},
+			TargetBucket: bucket,
+			Secure:       epURL.Scheme == "https",
+			API:          "s3v4",
+			Type:         madmin.ReplicationService,
+		}
+
+		// Re-use the ARN of an existing target for this peer so that the
+		// operation can be safely retried.
+		update := false
+		for _, t := range globalBucketTargetSys.ListTargets(ctx, bucket, string(madmin.ReplicationService)) {
+			if t.TargetBucket == bucket && t.URL().String() == target.URL().String() {
+				target.Arn = t.Arn
+				update = true
+				break
+			}
+		}
+		if !update {
+			target.Arn = globalBucketTargetSys.getRemoteARN(bucket, &target)
+		}
+		if err = globalBucketTargetSys.SetTarget(ctx, bucket, &target, update); err != nil {
+			return err
+		}
+		targets, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		tgtBytes, err := json.Marshal(&targets)
+		if err != nil {
+			return err
+		}
+		if err = globalBucketMetadataSys.Update(bucket, bucketTargetsFile, tgtBytes); err != nil {
+			return err
+		}
+
+		replicationConfig.Rules = append(replicationConfig.Rules, replication.Rule{
+			ID:                        "site-repl-" + peer.DeploymentID,
+			Status:                    replication.Enabled,
+			Priority:                  10 + i,
+			DeleteMarkerReplication:   replication.DeleteMarkerReplication{Status: replication.Enabled},
+			DeleteReplication:         replication.DeleteReplication{Status: replication.Enabled},
+			ExistingObjectReplication: replication.ExistingObjectReplication{Status: replication.Enabled},
+			Destination:               replication.Destination{Bucket: bucket, ARN: target.Arn},
+		})
+	}
+	if len(replicationConfig.Rules) == 0 {
+		return nil
+	}
+	if err = replicationConfig.Validate(bucket, false); err != nil {
+		return err
+	}
+	configData, err := xml.Marshal(replicationConfig)
+	if err != nil {
+		return err
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, configData)
+}
+
+// DeleteBucketHook - called after a bucket is deleted on this site, to
+// delete it on all peers.
+func (c *SiteReplicationSys) DeleteBucketHook(ctx context.Context, bucket string, forceDelete bool) error {
+	op := madmin.DeleteBucketBktOp
+	if forceDelete {
+		op = madmin.ForceDeleteBucketBktOp
+	}
+	return c.concDo(func(peer madmin.PeerInfo, admClient *madmin.AdminClient) error {
+		return admClient.SRPeerBucketOps(ctx, bucket, op, nil)
+	})
+}
+
+// PeerBucketDeleteHandler - deletes the bucket on this site, if it exists.
+func (c *SiteReplicationSys) PeerBucketDeleteHandler(ctx context.Context, bucket string, forceDelete bool) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if err := objAPI.DeleteBucket(ctx, bucket, forceDelete); err != nil {
+		if _, ok := err.(BucketNotFound); ok {
+			return nil
+		}
+		return err
+	}
+	globalNotificationSys.DeleteBucketMetadata(ctx, bucket)
+	return nil
+}
+
+// IAMChangeHook - called after an IAM entity is changed on this site, to
+// replicate the change to all peers.
+func (c *SiteReplicationSys) IAMChangeHook(ctx context.Context, item madmin.SRIAMItem) error {
+	return c.concDo(func(peer madmin.PeerInfo, admClient *madmin.AdminClient) error {
+		return admClient.SRPeerReplicateIAMItem(ctx, item)
+	})
+}
+
+// PeerIAMItemHandler - applies an IAM change replicated from a peer.
+func (c *SiteReplicationSys) PeerIAMItemHandler(ctx context.Context, item madmin.SRIAMItem) error {
+	switch item.Type {
+	case madmin.SRIAMItemPolicy:
+		return c.peerPolicyHandler(ctx, item.Name, item.Policy)
+	case madmin.SRIAMItemUser:
+		if item.UserInfo == nil {
+			return errSRInvalidRequest(errInvalidArgument)
+		}
+		return c.peerUserHandler(ctx, *item.UserInfo)
+	case madmin.SRIAMItemGroupInfo:
+		if item.GroupInfo == nil {
+			return errSRInvalidRequest(errInvalidArgument)
+		}
+		return c.peerGroupHandler(ctx, *item.GroupInfo)
+	case madmin.SRIAMItemPolicyMapping:
+		if item.PolicyMapping == nil {
+			return errSRInvalidRequest(errInvalidArgument)
+		}
+		mapping := item.PolicyMapping
+		if err := globalIAMSys.PolicyDBSet(mapping.UserOrGroup, mapping.Policy, mapping.IsGroup); err != nil {
+			return err
+		}
+		srLogNotificationErrs(ctx, globalNotificationSys.LoadPolicyMapping(mapping.UserOrGroup, mapping.IsGroup))
+		return nil
+	case madmin.SRIAMItemSvcAcc:
+		if item.SvcAccChange == nil {
+			return errSRInvalidRequest(errInvalidArgument)
+		}
+		return c.peerSvcAccHandler(ctx, *item.SvcAccChange)
+	}
+	return errSRInvalidRequest(fmt.Errorf("unknown IAM item type %q", item.Type))
+}
+
+func (c *SiteReplicationSys) peerPolicyHandler(ctx context.Context, name string, policy json.RawMessage) error {
+	if name == "" {
+		return errSRInvalidRequest(errInvalidArgument)
+	}
+	if len(policy) == 0 {
+		if err := globalIAMSys.DeletePolicy(name); err != nil {
+			return err
+		}
+		srLogNotificationErrs(ctx, globalNotificationSys.DeletePolicy(name))
+		return nil
+	}
+
+	p, err := iampolicy.ParseConfig(bytes.NewReader(policy))
+	if err != nil {
+		return err
+	}
+	if err = globalIAMSys.SetPolicy(name, *p); err != nil {
+		return err
+	}
+	srLogNotificationErrs(ctx, globalNotificationSys.LoadPolicy(name))
+	return nil
+}
+
+func (c *SiteReplicationSys) peerUserHandler(ctx context.Context, info madmin.SRUserInfo) error {
+	if info.AccessKey == "" {
+		return errSRInvalidRequest(errInvalidArgument)
+	}
+	if info.IsDeleteReq {
+		if err := globalIAMSys.DeleteUser(info.AccessKey); err != nil && err != errNoSuchUser {
+			return err
+		}
+		srLogNotificationErrs(ctx, globalNotificationSys.DeleteUser(info.AccessKey))
+		return nil
+	}
+
+	if err := globalIAMSys.CreateUser(info.AccessKey, info.UserInfo); err != nil {
+		return err
+	}
+	srLogNotificationErrs(ctx, globalNotificationSys.LoadUser(info.AccessKey, false))
+	return nil
+}
+
+func (c *SiteReplicationSys) peerGroupHandler(ctx context.Context, info madmin.SRGroupInfo) error {
+	req := info.UpdateReq
+	var err error
+	switch {
+	case info.Status == statusEnabled:
+		err = globalIAMSys.SetGroupStatus(req.Group, true)
+	case info.Status == statusDisabled:
+		err = globalIAMSys.SetGroupStatus(req.Group, false)
+	case info.Status != "":
+		err = errSRInvalidRequest(fmt.Errorf("invalid group status %q", info.Status))
+	case req.IsRemove:
+		err = globalIAMSys.RemoveUsersFromGroup(req.Group, req.Members)
+		if err == errNoSuchGroup {
+			err = nil
+		}
+	default:
+		err = globalIAMSys.AddUsersToGroup(req.Group, req.Members)
+	}
+	if err != nil {
+		return err
+	}
+	srLogNotificationErrs(ctx, globalNotificationSys.LoadGroup(req.Group))
+	return nil
+}
+
+func (c *SiteReplicationSys) peerSvcAccHandler(ctx context.Context, change madmin.SRSvcAccChange) error {
+	parseSessionPolicy := func(b json.RawMessage) (*iampolicy.Policy, error) {
+		if len(b) == 0 || string(b) == "null" {
+			return nil, nil
+		}
+		return iampolicy.ParseConfig(bytes.NewReader(b))
+	}
+
+	switch {
+	case change.Create != nil:
+		cr := change.Create
+		sp, err := parseSessionPolicy(cr.SessionPolicy)
+		if err != nil {
+			return err
+		}
+		opts := newServiceAccountOpts{sessionPolicy: sp, accessKey: cr.AccessKey, secretKey: cr.SecretKey}
+		if _, err = globalIAMSys.NewServiceAccount(ctx, cr.Parent, cr.Groups, opts); err != nil {
+			return err
+		}
+		if cr.Status != "" && cr.Status != auth.AccountOn {
+			if err = globalIAMSys.UpdateServiceAccount(ctx, cr.AccessKey, updateServiceAccountOpts{status: cr.Status}); err != nil {
+				return err
+			}
+		}
+		srLogNotificationErrs(ctx, globalNotificationSys.LoadServiceAccount(cr.AccessKey))
+	case change.Update != nil:
+		up := change.Update
+		sp, err := parseSessionPolicy(up.SessionPolicy)
+		if err != nil {
+			return err
+		}
+		opts := updateServiceAccountOpts{sessionPolicy: sp, secretKey: up.SecretKey, status: up.Status}
+		if err = globalIAMSys.UpdateServiceAccount(ctx, up.AccessKey, opts); err != nil {
+			return err
+		}
+		srLogNotificationErrs(ctx, globalNotificationSys.LoadServiceAccount(up.AccessKey))
+	case change.Delete != nil:
+		if err := globalIAMSys.DeleteServiceAccount(ctx, change.Delete.AccessKey); err != nil {
+			return err
+		}
+		srLogNotificationErrs(ctx, globalNotificationSys.DeleteUser(change.Delete.AccessKey))
+	default:
+		return errSRInvalidRequest(errInvalidArgument)
+	}
+	return nil
+}
+
+// BucketMetaHook - called after a bucket metadata configuration is changed
+// on this site, to replicate the change to all peers.
+func (c *SiteReplicationSys) BucketMetaHook(ctx context.Context, item madmin.SRBucketMeta) error {
+	return c.concDo(func(peer madmin.PeerInfo, admClient *madmin.AdminClient) error {
+		return admClient.SRPeerReplicateBucketMeta(ctx, item)
+	})
+}
+
+// PeerBucketMetaHandler - applies a bucket metadata change replicated from
+// a peer.
+func (c *SiteReplicationSys) PeerBucketMetaHandler(ctx context.Context, item madmin.SRBucketMeta) error {
+	configFile, ok := srBucketMetaConfigFiles[item.Type]
+	if !ok {
+		return errSRInvalidRequest(fmt.Errorf("unknown bucket metadata type %q", item.Type))
+	}
+	if len(item.Config) == 0 {
+		item.Config = nil
+	}
+	return globalBucketMetadataSys.Update(item.Bucket, configFile, item.Config)
+}
+
+// srHookBucketMeta replicates a bucket metadata change made on this site to
+// all peers, if site replication is enabled.
+func srHookBucketMeta(ctx context.Context, metaType, bucket string, config []byte) error {
+	if !globalSiteReplicationSys.isEnabled() {
+		return nil
+	}
+	return globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
+		Type:   metaType,
+		Bucket: bucket,
+		Config: config,
+	})
+}
+
+// srHookIAM replicates an IAM change made on this site to all peers, if
+// site replication is enabled.
+func srHookIAM(ctx context.Context, item madmin.SRIAMItem) error {
+	if !globalSiteReplicationSys.isEnabled() {
+		return nil
+	}
+	return globalSiteReplicationSys.IAMChangeHook(ctx, item)
+}
+
+// syncLocalToPeers replicates all buckets, IAM entities and bucket
+// metadata of this site to all peers. It is run once when sites are added.
+func (c *SiteReplicationSys) syncLocalToPeers(ctx context.Context) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// IAM entities are replicated first, as bucket policies and
+	// replication may depend on them.
+	policies, err := globalIAMSys.ListPolicies()
+	if err != nil {
+		return err
+	}
+	for name, p := range policies {
+		policyJSON, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		if err = c.IAMChangeHook(ctx, madmin.SRIAMItem{
+			Type:   madmin.SRIAMItemPolicy,
+			Name:   name,
+			Policy: policyJSON,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Users are only managed by MinIO when no external identity provider
+	// is configured.
+	if globalIAMSys.usersSysType == MinIOUsersSysType {
+		users, err := globalIAMSys.ListUsers()
+		if err != nil {
+			return err
+		}
+		for accessKey, info := range users {
+			if accessKey == siteReplicatorSvcAcc {
+				continue
+			}
+			// GetUser reports disabled users as not found but still
+			// returns their credentials.
+			cred, _ := globalIAMSys.GetUser(accessKey)
+			if cred.AccessKey == "" {
+				continue
+			}
+			info.SecretKey = cred.SecretKey
+			if err = c.IAMChangeHook(ctx, madmin.SRIAMItem{
+				Type: madmin.SRIAMItemUser,
+				UserInfo: &madmin.SRUserInfo{
+					AccessKey: accessKey,
+					UserInfo:  info,
+				},
+			}); err != nil {
+				return err
+			}
+		}
+
+		groups, err := globalIAMSys.ListGroups()
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			gd, err := globalIAMSys.GetGroupDescription(group)
+			if err != nil {
+				return err
+			}
+			if err = c.IAMChangeHook(ctx, madmin.SRIAMItem{
+				Type: madmin.SRIAMItemGroupInfo,
+				GroupInfo: &madmin.SRGroupInfo{
+					UpdateReq: madmin.GroupAddRemove{Group: group, Members: gd.Members},
+				},
+			}); err != nil {
+				return err
+			}
+			if gd.Status == statusDisabled {
+				if err = c.IAMChangeHook(ctx, madmin.SRIAMItem{
+					Type: madmin.SRIAMItemGroupInfo,
+					GroupInfo: &madmin.SRGroupInfo{
+						UpdateReq: madmin.GroupAddRemove{Group: group},
+						Status:    statusDisabled,
+					},
+				}); err != nil {
+					return err
+				}
+			}
+			if gd.Policy != "" {
+				if err = c.IAMChangeHook(ctx, madmin.SRIAMItem{
+					Type: madmin.SRIAMItemPolicyMapping,
+					PolicyMapping: &madmin.SRPolicyMapping{
+						UserOrGroup: group,
+						IsGroup:     true,
+						Policy:      gd.Policy,
+					},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		for parent := range users {
+			if parent == siteReplicatorSvcAcc {
+				continue
+			}
+			svcAccs, err := globalIAMSys.ListServiceAccounts(ctx, parent)
+			if err != nil {
+				return err
+			}
+			for _, sa := range svcAccs {
+				_, sp, err := globalIAMSys.GetServiceAccount(ctx, sa.AccessKey)
+				if err != nil {
+					return err
+				}
+				saCred, _ := globalIAMSys.GetUser(sa.AccessKey)
+				var spJSON []byte
+				if sp != nil {
+					if spJSON, err = json.Marshal(sp); err != nil {
+						return err
+					}
+				}
+				if err = c.IAMChangeHook(ctx, madmin.SRIAMItem{
+					Type: madmin.SRIAMItemSvcAcc,
+					SvcAccChange: &madmin.SRSvcAccChange{
+						Create: &madmin.SRSvcAccCreate{
+							Parent:        parent,
+							AccessKey:     sa.AccessKey,
+							SecretKey:     saCred.SecretKey,
+							Groups:        sa.Groups,
+							SessionPolicy: spJSON,
+							Status:        sa.Status,
+						},
+					},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		lockEnabled := false
+		if rcfg, err := globalBucketObjectLockSys.Get(bucket.Name); err == nil {
+			lockEnabled = rcfg.LockEnabled
+		}
+		if err = c.MakeBucketHook(ctx, bucket.Name, BucketOptions{LockEnabled: lockEnabled}); err != nil {
+			return err
+		}
+
+		meta, err := globalBucketMetadataSys.GetConfig(bucket.Name)
+		if err != nil {
+			return err
+		}
+		for metaType, config := range srBucketMetaConfigs(meta) {
+			if err = c.BucketMetaHook(ctx, madmin.SRBucketMeta{
+				Type:   metaType,
+				Bucket: bucket.Name,
+				Config: config,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// srChecksum returns a hex encoded checksum of b.
+func srChecksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// SiteReplicationMetaInfo - returns the replicated state of this site.
+func (c *SiteReplicationSys) SiteReplicationMetaInfo(ctx context.Context, objAPI ObjectLayer) (info madmin.SRInfo, err error) {
+	c.RLock()
+	info.Enabled = c.enabled
+	info.Name = c.state.Name
+	c.RUnlock()
+	info.DeploymentID = globalDeploymentID
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return info, err
+	}
+	info.Buckets = make(map[string]madmin.SRBucketInfo, len(buckets))
+	for _, bucket := range buckets {
+		meta, err := globalBucketMetadataSys.GetConfig(bucket.Name)
+		if err != nil && err != errConfigNotFound {
+			return info, err
+		}
+		bi := madmin.SRBucketInfo{
+			Bucket:             bucket.Name,
+			CreatedAt:          bucket.Created,
+			ReplicationEnabled: len(meta.ReplicationConfigXML) > 0,
+			Configs:            make(map[string]string),
+		}
+		for metaType, config := range srBucketMetaConfigs(meta) {
+			bi.Configs[metaType] = srChecksum(config)
+		}
+		info.Buckets[bucket.Name] = bi
+	}
+
+	policies, err := globalIAMSys.ListPolicies()
+	if err != nil {
+		return info, err
+	}
+	info.Policies = make(map[string]string, len(policies))
+	for name, p := range policies {
+		policyJSON, err := json.Marshal(p)
+		if err != nil {
+			return info, err
+		}
+		info.Policies[name] = srChecksum(policyJSON)
+	}
+
+	if globalIAMSys.usersSysType == MinIOUsersSysType {
+		users, err := globalIAMSys.ListUsers()
+		if err != nil {
+			return info, err
+		}
+		delete(users, siteReplicatorSvcAcc)
+		info.Users = users
+
+		groups, err := globalIAMSys.ListGroups()
+		if err != nil {
+			return info, err
+		}
+		info.Groups = make(map[string]madmin.GroupDesc, len(groups))
+		for _, group := range groups {
+			gd, err := globalIAMSys.GetGroupDescription(group)
+			if err != nil {
+				return info, err
+			}
+			sort.Strings(gd.Members)
+			info.Groups[group] = gd
+		}
+	}
+	return info, nil
+}
+
+// srOutOfSync returns the sites that are out of sync for an entity: the
+// sites where it is absent, and the sites where it differs from the value
+// held by a majority of the sites that have it (all of them if there is no
+// such majority). values maps a deployment ID to a fingerprint of the
+// entity on that site; a missing fingerprint means the entity is absent.
+func srOutOfSync(values map[string]string, sites []string) []string {
+	counts := make(map[string]int)
+	present := 0
+	for _, dID := range sites {
+		if v, ok := values[dID]; ok {
+			counts[v]++
+			present++
+		}
+	}
+
+	majority := ""
+	for v, n := range counts {
+		if 2*n > present {
+			majority = v
+		}
+	}
+
+	var outOfSync []string
+	for _, dID := range sites {
+		v, ok := values[dID]
+		if !ok || (len(counts) > 1 && v != majority) {
+			outOfSync = append(outOfSync, dID)
+		}
+	}
+	return outOfSync
+}
+
+// SiteReplicationStatus - returns the replication status of IAM entities
+// and bucket metadata across all sites.
+func (c *SiteReplicationSys) SiteReplicationStatus(ctx context.Context, objAPI ObjectLayer) (info madmin.SRStatusInfo, err error) {
+	if !c.isEnabled() {
+		return info, nil
+	}
+
+	c.RLock()
+	info.Sites = make(map[string]madmin.PeerInfo, len(c.state.Peers))
+	for dID, peer := range c.state.Peers {
+		info.Sites[dID] = peer
+	}
+	c.RUnlock()
+	info.Enabled = true
+
+	self, err := c.SiteReplicationMetaInfo(ctx, objAPI)
+	if err != nil {
+		return info, err
+	}
+	sris := map[string]madmin.SRInfo{globalDeploymentID: self}
+
+	var mu sync.Mutex
+	_ = c.concDo(func(peer madmin.PeerInfo, admClient *madmin.AdminClient) error {
+		sri, err := admClient.SRPeerGetMetaInfo(ctx)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if info.Errors == nil {
+				info.Errors = make(map[string]string)
+			}
+			info.Errors[peer.DeploymentID] = err.Error()
+			return err
+		}
+		sris[peer.DeploymentID] = sri
+		return nil
+	})
+
+	sites := make([]string, 0, len(sris))
+	for dID := range sris {
+		sites = append(sites, dID)
+	}
+	sort.Strings(sites)
+
+	info.StatsSummary = make(map[string]madmin.SRSiteSummary, len(sris))
+	bucketVals := make(map[string]map[string]string)
+	policyVals := make(map[string]map[string]string)
+	userVals := make(map[string]map[string]string)
+	groupVals := make(map[string]map[string]string)
+	addVal := func(vals map[string]map[string]string, name, dID, v string) {
+		if vals[name] == nil {
+			vals[name] = make(map[string]string)
+		}
+		vals[name][dID] = v
+	}
+
+	for dID, sri := range sris {
+		summary := madmin.SRSiteSummary{
+			TotalBucketsCount:  len(sri.Buckets),
+			TotalPoliciesCount: len(sri.Policies),
+			TotalUsersCount:    len(sri.Users),
+			TotalGroupsCount:   len(sri.Groups),
+		}
+		for name, bi := range sri.Buckets {
+			if bi.ReplicationEnabled {
+				summary.ReplicatedBucketsCount++
+			}
+			metaTypes := make([]string, 0, len(bi.Configs))
+			for metaType := range bi.Configs {
+				metaTypes = append(metaTypes, metaType)
+			}
+			sort.Strings(metaTypes)
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "replication=%t", bi.ReplicationEnabled)
+			for _, metaType := range metaTypes {
+				fmt.Fprintf(&sb, ";%s=%s", metaType, bi.Configs[metaType])
+			}
+			addVal(bucketVals, name, dID, sb.String())
+		}
+		for name, sum := range sri.Policies {
+			addVal(policyVals, name, dID, sum)
+		}
+		for name, ui := range sri.Users {
+			addVal(userVals, name, dID, fmt.Sprintf("status=%s;policy=%s", ui.Status, ui.PolicyName))
+		}
+		for name, gd := range sri.Groups {
+			addVal(groupVals, name, dID, fmt.Sprintf("status=%s;policy=%s;members=%s", gd.Status, gd.Policy, strings.Join(gd.Members, ",")))
+		}
+		info.StatsSummary[dID] = summary
+	}
+
+	outOfSync := func(vals map[string]map[string]string) map[string][]string {
+		res := make(map[string][]string)
+		for name, v := range vals {
+			if dIDs := srOutOfSync(v, sites); len(dIDs) > 0 {
+				res[name] = dIDs
+			}
+		}
+		return res
+	}
+	info.BucketsOutOfSync = outOfSync(bucketVals)
+	info.PoliciesOutOfSync = outOfSync(policyVals)
+	info.UsersOutOfSync = outOfSync(userVals)
+	info.GroupsOutOfSync = outOfSync(groupVals)
+	return info, nil
+}
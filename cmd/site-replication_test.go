@@ -0,0 +1,136 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestSROutOfSync(t *testing.T) {
+	sites := []string{"a", "b", "c"}
+	testCases := []struct {
+		values   map[string]string
+		expected []string
+	}{
+		// In sync on all sites.
+		{map[string]string{"a": "x", "b": "x", "c": "x"}, nil},
+		// Absent on one site.
+		{map[string]string{"a": "x", "b": "x"}, []string{"c"}},
+		// Differs from the majority on one site.
+		{map[string]string{"a": "x", "b": "y", "c": "x"}, []string{"b"}},
+		// No majority, all sites are out of sync.
+		{map[string]string{"a": "x", "b": "y", "c": "z"}, []string{"a", "b", "c"}},
+		// Present on a single site only.
+		{map[string]string{"b": "y"}, []string{"a", "c"}},
+		// Two sites that disagree have no majority.
+		{map[string]string{"a": "x", "b": "y"}, []string{"a", "b", "c"}},
+	}
+	for i, testCase := range testCases {
+		if got := srOutOfSync(testCase.values, sites); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestSRBucketMetaConfigs(t *testing.T) {
+	meta := newBucketMetadata("bucket")
+	meta.PolicyConfigJSON = []byte(`{"Version":"2012-10-17"}`)
+	meta.TaggingConfigXML = []byte(`<Tagging></Tagging>`)
+
+	configs := srBucketMetaConfigs(meta)
+	if len(configs) != 2 {
+		t.Fatalf("Expected 2 configs, got %d", len(configs))
+	}
+	if string(configs[madmin.SRBucketMetaTypePolicy]) != string(meta.PolicyConfigJSON) {
+		t.Fatalf("Unexpected policy config %s", configs[madmin.SRBucketMetaTypePolicy])
+	}
+	if _, ok := configs[madmin.SRBucketMetaTypeLifecycle]; ok {
+		t.Fatal("Expected unset lifecycle config to be omitted")
+	}
+	for metaType := range configs {
+		if _, ok := srBucketMetaConfigFiles[metaType]; !ok {
+			t.Fatalf("No config file for bucket metadata type %s", metaType)
+		}
+	}
+}
+
+func TestSiteReplicationSysLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	sys := NewSiteReplicationSys()
+	if err = sys.load(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if sys.isEnabled() {
+		t.Fatal("Expected site replication to be disabled without a saved state")
+	}
+
+	state := srState{
+		Version: srStateFormatVersion1,
+		Name:    "site1",
+		Peers: map[string]madmin.PeerInfo{
+			"d1": {Endpoint: "http://site1:9000", Name: "site1", DeploymentID: "d1"},
+			"d2": {Endpoint: "http://site2:9000", Name: "site2", DeploymentID: "d2"},
+		},
+		ServiceAccountAccessKey: siteReplicatorSvcAcc,
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, pathJoin(srStatePrefix, srStateFile), data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = sys.load(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if !sys.isEnabled() || !sys.isReplicatorUser(siteReplicatorSvcAcc) || sys.isReplicatorUser("user") {
+		t.Fatal("Expected site replication to be enabled with the replicator user")
+	}
+	info, err := sys.GetClusterInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Enabled || info.Name != "site1" || len(info.Sites) != 2 || info.Sites[0].Name != "site1" {
+		t.Fatalf("Unexpected site replication info %+v", info)
+	}
+
+	state.Version = 2
+	if data, err = json.Marshal(state); err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, pathJoin(srStatePrefix, srStateFile), data); err != nil {
+		t.Fatal(err)
+	}
+	if err = sys.load(ctx, objLayer); err == nil {
+		t.Fatal("Expected an error loading an unknown state version")
+	}
+}
@@ -38,6 +38,7 @@ import (
 const (
 	emptySHA256              = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
 	streamingContentSHA256   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	unsignedPayloadTrailer   = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
 	signV4ChunkedAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
 	streamingContentEncoding = "aws-chunked"
 )
@@ -0,0 +1,205 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+// maxTrailerLines is the maximum number of trailing header lines
+// accepted at the end of an unsigned streaming upload.
+const maxTrailerLines = 16
+
+// newUnsignedV4ChunkedReader returns a new s3UnsignedChunkedReader that
+// translates the data read from the body of an unsigned streaming upload,
+// i.e. 'STREAMING-UNSIGNED-PAYLOAD-TRAILER', out of the "aws-chunked" format.
+// The trailing headers sent after the final 0-length chunk are stored in
+// req.Trailer, they are parsed as soon as the last byte of the payload is
+// returned, or right away for an empty payload.
+func newUnsignedV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
+	if req.Trailer == nil {
+		req.Trailer = make(http.Header)
+	}
+	allowed := make(map[string]struct{})
+	for _, key := range strings.Split(req.Header.Get(xhttp.AmzTrailer), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			allowed[http.CanonicalHeaderKey(key)] = struct{}{}
+		}
+	}
+	cr := &s3UnsignedChunkedReader{
+		reader:   bufio.NewReader(req.Body),
+		trailers: req.Trailer,
+		allowed:  allowed,
+	}
+	// Readers stop at the decoded length and never read an empty
+	// payload, read its final chunk and the trailers right away.
+	if req.Header.Get(xhttp.AmzDecodedContentLength) == "0" {
+		if cr.err = cr.nextChunk(); cr.err != io.EOF {
+			return nil, ErrIncompleteBody
+		}
+	}
+	return cr, ErrNone
+}
+
+// Represents the overall state that is required for decoding an
+// unsigned AWS Signature V4 chunked reader.
+type s3UnsignedChunkedReader struct {
+	reader    *bufio.Reader
+	trailers  http.Header
+	allowed   map[string]struct{}
+	remaining int  // bytes left in the current chunk
+	inChunk   bool // whether the data of a chunk has been read
+	err       error
+}
+
+func (cr *s3UnsignedChunkedReader) Close() (err error) {
+	return nil
+}
+
+// Read - implements `io.Reader`, which transparently decodes
+// the incoming unsigned AWS Signature V4 streaming payload.
+func (cr *s3UnsignedChunkedReader) Read(buf []byte) (n int, err error) {
+	for n < len(buf) && cr.err == nil {
+		if cr.remaining == 0 {
+			cr.err = cr.nextChunk()
+			continue
+		}
+		m := len(buf) - n
+		if m > cr.remaining {
+			m = cr.remaining
+		}
+		m, err = cr.reader.Read(buf[n : n+m])
+		n += m
+		cr.remaining -= m
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			cr.err = err
+			break
+		}
+		if cr.remaining == 0 {
+			// Read ahead the header of the next chunk, such that the
+			// trailers are available once the payload has been read
+			// completely, even by readers which stop at the expected size.
+			cr.err = cr.nextChunk()
+		}
+	}
+	if n > 0 && cr.err == io.EOF {
+		return n, nil
+	}
+	return n, cr.err
+}
+
+// nextChunk reads the end of the current chunk and the header of the
+// following one. A chunk has the following format:
+//
+//	<chunk-size-as-hex> + "\r\n" + <payload> + "\r\n"
+//
+// The last chunk is 0-sized and followed by the trailing headers:
+//
+//	"0\r\n" + <trailer-key> + ":" + <trailer-value> + "\r\n" ... + "\r\n"
+func (cr *s3UnsignedChunkedReader) nextChunk() error {
+	if cr.inChunk {
+		if err := readCRLF(cr.reader); err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	line, err := cr.readLine()
+	if err != nil {
+		return err
+	}
+	// Ignore chunk extensions, if any.
+	if i := bytes.IndexByte(line, ';'); i >= 0 {
+		line = line[:i]
+	}
+	size, err := parseHexUint(line)
+	if err != nil || len(line) == 0 {
+		return errMalformedEncoding
+	}
+	const maxSize = 16 << 20 // 16 MiB
+	if size > maxSize {
+		return errMalformedEncoding
+	}
+	if size == 0 {
+		if err = cr.readTrailers(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	cr.remaining = int(size)
+	cr.inChunk = true
+	return nil
+}
+
+// readTrailers reads the trailing headers until an empty line or
+// the end of the body.
+func (cr *s3UnsignedChunkedReader) readTrailers() error {
+	for i := 0; ; i++ {
+		line, err := cr.readLine()
+		last := err == io.ErrUnexpectedEOF // Tolerate a missing final CRLF.
+		if err != nil && !last {
+			return err
+		}
+		if len(line) == 0 {
+			return nil
+		}
+		if i >= maxTrailerLines {
+			return errMalformedEncoding
+		}
+		idx := bytes.IndexByte(line, ':')
+		if idx <= 0 {
+			return errMalformedEncoding
+		}
+		key := http.CanonicalHeaderKey(string(bytes.TrimSpace(line[:idx])))
+		if _, ok := cr.allowed[key]; !ok {
+			// Only the trailers announced in x-amz-trailer are accepted.
+			return errMalformedEncoding
+		}
+		cr.trailers.Set(key, string(bytes.TrimSpace(line[idx+1:])))
+		if last {
+			return nil
+		}
+	}
+}
+
+// readLine reads a line terminated by "\r\n" or "\n" without the
+// line ending, lines longer than maxLineLength are rejected.
+func (cr *s3UnsignedChunkedReader) readLine() ([]byte, error) {
+	buf, err := cr.reader.ReadSlice('\n')
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		} else if err == bufio.ErrBufferFull {
+			err = errLineTooLong
+		}
+		return trimTrailingWhitespace(buf), err
+	}
+	if len(buf) >= maxLineLength {
+		return nil, errLineTooLong
+	}
+	return trimTrailingWhitespace(buf), nil
+}
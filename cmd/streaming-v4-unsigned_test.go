@@ -0,0 +1,190 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/minio/minio/pkg/hash"
+)
+
+func TestUnsignedV4ChunkedReader(t *testing.T) {
+	testCases := []struct {
+		body        string
+		trailer     string
+		expected    string
+		trailers    map[string]string
+		expectedErr error
+	}{
+		// Test - 1 - multiple chunks with a trailing checksum.
+		{
+			body:     "5\r\nhello\r\n6\r\n world\r\n0\r\nx-amz-checksum-crc32c:yZRlqg==\r\n\r\n",
+			trailer:  "x-amz-checksum-crc32c",
+			expected: "hello world",
+			trailers: map[string]string{"X-Amz-Checksum-Crc32c": "yZRlqg=="},
+		},
+		// Test - 2 - missing final CRLF is tolerated.
+		{
+			body:     "5\r\nhello\r\n0\r\nx-amz-checksum-crc32:NhCmhg==\r\n",
+			trailer:  "x-amz-checksum-crc32",
+			expected: "hello",
+			trailers: map[string]string{"X-Amz-Checksum-Crc32": "NhCmhg=="},
+		},
+		// Test - 3 - chunk extensions are ignored, no trailers.
+		{
+			body:     "5;ext=1\r\nhello\r\n0\r\n\r\n",
+			expected: "hello",
+			trailers: map[string]string{},
+		},
+		// Test - 4 - trailer not announced in x-amz-trailer.
+		{
+			body:        "5\r\nhello\r\n0\r\nx-amz-checksum-sha1:qvTGHdzF6KLavt4PO0gs2a6pQ00=\r\n\r\n",
+			trailer:     "x-amz-checksum-crc32",
+			expectedErr: errMalformedEncoding,
+		},
+		// Test - 5 - invalid chunk size.
+		{
+			body:        "zz\r\nhello\r\n0\r\n\r\n",
+			expectedErr: errMalformedEncoding,
+		},
+		// Test - 6 - truncated chunk.
+		{
+			body:        "a\r\nhello",
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+	}
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", bytes.NewReader([]byte(testCase.body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testCase.trailer != "" {
+			req.Header.Set("x-amz-trailer", testCase.trailer)
+		}
+		reader, s3Err := newUnsignedV4ChunkedReader(req)
+		if s3Err != ErrNone {
+			t.Fatalf("Test %d: unexpected error %v", i+1, s3Err)
+		}
+		data, err := ioutil.ReadAll(reader)
+		if testCase.expectedErr != nil {
+			if err == nil || (err != testCase.expectedErr && err.Error() != testCase.expectedErr.Error()) {
+				t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if string(data) != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, string(data))
+		}
+		if len(req.Trailer) != len(testCase.trailers) {
+			t.Errorf("Test %d: expected trailers %v, got %v", i+1, testCase.trailers, req.Trailer)
+		}
+		for k, v := range testCase.trailers {
+			if got := req.Trailer.Get(k); got != v {
+				t.Errorf("Test %d: expected trailer %s=%s, got %s", i+1, k, v, got)
+			}
+		}
+	}
+}
+
+func TestUnsignedV4ChunkedReaderChecksum(t *testing.T) {
+	testCases := []struct {
+		checksum    string
+		expectedErr bool
+	}{
+		{"yZRlqg==", false},
+		{"AAAAAA==", true},
+	}
+	for i, testCase := range testCases {
+		body := "5\r\nhello\r\n6\r\n world\r\n0\r\nx-amz-checksum-crc32c:" + testCase.checksum + "\r\n\r\n"
+		req, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", bytes.NewReader([]byte(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("x-amz-trailer", "x-amz-checksum-crc32c")
+		reader, s3Err := newUnsignedV4ChunkedReader(req)
+		if s3Err != ErrNone {
+			t.Fatalf("Test %d: unexpected error %v", i+1, s3Err)
+		}
+		// The hash reader stops at the decoded length, the trailing
+		// checksum must be available at that point.
+		hr, err := hash.NewReader(reader, 11, "", "", 11)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = hr.AddChecksum(req, false); err != nil {
+			t.Fatal(err)
+		}
+		_, err = ioutil.ReadAll(hr)
+		if testCase.expectedErr != (err != nil) {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+		if !testCase.expectedErr {
+			if cs := hr.ContentChecksum(); cs == nil || cs.Encoded != testCase.checksum {
+				t.Errorf("Test %d: expected checksum %s, got %v", i+1, testCase.checksum, cs)
+			}
+		}
+	}
+}
+
+func TestUnsignedV4ChunkedReaderEmpty(t *testing.T) {
+	testCases := []struct {
+		body        string
+		expectedErr APIErrorCode
+		checksumErr bool
+	}{
+		{"0\r\nx-amz-checksum-crc32c:AAAAAA==\r\n\r\n", ErrNone, false},
+		{"0\r\nx-amz-checksum-crc32c:yZRlqg==\r\n\r\n", ErrNone, true},
+		{"0\r\n\r\n", ErrNone, true},
+		{"5\r\nhello\r\n0\r\n\r\n", ErrIncompleteBody, false},
+		{"0\r\nx-amz-meta-foo:bar\r\n\r\n", ErrIncompleteBody, false},
+	}
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", bytes.NewReader([]byte(testCase.body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("x-amz-trailer", "x-amz-checksum-crc32c")
+		req.Header.Set("x-amz-decoded-content-length", "0")
+		reader, s3Err := newUnsignedV4ChunkedReader(req)
+		if s3Err != testCase.expectedErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, s3Err)
+		}
+		if s3Err != ErrNone {
+			continue
+		}
+		// The hash reader never reads from an empty payload, the
+		// trailing checksum must be available nonetheless.
+		hr, err := hash.NewReader(reader, 0, "", "", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = hr.AddChecksum(req, false); err != nil {
+			t.Fatal(err)
+		}
+		_, err = ioutil.ReadAll(hr)
+		if testCase.checksumErr != (err != nil) {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.checksumErr, err)
+		}
+	}
+}
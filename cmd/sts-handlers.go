@@ -41,6 +41,7 @@ const (
 	stsPolicy           = "Policy"
 	stsToken            = "Token"
 	stsWebIdentityToken = "WebIdentityToken"
+	stsRoleArn          = "RoleArn"
 	stsDurationSeconds  = "DurationSeconds"
 	stsLDAPUsername     = "LDAPUsername"
 	stsLDAPPassword     = "LDAPPassword"
@@ -291,17 +292,19 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	v, err := globalOpenIDValidators.Get("jwt")
-	if err != nil {
-		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
-		return
-	}
-
 	token := r.Form.Get(stsToken)
 	if token == "" {
 		token = r.Form.Get(stsWebIdentityToken)
 	}
 
+	// Select the OpenID provider by the requested role, or by the
+	// issuer of the token.
+	v, err := globalOpenIDValidators.GetJWT(r.Form.Get(stsRoleArn), token)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
 	m, err := v.Validate(token, r.Form.Get(stsDurationSeconds))
 	if err != nil {
 		switch err {
@@ -324,18 +327,30 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	// JWT has requested a custom claim with policy value set.
 	// This is a MinIO STS API specific value, this value should
 	// be set and configured on your identity provider as part of
-	// JWT custom claims.
+	// JWT custom claims. Providers with a role policy assign the
+	// same policy to all of their users instead.
 	var policyName string
-	policySet, ok := iampolicy.GetPoliciesFromClaims(m, iamPolicyClaimNameOpenID())
-	if ok {
-		policyName = globalIAMSys.CurrentPolicies(strings.Join(policySet.ToSlice(), ","))
-	}
+	if v.RolePolicy != "" {
+		policyName = globalIAMSys.CurrentPolicies(v.RolePolicy)
+		if policyName == "" && globalPolicyOPA == nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+				fmt.Errorf("role policy %s of %s does not exist, credentials will not be generated", v.RolePolicy, v.RoleArn()))
+			return
+		}
+	} else {
+		policySet, ok := iampolicy.GetPoliciesFromClaims(m, v.PolicyClaimName())
+		if ok {
+			policyName = globalIAMSys.CurrentPolicies(strings.Join(policySet.ToSlice(), ","))
+		}
 
-	if policyName == "" && globalPolicyOPA == nil {
-		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
-			fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", iamPolicyClaimNameOpenID()))
-		return
+		if policyName == "" && globalPolicyOPA == nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+				fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", v.PolicyClaimName()))
+			return
+		}
 	}
+	// Temporary credentials carry the policy in the policy claim of
+	// the default provider, independent of the issuing provider.
 	m[iamPolicyClaimNameOpenID()] = policyName
 
 	sessionPolicyStr := r.Form.Get(stsPolicy)
@@ -0,0 +1,136 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/identity/openid"
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+// Tests that AssumeRoleWithWebIdentity rejects tokens that were not
+// issued by, or for the client of, the OpenID provider selected by
+// the role ARN.
+func TestAssumeRoleWithWebIdentityCrossClient(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var issuer string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			fmt.Fprintf(w, `{"issuer": %q, "jwks_uri": %q}`, issuer, issuer+"/jwks")
+		case "/jwks":
+			fmt.Fprintf(w, `{"keys": [{"kty": "RSA", "kid": "key", "n": %q, "e": %q}]}`,
+				base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	issuer = ts.URL
+
+	kvsMap := map[string]config.KVS{
+		"apps": {
+			config.KV{Key: openid.ConfigURL, Value: ts.URL + "/.well-known/openid-configuration"},
+			config.KV{Key: openid.ClientID, Value: "apps"},
+			config.KV{Key: openid.RolePolicy, Value: "readwrite"},
+		},
+		"web": {
+			config.KV{Key: openid.ConfigURL, Value: ts.URL + "/.well-known/openid-configuration"},
+			config.KV{Key: openid.ClientID, Value: "web"},
+		},
+	}
+	cfgs, err := openid.LookupConfigs(kvsMap, ts.Client().Transport.(*http.Transport), xhttp.DrainBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	savedValidators := globalOpenIDValidators
+	defer func() { globalOpenIDValidators = savedValidators }()
+	globalOpenIDValidators = getOpenIDValidators(cfgs)
+
+	newToken := func(claims jwtgo.MapClaims) string {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, claims)
+		token.Header["kid"] = "key"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	testCases := []struct {
+		roleArn string
+		claims  jwtgo.MapClaims
+		errMsg  string
+	}{
+		// Token of another client of the same issuer.
+		{"arn:minio:iam:::role/apps", jwtgo.MapClaims{"iss": issuer, "aud": "web"}, openid.ErrTokenAudience.Error()},
+		{"arn:minio:iam:::role/apps", jwtgo.MapClaims{"iss": issuer, "azp": "web", "aud": []string{"web", "account"}}, openid.ErrTokenAudience.Error()},
+		{"arn:minio:iam:::role/apps", jwtgo.MapClaims{"iss": issuer}, openid.ErrTokenAudience.Error()},
+		// Token of another issuer sharing the keys.
+		{"arn:minio:iam:::role/apps", jwtgo.MapClaims{"iss": "https://other.example.com", "aud": "apps"}, openid.ErrTokenIssuer.Error()},
+		// Token of an unknown client without a role ARN.
+		{"", jwtgo.MapClaims{"iss": issuer, "aud": "other"}, openid.ErrNoProvider.Error()},
+		{"arn:minio:iam:::role/unknown", jwtgo.MapClaims{"iss": issuer, "aud": "apps"}, openid.ErrNoProvider.Error()},
+	}
+
+	for i, testCase := range testCases {
+		form := url.Values{}
+		form.Set(stsVersion, stsAPIVersion)
+		form.Set(stsAction, webIdentity)
+		form.Set(stsWebIdentityToken, newToken(testCase.claims))
+		if testCase.roleArn != "" {
+			form.Set(stsRoleArn, testCase.roleArn)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		(&stsAPIHandlers{}).AssumeRoleWithSSO(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("Test %d: got status %d, want %d: %s", i+1, rec.Code, http.StatusBadRequest, rec.Body.String())
+		}
+		var errResp STSErrorResponse
+		if err = xml.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if errResp.Error.Code != "InvalidParameterValue" || errResp.Error.Message != testCase.errMsg {
+			t.Fatalf("Test %d: got error %s: %s, want InvalidParameterValue: %s", i+1, errResp.Error.Code, errResp.Error.Message, testCase.errMsg)
+		}
+	}
+}
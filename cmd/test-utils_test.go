@@ -1437,6 +1437,38 @@ func getBucketLifecycleURL(endPoint, bucketName string) (ret string) {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL for bucket CORS configuration.
+func getBucketCorsURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("cors", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket website configuration.
+func getBucketWebsiteURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("website", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket server access logging configuration.
+func getBucketLoggingURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("logging", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket inventory configurations, all configurations
+// are listed when id is empty.
+func getBucketInventoryURL(endPoint, bucketName, id string) string {
+	queryValue := url.Values{}
+	queryValue.Set("inventory", "")
+	if id != "" {
+		queryValue.Set("id", id)
+	}
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for listing objects in the bucket with V1 legacy API.
 func getListObjectsV1URL(endPoint, bucketName, prefix, maxKeys, encodingType string) string {
 	queryValue := url.Values{}
@@ -2048,6 +2080,30 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketLifecycleHandler).Queries("lifecycle", "")
 		case "DeleteBucketLifecycle":
 			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketLifecycleHandler).Queries("lifecycle", "")
+		case "GetBucketCors":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketCorsHandler).Queries("cors", "")
+		case "PutBucketCors":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketCorsHandler).Queries("cors", "")
+		case "DeleteBucketCors":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketCorsHandler).Queries("cors", "")
+		case "GetBucketWebsite":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketWebsiteHandler).Queries("website", "")
+		case "PutBucketWebsite":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketWebsiteHandler).Queries("website", "")
+		case "DeleteBucketWebsite":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketWebsiteHandler).Queries("website", "")
+		case "GetBucketLogging":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLoggingHandler).Queries("logging", "")
+		case "PutBucketLogging":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketLoggingHandler).Queries("logging", "")
+		case "GetBucketInventoryConfiguration":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketInventoryConfigurationHandler).Queries("inventory", "", "id", "{id:.*}")
+		case "ListBucketInventoryConfigurations":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.ListBucketInventoryConfigurationsHandler).Queries("inventory", "")
+		case "PutBucketInventoryConfiguration":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketInventoryConfigurationHandler).Queries("inventory", "", "id", "{id:.*}")
+		case "DeleteBucketInventoryConfiguration":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketInventoryConfigurationHandler).Queries("inventory", "", "id", "{id:.*}")
 		case "GetBucketLocation":
 			// Register GetBucketLocation handler.
 			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLocationHandler).Queries("location", "")
@@ -2399,7 +2455,7 @@ func uploadTestObject(t *testing.T, apiRouter http.Handler, creds auth.Credentia
 				if etag == "" {
 					t.Fatalf("Unexpected empty etag")
 				}
-				cp = append(cp, CompletePart{partID, etag[1 : len(etag)-1]})
+				cp = append(cp, CompletePart{PartNumber: partID, ETag: etag[1 : len(etag)-1]})
 			} else {
 				t.Fatalf("Missing etag header")
 			}
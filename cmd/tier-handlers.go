@@ -0,0 +1,200 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// AddTierHandler - PUT /minio/admin/v3/tier
+// Adds a remote tier objects can be transitioned to.
+func (a adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	reqBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	var cfg madmin.TierConfig
+	if err = json.Unmarshal(reqBytes, &cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	// Refresh from the backend, the configuration may have been updated
+	// by another server.
+	if err = globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalTierConfigMgr.Add(ctx, cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
+
+// ListTierHandler - GET /minio/admin/v3/tier
+// Lists the remote tiers configured, with their secrets redacted.
+func (a adminAPIHandlers) ListTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	data, err := json.Marshal(globalTierConfigMgr.ListTiers())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// EditTierHandler - POST /minio/admin/v3/tier/{tier}
+// Updates the credentials of a remote tier.
+func (a adminAPIHandlers) EditTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "EditTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	tierName := vars["tier"]
+
+	reqBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	var creds madmin.TierCreds
+	if err = json.Unmarshal(reqBytes, &creds); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if err = globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalTierConfigMgr.Edit(ctx, tierName, creds); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
+
+// RemoveTierHandler - DELETE /minio/admin/v3/tier/{tier}
+// Removes a remote tier which no longer holds transitioned objects.
+func (a adminAPIHandlers) RemoveTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	tierName := vars["tier"]
+
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if !globalTierConfigMgr.IsTierValid(tierName) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errTierNotFound), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Remove(ctx, tierName); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
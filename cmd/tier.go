@@ -0,0 +1,344 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// tierConfigPath is the path to the remote tier configuration, relative to
+// the minio meta bucket.
+var tierConfigPath = path.Join(minioConfigPrefix, "tier-config.json")
+
+var (
+	errTierAlreadyExists = AdminError{
+		Code:       "XMinioAdminTierAlreadyExists",
+		Message:    "Specified remote tier already exists",
+		StatusCode: http.StatusConflict,
+	}
+	errTierNotFound = AdminError{
+		Code:       "XMinioAdminTierNotFound",
+		Message:    "Specified remote tier was not found",
+		StatusCode: http.StatusNotFound,
+	}
+	errTierNameNotUppercase = AdminError{
+		Code:       "XMinioAdminTierNameNotUpperCase",
+		Message:    "Tier name must be in uppercase",
+		StatusCode: http.StatusBadRequest,
+	}
+	errTierTypeUnsupported = AdminError{
+		Code:       "XMinioAdminTierTypeUnsupported",
+		Message:    "Specified tier type is unsupported",
+		StatusCode: http.StatusBadRequest,
+	}
+	errTierBackendInUse = AdminError{
+		Code:       "XMinioAdminTierBackendInUse",
+		Message:    "Specified remote tier is in use by transitioned objects",
+		StatusCode: http.StatusConflict,
+	}
+)
+
+func errTierInvalidConfig(err error) error {
+	return AdminError{
+		Code:       "XMinioAdminTierInvalidConfig",
+		Message:    "Unable to setup remote tier, check tier configuration: " + err.Error(),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// TierConfigMgr holds the remote tiers objects can be transitioned to,
+// along with the WarmBackend of each tier instantiated on first use.
+type TierConfigMgr struct {
+	sync.RWMutex `json:"-"`
+
+	drivercache map[string]WarmBackend
+	Tiers       map[string]madmin.TierConfig `json:"tiers"`
+}
+
+// newTierConfigMgr returns an empty remote tier configuration.
+func newTierConfigMgr() *TierConfigMgr {
+	return &TierConfigMgr{
+		drivercache: make(map[string]WarmBackend),
+		Tiers:       make(map[string]madmin.TierConfig),
+	}
+}
+
+// IsTierValid returns true if there is a remote tier with the given name.
+func (config *TierConfigMgr) IsTierValid(tierName string) bool {
+	config.RLock()
+	defer config.RUnlock()
+	_, valid := config.isTierNameInUse(tierName)
+	return valid
+}
+
+func (config *TierConfigMgr) isTierNameInUse(tierName string) (madmin.TierType, bool) {
+	if t, ok := config.Tiers[tierName]; ok {
+		return t.Type, true
+	}
+	return "", false
+}
+
+// Add adds tier to the configuration after verifying the remote backend
+// is reachable with the given credentials.
+func (config *TierConfigMgr) Add(ctx context.Context, tier madmin.TierConfig) error {
+	config.Lock()
+	defer config.Unlock()
+
+	if err := tier.Validate(); err != nil {
+		switch err {
+		case madmin.ErrTierInvalidName:
+			return errTierNameNotUppercase
+		case madmin.ErrTierTypeInvalid:
+			return errTierTypeUnsupported
+		}
+		return errTierInvalidConfig(err)
+	}
+	if _, exists := config.isTierNameInUse(tier.Name); exists {
+		return errTierAlreadyExists
+	}
+
+	d, err := newWarmBackend(ctx, tier)
+	if err != nil {
+		return err
+	}
+	if inuse, err := d.InUse(ctx); err != nil {
+		return errTierInvalidConfig(err)
+	} else if inuse {
+		return errTierInvalidConfig(errTierBackendNotEmpty)
+	}
+
+	tier.Version = madmin.TierConfigV1
+	config.Tiers[tier.Name] = tier
+	config.drivercache[tier.Name] = d
+	return nil
+}
+
+// Remove removes tierName from the configuration, provided no transitioned
+// objects remain in its remote backend.
+func (config *TierConfigMgr) Remove(ctx context.Context, tierName string) error {
+	d, err := config.getDriver(ctx, tierName)
+	if err != nil {
+		if errors.Is(err, errTierNotFound) {
+			return nil
+		}
+		return err
+	}
+	if inuse, err := d.InUse(ctx); err != nil {
+		return err
+	} else if inuse {
+		return errTierBackendInUse
+	}
+
+	config.Lock()
+	delete(config.Tiers, tierName)
+	delete(config.drivercache, tierName)
+	config.Unlock()
+	return nil
+}
+
+// ListTiers lists the remote tiers configured, with their secrets redacted.
+func (config *TierConfigMgr) ListTiers() []madmin.TierConfig {
+	config.RLock()
+	defer config.RUnlock()
+
+	tierCfgs := make([]madmin.TierConfig, 0, len(config.Tiers))
+	for _, tier := range config.Tiers {
+		tierCfgs = append(tierCfgs, tier.Clone())
+	}
+	sort.Slice(tierCfgs, func(i, j int) bool {
+		return tierCfgs[i].Name < tierCfgs[j].Name
+	})
+	return tierCfgs
+}
+
+// Edit replaces the credentials of tierName with creds.
+func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds madmin.TierCreds) error {
+	config.Lock()
+	defer config.Unlock()
+
+	tierType, exists := config.isTierNameInUse(tierName)
+	if !exists {
+		return errTierNotFound
+	}
+
+	newCfg := config.Tiers[tierName]
+	switch tierType {
+	case madmin.S3Tier:
+		s3 := *newCfg.S3
+		if creds.AccessKey == "" || creds.SecretKey == "" {
+			return errTierInvalidConfig(madmin.ErrTierInvalidConfig)
+		}
+		s3.AccessKey = creds.AccessKey
+		s3.SecretKey = creds.SecretKey
+		newCfg.S3 = &s3
+	case madmin.AzureTier:
+		az := *newCfg.Azure
+		if creds.SecretKey == "" {
+			return errTierInvalidConfig(madmin.ErrTierInvalidConfig)
+		}
+		az.AccountKey = creds.SecretKey
+		newCfg.Azure = &az
+	case madmin.GCSTier:
+		gcs := *newCfg.GCS
+		if len(creds.CredsJSON) == 0 {
+			return errTierInvalidConfig(madmin.ErrTierInvalidConfig)
+		}
+		gcs.Creds = base64.StdEncoding.EncodeToString(creds.CredsJSON)
+		newCfg.GCS = &gcs
+	case madmin.FSTier:
+		// A directory tier has no credentials to update.
+		return errTierInvalidConfig(madmin.ErrTierInvalidConfig)
+	}
+
+	d, err := newWarmBackend(ctx, newCfg)
+	if err != nil {
+		return err
+	}
+
+	config.Tiers[tierName] = newCfg
+	config.drivercache[tierName] = d
+	return nil
+}
+
+// getDriver returns the WarmBackend of tierName, instantiating it if
+// needed.
+func (config *TierConfigMgr) getDriver(ctx context.Context, tierName string) (d WarmBackend, err error) {
+	config.RLock()
+	d, ok := config.drivercache[tierName]
+	config.RUnlock()
+	if ok {
+		return d, nil
+	}
+
+	config.Lock()
+	defer config.Unlock()
+	// Another caller may have instantiated the driver meanwhile.
+	if d, ok = config.drivercache[tierName]; ok {
+		return d, nil
+	}
+	t, ok := config.Tiers[tierName]
+	if !ok {
+		return nil, errTierNotFound
+	}
+	if d, err = newWarmBackend(ctx, t); err != nil {
+		return nil, err
+	}
+	config.drivercache[tierName] = d
+	return d, nil
+}
+
+// Bytes returns the JSON encoded remote tier configuration.
+func (config *TierConfigMgr) Bytes() ([]byte, error) {
+	config.RLock()
+	defer config.RUnlock()
+	return json.Marshal(config)
+}
+
+// Save saves the remote tier configuration to the backend.
+func (config *TierConfigMgr) Save(ctx context.Context, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	data, err := config.Bytes()
+	if err != nil {
+		return err
+	}
+
+	// The remote tier configuration holds the tier credentials, encrypt
+	// it like the server configuration.
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, tierConfigPath, data)
+}
+
+// Reset clears the remote tier configuration along with the cached
+// backends.
+func (config *TierConfigMgr) Reset() {
+	config.Lock()
+	defer config.Unlock()
+	config.drivercache = make(map[string]WarmBackend)
+	config.Tiers = make(map[string]madmin.TierConfig)
+}
+
+// Reload replaces the remote tier configuration with the one saved in
+// the backend.
+func (config *TierConfigMgr) Reload(ctx context.Context, objAPI ObjectLayer) error {
+	newConfig, err := loadTierConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	config.Lock()
+	defer config.Unlock()
+	// Backends are instantiated lazily with the new configuration.
+	config.drivercache = make(map[string]WarmBackend)
+	config.Tiers = newConfig.Tiers
+	return nil
+}
+
+// loadTierConfig loads the remote tier configuration saved in the backend,
+// returning an empty configuration if none was saved.
+func loadTierConfig(ctx context.Context, objAPI ObjectLayer) (*TierConfigMgr, error) {
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	data, err := readConfig(ctx, objAPI, tierConfigPath)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return newTierConfigMgr(), nil
+		}
+		return nil, err
+	}
+
+	if globalConfigEncrypted && !utf8.Valid(data) {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			if err == madmin.ErrMaliciousData {
+				return nil, config.ErrInvalidCredentialsBackendEncrypted(nil)
+			}
+			return nil, err
+		}
+	}
+
+	config := newTierConfigMgr()
+	if err = json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if config.Tiers == nil {
+		config.Tiers = make(map[string]madmin.TierConfig)
+	}
+	return config, nil
+}
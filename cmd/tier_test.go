@@ -0,0 +1,224 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"unicode/utf8"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func newTestFSTier(t *testing.T, name string) madmin.TierConfig {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "minio-tier-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return madmin.TierConfig{
+		Type: madmin.FSTier,
+		Name: name,
+		FS:   &madmin.TierFS{Path: dir, Prefix: "transitioned"},
+	}
+}
+
+func TestTierConfigMgr(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTierConfigMgr()
+
+	tier := newTestFSTier(t, "WARM")
+	if err := mgr.Add(ctx, tier); err != nil {
+		t.Fatalf("Unexpected error adding tier: %v", err)
+	}
+	if err := mgr.Add(ctx, tier); err != errTierAlreadyExists {
+		t.Fatalf("Expected %v, got %v", errTierAlreadyExists, err)
+	}
+	if err := mgr.Add(ctx, newTestFSTier(t, "warm")); err != errTierNameNotUppercase {
+		t.Fatalf("Expected %v, got %v", errTierNameNotUppercase, err)
+	}
+	if !mgr.IsTierValid("WARM") || mgr.IsTierValid("COLD") {
+		t.Fatal("Unexpected tier validity")
+	}
+	if tiers := mgr.ListTiers(); len(tiers) != 1 || tiers[0].Name != "WARM" {
+		t.Fatalf("Unexpected tiers listed: %v", tiers)
+	}
+	if err := mgr.Edit(ctx, "COLD", madmin.TierCreds{}); err != errTierNotFound {
+		t.Fatalf("Expected %v, got %v", errTierNotFound, err)
+	}
+
+	w, err := mgr.getDriver(ctx, "WARM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hello, world")
+	if err = w.Put(ctx, "aa/bb/object", bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	r, err := w.Get(ctx, "aa/bb/object", WarmBackendGetOpts{startOffset: 7, length: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "world" {
+		t.Fatalf("Expected %q, got %q", "world", got)
+	}
+
+	if err = mgr.Remove(ctx, "WARM"); err != errTierBackendInUse {
+		t.Fatalf("Expected %v, got %v", errTierBackendInUse, err)
+	}
+	if err = w.Remove(ctx, "aa/bb/object"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Get(ctx, "aa/bb/object", WarmBackendGetOpts{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not found, got %v", err)
+	}
+	if err = mgr.Remove(ctx, "WARM"); err != nil {
+		t.Fatal(err)
+	}
+	if mgr.IsTierValid("WARM") {
+		t.Fatal("Expected tier to be removed")
+	}
+}
+
+func TestTierConfigSaveLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	mgr := newTierConfigMgr()
+	if err = mgr.Add(ctx, newTestFSTier(t, "WARM")); err != nil {
+		t.Fatal(err)
+	}
+	if err = mgr.Save(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	// The saved configuration holds the tier credentials, it must be
+	// encrypted.
+	data, err := readConfig(ctx, objLayer, tierConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if utf8.Valid(data) || bytes.Contains(data, []byte("transitioned")) {
+		t.Fatalf("Expected the tier config to be encrypted, got %s", data)
+	}
+
+	loaded, err := loadTierConfig(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.IsTierValid("WARM") {
+		t.Fatal("Expected tier to be loaded")
+	}
+	if cfg := loaded.Tiers["WARM"]; cfg.Version != madmin.TierConfigV1 || cfg.FS == nil || cfg.FS.Prefix != "transitioned" {
+		t.Fatalf("Unexpected tier config loaded: %+v", cfg)
+	}
+}
+
+func TestTransitionObjectToTier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	globalTierConfigMgr = newTierConfigMgr()
+	defer func() { globalTierConfigMgr = newTierConfigMgr() }()
+	if err = globalTierConfigMgr.Add(ctx, newTestFSTier(t, "WARM")); err != nil {
+		t.Fatal(err)
+	}
+
+	const bucket, object = "bucket", "object"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 256*humanize.KiByte)
+	objInfo, err := objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = transitionObjectToTier(ctx, objLayer, objInfo, "WARM"); err != nil {
+		t.Fatal(err)
+	}
+
+	oi, err := objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.TransitionStatus != lifecycle.TransitionComplete {
+		t.Fatalf("Expected transition status %q, got %q", lifecycle.TransitionComplete, oi.TransitionStatus)
+	}
+	if oi.TransitionedObject.Tier != "WARM" || oi.TransitionedObject.Name == "" {
+		t.Fatalf("Unexpected transitioned object %+v", oi.TransitionedObject)
+	}
+	if oi.StorageClass != "WARM" {
+		t.Fatalf("Expected storage class WARM, got %s", oi.StorageClass)
+	}
+
+	rs := &HTTPRangeSpec{Start: 10, End: 19}
+	gr, err := objLayer.GetObjectNInfo(ctx, bucket, object, rs, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data[10:20]) {
+		t.Fatalf("Unexpected content read from the remote tier: %q", got)
+	}
+
+	if err = deleteTransitionedObject(ctx, objLayer, bucket, object, lifecycle.ObjectOpts{
+		Name:             object,
+		TransitionStatus: oi.TransitionStatus,
+	}, oi.TransitionedObject, false, false); err != nil {
+		t.Fatal(err)
+	}
+	w, err := globalTierConfigMgr.getDriver(ctx, "WARM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inuse, err := w.InUse(ctx); err != nil || inuse {
+		t.Fatalf("Expected remote tier to be empty, got %v, %v", inuse, err)
+	}
+	if _, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not found, got %v", err)
+	}
+}
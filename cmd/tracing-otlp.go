@@ -0,0 +1,119 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/minio/minio/cmd/config/tracing"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/trace/otlp"
+)
+
+var (
+	globalOTLPTracingConfig   tracing.Config
+	globalOTLPTracingConfigMu sync.Mutex
+)
+
+// applyOTLPTracingConfig - starts, restarts or stops exporting the
+// spans as per the new configuration.
+func applyOTLPTracingConfig(cfg tracing.Config) {
+	globalOTLPTracingConfigMu.Lock()
+	defer globalOTLPTracingConfigMu.Unlock()
+
+	if cfg == globalOTLPTracingConfig {
+		return
+	}
+	globalOTLPTracingConfig = cfg
+
+	var tracer *otlp.Tracer
+	if cfg.Enabled {
+		exporter := otlp.NewExporter(
+			otlp.WithEndpoint(cfg.Endpoint),
+			otlp.WithAuthToken(cfg.AuthToken),
+			otlp.WithTransport(NewGatewayHTTPTransport()),
+			otlp.WithResource(
+				otlp.String("service.name", "minio"),
+				otlp.String("service.version", Version),
+				otlp.String("service.instance.id", globalLocalNodeName),
+			),
+			otlp.WithErrorHandler(func(err error) {
+				logger.LogOnceIf(GlobalContext, fmt.Errorf("Unable to export traces: %w", err), cfg.Endpoint)
+			}),
+		)
+		tracer = otlp.NewTracer(exporter, cfg.SampleRatio)
+	}
+	if old := otlp.SetTracer(tracer); old != nil {
+		// Send the pending spans in the background.
+		go old.Close()
+	}
+}
+
+// httpTraceSpan - records a server span of the requests handled by
+// next, a child of the span of the client if the request has a trace
+// context. The span is named after the handler f.
+func httpTraceSpan(f http.HandlerFunc, next http.HandlerFunc) http.HandlerFunc {
+	name := getOpName(runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name())
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !otlp.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, span := otlp.Start(otlp.Extract(r.Context(), r.Header), name, otlp.SpanKindServer,
+			otlp.String("http.method", r.Method),
+			otlp.String("http.target", r.URL.Path),
+			otlp.String("net.peer.ip", handlers.GetSourceIP(r)),
+		)
+		defer span.End()
+
+		statsWriter := logger.NewResponseWriter(w)
+		next.ServeHTTP(statsWriter, r.WithContext(ctx))
+
+		span.SetAttributes(otlp.Int("http.status_code", int64(statsWriter.StatusCode)))
+		if statsWriter.StatusCode >= http.StatusInternalServerError {
+			span.SetError(errors.New(http.StatusText(statsWriter.StatusCode)))
+		}
+	}
+}
+
+// startObjectSpan - starts a span of an object layer operation, the
+// returned function ends it with the error of the operation, if any.
+func startObjectSpan(ctx context.Context, name, bucket, object string, attributes ...otlp.Attribute) (context.Context, func(*error)) {
+	if !otlp.Enabled() {
+		return ctx, func(*error) {}
+	}
+
+	attributes = append([]otlp.Attribute{
+		otlp.String("bucket", bucket),
+		otlp.String("object", object),
+	}, attributes...)
+	ctx, span := otlp.Start(ctx, name, otlp.SpanKindInternal, attributes...)
+	return ctx, func(err *error) {
+		if err != nil {
+			span.SetError(*err)
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/cmd/config/tracing"
+	"github.com/minio/minio/pkg/trace/otlp"
+)
+
+func TestHTTPTraceSpan(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer collector.Close()
+
+	applyOTLPTracingConfig(tracing.Config{
+		Enabled:     true,
+		Endpoint:    collector.URL,
+		SampleRatio: 1,
+	})
+	defer applyOTLPTracingConfig(tracing.Config{})
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	var serverSpan, objectSpan otlp.SpanContext
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		serverSpan = otlp.SpanContextFromContext(r.Context())
+		ctx, endSpan := startObjectSpan(r.Context(), "objectLayer.GetObjectInfo", "bucket", "object")
+		objectSpan = otlp.SpanContextFromContext(ctx)
+		endSpan(nil)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	req.Header.Set(otlp.TraceparentHeader, "00-"+traceID+"-"+parentID+"-01")
+	httpTraceSpan(handler, handler)(httptest.NewRecorder(), req)
+
+	if serverSpan.TraceID.String() != traceID || objectSpan.TraceID.String() != traceID {
+		t.Fatalf("expected trace %s, got %s and %s", traceID, serverSpan.TraceID, objectSpan.TraceID)
+	}
+	if !serverSpan.Sampled || !objectSpan.Sampled {
+		t.Fatal("expected the spans to be sampled as per the remote parent")
+	}
+	if serverSpan.SpanID.String() == parentID || serverSpan.SpanID == objectSpan.SpanID {
+		t.Fatalf("expected new spans, got %s and %s", serverSpan.SpanID, objectSpan.SpanID)
+	}
+
+	// The trace context of the object layer span is forwarded to the peers.
+	h := http.Header{}
+	otlp.Inject(otlp.ContextWithRemoteSpanContext(req.Context(), objectSpan), h)
+	if got, want := h.Get(otlp.TraceparentHeader), "00-"+traceID+"-"+objectSpan.SpanID.String()+"-01"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,325 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// azureAPIVersion is the version of the Azure blob service REST API
+	// used, the first to support block blobs up to 5000 MiB in a single
+	// request.
+	azureAPIVersion = "2019-12-12"
+
+	// Objects larger than azureMaxPutBlobSize are uploaded as a list of
+	// blocks of azureBlockSize.
+	azureMaxPutBlobSize = 256 * humanize.MiByte
+	azureBlockSize      = 100 * humanize.MiByte
+)
+
+// warmBackendAzure transitions objects to an Azure blob storage container,
+// using the blob service REST API with shared key authorization.
+type warmBackendAzure struct {
+	client      *http.Client
+	endpoint    *url.URL
+	accountName string
+	accountKey  []byte
+	Bucket      string
+	Prefix      string
+}
+
+func (az *warmBackendAzure) getDest(object string) string {
+	return warmBackendObject(az.Prefix, object)
+}
+
+// azureError is the error response returned by the blob service.
+type azureError struct {
+	XMLName    xml.Name `xml:"Error"`
+	Code       string   `xml:"Code"`
+	Message    string   `xml:"Message"`
+	StatusCode int      `xml:"-"`
+}
+
+func (e azureError) Error() string {
+	return fmt.Sprintf("azure: %s (%d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// sign computes the shared key signature of req as documented in
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (az *warmBackendAzure) sign(req *http.Request) {
+	h := req.Header
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var msHeaders []string
+	for k := range h {
+		if k = strings.ToLower(k); strings.HasPrefix(k, "x-ms-") {
+			msHeaders = append(msHeaders, k)
+		}
+	}
+	sort.Strings(msHeaders)
+
+	var b strings.Builder
+	for _, v := range []string{
+		req.Method,
+		h.Get("Content-Encoding"),
+		h.Get("Content-Language"),
+		contentLength,
+		h.Get("Content-MD5"),
+		h.Get(xhttp.ContentType),
+		"", // Date, x-ms-date is used instead.
+		h.Get(xhttp.IfModifiedSince),
+		h.Get(xhttp.IfMatch),
+		h.Get(xhttp.IfNoneMatch),
+		h.Get(xhttp.IfUnmodifiedSince),
+		h.Get(xhttp.Range),
+	} {
+		b.WriteString(v)
+		b.WriteByte('\n')
+	}
+	for _, k := range msHeaders {
+		b.WriteString(k + ":" + strings.TrimSpace(h.Get(k)) + "\n")
+	}
+
+	b.WriteString("/" + az.accountName + req.URL.EscapedPath())
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		b.WriteString("\n" + strings.ToLower(k) + ":" + strings.Join(values, ","))
+	}
+
+	mac := hmac.New(sha256.New, az.accountKey)
+	mac.Write([]byte(b.String()))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	h.Set(xhttp.Authorization, "SharedKey "+az.accountName+":"+signature)
+}
+
+// do sends a signed request for blob, or for the container when blob is
+// empty, returning an azureError for unsuccessful responses.
+func (az *warmBackendAzure) do(ctx context.Context, method, blob string, query url.Values, body io.Reader, length int64, h http.Header) (*http.Response, error) {
+	u := *az.endpoint
+	u.Path = path.Join(u.Path, az.Bucket, blob)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range h {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.ContentLength = length
+		if length == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	az.sign(req)
+
+	resp, err := az.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	azErr := azureError{StatusCode: resp.StatusCode}
+	if err = xml.NewDecoder(io.LimitReader(resp.Body, 64*humanize.KiByte)).Decode(&azErr); err != nil {
+		// HEAD responses and some proxies do not return a body.
+		azErr.Code = resp.Header.Get("x-ms-error-code")
+		azErr.Message = resp.Status
+	}
+	return nil, azErr
+}
+
+func (az *warmBackendAzure) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	blob := az.getDest(object)
+	h := http.Header{}
+	if length <= azureMaxPutBlobSize {
+		h.Set("x-ms-blob-type", "BlockBlob")
+		resp, err := az.do(ctx, http.MethodPut, blob, nil, r, length, h)
+		if err != nil {
+			return az.ToObjectError(err, object)
+		}
+		xhttp.DrainBody(resp.Body)
+		return nil
+	}
+
+	// Upload the object as blocks streamed from r, then commit the list
+	// of blocks as the content of the blob.
+	var blockList bytes.Buffer
+	blockList.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for i, remaining := 0, length; remaining > 0; i++ {
+		n := int64(azureBlockSize)
+		if remaining < n {
+			n = remaining
+		}
+		blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", i)))
+		query := url.Values{}
+		query.Set("comp", "block")
+		query.Set("blockid", blockID)
+		resp, err := az.do(ctx, http.MethodPut, blob, query, io.LimitReader(r, n), n, nil)
+		if err != nil {
+			return az.ToObjectError(err, object)
+		}
+		xhttp.DrainBody(resp.Body)
+		blockList.WriteString("<Latest>" + blockID + "</Latest>")
+		remaining -= n
+	}
+	blockList.WriteString("</BlockList>")
+
+	query := url.Values{}
+	query.Set("comp", "blocklist")
+	resp, err := az.do(ctx, http.MethodPut, blob, query, &blockList, int64(blockList.Len()), nil)
+	if err != nil {
+		return az.ToObjectError(err, object)
+	}
+	xhttp.DrainBody(resp.Body)
+	return nil
+}
+
+func (az *warmBackendAzure) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	h := http.Header{}
+	if opts.startOffset >= 0 && opts.length > 0 {
+		h.Set("x-ms-range", fmt.Sprintf("bytes=%d-%d", opts.startOffset, opts.startOffset+opts.length-1))
+	} else if opts.startOffset > 0 {
+		h.Set("x-ms-range", fmt.Sprintf("bytes=%d-", opts.startOffset))
+	}
+	resp, err := az.do(ctx, http.MethodGet, az.getDest(object), nil, nil, 0, h)
+	if err != nil {
+		return nil, az.ToObjectError(err, object)
+	}
+	return resp.Body, nil
+}
+
+func (az *warmBackendAzure) Remove(ctx context.Context, object string) error {
+	resp, err := az.do(ctx, http.MethodDelete, az.getDest(object), nil, nil, 0, nil)
+	if err != nil {
+		if azErr, ok := err.(azureError); ok && azErr.Code == "BlobNotFound" {
+			return nil
+		}
+		return az.ToObjectError(err, object)
+	}
+	xhttp.DrainBody(resp.Body)
+	return nil
+}
+
+func (az *warmBackendAzure) InUse(ctx context.Context) (bool, error) {
+	query := url.Values{}
+	query.Set("restype", "container")
+	query.Set("comp", "list")
+	query.Set("maxresults", "1")
+	if az.Prefix != "" {
+		query.Set("prefix", az.Prefix+SlashSeparator)
+	}
+	resp, err := az.do(ctx, http.MethodGet, "", query, nil, 0, nil)
+	if err != nil {
+		return false, az.ToObjectError(err, "")
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	var result struct {
+		Blobs []struct {
+			Name string `xml:"Name"`
+		} `xml:"Blobs>Blob"`
+	}
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, err
+	}
+	return len(result.Blobs) > 0, nil
+}
+
+// ToObjectError converts errors returned by the blob service to object
+// layer errors.
+func (az *warmBackendAzure) ToObjectError(err error, object string) error {
+	azErr, ok := err.(azureError)
+	if !ok {
+		return err
+	}
+	switch azErr.Code {
+	case "ContainerNotFound":
+		return BucketNotFound{Bucket: az.Bucket}
+	case "BlobNotFound":
+		return ObjectNotFound{Bucket: az.Bucket, Object: object}
+	case "AuthenticationFailed", "AuthorizationFailure", "AuthorizationPermissionMismatch":
+		return PrefixAccessDenied{Bucket: az.Bucket, Object: object}
+	}
+	return err
+}
+
+func newWarmBackendAzure(conf madmin.TierAzure) (*warmBackendAzure, error) {
+	accountKey, err := base64.StdEncoding.DecodeString(conf.AccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("azure account key must be base64 encoded: %w", err)
+	}
+
+	endpoint := conf.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + conf.AccountName + ".blob.core.windows.net"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("azure tier endpoint must include a scheme, e.g. https://%s.blob.core.windows.net", conf.AccountName)
+	}
+
+	getRemoteTargetInstanceTransportOnce.Do(func() {
+		getRemoteTargetInstanceTransport = NewRemoteTargetHTTPTransport()
+	})
+	return &warmBackendAzure{
+		client:      &http.Client{Transport: getRemoteTargetInstanceTransport},
+		endpoint:    u,
+		accountName: conf.AccountName,
+		accountKey:  accountKey,
+		Bucket:      conf.Bucket,
+		Prefix:      conf.Prefix,
+	}, nil
+}
@@ -0,0 +1,137 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// fakeAzureBlobServer is a minimal in-memory blob service, serving a
+// single container under a path style endpoint.
+type fakeAzureBlobServer struct {
+	sync.Mutex
+	blobs map[string][]byte
+}
+
+func (f *fakeAzureBlobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey devaccount:") || r.Header.Get("x-ms-date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<Error><Code>AuthenticationFailed</Code></Error>")
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/devaccount/container/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("comp") == "list":
+		var b strings.Builder
+		b.WriteString("<EnumerationResults><Blobs>")
+		for k := range f.blobs {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				b.WriteString("<Blob><Name>" + k + "</Name></Blob>")
+				break
+			}
+		}
+		b.WriteString("</Blobs></EnumerationResults>")
+		fmt.Fprint(w, b.String())
+	case r.Method == http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		f.blobs[name] = data
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet, r.Method == http.MethodDelete:
+		data, ok := f.blobs[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>BlobNotFound</Code></Error>")
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.blobs, name)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		var start, end int
+		if rng := r.Header.Get("x-ms-range"); rng != "" {
+			fmt.Sscanf(rng, "bytes=%d-%d", &start, &end)
+			data = data[start : end+1]
+		}
+		w.Write(data)
+	}
+}
+
+func TestWarmBackendAzure(t *testing.T) {
+	ts := httptest.NewServer(&fakeAzureBlobServer{blobs: make(map[string][]byte)})
+	defer ts.Close()
+
+	ctx := context.Background()
+	az, err := newWarmBackendAzure(madmin.TierAzure{
+		Endpoint:    ts.URL + "/devaccount",
+		AccountName: "devaccount",
+		AccountKey:  "a2V5",
+		Bucket:      "container",
+		Prefix:      "tier",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = checkWarmBackend(ctx, az); err != nil {
+		t.Fatal(err)
+	}
+	if inuse, err := az.InUse(ctx); err != nil || inuse {
+		t.Fatalf("Expected empty container, got %v, %v", inuse, err)
+	}
+
+	data := []byte("hello, world")
+	if err = az.Put(ctx, "object", bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	if inuse, err := az.InUse(ctx); err != nil || !inuse {
+		t.Fatalf("Expected container in use, got %v, %v", inuse, err)
+	}
+	r, err := az.Get(ctx, "object", WarmBackendGetOpts{startOffset: 7, length: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := ioutil.ReadAll(r)
+	r.Close()
+	if string(got) != "world" {
+		t.Fatalf("Expected %q, got %q", "world", got)
+	}
+	if err = az.Remove(ctx, "object"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = az.Get(ctx, "object", WarmBackendGetOpts{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not found, got %v", err)
+	}
+	// Removing a missing blob is not an error.
+	if err = az.Remove(ctx, "object"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// warmBackendFS transitions objects to a directory, typically a network
+// mount shared by all the servers of the deployment.
+type warmBackendFS struct {
+	Path   string
+	Prefix string
+}
+
+func (fs *warmBackendFS) getDest(object string) string {
+	return filepath.Join(fs.Path, filepath.FromSlash(warmBackendObject(fs.Prefix, object)))
+}
+
+func (fs *warmBackendFS) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	dest := fs.getDest(object)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o777); err != nil {
+		return fs.ToObjectError(err, object)
+	}
+
+	// Write to a temporary file first, so that a partially written object
+	// is never visible at its final location.
+	f, err := ioutil.TempFile(filepath.Dir(dest), ".tmp-"+filepath.Base(dest))
+	if err != nil {
+		return fs.ToObjectError(err, object)
+	}
+	tmp := f.Name()
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && length >= 0 && n != length {
+		err = IncompleteBody{Object: object}
+	}
+	if err == nil {
+		err = os.Rename(tmp, dest)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fs.ToObjectError(err, object)
+	}
+	return nil
+}
+
+func (fs *warmBackendFS) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	f, err := os.Open(fs.getDest(object))
+	if err != nil {
+		return nil, fs.ToObjectError(err, object)
+	}
+	if opts.startOffset > 0 {
+		if _, err = f.Seek(opts.startOffset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, fs.ToObjectError(err, object)
+		}
+	}
+	if opts.length > 0 {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(f, opts.length), f}, nil
+	}
+	return f, nil
+}
+
+func (fs *warmBackendFS) Remove(ctx context.Context, object string) error {
+	dest := fs.getDest(object)
+	if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+		return fs.ToObjectError(err, object)
+	}
+
+	// Cleanup the now empty parent directories, up to the tier root.
+	base := filepath.Join(fs.Path, filepath.FromSlash(fs.Prefix))
+	for dir := filepath.Dir(dest); len(dir) > len(base); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+var errWarmBackendFSFound = errors.New("found")
+
+func (fs *warmBackendFS) InUse(ctx context.Context) (bool, error) {
+	base := filepath.Join(fs.Path, filepath.FromSlash(fs.Prefix))
+	err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			return errWarmBackendFSFound
+		}
+		return nil
+	})
+	switch {
+	case err == errWarmBackendFSFound:
+		return true, nil
+	case err == nil || os.IsNotExist(err):
+		return false, nil
+	}
+	return false, err
+}
+
+// ToObjectError converts file system errors to object layer errors.
+func (fs *warmBackendFS) ToObjectError(err error, object string) error {
+	switch {
+	case err == nil:
+		return nil
+	case os.IsNotExist(err):
+		return ObjectNotFound{Bucket: fs.Path, Object: object}
+	case os.IsPermission(err):
+		return PrefixAccessDenied{Bucket: fs.Path, Object: object}
+	}
+	return err
+}
+
+func newWarmBackendFS(conf madmin.TierFS) (*warmBackendFS, error) {
+	fi, err := os.Stat(conf.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", conf.Path)
+	}
+	return &warmBackendFS{
+		Path:   filepath.Clean(conf.Path),
+		Prefix: conf.Prefix,
+	}, nil
+}
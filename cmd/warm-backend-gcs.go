@@ -0,0 +1,124 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/minio/minio/pkg/madmin"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// warmBackendGCS transitions objects to a Google Cloud Storage bucket.
+type warmBackendGCS struct {
+	client *storage.Client
+	Bucket string
+	Prefix string
+}
+
+func (gcs *warmBackendGCS) getDest(object string) string {
+	return warmBackendObject(gcs.Prefix, object)
+}
+
+func (gcs *warmBackendGCS) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	w := gcs.client.Bucket(gcs.Bucket).Object(gcs.getDest(object)).NewWriter(ctx)
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return gcs.ToObjectError(err, object)
+	}
+	return gcs.ToObjectError(w.Close(), object)
+}
+
+func (gcs *warmBackendGCS) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	length := opts.length
+	if length <= 0 {
+		// A negative length reads until the end of the object.
+		length = -1
+	}
+	r, err := gcs.client.Bucket(gcs.Bucket).Object(gcs.getDest(object)).NewRangeReader(ctx, opts.startOffset, length)
+	if err != nil {
+		return nil, gcs.ToObjectError(err, object)
+	}
+	return r, nil
+}
+
+func (gcs *warmBackendGCS) Remove(ctx context.Context, object string) error {
+	err := gcs.client.Bucket(gcs.Bucket).Object(gcs.getDest(object)).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil
+	}
+	return gcs.ToObjectError(err, object)
+}
+
+func (gcs *warmBackendGCS) InUse(ctx context.Context) (bool, error) {
+	prefix := gcs.Prefix
+	if prefix != "" {
+		prefix += SlashSeparator
+	}
+	it := gcs.client.Bucket(gcs.Bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	if _, err := it.Next(); err != nil {
+		if err == iterator.Done {
+			return false, nil
+		}
+		return false, gcs.ToObjectError(err, "")
+	}
+	return true, nil
+}
+
+// ToObjectError converts errors returned by Google Cloud Storage to object
+// layer errors.
+func (gcs *warmBackendGCS) ToObjectError(err error, object string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, storage.ErrBucketNotExist):
+		return BucketNotFound{Bucket: gcs.Bucket}
+	case errors.Is(err, storage.ErrObjectNotExist):
+		return ObjectNotFound{Bucket: gcs.Bucket, Object: object}
+	}
+	return err
+}
+
+func newWarmBackendGCS(conf madmin.TierGCS) (*warmBackendGCS, error) {
+	credsJSON, err := base64.StdEncoding.DecodeString(conf.Creds)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []option.ClientOption{
+		option.WithCredentialsJSON(credsJSON),
+		option.WithScopes(storage.ScopeReadWrite),
+	}
+	if conf.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(conf.Endpoint))
+	}
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &warmBackendGCS{
+		client: client,
+		Bucket: conf.Bucket,
+		Prefix: conf.Prefix,
+	}, nil
+}
@@ -0,0 +1,144 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// warmBackendS3 transitions objects to an S3 compatible endpoint.
+type warmBackendS3 struct {
+	client       *miniogo.Client
+	Bucket       string
+	Prefix       string
+	StorageClass string
+}
+
+func (s3 *warmBackendS3) getDest(object string) string {
+	return warmBackendObject(s3.Prefix, object)
+}
+
+func (s3 *warmBackendS3) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	_, err := s3.client.PutObject(ctx, s3.Bucket, s3.getDest(object), r, length, miniogo.PutObjectOptions{
+		StorageClass: s3.StorageClass,
+	})
+	return s3.ToObjectError(err, object)
+}
+
+func (s3 *warmBackendS3) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	gopts := miniogo.GetObjectOptions{}
+	if opts.startOffset >= 0 && opts.length > 0 {
+		if err := gopts.SetRange(opts.startOffset, opts.startOffset+opts.length-1); err != nil {
+			return nil, s3.ToObjectError(err, object)
+		}
+	} else if opts.startOffset > 0 {
+		if err := gopts.SetRange(opts.startOffset, 0); err != nil {
+			return nil, s3.ToObjectError(err, object)
+		}
+	}
+	c := miniogo.Core{Client: s3.client}
+	// Core is used here to avoid the lazy requests of the high level
+	// GetObject, so that errors are reported right away.
+	r, _, _, err := c.GetObject(ctx, s3.Bucket, s3.getDest(object), gopts)
+	if err != nil {
+		return nil, s3.ToObjectError(err, object)
+	}
+	return r, nil
+}
+
+func (s3 *warmBackendS3) Remove(ctx context.Context, object string) error {
+	err := s3.client.RemoveObject(ctx, s3.Bucket, s3.getDest(object), miniogo.RemoveObjectOptions{})
+	return s3.ToObjectError(err, object)
+}
+
+func (s3 *warmBackendS3) InUse(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	prefix := s3.Prefix
+	if prefix != "" {
+		prefix += SlashSeparator
+	}
+	for obj := range s3.client.ListObjects(ctx, s3.Bucket, miniogo.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+		MaxKeys:   1,
+	}) {
+		if obj.Err != nil {
+			return false, s3.ToObjectError(obj.Err, "")
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// ToObjectError converts errors returned by the remote endpoint to object
+// layer errors.
+func (s3 *warmBackendS3) ToObjectError(err error, object string) error {
+	if err == nil {
+		return nil
+	}
+	var errResp miniogo.ErrorResponse
+	if errors.As(err, &errResp) {
+		switch errResp.Code {
+		case "NoSuchBucket":
+			return BucketNotFound{Bucket: s3.Bucket}
+		case "NoSuchKey":
+			return ObjectNotFound{Bucket: s3.Bucket, Object: object}
+		case "AccessDenied":
+			return PrefixAccessDenied{Bucket: s3.Bucket, Object: object}
+		}
+	}
+	return err
+}
+
+func newWarmBackendS3(conf madmin.TierS3) (*warmBackendS3, error) {
+	u, err := url.Parse(conf.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, errors.New("s3 tier endpoint must include a scheme, e.g. https://s3.amazonaws.com")
+	}
+
+	getRemoteTargetInstanceTransportOnce.Do(func() {
+		getRemoteTargetInstanceTransport = NewRemoteTargetHTTPTransport()
+	})
+	client, err := miniogo.New(u.Host, &miniogo.Options{
+		Creds:     credentials.NewStaticV4(conf.AccessKey, conf.SecretKey, ""),
+		Secure:    u.Scheme == "https",
+		Region:    conf.Region,
+		Transport: getRemoteTargetInstanceTransport,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &warmBackendS3{
+		client:       client,
+		Bucket:       conf.Bucket,
+		Prefix:       conf.Prefix,
+		StorageClass: conf.StorageClass,
+	}, nil
+}
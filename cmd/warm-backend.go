@@ -0,0 +1,135 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"path"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// WarmBackendGetOpts is used to express byte ranges within an object. The
+// zero value represents the entire object.
+type WarmBackendGetOpts struct {
+	startOffset int64 // start offset
+	length      int64 // length of the range, 0 or negative for the rest of the object
+}
+
+// WarmBackend provides access to the remote tier objects are transitioned to.
+type WarmBackend interface {
+	Put(ctx context.Context, object string, r io.Reader, length int64) error
+	Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error)
+	Remove(ctx context.Context, object string) error
+	InUse(ctx context.Context) (bool, error)
+}
+
+// errTierBackendNotEmpty is returned when a tier being removed still holds
+// transitioned objects.
+var errTierBackendNotEmpty = errors.New("remote tier backend holds transitioned objects")
+
+const probeObject = "probeobject"
+
+// checkWarmBackend checks if tier config credentials have sufficient
+// privileges to perform all operations defined in the WarmBackend interface.
+func checkWarmBackend(ctx context.Context, w WarmBackend) error {
+	var empty bytes.Reader
+	if err := w.Put(ctx, probeObject, &empty, 0); err != nil {
+		return tierPermErr{Op: tierPut, Err: err}
+	}
+
+	r, err := w.Get(ctx, probeObject, WarmBackendGetOpts{})
+	if err != nil {
+		return tierPermErr{Op: tierGet, Err: err}
+	}
+	io.Copy(ioutil.Discard, r)
+	r.Close()
+
+	if err = w.Remove(ctx, probeObject); err != nil {
+		return tierPermErr{Op: tierDelete, Err: err}
+	}
+	return nil
+}
+
+type tierOp uint8
+
+const (
+	_ tierOp = iota
+	tierGet
+	tierPut
+	tierDelete
+)
+
+func (op tierOp) String() string {
+	switch op {
+	case tierGet:
+		return "GET"
+	case tierPut:
+		return "PUT"
+	case tierDelete:
+		return "DELETE"
+	}
+	return "UNKNOWN"
+}
+
+type tierPermErr struct {
+	Op  tierOp
+	Err error
+}
+
+func (te tierPermErr) Error() string {
+	return "failed to perform " + te.Op.String() + " on remote tier: " + te.Err.Error()
+}
+
+// newWarmBackend instantiates the WarmBackend for the given tier
+// configuration and verifies it is usable.
+func newWarmBackend(ctx context.Context, tier madmin.TierConfig) (d WarmBackend, err error) {
+	switch tier.Type {
+	case madmin.S3Tier:
+		d, err = newWarmBackendS3(*tier.S3)
+	case madmin.AzureTier:
+		d, err = newWarmBackendAzure(*tier.Azure)
+	case madmin.GCSTier:
+		d, err = newWarmBackendGCS(*tier.GCS)
+	case madmin.FSTier:
+		d, err = newWarmBackendFS(*tier.FS)
+	default:
+		return nil, errTierTypeUnsupported
+	}
+	if err != nil {
+		return nil, errTierInvalidConfig(err)
+	}
+
+	if err = checkWarmBackend(ctx, d); err != nil {
+		return nil, errTierInvalidConfig(err)
+	}
+	return d, nil
+}
+
+// warmBackendObject returns the name of object in the remote tier, taking
+// into account the configured prefix.
+func warmBackendObject(prefix, object string) string {
+	if prefix == "" {
+		return object
+	}
+	return path.Join(prefix, object)
+}
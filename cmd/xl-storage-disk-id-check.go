@@ -26,6 +26,7 @@ import (
 
 	ewma "github.com/VividCortex/ewma"
 	trace "github.com/minio/minio/pkg/trace"
+	"github.com/minio/minio/pkg/trace/otlp"
 )
 
 //go:generate stringer -type=storageMetric -trimprefix=storageMetric $GOFILE
@@ -228,7 +229,8 @@ func (p *xlStorageDiskIDCheck) DiskInfo(ctx context.Context) (info DiskInfo, err
 }
 
 func (p *xlStorageDiskIDCheck) MakeVolBulk(ctx context.Context, volumes ...string) (err error) {
-	defer p.updateStorageMetrics(storageMetricMakeVolBulk, volumes...)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricMakeVolBulk, volumes...)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -243,7 +245,8 @@ func (p *xlStorageDiskIDCheck) MakeVolBulk(ctx context.Context, volumes ...strin
 }
 
 func (p *xlStorageDiskIDCheck) MakeVol(ctx context.Context, volume string) (err error) {
-	defer p.updateStorageMetrics(storageMetricMakeVol, volume)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricMakeVol, volume)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -258,7 +261,8 @@ func (p *xlStorageDiskIDCheck) MakeVol(ctx context.Context, volume string) (err
 }
 
 func (p *xlStorageDiskIDCheck) ListVols(ctx context.Context) ([]VolInfo, error) {
-	defer p.updateStorageMetrics(storageMetricListVols, "/")()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricListVols, "/")
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -273,7 +277,8 @@ func (p *xlStorageDiskIDCheck) ListVols(ctx context.Context) ([]VolInfo, error)
 }
 
 func (p *xlStorageDiskIDCheck) StatVol(ctx context.Context, volume string) (vol VolInfo, err error) {
-	defer p.updateStorageMetrics(storageMetricStatVol, volume)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricStatVol, volume)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -288,7 +293,8 @@ func (p *xlStorageDiskIDCheck) StatVol(ctx context.Context, volume string) (vol
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVol(ctx context.Context, volume string, forceDelete bool) (err error) {
-	defer p.updateStorageMetrics(storageMetricDeleteVol, volume)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricDeleteVol, volume)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -303,7 +309,8 @@ func (p *xlStorageDiskIDCheck) DeleteVol(ctx context.Context, volume string, for
 }
 
 func (p *xlStorageDiskIDCheck) ListDir(ctx context.Context, volume, dirPath string, count int) ([]string, error) {
-	defer p.updateStorageMetrics(storageMetricListDir, volume, dirPath)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricListDir, volume, dirPath)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -319,7 +326,8 @@ func (p *xlStorageDiskIDCheck) ListDir(ctx context.Context, volume, dirPath stri
 }
 
 func (p *xlStorageDiskIDCheck) ReadFile(ctx context.Context, volume string, path string, offset int64, buf []byte, verifier *BitrotVerifier) (n int64, err error) {
-	defer p.updateStorageMetrics(storageMetricReadFile, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricReadFile, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -335,7 +343,8 @@ func (p *xlStorageDiskIDCheck) ReadFile(ctx context.Context, volume string, path
 }
 
 func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, path string, buf []byte) (err error) {
-	defer p.updateStorageMetrics(storageMetricAppendFile, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricAppendFile, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -351,7 +360,8 @@ func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, pa
 }
 
 func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path string, size int64, reader io.Reader) error {
-	defer p.updateStorageMetrics(storageMetricCreateFile, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricCreateFile, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -367,7 +377,8 @@ func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path stri
 }
 
 func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (io.ReadCloser, error) {
-	defer p.updateStorageMetrics(storageMetricReadFileStream, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricReadFileStream, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -383,7 +394,8 @@ func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path
 }
 
 func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPath, dstVolume, dstPath string) error {
-	defer p.updateStorageMetrics(storageMetricRenameFile, srcVolume, srcPath, dstVolume, dstPath)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricRenameFile, srcVolume, srcPath, dstVolume, dstPath)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -399,7 +411,8 @@ func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPat
 }
 
 func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPath string, fi FileInfo, dstVolume, dstPath string) error {
-	defer p.updateStorageMetrics(storageMetricRenameData, srcPath, fi.DataDir, dstVolume, dstPath)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricRenameData, srcPath, fi.DataDir, dstVolume, dstPath)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -415,7 +428,8 @@ func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPat
 }
 
 func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (err error) {
-	defer p.updateStorageMetrics(storageMetricCheckParts, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricCheckParts, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -431,7 +445,8 @@ func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, pa
 }
 
 func (p *xlStorageDiskIDCheck) CheckFile(ctx context.Context, volume string, path string) (err error) {
-	defer p.updateStorageMetrics(storageMetricCheckFile, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricCheckFile, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -447,7 +462,8 @@ func (p *xlStorageDiskIDCheck) CheckFile(ctx context.Context, volume string, pat
 }
 
 func (p *xlStorageDiskIDCheck) Delete(ctx context.Context, volume string, path string, recursive bool) (err error) {
-	defer p.updateStorageMetrics(storageMetricDelete, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricDelete, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -471,7 +487,8 @@ func (p *xlStorageDiskIDCheck) DeleteVersions(ctx context.Context, volume string
 		path = versions[0].Name
 	}
 
-	defer p.updateStorageMetrics(storageMetricDeleteVersions, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricDeleteVersions, volume, path)
+	defer done()
 
 	errs = make([]error, len(versions))
 
@@ -495,7 +512,8 @@ func (p *xlStorageDiskIDCheck) DeleteVersions(ctx context.Context, volume string
 }
 
 func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error {
-	defer p.updateStorageMetrics(storageMetricVerifyFile, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricVerifyFile, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -511,7 +529,8 @@ func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path stri
 }
 
 func (p *xlStorageDiskIDCheck) WriteAll(ctx context.Context, volume string, path string, b []byte) (err error) {
-	defer p.updateStorageMetrics(storageMetricWriteAll, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricWriteAll, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -527,7 +546,8 @@ func (p *xlStorageDiskIDCheck) WriteAll(ctx context.Context, volume string, path
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVersion(ctx context.Context, volume, path string, fi FileInfo, forceDelMarker bool) (err error) {
-	defer p.updateStorageMetrics(storageMetricDeleteVersion, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricDeleteVersion, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -543,7 +563,8 @@ func (p *xlStorageDiskIDCheck) DeleteVersion(ctx context.Context, volume, path s
 }
 
 func (p *xlStorageDiskIDCheck) UpdateMetadata(ctx context.Context, volume, path string, fi FileInfo) (err error) {
-	defer p.updateStorageMetrics(storageMetricUpdateMetadata, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricUpdateMetadata, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -559,7 +580,8 @@ func (p *xlStorageDiskIDCheck) UpdateMetadata(ctx context.Context, volume, path
 }
 
 func (p *xlStorageDiskIDCheck) WriteMetadata(ctx context.Context, volume, path string, fi FileInfo) (err error) {
-	defer p.updateStorageMetrics(storageMetricWriteMetadata, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricWriteMetadata, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -575,7 +597,8 @@ func (p *xlStorageDiskIDCheck) WriteMetadata(ctx context.Context, volume, path s
 }
 
 func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, versionID string, readData bool) (fi FileInfo, err error) {
-	defer p.updateStorageMetrics(storageMetricReadVersion, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricReadVersion, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -591,7 +614,8 @@ func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, ve
 }
 
 func (p *xlStorageDiskIDCheck) ReadAll(ctx context.Context, volume string, path string) (buf []byte, err error) {
-	defer p.updateStorageMetrics(storageMetricReadAll, volume, path)()
+	ctx, done := p.updateStorageMetrics(ctx, storageMetricReadAll, volume, path)
+	defer done()
 
 	select {
 	case <-ctx.Done():
@@ -620,10 +644,19 @@ func storageTrace(s storageMetric, startTime time.Time, duration time.Duration,
 }
 
 // Update storage metrics
-func (p *xlStorageDiskIDCheck) updateStorageMetrics(s storageMetric, paths ...string) func() {
+func (p *xlStorageDiskIDCheck) updateStorageMetrics(ctx context.Context, s storageMetric, paths ...string) (context.Context, func()) {
 	startTime := time.Now()
 	trace := globalTrace.NumSubscribers() > 0
-	return func() {
+
+	// Record a child span of the calling object layer operation,
+	// remote disks propagate it further to the peer.
+	var span *otlp.Span
+	if otlp.Enabled() {
+		ctx, span = otlp.Start(ctx, "storage."+s.String(), otlp.SpanKindInternal,
+			otlp.String("disk", p.storage.String()),
+			otlp.String("path", strings.Join(paths, " ")))
+	}
+	return ctx, func() {
 		duration := time.Since(startTime)
 
 		atomic.AddUint64(&p.apiCalls[s], 1)
@@ -632,5 +665,6 @@ func (p *xlStorageDiskIDCheck) updateStorageMetrics(s storageMetric, paths ...st
 		if trace {
 			globalTrace.Publish(storageTrace(s, startTime, duration, strings.Join(paths, " ")))
 		}
+		span.End()
 	}
 }
@@ -126,6 +126,9 @@ type ObjectPartInfo struct {
 	Number     int    `json:"number"`
 	Size       int64  `json:"size"`
 	ActualSize int64  `json:"actualSize"`
+	// Checksum is the content checksum of the part in the
+	// "<algorithm>:<value>" form, empty if there is none.
+	Checksum string `json:"checksum,omitempty" msg:"Checksum,omitempty"`
 }
 
 // ChecksumInfo - carries checksums of individual scattered parts per disk.
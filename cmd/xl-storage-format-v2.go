@@ -189,6 +189,7 @@ type xlMetaV2Object struct {
 	PartETags          []string          `json:"PartETags" msg:"PartETags"`                       // Part ETags
 	PartSizes          []int64           `json:"PartSizes" msg:"PartSizes"`                       // Part Sizes
 	PartActualSizes    []int64           `json:"PartASizes,omitempty" msg:"PartASizes,omitempty"` // Part ActualSizes (compression)
+	PartChecksums      []string          `json:"PartCsums,omitempty" msg:"PartCsums,omitempty"`   // Part content checksums
 	Size               int64             `json:"Size" msg:"Size"`                                 // Object version size
 	ModTime            int64             `json:"MTime" msg:"MTime"`                               // Object version modified time
 	MetaSys            map[string][]byte `json:"MetaSys,omitempty" msg:"MetaSys,omitempty"`       // Object version internal metadata
@@ -826,6 +827,12 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 			}
 			ventry.ObjectV2.PartNumbers[i] = fi.Parts[i].Number
 			ventry.ObjectV2.PartActualSizes[i] = fi.Parts[i].ActualSize
+			if fi.Parts[i].Checksum != "" {
+				if ventry.ObjectV2.PartChecksums == nil {
+					ventry.ObjectV2.PartChecksums = make([]string, len(fi.Parts))
+				}
+				ventry.ObjectV2.PartChecksums[i] = fi.Parts[i].Checksum
+			}
 		}
 
 		for k, v := range fi.Metadata {
@@ -929,6 +936,9 @@ func (j xlMetaV2Object) ToFileInfo(volume, path string) (FileInfo, error) {
 		fi.Parts[i].Size = j.PartSizes[i]
 		fi.Parts[i].ETag = j.PartETags[i]
 		fi.Parts[i].ActualSize = j.PartActualSizes[i]
+		if len(j.PartChecksums) == len(fi.Parts) {
+			fi.Parts[i].Checksum = j.PartChecksums[i]
+		}
 	}
 	fi.Erasure.Checksums = make([]ChecksumInfo, len(j.PartSizes))
 	for i := range fi.Parts {
@@ -1084,6 +1094,9 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 			if version.ObjectV1.VersionID == fi.VersionID {
 				if fi.TransitionStatus != "" {
 					z.Versions[i].ObjectV1.Meta[ReservedMetadataPrefixLower+"transition-status"] = fi.TransitionStatus
+					for k, v := range fi.Metadata {
+						z.Versions[i].ObjectV1.Meta[k] = v
+					}
 					return uuid.UUID(version.ObjectV2.DataDir).String(), len(z.Versions) == 0, nil
 				}
 
@@ -1132,6 +1145,9 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 			if version.ObjectV2.VersionID == uv {
 				if fi.TransitionStatus != "" {
 					z.Versions[i].ObjectV2.MetaSys[ReservedMetadataPrefixLower+"transition-status"] = []byte(fi.TransitionStatus)
+					for k, v := range fi.Metadata {
+						z.Versions[i].ObjectV2.MetaSys[k] = []byte(v)
+					}
 					return uuid.UUID(version.ObjectV2.DataDir).String(), len(z.Versions) == 0, nil
 				}
 				z.Versions = append(z.Versions[:i], z.Versions[i+1:]...)
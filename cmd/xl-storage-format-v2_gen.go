@@ -729,6 +729,25 @@ func (z *xlMetaV2Object) DecodeMsg(dc *msgp.Reader) (err error) {
 					return
 				}
 			}
+		case "PartCsums":
+			var zb0009 uint32
+			zb0009, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PartChecksums")
+				return
+			}
+			if cap(z.PartChecksums) >= int(zb0009) {
+				z.PartChecksums = (z.PartChecksums)[:zb0009]
+			} else {
+				z.PartChecksums = make([]string, zb0009)
+			}
+			for za0008 := range z.PartChecksums {
+				z.PartChecksums[za0008], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "PartChecksums", za0008)
+					return
+				}
+			}
 		case "Size":
 			z.Size, err = dc.ReadInt64()
 			if err != nil {
@@ -742,64 +761,64 @@ func (z *xlMetaV2Object) DecodeMsg(dc *msgp.Reader) (err error) {
 				return
 			}
 		case "MetaSys":
-			var zb0009 uint32
-			zb0009, err = dc.ReadMapHeader()
+			var zb0010 uint32
+			zb0010, err = dc.ReadMapHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "MetaSys")
 				return
 			}
 			if z.MetaSys == nil {
-				z.MetaSys = make(map[string][]byte, zb0009)
+				z.MetaSys = make(map[string][]byte, zb0010)
 			} else if len(z.MetaSys) > 0 {
 				for key := range z.MetaSys {
 					delete(z.MetaSys, key)
 				}
 			}
-			for zb0009 > 0 {
-				zb0009--
-				var za0008 string
-				var za0009 []byte
-				za0008, err = dc.ReadString()
+			for zb0010 > 0 {
+				zb0010--
+				var za0009 string
+				var za0010 []byte
+				za0009, err = dc.ReadString()
 				if err != nil {
 					err = msgp.WrapError(err, "MetaSys")
 					return
 				}
-				za0009, err = dc.ReadBytes(za0009)
+				za0010, err = dc.ReadBytes(za0010)
 				if err != nil {
-					err = msgp.WrapError(err, "MetaSys", za0008)
+					err = msgp.WrapError(err, "MetaSys", za0009)
 					return
 				}
-				z.MetaSys[za0008] = za0009
+				z.MetaSys[za0009] = za0010
 			}
 		case "MetaUsr":
-			var zb0010 uint32
-			zb0010, err = dc.ReadMapHeader()
+			var zb0011 uint32
+			zb0011, err = dc.ReadMapHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "MetaUser")
 				return
 			}
 			if z.MetaUser == nil {
-				z.MetaUser = make(map[string]string, zb0010)
+				z.MetaUser = make(map[string]string, zb0011)
 			} else if len(z.MetaUser) > 0 {
 				for key := range z.MetaUser {
 					delete(z.MetaUser, key)
 				}
 			}
-			for zb0010 > 0 {
-				zb0010--
-				var za0010 string
+			for zb0011 > 0 {
+				zb0011--
 				var za0011 string
-				za0010, err = dc.ReadString()
+				var za0012 string
+				za0011, err = dc.ReadString()
 				if err != nil {
 					err = msgp.WrapError(err, "MetaUser")
 					return
 				}
-				za0011, err = dc.ReadString()
+				za0012, err = dc.ReadString()
 				if err != nil {
-					err = msgp.WrapError(err, "MetaUser", za0010)
+					err = msgp.WrapError(err, "MetaUser", za0011)
 					return
 				}
-				z.MetaUser[za0010] = za0011
+				z.MetaUser[za0011] = za0012
 			}
 		default:
 			err = dc.Skip()
@@ -815,19 +834,23 @@ func (z *xlMetaV2Object) DecodeMsg(dc *msgp.Reader) (err error) {
 // EncodeMsg implements msgp.Encodable
 func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 	// omitempty: check for empty values
-	zb0001Len := uint32(17)
-	var zb0001Mask uint32 /* 17 bits */
+	zb0001Len := uint32(18)
+	var zb0001Mask uint32 /* 18 bits */
 	if z.PartActualSizes == nil {
 		zb0001Len--
 		zb0001Mask |= 0x1000
 	}
+	if z.PartChecksums == nil {
+		zb0001Len--
+		zb0001Mask |= 0x2000
+	}
 	if z.MetaSys == nil {
 		zb0001Len--
-		zb0001Mask |= 0x8000
+		zb0001Mask |= 0x10000
 	}
 	if z.MetaUser == nil {
 		zb0001Len--
-		zb0001Mask |= 0x10000
+		zb0001Mask |= 0x20000
 	}
 	// variable map header, size zb0001Len
 	err = en.WriteMapHeader(zb0001Len)
@@ -1004,6 +1027,25 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	if (zb0001Mask & 0x2000) == 0 { // if not empty
+		// write "PartCsums"
+		err = en.Append(0xa9, 0x50, 0x61, 0x72, 0x74, 0x43, 0x73, 0x75, 0x6d, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteArrayHeader(uint32(len(z.PartChecksums)))
+		if err != nil {
+			err = msgp.WrapError(err, "PartChecksums")
+			return
+		}
+		for za0008 := range z.PartChecksums {
+			err = en.WriteString(z.PartChecksums[za0008])
+			if err != nil {
+				err = msgp.WrapError(err, "PartChecksums", za0008)
+				return
+			}
+		}
+	}
 	// write "Size"
 	err = en.Append(0xa4, 0x53, 0x69, 0x7a, 0x65)
 	if err != nil {
@@ -1024,7 +1066,7 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "ModTime")
 		return
 	}
-	if (zb0001Mask & 0x8000) == 0 { // if not empty
+	if (zb0001Mask & 0x10000) == 0 { // if not empty
 		// write "MetaSys"
 		err = en.Append(0xa7, 0x4d, 0x65, 0x74, 0x61, 0x53, 0x79, 0x73)
 		if err != nil {
@@ -1035,20 +1077,20 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 			err = msgp.WrapError(err, "MetaSys")
 			return
 		}
-		for za0008, za0009 := range z.MetaSys {
-			err = en.WriteString(za0008)
+		for za0009, za0010 := range z.MetaSys {
+			err = en.WriteString(za0009)
 			if err != nil {
 				err = msgp.WrapError(err, "MetaSys")
 				return
 			}
-			err = en.WriteBytes(za0009)
+			err = en.WriteBytes(za0010)
 			if err != nil {
-				err = msgp.WrapError(err, "MetaSys", za0008)
+				err = msgp.WrapError(err, "MetaSys", za0009)
 				return
 			}
 		}
 	}
-	if (zb0001Mask & 0x10000) == 0 { // if not empty
+	if (zb0001Mask & 0x20000) == 0 { // if not empty
 		// write "MetaUsr"
 		err = en.Append(0xa7, 0x4d, 0x65, 0x74, 0x61, 0x55, 0x73, 0x72)
 		if err != nil {
@@ -1059,15 +1101,15 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 			err = msgp.WrapError(err, "MetaUser")
 			return
 		}
-		for za0010, za0011 := range z.MetaUser {
-			err = en.WriteString(za0010)
+		for za0011, za0012 := range z.MetaUser {
+			err = en.WriteString(za0011)
 			if err != nil {
 				err = msgp.WrapError(err, "MetaUser")
 				return
 			}
-			err = en.WriteString(za0011)
+			err = en.WriteString(za0012)
 			if err != nil {
-				err = msgp.WrapError(err, "MetaUser", za0010)
+				err = msgp.WrapError(err, "MetaUser", za0011)
 				return
 			}
 		}
@@ -1079,19 +1121,23 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 func (z *xlMetaV2Object) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// omitempty: check for empty values
-	zb0001Len := uint32(17)
-	var zb0001Mask uint32 /* 17 bits */
+	zb0001Len := uint32(18)
+	var zb0001Mask uint32 /* 18 bits */
 	if z.PartActualSizes == nil {
 		zb0001Len--
 		zb0001Mask |= 0x1000
 	}
+	if z.PartChecksums == nil {
+		zb0001Len--
+		zb0001Mask |= 0x2000
+	}
 	if z.MetaSys == nil {
 		zb0001Len--
-		zb0001Mask |= 0x8000
+		zb0001Mask |= 0x10000
 	}
 	if z.MetaUser == nil {
 		zb0001Len--
-		zb0001Mask |= 0x10000
+		zb0001Mask |= 0x20000
 	}
 	// variable map header, size zb0001Len
 	o = msgp.AppendMapHeader(o, zb0001Len)
@@ -1154,28 +1200,36 @@ func (z *xlMetaV2Object) MarshalMsg(b []byte) (o []byte, err error) {
 			o = msgp.AppendInt64(o, z.PartActualSizes[za0007])
 		}
 	}
+	if (zb0001Mask & 0x2000) == 0 { // if not empty
+		// string "PartCsums"
+		o = append(o, 0xa9, 0x50, 0x61, 0x72, 0x74, 0x43, 0x73, 0x75, 0x6d, 0x73)
+		o = msgp.AppendArrayHeader(o, uint32(len(z.PartChecksums)))
+		for za0008 := range z.PartChecksums {
+			o = msgp.AppendString(o, z.PartChecksums[za0008])
+		}
+	}
 	// string "Size"
 	o = append(o, 0xa4, 0x53, 0x69, 0x7a, 0x65)
 	o = msgp.AppendInt64(o, z.Size)
 	// string "MTime"
 	o = append(o, 0xa5, 0x4d, 0x54, 0x69, 0x6d, 0x65)
 	o = msgp.AppendInt64(o, z.ModTime)
-	if (zb0001Mask & 0x8000) == 0 { // if not empty
+	if (zb0001Mask & 0x10000) == 0 { // if not empty
 		// string "MetaSys"
 		o = append(o, 0xa7, 0x4d, 0x65, 0x74, 0x61, 0x53, 0x79, 0x73)
 		o = msgp.AppendMapHeader(o, uint32(len(z.MetaSys)))
-		for za0008, za0009 := range z.MetaSys {
-			o = msgp.AppendString(o, za0008)
-			o = msgp.AppendBytes(o, za0009)
+		for za0009, za0010 := range z.MetaSys {
+			o = msgp.AppendString(o, za0009)
+			o = msgp.AppendBytes(o, za0010)
 		}
 	}
-	if (zb0001Mask & 0x10000) == 0 { // if not empty
+	if (zb0001Mask & 0x20000) == 0 { // if not empty
 		// string "MetaUsr"
 		o = append(o, 0xa7, 0x4d, 0x65, 0x74, 0x61, 0x55, 0x73, 0x72)
 		o = msgp.AppendMapHeader(o, uint32(len(z.MetaUser)))
-		for za0010, za0011 := range z.MetaUser {
-			o = msgp.AppendString(o, za0010)
+		for za0011, za0012 := range z.MetaUser {
 			o = msgp.AppendString(o, za0011)
+			o = msgp.AppendString(o, za0012)
 		}
 	}
 	return
@@ -1350,6 +1404,25 @@ func (z *xlMetaV2Object) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "PartCsums":
+			var zb0009 uint32
+			zb0009, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PartChecksums")
+				return
+			}
+			if cap(z.PartChecksums) >= int(zb0009) {
+				z.PartChecksums = (z.PartChecksums)[:zb0009]
+			} else {
+				z.PartChecksums = make([]string, zb0009)
+			}
+			for za0008 := range z.PartChecksums {
+				z.PartChecksums[za0008], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "PartChecksums", za0008)
+					return
+				}
+			}
 		case "Size":
 			z.Size, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
@@ -1363,64 +1436,64 @@ func (z *xlMetaV2Object) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "MetaSys":
-			var zb0009 uint32
-			zb0009, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0010 uint32
+			zb0010, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "MetaSys")
 				return
 			}
 			if z.MetaSys == nil {
-				z.MetaSys = make(map[string][]byte, zb0009)
+				z.MetaSys = make(map[string][]byte, zb0010)
 			} else if len(z.MetaSys) > 0 {
 				for key := range z.MetaSys {
 					delete(z.MetaSys, key)
 				}
 			}
-			for zb0009 > 0 {
-				var za0008 string
-				var za0009 []byte
-				zb0009--
-				za0008, bts, err = msgp.ReadStringBytes(bts)
+			for zb0010 > 0 {
+				var za0009 string
+				var za0010 []byte
+				zb0010--
+				za0009, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "MetaSys")
 					return
 				}
-				za0009, bts, err = msgp.ReadBytesBytes(bts, za0009)
+				za0010, bts, err = msgp.ReadBytesBytes(bts, za0010)
 				if err != nil {
-					err = msgp.WrapError(err, "MetaSys", za0008)
+					err = msgp.WrapError(err, "MetaSys", za0009)
 					return
 				}
-				z.MetaSys[za0008] = za0009
+				z.MetaSys[za0009] = za0010
 			}
 		case "MetaUsr":
-			var zb0010 uint32
-			zb0010, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0011 uint32
+			zb0011, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "MetaUser")
 				return
 			}
 			if z.MetaUser == nil {
-				z.MetaUser = make(map[string]string, zb0010)
+				z.MetaUser = make(map[string]string, zb0011)
 			} else if len(z.MetaUser) > 0 {
 				for key := range z.MetaUser {
 					delete(z.MetaUser, key)
 				}
 			}
-			for zb0010 > 0 {
-				var za0010 string
+			for zb0011 > 0 {
 				var za0011 string
-				zb0010--
-				za0010, bts, err = msgp.ReadStringBytes(bts)
+				var za0012 string
+				zb0011--
+				za0011, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "MetaUser")
 					return
 				}
-				za0011, bts, err = msgp.ReadStringBytes(bts)
+				za0012, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "MetaUser", za0010)
+					err = msgp.WrapError(err, "MetaUser", za0011)
 					return
 				}
-				z.MetaUser[za0010] = za0011
+				z.MetaUser[za0011] = za0012
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -1440,18 +1513,22 @@ func (z *xlMetaV2Object) Msgsize() (s int) {
 	for za0005 := range z.PartETags {
 		s += msgp.StringPrefixSize + len(z.PartETags[za0005])
 	}
-	s += 10 + msgp.ArrayHeaderSize + (len(z.PartSizes) * (msgp.Int64Size)) + 11 + msgp.ArrayHeaderSize + (len(z.PartActualSizes) * (msgp.Int64Size)) + 5 + msgp.Int64Size + 6 + msgp.Int64Size + 8 + msgp.MapHeaderSize
+	s += 10 + msgp.ArrayHeaderSize + (len(z.PartSizes) * (msgp.Int64Size)) + 11 + msgp.ArrayHeaderSize + (len(z.PartActualSizes) * (msgp.Int64Size)) + 10 + msgp.ArrayHeaderSize
+	for za0008 := range z.PartChecksums {
+		s += msgp.StringPrefixSize + len(z.PartChecksums[za0008])
+	}
+	s += 5 + msgp.Int64Size + 6 + msgp.Int64Size + 8 + msgp.MapHeaderSize
 	if z.MetaSys != nil {
-		for za0008, za0009 := range z.MetaSys {
-			_ = za0009
-			s += msgp.StringPrefixSize + len(za0008) + msgp.BytesPrefixSize + len(za0009)
+		for za0009, za0010 := range z.MetaSys {
+			_ = za0010
+			s += msgp.StringPrefixSize + len(za0009) + msgp.BytesPrefixSize + len(za0010)
 		}
 	}
 	s += 8 + msgp.MapHeaderSize
 	if z.MetaUser != nil {
-		for za0010, za0011 := range z.MetaUser {
-			_ = za0011
-			s += msgp.StringPrefixSize + len(za0010) + msgp.StringPrefixSize + len(za0011)
+		for za0011, za0012 := range z.MetaUser {
+			_ = za0012
+			s += msgp.StringPrefixSize + len(za0011) + msgp.StringPrefixSize + len(za0012)
 		}
 	}
 	return
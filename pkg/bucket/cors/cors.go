@@ -0,0 +1,179 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+const (
+	// Maximum number of rules in a CORS configuration.
+	maxRules = 100
+)
+
+// Supported methods of a CORS rule.
+var supportedMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodPut:    {},
+	http.MethodHead:   {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+}
+
+// Rule - a single CORS rule of a bucket.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the CORS rule.
+func (r Rule) Validate() error {
+	if len(r.ID) > 255 {
+		return Errorf("ID must be less than 255 characters")
+	}
+	if len(r.AllowedMethods) == 0 {
+		return Errorf("at least one AllowedMethod must be specified")
+	}
+	for _, method := range r.AllowedMethods {
+		if _, ok := supportedMethods[method]; !ok {
+			return Errorf("found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return Errorf("at least one AllowedOrigin must be specified")
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return Errorf("AllowedOrigin %q can not have more than one wildcard", origin)
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return Errorf("AllowedHeader %q can not have more than one wildcard", header)
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return Errorf("MaxAgeSeconds must not be negative")
+	}
+	return nil
+}
+
+// MatchOrigin - returns true if the origin is allowed by the rule.
+func (r Rule) MatchOrigin(origin string) bool {
+	for _, allowedOrigin := range r.AllowedOrigins {
+		if wildcard.MatchSimple(allowedOrigin, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchMethod - returns true if the HTTP method is allowed by the rule.
+func (r Rule) MatchMethod(method string) bool {
+	for _, allowedMethod := range r.AllowedMethods {
+		if allowedMethod == method {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchHeaders - returns true if all the headers are allowed by the rule,
+// header names are matched case insensitively.
+func (r Rule) MatchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(header)
+		var allowed bool
+		for _, allowedHeader := range r.AllowedHeaders {
+			if wildcard.MatchSimple(strings.ToLower(allowedHeader), header) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsAnyOrigin - returns true if the origin matched a
+// rule which allows requests from all origins.
+func (r Rule) AllowsAnyOrigin() bool {
+	for _, allowedOrigin := range r.AllowedOrigins {
+		if allowedOrigin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Config - CORS configuration of a bucket.
+type Config struct {
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name `xml:"CORSConfiguration"`
+	CORSRules []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.CORSRules) == 0 {
+		return Errorf("at least one CORSRule must be specified")
+	}
+	if len(c.CORSRules) > maxRules {
+		return Errorf("CORS configuration can have at most %d rules", maxRules)
+	}
+	for _, rule := range c.CORSRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match - returns the first rule allowing a request from origin with
+// the given method and headers, headers are the ones announced in
+// a preflight request and are empty for actual requests.
+func (c Config) Match(origin, method string, headers []string) (Rule, bool) {
+	for _, rule := range c.CORSRules {
+		if rule.MatchOrigin(origin) && rule.MatchMethod(method) && rule.MatchHeaders(headers) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// ParseConfig - parses data in given reader to CORSConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
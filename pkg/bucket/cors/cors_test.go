@@ -0,0 +1,97 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expectedErr bool
+	}{
+		{ // valid configuration
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedMethod>GET</AllowedMethod><AllowedHeader>*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+		},
+		{ // no rules
+			input:       `<CORSConfiguration></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // unsupported method
+			input:       `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // missing origin
+			input:       `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // more than one wildcard in origin
+			input:       `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // malformed XML
+			input:       `<CORSConfiguration><CORSRule>`,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectedErr && err == nil {
+			t.Fatalf("Test %d: expected an error but got none", i+1)
+		}
+		if !tc.expectedErr && err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<CORSConfiguration>
+<CORSRule><ID>uploads</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>Content-*</AllowedHeader><AllowedHeader>x-amz-meta-*</AllowedHeader></CORSRule>
+<CORSRule><ID>public</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>
+</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+		expectedOk bool
+	}{
+		{"https://app.example.com", "PUT", []string{"content-type", "X-Amz-Meta-Owner"}, "uploads", true},
+		{"https://app.example.com", "PUT", []string{"authorization"}, "", false},
+		{"https://app.example.org", "PUT", nil, "", false},
+		{"https://app.example.org", "GET", nil, "public", true},
+		{"https://app.example.com", "DELETE", nil, "", false},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.Match(tc.origin, tc.method, tc.headers)
+		if ok != tc.expectedOk {
+			t.Fatalf("Test %d: expected match %v, got %v", i+1, tc.expectedOk, ok)
+		}
+		if rule.ID != tc.expectedID {
+			t.Fatalf("Test %d: expected rule %q, got %q", i+1, tc.expectedID, rule.ID)
+		}
+	}
+}
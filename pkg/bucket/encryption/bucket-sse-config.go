@@ -20,6 +20,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"io"
+	"strings"
 )
 
 const (
@@ -69,6 +70,10 @@ type SSERule struct {
 
 const xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
 
+// arnKMSPrefix is the prefix of AWS KMS key ARNs, MinIO accepts them for
+// compatibility and only uses the key name.
+const arnKMSPrefix = "arn:aws:kms:"
+
 // BucketSSEConfig - represents default bucket encryption configuration
 type BucketSSEConfig struct {
 	XMLNS   string    `xml:"xmlns,attr,omitempty"`
@@ -109,3 +114,21 @@ func ParseBucketSSEConfig(r io.Reader) (*BucketSSEConfig, error) {
 
 	return &config, nil
 }
+
+// Algo returns the SSE algorithm of the default encryption rule.
+func (b *BucketSSEConfig) Algo() SSEAlgorithm {
+	for _, rule := range b.Rules {
+		return rule.DefaultEncryptionAction.Algorithm
+	}
+	return ""
+}
+
+// KeyID returns the KMS master key ID of the default encryption rule,
+// without any AWS KMS key ARN prefix. It is empty unless the algorithm
+// is aws:kms.
+func (b *BucketSSEConfig) KeyID() string {
+	for _, rule := range b.Rules {
+		return strings.TrimPrefix(rule.DefaultEncryptionAction.MasterKeyID, arnKMSPrefix)
+	}
+	return ""
+}
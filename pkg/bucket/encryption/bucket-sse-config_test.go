@@ -138,3 +138,39 @@ func TestParseBucketSSEConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestBucketSSEConfigKeyID(t *testing.T) {
+	testCases := []struct {
+		inputXML string
+		algo     SSEAlgorithm
+		keyID    string
+	}{
+		{
+			inputXML: `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			algo:     AES256,
+		},
+		{
+			inputXML: `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			algo:     AWSKms,
+			keyID:    "my-key",
+		},
+		{
+			inputXML: `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>arn:aws:kms:my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			algo:     AWSKms,
+			keyID:    "my-key",
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseBucketSSEConfig(bytes.NewReader([]byte(tc.inputXML)))
+		if err != nil {
+			t.Fatalf("Test case %d: %v", i+1, err)
+		}
+		if algo := config.Algo(); algo != tc.algo {
+			t.Errorf("Test case %d: Expected algorithm %s but got %s", i+1, tc.algo, algo)
+		}
+		if keyID := config.KeyID(); keyID != tc.keyID {
+			t.Errorf("Test case %d: Expected key ID %q but got %q", i+1, tc.keyID, keyID)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Maximum number of inventory configurations of a bucket.
+const maxConfigs = 1000
+
+// DestinationARNPrefix - prefix of the ARN of destination buckets.
+const DestinationARNPrefix = "arn:aws:s3:::"
+
+// Format - format of the inventory data files.
+type Format string
+
+// Supported formats, JSON writes JSON lines.
+const (
+	FormatCSV     Format = "CSV"
+	FormatJSON    Format = "JSON"
+	FormatParquet Format = "Parquet"
+)
+
+// Frequency - how often inventory reports are generated.
+type Frequency string
+
+// Supported frequencies.
+const (
+	Daily  Frequency = "Daily"
+	Weekly Frequency = "Weekly"
+)
+
+// Interval - returns the duration between two reports.
+func (f Frequency) Interval() time.Duration {
+	if f == Weekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Versions included in inventory reports.
+const (
+	VersionsAll     = "All"
+	VersionsCurrent = "Current"
+)
+
+// Field - optional field of inventory reports.
+type Field string
+
+// Supported optional fields, Tags is a MinIO extension.
+const (
+	FieldSize                Field = "Size"
+	FieldLastModifiedDate    Field = "LastModifiedDate"
+	FieldStorageClass        Field = "StorageClass"
+	FieldETag                Field = "ETag"
+	FieldIsMultipartUploaded Field = "IsMultipartUploaded"
+	FieldReplicationStatus   Field = "ReplicationStatus"
+	FieldEncryptionStatus    Field = "EncryptionStatus"
+	FieldTags                Field = "Tags"
+)
+
+var supportedFields = map[Field]struct{}{
+	FieldSize:                {},
+	FieldLastModifiedDate:    {},
+	FieldStorageClass:        {},
+	FieldETag:                {},
+	FieldIsMultipartUploaded: {},
+	FieldReplicationStatus:   {},
+	FieldEncryptionStatus:    {},
+	FieldTags:                {},
+}
+
+var validID = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+// Filter - limits the inventory to the objects with a prefix.
+type Filter struct {
+	Prefix string `xml:"Prefix,omitempty"`
+}
+
+// S3BucketDestination - bucket where inventory reports are written,
+// report objects are prefixed with Prefix.
+type S3BucketDestination struct {
+	AccountID string `xml:"AccountId,omitempty"`
+	Bucket    string `xml:"Bucket"`
+	Format    Format `xml:"Format"`
+	Prefix    string `xml:"Prefix,omitempty"`
+}
+
+// BucketName - returns the name of the destination bucket.
+func (d S3BucketDestination) BucketName() string {
+	return strings.TrimPrefix(d.Bucket, DestinationARNPrefix)
+}
+
+// Destination - where inventory reports are written.
+type Destination struct {
+	S3BucketDestination S3BucketDestination `xml:"S3BucketDestination"`
+}
+
+// Schedule - when inventory reports are generated.
+type Schedule struct {
+	Frequency Frequency `xml:"Frequency"`
+}
+
+// Config - inventory configuration of a bucket.
+type Config struct {
+	XMLNS                  string      `xml:"xmlns,attr,omitempty"`
+	XMLName                xml.Name    `xml:"InventoryConfiguration"`
+	ID                     string      `xml:"Id"`
+	IsEnabled              bool        `xml:"IsEnabled"`
+	Filter                 *Filter     `xml:"Filter,omitempty"`
+	Destination            Destination `xml:"Destination"`
+	Schedule               Schedule    `xml:"Schedule"`
+	IncludedObjectVersions string      `xml:"IncludedObjectVersions"`
+	OptionalFields         []Field     `xml:"OptionalFields>Field,omitempty"`
+}
+
+// Prefix - returns the prefix of the objects in the inventory.
+func (c Config) Prefix() string {
+	if c.Filter == nil {
+		return ""
+	}
+	return c.Filter.Prefix
+}
+
+// AllVersions - returns true if all versions of the objects are listed.
+func (c Config) AllVersions() bool {
+	return c.IncludedObjectVersions == VersionsAll
+}
+
+// Validate - validates the inventory configuration.
+func (c Config) Validate() error {
+	if !validID.MatchString(c.ID) {
+		return Errorf("Id must be 1 to 64 letters, digits, '.', '-' or '_'")
+	}
+	dest := c.Destination.S3BucketDestination
+	if !strings.HasPrefix(dest.Bucket, DestinationARNPrefix) || dest.BucketName() == "" {
+		return Errorf("Destination bucket must be an ARN of the form %s<bucket>", DestinationARNPrefix)
+	}
+	switch dest.Format {
+	case FormatCSV, FormatJSON, FormatParquet:
+	default:
+		return Errorf("unsupported Format '%s'", dest.Format)
+	}
+	switch c.Schedule.Frequency {
+	case Daily, Weekly:
+	default:
+		return Errorf("unsupported Frequency '%s'", c.Schedule.Frequency)
+	}
+	switch c.IncludedObjectVersions {
+	case VersionsAll, VersionsCurrent:
+	default:
+		return Errorf("unsupported IncludedObjectVersions '%s'", c.IncludedObjectVersions)
+	}
+	seen := make(map[Field]struct{}, len(c.OptionalFields))
+	for _, field := range c.OptionalFields {
+		if _, ok := supportedFields[field]; !ok {
+			return Errorf("unsupported optional Field '%s'", field)
+		}
+		if _, ok := seen[field]; ok {
+			return Errorf("duplicate optional Field '%s'", field)
+		}
+		seen[field] = struct{}{}
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to InventoryConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Configs - all inventory configurations of a bucket, sorted by ID.
+type Configs struct {
+	XMLName xml.Name `xml:"InventoryConfigurations"`
+	Configs []Config `xml:"InventoryConfiguration"`
+}
+
+// Get - returns the inventory configuration with given ID.
+func (cs Configs) Get(id string) (Config, bool) {
+	i := sort.Search(len(cs.Configs), func(i int) bool { return cs.Configs[i].ID >= id })
+	if i < len(cs.Configs) && cs.Configs[i].ID == id {
+		return cs.Configs[i], true
+	}
+	return Config{}, false
+}
+
+// Set - adds or replaces the inventory configuration with the ID of c.
+func (cs *Configs) Set(c Config) error {
+	c.XMLNS = ""
+	i := sort.Search(len(cs.Configs), func(i int) bool { return cs.Configs[i].ID >= c.ID })
+	if i < len(cs.Configs) && cs.Configs[i].ID == c.ID {
+		cs.Configs[i] = c
+		return nil
+	}
+	if len(cs.Configs) >= maxConfigs {
+		return Errorf("a bucket cannot have more than %d inventory configurations", maxConfigs)
+	}
+	cs.Configs = append(cs.Configs, Config{})
+	copy(cs.Configs[i+1:], cs.Configs[i:])
+	cs.Configs[i] = c
+	return nil
+}
+
+// Remove - removes the inventory configuration with given ID,
+// returns false if there is none.
+func (cs *Configs) Remove(id string) bool {
+	i := sort.Search(len(cs.Configs), func(i int) bool { return cs.Configs[i].ID >= id })
+	if i == len(cs.Configs) || cs.Configs[i].ID != id {
+		return false
+	}
+	cs.Configs = append(cs.Configs[:i], cs.Configs[i+1:]...)
+	return true
+}
+
+// ParseConfigs - parses data in given reader to InventoryConfigurations.
+func ParseConfigs(reader io.Reader) (*Configs, error) {
+	var cs Configs
+	if err := xml.NewDecoder(reader).Decode(&cs); err != nil {
+		return nil, err
+	}
+	for _, c := range cs.Configs {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(cs.Configs, func(i, j int) bool { return cs.Configs[i].ID < cs.Configs[j].ID })
+	return &cs, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testConfig = `<InventoryConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Id>%s</Id>
+  <IsEnabled>true</IsEnabled>
+  <Filter><Prefix>photos/</Prefix></Filter>
+  <Destination>
+    <S3BucketDestination>
+      <Bucket>arn:aws:s3:::reports</Bucket>
+      <Format>%s</Format>
+      <Prefix>inventory</Prefix>
+    </S3BucketDestination>
+  </Destination>
+  <Schedule><Frequency>Daily</Frequency></Schedule>
+  <IncludedObjectVersions>Current</IncludedObjectVersions>
+  <OptionalFields><Field>Size</Field><Field>%s</Field></OptionalFields>
+</InventoryConfiguration>`
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		id, format, field string
+		expectedErr       bool
+	}{
+		{id: "report1", format: "CSV", field: "ETag"},
+		{id: "report-2", format: "JSON", field: "Tags"},
+		{id: "report_3", format: "Parquet", field: "EncryptionStatus"},
+		{id: "report 4", format: "CSV", field: "ETag", expectedErr: true},
+		{id: "report5", format: "ORC", field: "ETag", expectedErr: true},
+		{id: "report6", format: "CSV", field: "Owner", expectedErr: true},
+		{id: "report7", format: "CSV", field: "Size", expectedErr: true},
+	}
+
+	for i, tc := range testCases {
+		input := fmt.Sprintf(testConfig, tc.id, tc.format, tc.field)
+		config, err := ParseConfig(strings.NewReader(input))
+		if tc.expectedErr && err == nil {
+			t.Fatalf("Test %d: expected an error but got none", i+1)
+		}
+		if !tc.expectedErr && err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err != nil {
+			continue
+		}
+		if config.ID != tc.id || config.Prefix() != "photos/" || config.AllVersions() ||
+			config.Destination.S3BucketDestination.BucketName() != "reports" {
+			t.Fatalf("Test %d: unexpected config %#v", i+1, config)
+		}
+	}
+
+	if _, err := ParseConfig(strings.NewReader(`<InventoryConfiguration><Id>report1</Id><Destination><S3BucketDestination><Bucket>reports</Bucket><Format>CSV</Format></S3BucketDestination></Destination><Schedule><Frequency>Daily</Frequency></Schedule><IncludedObjectVersions>All</IncludedObjectVersions></InventoryConfiguration>`)); err == nil {
+		t.Fatal("expected an error for a destination bucket which is not an ARN")
+	}
+}
+
+func TestConfigs(t *testing.T) {
+	var cs Configs
+	for _, id := range []string{"b", "c", "a", "b"} {
+		if err := cs.Set(Config{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(cs.Configs) != 3 || cs.Configs[0].ID != "a" || cs.Configs[2].ID != "c" {
+		t.Fatalf("unexpected configs %v", cs.Configs)
+	}
+	if _, ok := cs.Get("b"); !ok {
+		t.Fatal("expected to find config b")
+	}
+	if !cs.Remove("b") || cs.Remove("b") {
+		t.Fatal("expected config b to be removed once")
+	}
+	if _, ok := cs.Get("b"); ok {
+		t.Fatal("expected config b to be removed")
+	}
+
+	cs = Configs{}
+	c := Config{
+		ID:                     "report1",
+		IsEnabled:              true,
+		Destination:            Destination{S3BucketDestination: S3BucketDestination{Bucket: DestinationARNPrefix + "reports", Format: FormatCSV}},
+		Schedule:               Schedule{Frequency: Weekly},
+		IncludedObjectVersions: VersionsAll,
+	}
+	if err := cs.Set(c); err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseConfigs(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := parsed.Get("report1"); !ok || !got.AllVersions() || got.Schedule.Frequency.Interval() != c.Schedule.Frequency.Interval() {
+		t.Fatalf("unexpected configs %v", parsed.Configs)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry - a single server access log record, see
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/LogFormat.html
+type Entry struct {
+	BucketOwner      string
+	Bucket           string
+	Time             time.Time
+	RemoteIP         string
+	Requester        string
+	RequestID        string
+	Operation        string
+	Key              string
+	RequestURI       string
+	HTTPStatus       int
+	ErrorCode        string
+	BytesSent        int64 // negative when unknown
+	ObjectSize       int64 // negative when unknown
+	TotalTime        time.Duration
+	TurnAroundTime   time.Duration
+	Referer          string
+	UserAgent        string
+	VersionID        string
+	HostID           string
+	SignatureVersion string
+	CipherSuite      string
+	AuthType         string
+	HostHeader       string
+	TLSVersion       string
+}
+
+// field - returns the value or "-" for empty values.
+func field(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// quoted - returns the value quoted, or "-" for empty values.
+func quoted(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func size(n int64) string {
+	if n < 0 {
+		return "-"
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func millis(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
+
+// String - returns the record as a line of the S3 server access log format.
+func (e Entry) String() string {
+	fields := []string{
+		field(e.BucketOwner),
+		field(e.Bucket),
+		"[" + e.Time.UTC().Format("02/Jan/2006:15:04:05 -0700") + "]",
+		field(e.RemoteIP),
+		field(e.Requester),
+		field(e.RequestID),
+		field(e.Operation),
+		field(e.Key),
+		quoted(e.RequestURI),
+		strconv.Itoa(e.HTTPStatus),
+		field(e.ErrorCode),
+		size(e.BytesSent),
+		size(e.ObjectSize),
+		millis(e.TotalTime),
+		millis(e.TurnAroundTime),
+		quoted(e.Referer),
+		quoted(e.UserAgent),
+		field(e.VersionID),
+		field(e.HostID),
+		field(e.SignatureVersion),
+		field(e.CipherSuite),
+		field(e.AuthType),
+		field(e.HostHeader),
+		field(e.TLSVersion),
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,62 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// LoggingEnabled - describes where server access logs of a bucket
+// are delivered, log object names are prefixed with TargetPrefix.
+type LoggingEnabled struct {
+	TargetBucket string `xml:"TargetBucket"`
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+// Config - server access logging configuration of a bucket,
+// logging is disabled when LoggingEnabled is not set.
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// Enabled - returns true if server access logging is enabled.
+func (c Config) Enabled() bool {
+	return c.LoggingEnabled != nil
+}
+
+// Validate - validates the bucket logging configuration.
+func (c Config) Validate() error {
+	if c.LoggingEnabled != nil && c.LoggingEnabled.TargetBucket == "" {
+		return Errorf("LoggingEnabled must specify a TargetBucket")
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to BucketLoggingStatus.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
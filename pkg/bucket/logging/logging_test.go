@@ -0,0 +1,86 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		enabled     bool
+		expectedErr bool
+	}{
+		{ // logging enabled
+			input:   `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			enabled: true,
+		},
+		{ // logging disabled
+			input: `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`,
+		},
+		{ // missing target bucket
+			input:       `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr: true,
+		},
+		{ // malformed XML
+			input:       `<BucketLoggingStatus><LoggingEnabled>`,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectedErr && err == nil {
+			t.Fatalf("Test %d: expected an error but got none", i+1)
+		}
+		if !tc.expectedErr && err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err == nil && config.Enabled() != tc.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, tc.enabled, config.Enabled())
+		}
+	}
+}
+
+func TestEntryString(t *testing.T) {
+	entry := Entry{
+		BucketOwner:      "minio",
+		Bucket:           "mybucket",
+		Time:             time.Date(2021, time.February, 6, 0, 0, 38, 0, time.UTC),
+		RemoteIP:         "192.0.2.3",
+		Requester:        "minioadmin",
+		RequestID:        "3E57427F3EXAMPLE",
+		Operation:        "REST.GET.OBJECT",
+		Key:              "photos/cat.jpg",
+		RequestURI:       "GET /mybucket/photos/cat.jpg HTTP/1.1",
+		HTTPStatus:       200,
+		BytesSent:        113,
+		ObjectSize:       -1,
+		TotalTime:        7 * time.Millisecond,
+		UserAgent:        `curl/7.68.0 "test"`,
+		SignatureVersion: "SigV4",
+		AuthType:         "AuthHeader",
+		HostHeader:       "localhost:9000",
+	}
+	expected := `minio mybucket [06/Feb/2021:00:00:38 +0000] 192.0.2.3 minioadmin 3E57427F3EXAMPLE REST.GET.OBJECT photos/cat.jpg "GET /mybucket/photos/cat.jpg HTTP/1.1" 200 - 113 - 7 - - "curl/7.68.0 \"test\"" - - SigV4 - AuthHeader localhost:9000 -`
+	if got := entry.String(); got != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
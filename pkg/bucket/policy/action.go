@@ -141,6 +141,30 @@ const (
 	// GetBucketVersioningAction - GetBucketVersioning REST API action
 	GetBucketVersioningAction = "s3:GetBucketVersioning"
 
+	// PutBucketCorsAction - PutBucketCors, DeleteBucketCors REST API action
+	PutBucketCorsAction = "s3:PutBucketCORS"
+	// GetBucketCorsAction - GetBucketCors REST API action
+	GetBucketCorsAction = "s3:GetBucketCORS"
+
+	// PutBucketWebsiteAction - PutBucketWebsite REST API action
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+	// GetBucketWebsiteAction - GetBucketWebsite REST API action
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite REST API action
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketLoggingAction - PutBucketLogging REST API action
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+	// GetBucketLoggingAction - GetBucketLogging REST API action
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
+	// PutInventoryConfigurationAction - PutBucketInventoryConfiguration and
+	// DeleteBucketInventoryConfiguration REST API action
+	PutInventoryConfigurationAction = "s3:PutInventoryConfiguration"
+	// GetInventoryConfigurationAction - GetBucketInventoryConfiguration and
+	// ListBucketInventoryConfigurations REST API action
+	GetInventoryConfigurationAction = "s3:GetInventoryConfiguration"
+
 	// DeleteObjectVersionAction - DeleteObjectVersion Rest API action.
 	DeleteObjectVersionAction = "s3:DeleteObjectVersion"
 
@@ -150,6 +174,9 @@ const (
 	// GetObjectVersionAction - GetObjectVersionAction Rest API action.
 	GetObjectVersionAction = "s3:GetObjectVersion"
 
+	// GetObjectAttributesAction - GetObjectAttributes Rest API action.
+	GetObjectAttributesAction = "s3:GetObjectAttributes"
+
 	// GetObjectVersionTaggingAction - GetObjectVersionTagging Rest API action.
 	GetObjectVersionTaggingAction = "s3:GetObjectVersionTagging"
 
@@ -193,6 +220,7 @@ var supportedObjectActions = map[Action]struct{}{
 	PutObjectTaggingAction:               {},
 	DeleteObjectTaggingAction:            {},
 	GetObjectVersionAction:               {},
+	GetObjectAttributesAction:            {},
 	GetObjectVersionTaggingAction:        {},
 	DeleteObjectVersionAction:            {},
 	DeleteObjectVersionTaggingAction:     {},
@@ -245,6 +273,7 @@ var supportedActions = map[Action]struct{}{
 	PutBucketTaggingAction:                 {},
 	GetBucketTaggingAction:                 {},
 	GetObjectVersionAction:                 {},
+	GetObjectAttributesAction:              {},
 	GetObjectVersionTaggingAction:          {},
 	DeleteObjectVersionAction:              {},
 	DeleteObjectVersionTaggingAction:       {},
@@ -257,6 +286,15 @@ var supportedActions = map[Action]struct{}{
 	GetBucketEncryptionAction:              {},
 	PutBucketVersioningAction:              {},
 	GetBucketVersioningAction:              {},
+	PutBucketCorsAction:                    {},
+	GetBucketCorsAction:                    {},
+	PutBucketWebsiteAction:                 {},
+	GetBucketWebsiteAction:                 {},
+	DeleteBucketWebsiteAction:              {},
+	PutBucketLoggingAction:                 {},
+	GetBucketLoggingAction:                 {},
+	PutInventoryConfigurationAction:        {},
+	GetInventoryConfigurationAction:        {},
 	GetReplicationConfigurationAction:      {},
 	PutReplicationConfigurationAction:      {},
 	ReplicateObjectAction:                  {},
@@ -358,6 +396,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzCopySource,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
+			condition.S3XAmzServerSideEncryptionAwsKmsKeyID,
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
 			condition.S3ObjectLockRetainUntilDate,
@@ -397,12 +436,25 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	PutObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	GetObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
+	PutBucketCorsAction:                    condition.NewKeySet(condition.CommonKeys...),
+	GetBucketCorsAction:                    condition.NewKeySet(condition.CommonKeys...),
+	PutBucketWebsiteAction:                 condition.NewKeySet(condition.CommonKeys...),
+	GetBucketWebsiteAction:                 condition.NewKeySet(condition.CommonKeys...),
+	DeleteBucketWebsiteAction:              condition.NewKeySet(condition.CommonKeys...),
+	PutBucketLoggingAction:                 condition.NewKeySet(condition.CommonKeys...),
+	GetBucketLoggingAction:                 condition.NewKeySet(condition.CommonKeys...),
+	PutInventoryConfigurationAction:        condition.NewKeySet(condition.CommonKeys...),
+	GetInventoryConfigurationAction:        condition.NewKeySet(condition.CommonKeys...),
 
 	PutObjectVersionTaggingAction: condition.NewKeySet(condition.CommonKeys...),
 	GetObjectVersionAction: condition.NewKeySet(
 		append([]condition.Key{
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
+	GetObjectAttributesAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3VersionID,
+		}, condition.CommonKeys...)...),
 	GetObjectVersionTaggingAction: condition.NewKeySet(
 		append([]condition.Key{
 			condition.S3VersionID,
@@ -39,6 +39,10 @@ const (
 	// x-amz-server-side-encryption-customer-algorithm HTTP header applicable to PutObject API only.
 	S3XAmzServerSideEncryptionCustomerAlgorithm Key = "s3:x-amz-server-side-encryption-customer-algorithm"
 
+	// S3XAmzServerSideEncryptionAwsKmsKeyID - key representing
+	// x-amz-server-side-encryption-aws-kms-key-id HTTP header applicable to PutObject API only.
+	S3XAmzServerSideEncryptionAwsKmsKeyID Key = "s3:x-amz-server-side-encryption-aws-kms-key-id"
+
 	// S3XAmzMetadataDirective - key representing x-amz-metadata-directive HTTP header applicable to
 	// PutObject API only.
 	S3XAmzMetadataDirective Key = "s3:x-amz-metadata-directive"
@@ -125,6 +129,7 @@ var AllSupportedKeys = append([]Key{
 	S3XAmzCopySource,
 	S3XAmzServerSideEncryption,
 	S3XAmzServerSideEncryptionCustomerAlgorithm,
+	S3XAmzServerSideEncryptionAwsKmsKeyID,
 	S3XAmzMetadataDirective,
 	S3XAmzStorageClass,
 	S3XAmzContentSha256,
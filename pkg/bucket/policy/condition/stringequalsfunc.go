@@ -134,10 +134,14 @@ func validateStringEqualsValues(n name, key Key, values set.StringSet) error {
 			if err := s3utils.CheckValidBucketName(bucket); err != nil {
 				return err
 			}
-		case S3XAmzServerSideEncryption, S3XAmzServerSideEncryptionCustomerAlgorithm:
-			if s != "AES256" {
+		case S3XAmzServerSideEncryption:
+			if s != "AES256" && s != "aws:kms" {
 				return fmt.Errorf("invalid value '%v' for '%v' for %v condition", s, S3XAmzServerSideEncryption, n)
 			}
+		case S3XAmzServerSideEncryptionCustomerAlgorithm:
+			if s != "AES256" {
+				return fmt.Errorf("invalid value '%v' for '%v' for %v condition", s, S3XAmzServerSideEncryptionCustomerAlgorithm, n)
+			}
 		case S3XAmzMetadataDirective:
 			if s != "COPY" && s != "REPLACE" {
 				return fmt.Errorf("invalid value '%v' for '%v' for %v condition", s, S3XAmzMetadataDirective, n)
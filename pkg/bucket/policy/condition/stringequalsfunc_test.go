@@ -42,6 +42,16 @@ func TestStringEqualsFuncEvaluate(t *testing.T) {
 		t.Fatalf("unexpected error. %v\n", err)
 	}
 
+	case5Function, err := newStringEqualsFunc(S3XAmzServerSideEncryption, NewValueSet(NewStringValue("aws:kms")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case6Function, err := newStringEqualsFunc(S3XAmzServerSideEncryptionAwsKmsKeyID, NewValueSet(NewStringValue("my-key")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
 	testCases := []struct {
 		function       Function
 		values         map[string][]string
@@ -65,6 +75,14 @@ func TestStringEqualsFuncEvaluate(t *testing.T) {
 		{case4Function, map[string][]string{"LocationConstraint": {"us-east-1"}}, false},
 		{case4Function, map[string][]string{}, false},
 		{case4Function, map[string][]string{"delimiter": {"/"}}, false},
+
+		{case5Function, map[string][]string{"x-amz-server-side-encryption": {"aws:kms"}}, true},
+		{case5Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, false},
+		{case5Function, map[string][]string{}, false},
+
+		{case6Function, map[string][]string{"x-amz-server-side-encryption-aws-kms-key-id": {"my-key"}}, true},
+		{case6Function, map[string][]string{"x-amz-server-side-encryption-aws-kms-key-id": {"other-key"}}, false},
+		{case6Function, map[string][]string{}, false},
 	}
 
 	for i, testCase := range testCases {
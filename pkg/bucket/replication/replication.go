@@ -57,6 +57,7 @@ var (
 	errReplicationUniquePriority      = Errorf("Replication configuration has duplicate priority")
 	errReplicationDestinationMismatch = Errorf("The destination bucket must be same for all rules")
 	errRoleArnMissing                 = Errorf("Missing required parameter `Role` in ReplicationConfiguration")
+	errRoleArnDestinationMismatch     = Errorf("The destination ARN of a rule must be the same as `Role` in ReplicationConfiguration")
 )
 
 // Config - replication configuration specified in
@@ -64,8 +65,10 @@ var (
 type Config struct {
 	XMLName xml.Name `xml:"ReplicationConfiguration" json:"-"`
 	Rules   []Rule   `xml:"Rule" json:"Rules"`
-	// RoleArn is being reused for MinIO replication ARN
-	RoleArn string `xml:"Role" json:"Role"`
+	// RoleArn is being reused for MinIO replication ARN, it may be
+	// omitted when every rule names its target in its destination,
+	// which allows replicating to several targets.
+	RoleArn string `xml:"Role,omitempty" json:"Role"`
 }
 
 // Maximum 2MiB size per replication config.
@@ -90,19 +93,22 @@ func (c Config) Validate(bucket string, sameTarget bool) error {
 	if len(c.Rules) == 0 {
 		return errReplicationNoRule
 	}
-	if c.RoleArn == "" {
-		return errRoleArnMissing
-	}
-	// Validate all the rules in the replication config
-	targetMap := make(map[string]struct{})
+	// Validate all the rules in the replication config, the rules
+	// replicating to a target must share its destination bucket.
+	targetMap := make(map[string]string)
 	priorityMap := make(map[string]struct{})
 	for _, r := range c.Rules {
-		if len(targetMap) == 0 {
-			targetMap[r.Destination.Bucket] = struct{}{}
+		if c.RoleArn == "" && r.Destination.ARN == "" {
+			return errRoleArnMissing
+		}
+		if c.RoleArn != "" && r.Destination.ARN != "" && r.Destination.ARN != c.RoleArn {
+			return errRoleArnDestinationMismatch
 		}
-		if _, ok := targetMap[r.Destination.Bucket]; !ok {
+		arn := c.targetArn(r)
+		if bucket, ok := targetMap[arn]; ok && bucket != r.Destination.Bucket {
 			return errReplicationDestinationMismatch
 		}
+		targetMap[arn] = r.Destination.Bucket
 		if err := r.Validate(bucket, sameTarget); err != nil {
 			return err
 		}
@@ -123,6 +129,7 @@ const (
 	DeleteReplicationType
 	MetadataReplicationType
 	HealReplicationType
+	ExistingObjectReplicationType
 )
 
 // ObjectOpts provides information to deduce whether replication
@@ -135,6 +142,20 @@ type ObjectOpts struct {
 	DeleteMarker bool
 	SSEC         bool
 	OpType       Type
+	// ExistingObject is set when the object was written before
+	// replication was configured, or lost by the target.
+	ExistingObject bool
+	// TargetArn restricts the rules to the ones replicating to this
+	// target, all the rules apply when empty.
+	TargetArn string
+}
+
+// targetArn returns the ARN of the target the rule replicates to.
+func (c Config) targetArn(r Rule) string {
+	if r.Destination.ARN != "" {
+		return r.Destination.ARN
+	}
+	return c.RoleArn
 }
 
 // FilterActionableRules returns the rules actions that need to be executed
@@ -148,6 +169,9 @@ func (c Config) FilterActionableRules(obj ObjectOpts) []Rule {
 		if rule.Status == Disabled {
 			continue
 		}
+		if obj.TargetArn != "" && c.targetArn(rule) != obj.TargetArn {
+			continue
+		}
 		if !strings.HasPrefix(obj.Name, rule.Prefix()) {
 			continue
 		}
@@ -169,6 +193,48 @@ func (c Config) GetDestination() Destination {
 	return Destination{}
 }
 
+// GetTargetDestination returns the destination of the rule with the
+// highest priority replicating to the target arn.
+func (c Config) GetTargetDestination(arn string) Destination {
+	var dest Destination
+	priority := -1
+	for _, rule := range c.Rules {
+		if c.targetArn(rule) == arn && rule.Priority > priority {
+			dest, priority = rule.Destination, rule.Priority
+		}
+	}
+	return dest
+}
+
+// TargetArns returns the ARNs of all the targets replicated to, in the
+// order of the rules.
+func (c Config) TargetArns() []string {
+	var arns []string
+	seen := make(map[string]struct{})
+	for _, rule := range c.Rules {
+		arn := c.targetArn(rule)
+		if _, ok := seen[arn]; ok || arn == "" {
+			continue
+		}
+		seen[arn] = struct{}{}
+		arns = append(arns, arn)
+	}
+	return arns
+}
+
+// FilterTargetArns returns the ARNs of the targets the object should be
+// replicated to.
+func (c Config) FilterTargetArns(obj ObjectOpts) []string {
+	var arns []string
+	for _, arn := range c.TargetArns() {
+		obj.TargetArn = arn
+		if c.Replicate(obj) {
+			arns = append(arns, arn)
+		}
+	}
+	return arns
+}
+
 // Replicate returns true if the object should be replicated.
 func (c Config) Replicate(obj ObjectOpts) bool {
 	if obj.SSEC {
@@ -186,6 +252,8 @@ func (c Config) Replicate(obj ObjectOpts) bool {
 			default:
 				return rule.DeleteMarkerReplication.Status == Enabled
 			}
+		} else if obj.ExistingObject { // objects replicated by a resync
+			return rule.ExistingObjectReplication.Status == Enabled
 		} else { // regular object/metadata replication
 			return true
 		}
@@ -193,6 +261,20 @@ func (c Config) Replicate(obj ObjectOpts) bool {
 	return false
 }
 
+// HasExistingObjectReplication returns true if any active rule replicating
+// to the target arn replicates the existing objects.
+func (c Config) HasExistingObjectReplication(arn string) bool {
+	for _, rule := range c.Rules {
+		if c.targetArn(rule) != arn {
+			continue
+		}
+		if rule.Status == Enabled && rule.ExistingObjectReplication.Status == Enabled {
+			return true
+		}
+	}
+	return false
+}
+
 // HasActiveRules - returns whether replication policy has active rules
 // Optionally a prefix can be supplied.
 // If recursive is specified the function will also return true if any level below the
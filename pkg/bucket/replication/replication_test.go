@@ -2,7 +2,9 @@ package replication
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -99,6 +101,48 @@ func TestParseAndValidateReplicationConfig(t *testing.T) {
 			expectedParsingErr:    fmt.Errorf("invalid destination '%v'", "destinationbucket2"),
 			expectedValidationErr: nil,
 		},
+		//13 invalid existing object replication status in replication config
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:aws:iam::AcctID:role/role-name</Role><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><ExistingObjectReplication><Status>Enable</Status></ExistingObjectReplication><Prefix>key-prefix</Prefix><Destination><Bucket>arn:aws:s3:::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errInvalidExistingObjectReplicationStatus,
+		},
+		//14 existing object replication enabled in replication config
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:aws:iam::AcctID:role/role-name</Role><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication><Prefix>key-prefix</Prefix><Destination><Bucket>arn:aws:s3:::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: nil,
+		},
+		//15 rules replicating to different targets without role
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:minio:replication::id1:destinationbucket</Bucket></Destination></Rule><Rule><Status>Enabled</Status><Priority>2</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:minio:replication::id2:destinationbucket2</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: nil,
+		},
+		//16 rule without target when the role is missing
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:minio:replication::id1:destinationbucket</Bucket></Destination></Rule><Rule><Status>Enabled</Status><Priority>2</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:aws:s3:::destinationbucket2</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errRoleArnMissing,
+		},
+		//17 rule target different from the role
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:minio:replication::id1:destinationbucket</Role><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:minio:replication::id2:destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errRoleArnDestinationMismatch,
+		},
+		//18 invalid target in destination
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:minio:replication::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    Errorf("invalid destination 'arn:minio:replication::destinationbucket'"),
+			expectedValidationErr: nil,
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
@@ -126,11 +170,12 @@ func TestReplicate(t *testing.T) {
 		{ //Config0 - Replication config has no filters, all replication enabled
 			Rules: []Rule{
 				{
-					Status:                  Enabled,
-					Priority:                3,
-					DeleteMarkerReplication: DeleteMarkerReplication{Status: Enabled},
-					DeleteReplication:       DeleteReplication{Status: Enabled},
-					Filter:                  Filter{},
+					Status:                    Enabled,
+					Priority:                  3,
+					DeleteMarkerReplication:   DeleteMarkerReplication{Status: Enabled},
+					DeleteReplication:         DeleteReplication{Status: Enabled},
+					ExistingObjectReplication: ExistingObjectReplication{Status: Enabled},
+					Filter:                    Filter{},
 				},
 			},
 		},
@@ -233,6 +278,11 @@ func TestReplicate(t *testing.T) {
 		{ObjectOpts{Name: "abc/c4test", DeleteMarker: true, VersionID: "vid", OpType: DeleteReplicationType}, cfgs[3], false},                   //37. matches rule 2 - DeleteReplication disallowed by rule for permanent delete of DeleteMarker
 		{ObjectOpts{Name: "abc/c4test", VersionID: "vid", OpType: DeleteReplicationType}, cfgs[3], false},                                       //38. matches rule 2 - DeleteReplication disallowed by rule for permanent delete of version
 
+		// existing objects replicated by a resync
+		{ObjectOpts{Name: "c1test", ExistingObject: true, OpType: ExistingObjectReplicationType}, cfgs[0], true},              //39. existing object replication enabled
+		{ObjectOpts{Name: "c1test", ExistingObject: true, SSEC: true, OpType: ExistingObjectReplicationType}, cfgs[0], false}, //40. existing object replication of SSE-C encrypted object, disqualified
+		{ObjectOpts{Name: "c2test", ExistingObject: true, OpType: ExistingObjectReplicationType}, cfgs[1], false},             //41. existing object replication not set
+
 	}
 
 	for i, testCase := range testCases {
@@ -244,6 +294,95 @@ func TestReplicate(t *testing.T) {
 	}
 }
 
+func TestHasExistingObjectReplication(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		arn         string
+		expected    bool
+	}{
+		// case 1 - existing object replication not set
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:minio:replication::id:bucket</Role><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:aws:s3:::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			arn:      "arn:minio:replication::id:bucket",
+			expected: false,
+		},
+		// case 2 - existing object replication enabled
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:minio:replication::id:bucket</Role><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication><Destination><Bucket>arn:aws:s3:::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			arn:      "arn:minio:replication::id:bucket",
+			expected: true,
+		},
+		// case 3 - existing object replication enabled for another target
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:minio:replication::id:bucket</Role><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication><Destination><Bucket>arn:aws:s3:::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			arn:      "arn:minio:replication::id2:bucket",
+			expected: false,
+		},
+		// case 4 - existing object replication enabled by a disabled rule
+		{inputConfig: `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:minio:replication::id:bucket</Role><Rule><Status>Disabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication><Destination><Bucket>arn:aws:s3:::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			arn:      "arn:minio:replication::id:bucket",
+			expected: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("Test_%d", i+1), func(t *testing.T) {
+			cfg, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if got := cfg.HasExistingObjectReplication(tc.arn); got != tc.expected {
+				t.Fatalf("Expected result: `%v`, got: `%v`", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestFilterTargetArns(t *testing.T) {
+	cfg := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:minio:replication::id1:bucket1</Bucket></Destination></Rule><Rule><Status>Enabled</Status><Priority>2</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>xy/</Prefix></Filter><Destination><Bucket>arn:minio:replication::id2:bucket2</Bucket><StorageClass>STANDARD</StorageClass></Destination></Rule><Rule><Status>Disabled</Status><Priority>3</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:minio:replication::id3:bucket3</Bucket></Destination></Rule></ReplicationConfiguration>`
+	c, err := ParseConfig(bytes.NewReader([]byte(cfg)))
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if err = c.Validate("bucket", false); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	expectedArns := []string{"arn:minio:replication::id1:bucket1", "arn:minio:replication::id2:bucket2", "arn:minio:replication::id3:bucket3"}
+	if arns := c.TargetArns(); !reflect.DeepEqual(arns, expectedArns) {
+		t.Fatalf("Expected targets %v, got %v", expectedArns, arns)
+	}
+	dest := c.GetTargetDestination("arn:minio:replication::id2:bucket2")
+	if dest.Bucket != "bucket2" || dest.StorageClass != "STANDARD" {
+		t.Fatalf("Unexpected destination %#v", dest)
+	}
+
+	testCases := []struct {
+		opts     ObjectOpts
+		expected []string
+	}{
+		{opts: ObjectOpts{Name: "c1test"}, expected: []string{"arn:minio:replication::id1:bucket1"}},
+		{opts: ObjectOpts{Name: "xy/c1test"}, expected: []string{"arn:minio:replication::id1:bucket1", "arn:minio:replication::id2:bucket2"}},
+		{opts: ObjectOpts{Name: "xy/c1test", TargetArn: "arn:minio:replication::id2:bucket2"}, expected: []string{"arn:minio:replication::id1:bucket1", "arn:minio:replication::id2:bucket2"}},
+		{opts: ObjectOpts{Name: "xy/c1test", SSEC: true}, expected: nil},
+	}
+	for i, tc := range testCases {
+		if got := c.FilterTargetArns(tc.opts); !reflect.DeepEqual(got, tc.expected) {
+			t.Fatalf("Test %d: Expected targets %v, got %v", i+1, tc.expected, got)
+		}
+	}
+	opts := ObjectOpts{Name: "c1test", TargetArn: "arn:minio:replication::id2:bucket2"}
+	if c.Replicate(opts) {
+		t.Fatalf("Expected object not to be replicated to target %s", opts.TargetArn)
+	}
+
+	// The destination is marshalled back as the target ARN.
+	b, err := xml.Marshal(c)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if !bytes.Contains(b, []byte("<Bucket>arn:minio:replication::id2:bucket2</Bucket>")) {
+		t.Fatalf("Expected target ARN in %s", b)
+	}
+}
+
 func TestHasActiveRules(t *testing.T) {
 	testCases := []struct {
 		inputConfig    string
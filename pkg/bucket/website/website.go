@@ -0,0 +1,249 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Maximum number of routing rules in a website configuration.
+	maxRoutingRules = 50
+)
+
+// IndexDocument - the object suffix served for requests on a
+// directory, i.e. keys that are empty or end with a slash.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the object served when a 4XX error occurs.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects every request to another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - the condition which must be met for a routing rule
+// redirect to apply.
+type Condition struct {
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+}
+
+// Redirect - describes where a matching request is redirected.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - a single redirect rule of a website configuration.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+func validateProtocol(protocol string) error {
+	switch protocol {
+	case "", "http", "https":
+		return nil
+	}
+	return Errorf("invalid protocol %q, must be one of http or https", protocol)
+}
+
+// Validate - validates the routing rule.
+func (r RoutingRule) Validate() error {
+	if r.Condition != nil {
+		if r.Condition.HTTPErrorCodeReturnedEquals == "" && r.Condition.KeyPrefixEquals == "" {
+			return Errorf("Condition must specify at least one of HttpErrorCodeReturnedEquals or KeyPrefixEquals")
+		}
+		if code := r.Condition.HTTPErrorCodeReturnedEquals; code != "" {
+			n, err := strconv.Atoi(code)
+			if err != nil || n < 400 || n > 599 {
+				return Errorf("invalid HttpErrorCodeReturnedEquals %q, must be a 4XX or 5XX code", code)
+			}
+		}
+	}
+	rd := r.Redirect
+	if rd == (Redirect{}) {
+		return Errorf("Redirect must specify at least one element")
+	}
+	if rd.ReplaceKeyPrefixWith != "" && rd.ReplaceKeyWith != "" {
+		return Errorf("ReplaceKeyPrefixWith and ReplaceKeyWith can not be specified together")
+	}
+	if code := rd.HTTPRedirectCode; code != "" {
+		n, err := strconv.Atoi(code)
+		if err != nil || n < 300 || n > 399 {
+			return Errorf("invalid HttpRedirectCode %q, must be a 3XX code", code)
+		}
+	}
+	return validateProtocol(rd.Protocol)
+}
+
+// Match - returns true if the routing rule applies to the object key.
+// errCode is the HTTP status code of the failed object lookup, or zero
+// when the rule is evaluated before the lookup. Rules conditioned on
+// an error code only match afterwards, all others only before.
+func (r RoutingRule) Match(key string, errCode int) bool {
+	if r.Condition == nil {
+		return errCode == 0
+	}
+	if !strings.HasPrefix(key, r.Condition.KeyPrefixEquals) {
+		return false
+	}
+	if r.Condition.HTTPErrorCodeReturnedEquals == "" {
+		return errCode == 0
+	}
+	return r.Condition.HTTPErrorCodeReturnedEquals == strconv.Itoa(errCode)
+}
+
+// StatusCode - returns the HTTP status code of the redirect.
+func (r RoutingRule) StatusCode() int {
+	if code, err := strconv.Atoi(r.Redirect.HTTPRedirectCode); err == nil {
+		return code
+	}
+	return http.StatusMovedPermanently
+}
+
+// Location - returns the redirect location of the object key, host and
+// protocol of the original request are used unless overridden.
+func (r RoutingRule) Location(protocol, host, key string) string {
+	rd := r.Redirect
+	if rd.Protocol != "" {
+		protocol = rd.Protocol
+	}
+	if rd.HostName != "" {
+		host = rd.HostName
+	}
+	switch {
+	case rd.ReplaceKeyWith != "":
+		key = rd.ReplaceKeyWith
+	case rd.ReplaceKeyPrefixWith != "":
+		var prefix string
+		if r.Condition != nil {
+			prefix = r.Condition.KeyPrefixEquals
+		}
+		key = rd.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+	return location(protocol, host, key)
+}
+
+// Location - returns the location every request of the object key
+// is redirected to.
+func (r RedirectAllRequestsTo) Location(protocol, key string) string {
+	if r.Protocol != "" {
+		protocol = r.Protocol
+	}
+	return location(protocol, r.HostName, key)
+}
+
+func location(protocol, host, key string) string {
+	u := url.URL{
+		Scheme: protocol,
+		Host:   host,
+		Path:   "/" + key,
+	}
+	return u.String()
+}
+
+// Config - website configuration of a bucket.
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+// Validate - validates the website configuration.
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return Errorf("RedirectAllRequestsTo can not be specified with other elements")
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return Errorf("RedirectAllRequestsTo must specify a HostName")
+		}
+		return validateProtocol(c.RedirectAllRequestsTo.Protocol)
+	}
+	if c.IndexDocument == nil {
+		return Errorf("IndexDocument or RedirectAllRequestsTo must be specified")
+	}
+	if c.IndexDocument.Suffix == "" || strings.Contains(c.IndexDocument.Suffix, "/") {
+		return Errorf("IndexDocument Suffix must be non-empty and must not contain a slash")
+	}
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return Errorf("ErrorDocument must specify a Key")
+	}
+	if len(c.RoutingRules) > maxRoutingRules {
+		return Errorf("website configuration can have at most %d routing rules", maxRoutingRules)
+	}
+	for _, rule := range c.RoutingRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexKey - returns the key of the index object for a directory key,
+// returns false if the key does not denote a directory.
+func (c Config) IndexKey(key string) (string, bool) {
+	if c.IndexDocument == nil {
+		return "", false
+	}
+	if key != "" && !strings.HasSuffix(key, "/") {
+		return "", false
+	}
+	return key + c.IndexDocument.Suffix, true
+}
+
+// MatchRoutingRule - returns the first routing rule applying to the
+// object key, see RoutingRule.Match for errCode.
+func (c Config) MatchRoutingRule(key string, errCode int) (RoutingRule, bool) {
+	for _, rule := range c.RoutingRules {
+		if rule.Match(key, errCode) {
+			return rule, true
+		}
+	}
+	return RoutingRule{}, false
+}
+
+// ParseConfig - parses data in given reader to WebsiteConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expectedErr bool
+	}{
+		{ // valid configuration
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+		},
+		{ // valid redirect of all requests
+			input: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+		},
+		{ // missing index document
+			input:       `<WebsiteConfiguration><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // index suffix with a slash
+			input:       `<WebsiteConfiguration><IndexDocument><Suffix>a/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // redirect all requests along with other elements
+			input:       `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // invalid protocol
+			input:       `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>ftp</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // both key replacements
+			input:       `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><ReplaceKeyPrefixWith>a/</ReplaceKeyPrefixWith><ReplaceKeyWith>b</ReplaceKeyWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // invalid redirect code
+			input:       `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // malformed XML
+			input:       `<WebsiteConfiguration><IndexDocument>`,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectedErr && err == nil {
+			t.Fatalf("Test %d: expected an error but got none", i+1)
+		}
+		if !tc.expectedErr && err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigRouting(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule><RoutingRule><Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition><Redirect><HostName>example.com</HostName><HttpRedirectCode>302</HttpRedirectCode><ReplaceKeyWith>missing.html</ReplaceKeyWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key, ok := config.IndexKey("photos/"); !ok || key != "photos/index.html" {
+		t.Fatalf("unexpected index key %q", key)
+	}
+	if key, ok := config.IndexKey(""); !ok || key != "index.html" {
+		t.Fatalf("unexpected index key %q", key)
+	}
+	if _, ok := config.IndexKey("photos"); ok {
+		t.Fatal("expected no index key for an object key")
+	}
+
+	testCases := []struct {
+		key        string
+		errCode    int
+		match      bool
+		location   string
+		statusCode int
+	}{
+		{key: "docs/a.html", match: true, location: "http://localhost:9000/documents/a.html", statusCode: 301},
+		{key: "docs/a.html", errCode: 404, match: true, location: "http://example.com/missing.html", statusCode: 302},
+		{key: "img/a.png"},
+		{key: "img/a.png", errCode: 403},
+		{key: "img/a.png", errCode: 404, match: true, location: "http://example.com/missing.html", statusCode: 302},
+	}
+	for i, tc := range testCases {
+		rule, ok := config.MatchRoutingRule(tc.key, tc.errCode)
+		if ok != tc.match {
+			t.Fatalf("Test %d: expected match %v, got %v", i+1, tc.match, ok)
+		}
+		if !ok {
+			continue
+		}
+		if location := rule.Location("http", "localhost:9000", tc.key); location != tc.location {
+			t.Fatalf("Test %d: expected location %s, got %s", i+1, tc.location, location)
+		}
+		if rule.StatusCode() != tc.statusCode {
+			t.Fatalf("Test %d: expected status %d, got %d", i+1, tc.statusCode, rule.StatusCode())
+		}
+	}
+
+	redirect := RedirectAllRequestsTo{HostName: "example.com", Protocol: "https"}
+	if location := redirect.Location("http", "a b.html"); location != "https://example.com/a%20b.html" {
+		t.Fatalf("unexpected location %s", location)
+	}
+}
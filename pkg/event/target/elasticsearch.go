@@ -305,10 +305,8 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan str
 	}
 
 	if target.store != nil && !test {
-		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go replayEvents(target, target.store, doneCh, target.loggerOnce)
 	}
 
 	return target, nil
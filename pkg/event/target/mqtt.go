@@ -266,10 +266,8 @@ func NewMQTTTarget(id string, args MQTTArgs, doneCh <-chan struct{}, loggerOnce
 
 		if !test {
 			go retryRegister()
-			// Replays the events from the store.
-			eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
 			// Start replaying events from the store.
-			go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+			go replayEvents(target, target.store, doneCh, target.loggerOnce)
 		}
 	} else {
 		if token.Wait() && token.Error() != nil {
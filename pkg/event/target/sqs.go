@@ -0,0 +1,468 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/pkg/event"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// SQS constants
+const (
+	SQSQueueURL     = "queue_url"
+	SQSRegion       = "region"
+	SQSAccessKey    = "access_key"
+	SQSSecretKey    = "secret_key"
+	SQSSessionToken = "session_token"
+	SQSBatchSize    = "batch_size"
+	SQSQueueDir     = "queue_dir"
+	SQSQueueLimit   = "queue_limit"
+
+	EnvSQSEnable       = "MINIO_NOTIFY_SQS_ENABLE"
+	EnvSQSQueueURL     = "MINIO_NOTIFY_SQS_QUEUE_URL"
+	EnvSQSRegion       = "MINIO_NOTIFY_SQS_REGION"
+	EnvSQSAccessKey    = "MINIO_NOTIFY_SQS_ACCESS_KEY"
+	EnvSQSSecretKey    = "MINIO_NOTIFY_SQS_SECRET_KEY"
+	EnvSQSSessionToken = "MINIO_NOTIFY_SQS_SESSION_TOKEN"
+	EnvSQSBatchSize    = "MINIO_NOTIFY_SQS_BATCH_SIZE"
+	EnvSQSQueueDir     = "MINIO_NOTIFY_SQS_QUEUE_DIR"
+	EnvSQSQueueLimit   = "MINIO_NOTIFY_SQS_QUEUE_LIMIT"
+)
+
+const (
+	sqsAPIVersion = "2012-11-05"
+	sqsService    = "sqs"
+
+	// SendMessageBatch accepts at most 10 entries and 256KiB of
+	// message bodies per request.
+	sqsMaxBatchSize  = 10
+	sqsMaxBatchBytes = 256 << 10
+
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+	sigV4DateFormat = "20060102"
+)
+
+// SQSArgs - SQS target arguments.
+type SQSArgs struct {
+	Enable       bool            `json:"enable"`
+	QueueURL     xnet.URL        `json:"queueURL"`
+	Region       string          `json:"region"`
+	AccessKey    string          `json:"accessKey"`
+	SecretKey    string          `json:"secretKey"`
+	SessionToken string          `json:"sessionToken"`
+	BatchSize    int             `json:"batchSize"`
+	Transport    *http.Transport `json:"-"`
+	QueueDir     string          `json:"queueDir"`
+	QueueLimit   uint64          `json:"queueLimit"`
+}
+
+// Validate SQSArgs fields
+func (s SQSArgs) Validate() error {
+	if !s.Enable {
+		return nil
+	}
+	if s.QueueURL.IsEmpty() {
+		return errors.New("queue_url empty")
+	}
+	if s.Region == "" {
+		return errors.New("region empty")
+	}
+	if s.AccessKey == "" && s.SecretKey != "" || s.AccessKey != "" && s.SecretKey == "" {
+		return errors.New("access_key and secret_key must be specified as a pair")
+	}
+	if s.BatchSize < 1 || s.BatchSize > sqsMaxBatchSize {
+		return fmt.Errorf("batch_size should be between 1 and %d", sqsMaxBatchSize)
+	}
+	if s.QueueDir != "" {
+		if !filepath.IsAbs(s.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+// isFIFO - returns true if the queue is a FIFO queue, which requires
+// a message group and deduplication ID for every message.
+func (s SQSArgs) isFIFO() bool {
+	return strings.HasSuffix(s.QueueURL.Path, ".fifo")
+}
+
+// SQSTarget - SQS target.
+type SQSTarget struct {
+	id         event.TargetID
+	args       SQSArgs
+	httpClient *http.Client
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+}
+
+// sqsEntry - a single message of a SendMessageBatch request.
+type sqsEntry struct {
+	id     string
+	bucket string
+	body   []byte
+}
+
+// sqsBatchResult - result of a SendMessageBatch request.
+type sqsBatchResult struct {
+	Successful []struct {
+		ID string `xml:"Id"`
+	} `xml:"SendMessageBatchResult>SendMessageBatchResultEntry"`
+	Failed []struct {
+		ID      string `xml:"Id"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"SendMessageBatchResult>BatchResultErrorEntry"`
+}
+
+// sqsErrorResponse - error returned by the SQS query API.
+type sqsErrorResponse struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// ID - returns target ID.
+func (target *SQSTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *SQSTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// IsActive - Return true if target is up and active
+func (target *SQSTarget) IsActive() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target.args.QueueURL.String(), nil)
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return false, errNotConnected
+		}
+		return false, err
+	}
+
+	resp, err := target.httpClient.Do(req)
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) || errors.Is(err, context.DeadlineExceeded) {
+			return false, errNotConnected
+		}
+		return false, err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	// No network failure i.e response from the target means its up
+	return true, nil
+}
+
+// Save - saves the events to the store if queuestore is configured, which will
+// be replayed in batches when the SQS endpoint is active.
+func (target *SQSTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	id, err := getNewUUID()
+	if err != nil {
+		return err
+	}
+	entry, err := newSQSEntry(id, eventData)
+	if err != nil {
+		return err
+	}
+	if _, err = target.sendBatch([]sqsEntry{entry}); err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+	}
+	return err
+}
+
+// newSQSEntry - converts an event to a SendMessageBatch entry.
+func newSQSEntry(id string, eventData event.Event) (sqsEntry, error) {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return sqsEntry{}, err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return sqsEntry{}, err
+	}
+	return sqsEntry{id: id, bucket: eventData.S3.Bucket.Name, body: data}, nil
+}
+
+// sendBatch - sends the entries with a single SendMessageBatch request and
+// returns the IDs of the entries which were accepted by the queue.
+func (target *SQSTarget) sendBatch(entries []sqsEntry) ([]string, error) {
+	form := url.Values{}
+	form.Set("Action", "SendMessageBatch")
+	form.Set("Version", sqsAPIVersion)
+	form.Set("QueueUrl", target.args.QueueURL.String())
+	for i, entry := range entries {
+		prefix := "SendMessageBatchRequestEntry." + strconv.Itoa(i+1) + "."
+		form.Set(prefix+"Id", entry.id)
+		form.Set(prefix+"MessageBody", string(entry.body))
+		if target.args.isFIFO() {
+			form.Set(prefix+"MessageGroupId", entry.bucket)
+			form.Set(prefix+"MessageDeduplicationId", entry.id)
+		}
+	}
+	body := []byte(form.Encode())
+
+	req, err := http.NewRequest(http.MethodPost, target.args.QueueURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if target.args.AccessKey != "" {
+		signV4(req, body, target.args.AccessKey, target.args.SecretKey,
+			target.args.SessionToken, target.args.Region, sqsService, time.Now().UTC())
+	}
+
+	resp, err := target.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errResp sqsErrorResponse
+		if xml.Unmarshal(respBody, &errResp) == nil && errResp.Code != "" {
+			return nil, fmt.Errorf("sending events failed with %v: %s: %s", resp.Status, errResp.Code, errResp.Message)
+		}
+		return nil, fmt.Errorf("sending events failed with %v", resp.Status)
+	}
+
+	var result sqsBatchResult
+	if err = xml.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unable to parse SendMessageBatch response: %w", err)
+	}
+
+	sent := make([]string, 0, len(result.Successful))
+	for _, entry := range result.Successful {
+		sent = append(sent, entry.ID)
+	}
+	if len(result.Failed) > 0 {
+		return sent, fmt.Errorf("sending %d event(s) failed, first error %s: %s",
+			len(result.Failed), result.Failed[0].Code, result.Failed[0].Message)
+	}
+	return sent, nil
+}
+
+// Send - reads the event from store along with the events queued after it
+// and sends them to SQS in a single batch.
+func (target *SQSTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The events sent in an earlier batch are still replayed by
+		// replayEvents(), such events do not exist anymore.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	entry, err := newSQSEntry(eventKey, eventData)
+	if err != nil {
+		return err
+	}
+	entries := []sqsEntry{entry}
+	size := len(entry.body)
+
+	if target.args.BatchSize > 1 {
+		names, err := target.store.List()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if len(entries) >= target.args.BatchSize {
+				break
+			}
+			key := strings.TrimSuffix(name, eventExt)
+			if key == eventKey {
+				continue
+			}
+			eventData, err := target.store.Get(key)
+			if err != nil {
+				// Skip the events which are already sent or are not
+				// readable, they are picked up by replayEvents().
+				continue
+			}
+			entry, err := newSQSEntry(key, eventData)
+			if err != nil {
+				continue
+			}
+			if size+len(entry.body) > sqsMaxBatchBytes {
+				break
+			}
+			size += len(entry.body)
+			entries = append(entries, entry)
+		}
+	}
+
+	sent, err := target.sendBatch(entries)
+	// Delete the events accepted by the queue from the store, even
+	// if some of the entries in the batch were rejected.
+	for _, key := range sent {
+		if dErr := target.store.Del(key); dErr != nil && !os.IsNotExist(dErr) && err == nil {
+			err = dErr
+		}
+	}
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+		return err
+	}
+	return nil
+}
+
+// Close - closes idle connections to the SQS endpoint.
+func (target *SQSTarget) Close() error {
+	target.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// NewSQSTarget - creates new SQS target.
+func NewSQSTarget(ctx context.Context, id string, args SQSArgs, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), transport *http.Transport, test bool) (*SQSTarget, error) {
+	var store Store
+	target := &SQSTarget{
+		id:         event.TargetID{ID: id, Name: "sqs"},
+		args:       args,
+		httpClient: &http.Client{Transport: transport},
+		loggerOnce: loggerOnce,
+	}
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-sqs-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if err := store.Open(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		target.store = store
+	}
+
+	_, err := target.IsActive()
+	if err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(ctx, err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Start replaying events from the store.
+		go replayEvents(target, target.store, ctx.Done(), target.loggerOnce)
+	}
+
+	return target, nil
+}
+
+// signV4 - signs the request with AWS signature version 4 for the given
+// service, as the query APIs of queue services other than S3 expect.
+func signV4(req *http.Request, body []byte, accessKey, secretKey, sessionToken, region, service string, t time.Time) {
+	amzDate := t.Format(sigV4TimeFormat)
+	scope := strings.Join([]string{t.Format(sigV4DateFormat), region, service, "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + strings.TrimSpace(headers[k]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), t.Format(sigV4DateFormat))
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", sigV4Algorithm+" Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
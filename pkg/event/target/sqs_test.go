@@ -0,0 +1,191 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/event"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+func TestSignV4(t *testing.T) {
+	// Example request from the AWS signature version 4 documentation.
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signV4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "",
+		"us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestSQSArgsValidate(t *testing.T) {
+	queueURL, err := xnet.ParseHTTPURL("http://localhost:9324/000000000000/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		args    SQSArgs
+		wantErr bool
+	}{
+		{SQSArgs{Enable: false}, false},
+		{SQSArgs{Enable: true, QueueURL: *queueURL, Region: "us-east-1", BatchSize: 10}, false},
+		{SQSArgs{Enable: true, QueueURL: *queueURL, Region: "us-east-1", BatchSize: 1, AccessKey: "access", SecretKey: "secret"}, false},
+		{SQSArgs{Enable: true, Region: "us-east-1", BatchSize: 10}, true},
+		{SQSArgs{Enable: true, QueueURL: *queueURL, BatchSize: 10}, true},
+		{SQSArgs{Enable: true, QueueURL: *queueURL, Region: "us-east-1", BatchSize: 11}, true},
+		{SQSArgs{Enable: true, QueueURL: *queueURL, Region: "us-east-1", BatchSize: 10, AccessKey: "access"}, true},
+		{SQSArgs{Enable: true, QueueURL: *queueURL, Region: "us-east-1", BatchSize: 10, QueueDir: "relative/dir"}, true},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.args.Validate(); (err != nil) != testCase.wantErr {
+			t.Errorf("test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+	}
+}
+
+// sqsTestServer - minimal SendMessageBatch endpoint which records the
+// received messages.
+type sqsTestServer struct {
+	sync.Mutex
+	batches  [][]string
+	authzHdr []string
+}
+
+func (s *sqsTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodHead {
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil || form.Get("Action") != "SendMessageBatch" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<ErrorResponse><Error><Code>InvalidAction</Code><Message>invalid action</Message></Error></ErrorResponse>`)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.authzHdr = append(s.authzHdr, r.Header.Get("Authorization"))
+
+	var messages []string
+	var result strings.Builder
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("SendMessageBatchRequestEntry.%d.", i)
+		id := form.Get(prefix + "Id")
+		if id == "" {
+			break
+		}
+		messages = append(messages, form.Get(prefix+"MessageBody"))
+		fmt.Fprintf(&result, "<SendMessageBatchResultEntry><Id>%s</Id></SendMessageBatchResultEntry>", id)
+	}
+	s.batches = append(s.batches, messages)
+	fmt.Fprintf(w, "<SendMessageBatchResponse><SendMessageBatchResult>%s</SendMessageBatchResult></SendMessageBatchResponse>", result.String())
+}
+
+func TestSQSTarget(t *testing.T) {
+	handler := &sqsTestServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	queueURL, err := xnet.ParseHTTPURL(server.URL + "/000000000000/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tearDownStore()
+
+	args := SQSArgs{
+		Enable:    true,
+		QueueURL:  *queueURL,
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+		BatchSize: 3,
+		QueueDir:  queueDir,
+	}
+	logOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {
+		t.Log(err)
+	}
+	target, err := NewSQSTarget(context.Background(), "1", args, logOnce, &http.Transport{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	for i := 0; i < 5; i++ {
+		if err = target.Save(testEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names, err := target.store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 5 {
+		t.Fatalf("expected 5 queued events, got %d", len(names))
+	}
+	for _, name := range names {
+		if err = target.Send(strings.TrimSuffix(name, eventExt)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if names, _ = target.store.List(); len(names) != 0 {
+		t.Fatalf("expected the store to be empty, got %d events", len(names))
+	}
+
+	handler.Lock()
+	defer handler.Unlock()
+	if len(handler.batches) != 2 || len(handler.batches[0]) != 3 || len(handler.batches[1]) != 2 {
+		t.Fatalf("expected batches of 3 and 2 messages, got %v", handler.batches)
+	}
+	var log event.Log
+	if err = json.Unmarshal([]byte(handler.batches[0][0]), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.EventName != testEvent.EventName || len(log.Records) != 1 {
+		t.Fatalf("unexpected message %s", handler.batches[0][0])
+	}
+	for _, authz := range handler.authzHdr {
+		if !strings.HasPrefix(authz, "AWS4-HMAC-SHA256 Credential=access/") || !strings.Contains(authz, "/us-east-1/sqs/aws4_request") {
+			t.Fatalf("unexpected authorization header %s", authz)
+		}
+	}
+}
@@ -251,10 +251,8 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 	}
 
 	if target.store != nil && !test {
-		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, ctx.Done(), target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, ctx.Done(), target.loggerOnce)
+		go replayEvents(target, target.store, ctx.Done(), target.loggerOnce)
 	}
 
 	return target, nil
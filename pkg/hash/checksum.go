@@ -0,0 +1,344 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ChecksumType contains information about the checksum type.
+type ChecksumType uint32
+
+const (
+	// ChecksumTrailing indicates the checksum will be sent in the trailing header.
+	// Another checksum type will be set.
+	ChecksumTrailing ChecksumType = 1 << iota
+
+	// ChecksumSHA256 indicates a SHA256 checksum.
+	ChecksumSHA256
+	// ChecksumSHA1 indicates a SHA-1 checksum.
+	ChecksumSHA1
+	// ChecksumCRC32 indicates a CRC32 checksum with IEEE table.
+	ChecksumCRC32
+	// ChecksumCRC32C indicates a CRC32 checksum with Castagnoli table.
+	ChecksumCRC32C
+	// ChecksumInvalid indicates an invalid checksum.
+	ChecksumInvalid
+	// ChecksumMultipart indicates the checksum is composed of the
+	// checksums of the parts of a multipart object.
+	ChecksumMultipart
+
+	// ChecksumNone indicates no checksum.
+	ChecksumNone ChecksumType = 0
+
+	checksumBaseTypes = ChecksumSHA256 | ChecksumSHA1 | ChecksumCRC32 | ChecksumCRC32C
+)
+
+// Checksum request headers.
+const (
+	amzSDKChecksumAlgo = "x-amz-sdk-checksum-algorithm"
+	amzTrailer         = "x-amz-trailer"
+)
+
+// Checksum is a checksum of a given type and its base64 encoded value.
+type Checksum struct {
+	Type    ChecksumType
+	Encoded string
+}
+
+// ErrInvalidChecksum is returned when an invalid checksum is provided in headers.
+var ErrInvalidChecksum = errors.New("invalid checksum")
+
+// ChecksumMismatch - when the checksum sent by the client does not match the computed checksum.
+type ChecksumMismatch struct {
+	Want string
+	Got  string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return "Bad checksum: Expected " + e.Want + " does not match calculated " + e.Got
+}
+
+// NewChecksumType returns the checksum type for the given algorithm,
+// e.g. "CRC32C". The algorithm is case insensitive.
+func NewChecksumType(alg string) ChecksumType {
+	switch strings.ToUpper(alg) {
+	case "CRC32":
+		return ChecksumCRC32
+	case "CRC32C":
+		return ChecksumCRC32C
+	case "SHA1":
+		return ChecksumSHA1
+	case "SHA256":
+		return ChecksumSHA256
+	case "":
+		return ChecksumNone
+	}
+	return ChecksumInvalid
+}
+
+// Is returns true if t has all the bits of cmp set.
+func (t ChecksumType) Is(cmp ChecksumType) bool {
+	if cmp == ChecksumNone {
+		return t == ChecksumNone
+	}
+	return t&cmp == cmp
+}
+
+// Base returns the checksum type without the trailing and multipart flags.
+func (t ChecksumType) Base() ChecksumType {
+	return t & checksumBaseTypes
+}
+
+// IsSet returns whether the type is valid and known.
+func (t ChecksumType) IsSet() bool {
+	return !t.Is(ChecksumInvalid) && t.Base() != ChecksumNone
+}
+
+// Trailing returns whether the checksum is sent in a trailing header.
+func (t ChecksumType) Trailing() bool {
+	return t.Is(ChecksumTrailing)
+}
+
+// String returns the name of the checksum algorithm, e.g. "CRC32C".
+func (t ChecksumType) String() string {
+	switch t.Base() {
+	case ChecksumCRC32:
+		return "CRC32"
+	case ChecksumCRC32C:
+		return "CRC32C"
+	case ChecksumSHA1:
+		return "SHA1"
+	case ChecksumSHA256:
+		return "SHA256"
+	}
+	return ""
+}
+
+// Key returns the header key of the checksum, e.g. "x-amz-checksum-crc32c".
+// It returns an empty string if the type is not set.
+func (t ChecksumType) Key() string {
+	if !t.IsSet() {
+		return ""
+	}
+	return "x-amz-checksum-" + strings.ToLower(t.String())
+}
+
+// RawByteLen returns the size of the unencoded checksum.
+func (t ChecksumType) RawByteLen() int {
+	switch t.Base() {
+	case ChecksumCRC32, ChecksumCRC32C:
+		return 4
+	case ChecksumSHA1:
+		return sha1.Size
+	case ChecksumSHA256:
+		return sha256.Size
+	}
+	return 0
+}
+
+// Hasher returns a hasher corresponding to the checksum type.
+// Returns nil if no checksum.
+func (t ChecksumType) Hasher() hash.Hash {
+	switch t.Base() {
+	case ChecksumCRC32:
+		return crc32.NewIEEE()
+	case ChecksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case ChecksumSHA1:
+		return sha1.New()
+	case ChecksumSHA256:
+		return sha256.New()
+	}
+	return nil
+}
+
+// NewChecksumString returns a new checksum from the specified algorithm
+// and the base64 encoded value. It returns nil if the value is not a
+// valid checksum of the algorithm.
+func NewChecksumString(alg, value string) *Checksum {
+	t := NewChecksumType(alg)
+	if !t.IsSet() {
+		return nil
+	}
+	c := Checksum{Type: t, Encoded: value}
+	if !c.Valid() {
+		return nil
+	}
+	return &c
+}
+
+// NewChecksumFromData returns a new checksum of the data.
+func NewChecksumFromData(t ChecksumType, data []byte) *Checksum {
+	if !t.IsSet() {
+		return nil
+	}
+	h := t.Hasher()
+	h.Write(data)
+	return &Checksum{Type: t.Base(), Encoded: base64.StdEncoding.EncodeToString(h.Sum(nil))}
+}
+
+// ParseChecksum parses a checksum in the "<algorithm>:<value>" form
+// returned by String.
+func ParseChecksum(s string) (*Checksum, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil, ErrInvalidChecksum
+	}
+	t := NewChecksumType(s[:i])
+	if !t.IsSet() {
+		return nil, ErrInvalidChecksum
+	}
+	c := Checksum{Type: t, Encoded: s[i+1:]}
+	if j := strings.LastIndexByte(c.Encoded, '-'); j >= 0 {
+		if _, err := strconv.Atoi(c.Encoded[j+1:]); err != nil {
+			return nil, ErrInvalidChecksum
+		}
+		c.Type |= ChecksumMultipart
+	}
+	if !c.Valid() {
+		return nil, ErrInvalidChecksum
+	}
+	return &c, nil
+}
+
+// String returns the checksum in the "<algorithm>:<value>" form.
+func (c Checksum) String() string {
+	return c.Type.String() + ":" + c.Encoded
+}
+
+// Raw returns the raw checksum value, nil if the checksum is
+// composed of the checksums of multiple parts.
+func (c Checksum) Raw() []byte {
+	if c.Type.Is(ChecksumMultipart) {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(c.Encoded)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// Valid returns whether the checksum has a known type and a value
+// of the expected length.
+func (c Checksum) Valid() bool {
+	if !c.Type.IsSet() {
+		return false
+	}
+	encoded := c.Encoded
+	if c.Type.Is(ChecksumMultipart) {
+		i := strings.LastIndexByte(encoded, '-')
+		if i < 0 {
+			return false
+		}
+		encoded = encoded[:i]
+	}
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	return err == nil && len(b) == c.Type.RawByteLen()
+}
+
+// Matches returns whether the given content matches the checksum.
+func (c Checksum) Matches(content []byte) error {
+	got := NewChecksumFromData(c.Type, content)
+	if got == nil || got.Encoded != c.Encoded {
+		var gotEncoded string
+		if got != nil {
+			gotEncoded = got.Encoded
+		}
+		return ChecksumMismatch{Want: c.Encoded, Got: gotEncoded}
+	}
+	return nil
+}
+
+// ComposeChecksum returns the checksum of a multipart object, which is
+// the checksum of the concatenated raw checksums of the parts followed
+// by the number of parts, e.g. "AAAAAA==-3".
+func ComposeChecksum(t ChecksumType, parts []Checksum) (*Checksum, error) {
+	h := t.Hasher()
+	if h == nil {
+		return nil, ErrInvalidChecksum
+	}
+	for _, part := range parts {
+		if part.Type.Base() != t.Base() {
+			return nil, ErrInvalidChecksum
+		}
+		raw := part.Raw()
+		if raw == nil {
+			return nil, ErrInvalidChecksum
+		}
+		h.Write(raw)
+	}
+	return &Checksum{
+		Type:    t.Base() | ChecksumMultipart,
+		Encoded: base64.StdEncoding.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(parts)),
+	}, nil
+}
+
+// GetContentChecksum returns the content checksum sent by the client in
+// the x-amz-checksum-* headers, or the trailing checksum announced by
+// the x-amz-trailer header. It returns nil if no checksum was sent and
+// ErrInvalidChecksum if the checksum headers are invalid.
+func GetContentChecksum(h http.Header) (*Checksum, error) {
+	if trailer := h.Get(amzTrailer); trailer != "" {
+		var res *Checksum
+		for _, key := range strings.Split(trailer, ",") {
+			key = strings.ToLower(strings.TrimSpace(key))
+			if !strings.HasPrefix(key, "x-amz-checksum-") {
+				continue
+			}
+			t := NewChecksumType(strings.TrimPrefix(key, "x-amz-checksum-"))
+			if !t.IsSet() || res != nil {
+				return nil, ErrInvalidChecksum
+			}
+			res = &Checksum{Type: t | ChecksumTrailing}
+		}
+		if res != nil {
+			return res, nil
+		}
+	}
+
+	var res *Checksum
+	for _, t := range []ChecksumType{ChecksumCRC32, ChecksumCRC32C, ChecksumSHA1, ChecksumSHA256} {
+		value := h.Get(t.Key())
+		if value == "" {
+			continue
+		}
+		if res != nil {
+			// Only a single checksum is allowed.
+			return nil, ErrInvalidChecksum
+		}
+		res = &Checksum{Type: t, Encoded: value}
+		if !res.Valid() {
+			return nil, ErrInvalidChecksum
+		}
+	}
+	if res == nil {
+		if alg := h.Get(amzSDKChecksumAlgo); alg != "" && !NewChecksumType(alg).IsSet() {
+			return nil, ErrInvalidChecksum
+		}
+	}
+	return res, nil
+}
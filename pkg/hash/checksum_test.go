@@ -0,0 +1,142 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+var checksumTestData = []byte("123456789")
+
+func TestChecksumTypes(t *testing.T) {
+	testCases := []struct {
+		alg     string
+		key     string
+		encoded string
+	}{
+		{"CRC32", "x-amz-checksum-crc32", "y/Q5Jg=="},
+		{"crc32c", "x-amz-checksum-crc32c", "4waSgw=="},
+		{"SHA1", "x-amz-checksum-sha1", "98O8HYCOBHMq32eZZczDTKeuNEE="},
+		{"SHA256", "x-amz-checksum-sha256", "FeKw08M4keuw8e9gnsQZQgwg4yDOlMZfvIwzEkSOsiU="},
+	}
+	for i, testCase := range testCases {
+		typ := NewChecksumType(testCase.alg)
+		if !typ.IsSet() {
+			t.Fatalf("Test %d: expected valid checksum type for %s", i+1, testCase.alg)
+		}
+		if typ.Key() != testCase.key {
+			t.Errorf("Test %d: expected key %s, got %s", i+1, testCase.key, typ.Key())
+		}
+		c := NewChecksumFromData(typ, checksumTestData)
+		if c.Encoded != testCase.encoded {
+			t.Errorf("Test %d: expected checksum %s, got %s", i+1, testCase.encoded, c.Encoded)
+		}
+		if err := c.Matches(checksumTestData); err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err := c.Matches([]byte("12345678")); err == nil {
+			t.Errorf("Test %d: expected checksum mismatch", i+1)
+		}
+		parsed, err := ParseChecksum(c.String())
+		if err != nil || *parsed != *c {
+			t.Errorf("Test %d: expected %v, got %v, %v", i+1, c, parsed, err)
+		}
+	}
+	if NewChecksumType("MD5").IsSet() {
+		t.Error("expected MD5 to be an invalid checksum type")
+	}
+}
+
+func TestComposeChecksum(t *testing.T) {
+	parts := []Checksum{
+		*NewChecksumFromData(ChecksumCRC32C, checksumTestData[:4]),
+		*NewChecksumFromData(ChecksumCRC32C, checksumTestData[4:]),
+	}
+	c, err := ComposeChecksum(ChecksumCRC32C, parts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := NewChecksumFromData(ChecksumCRC32C, append(parts[0].Raw(), parts[1].Raw()...)).Encoded + "-2"
+	if c.Encoded != expected || !c.Type.Is(ChecksumMultipart) {
+		t.Fatalf("expected %s, got %v", expected, c)
+	}
+	parsed, err := ParseChecksum(c.String())
+	if err != nil || *parsed != *c {
+		t.Fatalf("expected %v, got %v, %v", c, parsed, err)
+	}
+	if _, err = ComposeChecksum(ChecksumSHA256, parts); err == nil {
+		t.Fatal("expected error when composing checksums of different types")
+	}
+}
+
+func TestGetContentChecksum(t *testing.T) {
+	testCases := []struct {
+		header  http.Header
+		want    *Checksum
+		wantErr bool
+	}{
+		{header: http.Header{}},
+		{header: http.Header{"X-Amz-Checksum-Crc32": {"y/Q5Jg=="}}, want: &Checksum{Type: ChecksumCRC32, Encoded: "y/Q5Jg=="}},
+		{header: http.Header{"X-Amz-Trailer": {"x-amz-checksum-crc32c"}}, want: &Checksum{Type: ChecksumCRC32C | ChecksumTrailing}},
+		{header: http.Header{"X-Amz-Checksum-Crc32": {"invalid"}}, wantErr: true},
+		{header: http.Header{"X-Amz-Checksum-Crc32": {"y/Q5Jg=="}, "X-Amz-Checksum-Crc32c": {"4waSgw=="}}, wantErr: true},
+		{header: http.Header{"X-Amz-Trailer": {"x-amz-checksum-md5"}}, wantErr: true},
+		{header: http.Header{"X-Amz-Sdk-Checksum-Algorithm": {"MD5"}}, wantErr: true},
+	}
+	for i, testCase := range testCases {
+		got, err := GetContentChecksum(testCase.header)
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if (got == nil) != (testCase.want == nil) || got != nil && *got != *testCase.want {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.want, got)
+		}
+	}
+}
+
+func TestHashReaderChecksum(t *testing.T) {
+	testCases := []struct {
+		header  http.Header
+		trailer http.Header
+		err     error
+	}{
+		{header: http.Header{"X-Amz-Checksum-Sha256": {"FeKw08M4keuw8e9gnsQZQgwg4yDOlMZfvIwzEkSOsiU="}}},
+		{header: http.Header{"X-Amz-Checksum-Crc32": {"4waSgw=="}}, err: ChecksumMismatch{Want: "4waSgw==", Got: "y/Q5Jg=="}},
+		{header: http.Header{"X-Amz-Trailer": {"x-amz-checksum-crc32c"}}, trailer: http.Header{"X-Amz-Checksum-Crc32c": {"4waSgw=="}}},
+		{header: http.Header{"X-Amz-Trailer": {"x-amz-checksum-crc32c"}}, trailer: http.Header{}, err: ErrInvalidChecksum},
+	}
+	for i, testCase := range testCases {
+		r, err := NewReader(bytes.NewReader(checksumTestData), int64(len(checksumTestData)), "", "", int64(len(checksumTestData)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = r.AddChecksum(&http.Request{Header: testCase.header, Trailer: testCase.trailer}, false); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		_, err = io.Copy(ioutil.Discard, r)
+		if err != testCase.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if err == nil && r.ContentChecksum() == nil {
+			t.Fatalf("Test %d: expected content checksum", i+1)
+		}
+	}
+}
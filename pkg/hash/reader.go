@@ -23,6 +23,7 @@ import (
 	"errors"
 	"hash"
 	"io"
+	"net/http"
 
 	"github.com/minio/minio/pkg/etag"
 )
@@ -45,6 +46,12 @@ type Reader struct {
 	contentSHA256 []byte
 
 	sha256 hash.Hash
+
+	contentChecksum *Checksum   // expected content checksum
+	contentHasher   hash.Hash   // computes the content checksum
+	contentHash     Checksum    // computed content checksum
+	ignoreChecksum  bool        // do not verify the content checksum
+	trailer         http.Header // trailing headers of the request
 }
 
 // NewReader returns a new Reader that wraps src and computes
@@ -132,6 +139,9 @@ func (r *Reader) Read(p []byte) (int, error) {
 	if r.sha256 != nil {
 		r.sha256.Write(p[:n])
 	}
+	if r.contentHasher != nil {
+		r.contentHasher.Write(p[:n])
+	}
 
 	if err == io.EOF { // Verify content SHA256, if set.
 		if r.sha256 != nil {
@@ -142,6 +152,11 @@ func (r *Reader) Read(p []byte) (int, error) {
 				}
 			}
 		}
+		if r.contentHasher != nil {
+			if cErr := r.verifyChecksum(); cErr != nil {
+				return n, cErr
+			}
+		}
 	}
 	if err != nil && err != io.EOF {
 		if v, ok := err.(etag.VerifyError); ok {
@@ -214,6 +229,85 @@ func (r *Reader) SHA256HexString() string {
 	return hex.EncodeToString(r.contentSHA256)
 }
 
+// AddChecksum adds the content checksum sent by the client in the
+// request headers, if any. The checksum is computed while reading and
+// verified at the end of the content, unless ignoreValue is set.
+// Trailing checksums are verified against the trailing headers of
+// the request.
+func (r *Reader) AddChecksum(req *http.Request, ignoreValue bool) error {
+	cs, err := GetContentChecksum(req.Header)
+	if err != nil {
+		return ErrInvalidChecksum
+	}
+	if cs == nil {
+		return nil
+	}
+	r.contentChecksum = cs
+	r.contentHasher = cs.Type.Hasher()
+	r.ignoreChecksum = ignoreValue
+	r.trailer = req.Trailer
+	return nil
+}
+
+// AddChecksumType computes a content checksum of the given type
+// while reading, if the client did not send a checksum.
+func (r *Reader) AddChecksumType(t ChecksumType) error {
+	if r.contentChecksum != nil {
+		if r.contentChecksum.Type.Base() != t.Base() {
+			return ErrInvalidChecksum
+		}
+		return nil
+	}
+	if !t.IsSet() {
+		return ErrInvalidChecksum
+	}
+	r.contentChecksum = &Checksum{Type: t.Base()}
+	r.contentHasher = t.Hasher()
+	r.ignoreChecksum = true
+	return nil
+}
+
+// ContentChecksumType returns the type of the content checksum,
+// ChecksumNone if no checksum is computed.
+func (r *Reader) ContentChecksumType() ChecksumType {
+	if r.contentChecksum == nil {
+		return ChecksumNone
+	}
+	return r.contentChecksum.Type.Base()
+}
+
+// ContentChecksum returns the content checksum computed while
+// reading. It returns nil if no checksum is computed or the
+// content has not been read completely.
+func (r *Reader) ContentChecksum() *Checksum {
+	if r.contentHash.Encoded == "" {
+		return nil
+	}
+	c := r.contentHash
+	return &c
+}
+
+func (r *Reader) verifyChecksum() error {
+	got := base64.StdEncoding.EncodeToString(r.contentHasher.Sum(nil))
+	r.contentHash = Checksum{Type: r.contentChecksum.Type.Base(), Encoded: got}
+	if r.ignoreChecksum {
+		return nil
+	}
+	want := r.contentChecksum.Encoded
+	if r.contentChecksum.Type.Trailing() {
+		want = r.trailer.Get(r.contentChecksum.Type.Key())
+		if want == "" {
+			r.contentHash = Checksum{}
+			return ErrInvalidChecksum
+		}
+	}
+	if want != got {
+		r.contentHash = Checksum{}
+		return ChecksumMismatch{Want: want, Got: got}
+	}
+	return nil
+}
+
 var _ io.Closer = (*Reader)(nil) // compiler check
 
 // Close and release resources.
@@ -111,6 +111,9 @@ const (
 	// GetObjectVersionAction - GetObjectVersionAction Rest API action.
 	GetObjectVersionAction = "s3:GetObjectVersion"
 
+	// GetObjectAttributesAction - GetObjectAttributes Rest API action.
+	GetObjectAttributesAction = "s3:GetObjectAttributes"
+
 	// GetObjectVersionTaggingAction - GetObjectVersionTagging Rest API action.
 	GetObjectVersionTaggingAction = "s3:GetObjectVersionTagging"
 
@@ -164,6 +167,36 @@ const (
 
 	// GetBucketVersioningAction - GetBucketVersioning REST API action
 	GetBucketVersioningAction = "s3:GetBucketVersioning"
+
+	// PutBucketCorsAction - PutBucketCors, DeleteBucketCors REST API action
+	PutBucketCorsAction = "s3:PutBucketCORS"
+
+	// GetBucketCorsAction - GetBucketCors REST API action
+	GetBucketCorsAction = "s3:GetBucketCORS"
+
+	// PutBucketWebsiteAction - PutBucketWebsite REST API action
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+
+	// GetBucketWebsiteAction - GetBucketWebsite REST API action
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite REST API action
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketLoggingAction - PutBucketLogging REST API action
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+
+	// GetBucketLoggingAction - GetBucketLogging REST API action
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
+	// PutInventoryConfigurationAction - PutBucketInventoryConfiguration and
+	// DeleteBucketInventoryConfiguration REST API action
+	PutInventoryConfigurationAction = "s3:PutInventoryConfiguration"
+
+	// GetInventoryConfigurationAction - GetBucketInventoryConfiguration and
+	// ListBucketInventoryConfigurations REST API action
+	GetInventoryConfigurationAction = "s3:GetInventoryConfiguration"
+
 	// GetReplicationConfigurationAction  - GetReplicationConfiguration REST API action
 	GetReplicationConfigurationAction = "s3:GetReplicationConfiguration"
 	// PutReplicationConfigurationAction  - PutReplicationConfiguration REST API action
@@ -221,6 +254,7 @@ var supportedActions = map[Action]struct{}{
 	GetBucketTaggingAction:                 {},
 	PutBucketTaggingAction:                 {},
 	GetObjectVersionAction:                 {},
+	GetObjectAttributesAction:              {},
 	GetObjectVersionTaggingAction:          {},
 	DeleteObjectVersionAction:              {},
 	DeleteObjectVersionTaggingAction:       {},
@@ -232,6 +266,15 @@ var supportedActions = map[Action]struct{}{
 	GetBucketEncryptionAction:              {},
 	PutBucketVersioningAction:              {},
 	GetBucketVersioningAction:              {},
+	PutBucketCorsAction:                    {},
+	GetBucketCorsAction:                    {},
+	PutBucketWebsiteAction:                 {},
+	GetBucketWebsiteAction:                 {},
+	DeleteBucketWebsiteAction:              {},
+	PutBucketLoggingAction:                 {},
+	GetBucketLoggingAction:                 {},
+	PutInventoryConfigurationAction:        {},
+	GetInventoryConfigurationAction:        {},
 	GetReplicationConfigurationAction:      {},
 	PutReplicationConfigurationAction:      {},
 	ReplicateObjectAction:                  {},
@@ -258,6 +301,7 @@ var supportedObjectActions = map[Action]struct{}{
 	PutObjectTaggingAction:               {},
 	DeleteObjectTaggingAction:            {},
 	GetObjectVersionAction:               {},
+	GetObjectAttributesAction:            {},
 	GetObjectVersionTaggingAction:        {},
 	DeleteObjectVersionAction:            {},
 	DeleteObjectVersionTaggingAction:     {},
@@ -340,6 +384,7 @@ var iamActionConditionKeyMap = actionConditionKeyMap{
 			condition.S3XAmzCopySource,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
+			condition.S3XAmzServerSideEncryptionAwsKmsKeyID,
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
 			condition.S3VersionID,
@@ -407,6 +452,10 @@ var iamActionConditionKeyMap = actionConditionKeyMap{
 		append([]condition.Key{
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
+	GetObjectAttributesAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3VersionID,
+		}, condition.CommonKeys...)...),
 	GetObjectVersionTaggingAction: condition.NewKeySet(
 		append([]condition.Key{
 			condition.S3VersionID,
@@ -47,6 +47,11 @@ const (
 	KMSCreateKeyAdminAction = "admin:KMSCreateKey"
 	// KMSKeyStatusAdminAction - allow getting KMS key status
 	KMSKeyStatusAdminAction = "admin:KMSKeyStatus"
+	// KMSListKeysAdminAction - allow listing KMS master keys
+	KMSListKeysAdminAction = "admin:KMSListKeys"
+	// KMSRotateKeyAdminAction - allow rotating KMS master keys and
+	// re-wrapping object keys under another master key
+	KMSRotateKeyAdminAction = "admin:KMSRotateKey"
 	// ServerInfoAdminAction - allow listing server info
 	ServerInfoAdminAction = "admin:ServerInfo"
 	// HealthInfoAdminAction - allow obtaining cluster health information
@@ -61,6 +66,20 @@ const (
 	// ServiceStopAdminAction - allow stopping MinIO service.
 	ServiceStopAdminAction = "admin:ServiceStop"
 
+	// DecommissionAdminAction - allow starting, canceling and querying
+	// the decommissioning of server pools.
+	DecommissionAdminAction = "admin:Decommission"
+
+	// StartBatchJobAction - allow submitting batch jobs
+	StartBatchJobAction = "admin:StartBatchJob"
+	// ListBatchJobsAction - allow listing batch jobs
+	ListBatchJobsAction = "admin:ListBatchJobs"
+	// DescribeBatchJobAction - allow getting the description and
+	// the progress of a batch job
+	DescribeBatchJobAction = "admin:DescribeBatchJob"
+	// CancelBatchJobAction - allow canceling batch jobs
+	CancelBatchJobAction = "admin:CancelBatchJob"
+
 	// ConfigUpdateAdminAction - allow MinIO config management
 	ConfigUpdateAdminAction = "admin:ConfigUpdate"
 
@@ -130,6 +149,23 @@ const (
 	// GetBucketTargetAction - allow getting bucket targets
 	GetBucketTargetAction = "admin:GetBucketTarget"
 
+	// Remote Tier admin Actions
+
+	// SetTierAction - allow adding/editing a remote tier
+	SetTierAction = "admin:SetTier"
+	// ListTierAction - allow listing remote tiers
+	ListTierAction = "admin:ListTier"
+
+	// Site replication admin Actions
+
+	// SiteReplicationAddAction - allow adding clusters for site-level replication
+	SiteReplicationAddAction = "admin:SiteReplicationAdd"
+	// SiteReplicationInfoAction - allow getting site replication info
+	SiteReplicationInfoAction = "admin:SiteReplicationInfo"
+	// SiteReplicationOperationAction - allow performing site replication
+	// create and delete operations to peers
+	SiteReplicationOperationAction = "admin:SiteReplicationOperation"
+
 	// AllAdminActions - provides all admin permissions
 	AllAdminActions = "admin:*"
 )
@@ -144,12 +180,19 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	TraceAdminAction:                {},
 	ConsoleLogAdminAction:           {},
 	KMSKeyStatusAdminAction:         {},
+	KMSListKeysAdminAction:          {},
+	KMSRotateKeyAdminAction:         {},
 	ServerInfoAdminAction:           {},
 	HealthInfoAdminAction:           {},
 	BandwidthMonitorAction:          {},
 	ServerUpdateAdminAction:         {},
 	ServiceRestartAdminAction:       {},
 	ServiceStopAdminAction:          {},
+	DecommissionAdminAction:         {},
+	StartBatchJobAction:             {},
+	ListBatchJobsAction:             {},
+	DescribeBatchJobAction:          {},
+	CancelBatchJobAction:            {},
 	ConfigUpdateAdminAction:         {},
 	CreateUserAdminAction:           {},
 	DeleteUserAdminAction:           {},
@@ -176,6 +219,11 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	GetBucketQuotaAdminAction:       {},
 	SetBucketTargetAction:           {},
 	GetBucketTargetAction:           {},
+	SetTierAction:                   {},
+	ListTierAction:                  {},
+	SiteReplicationAddAction:        {},
+	SiteReplicationInfoAction:       {},
+	SiteReplicationOperationAction:  {},
 	AllAdminActions:                 {},
 }
 
@@ -199,9 +247,16 @@ var adminActionConditionKeyMap = map[Action]condition.KeySet{
 	TraceAdminAction:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ConsoleLogAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	KMSKeyStatusAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	KMSListKeysAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	KMSRotateKeyAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ServerUpdateAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ServiceRestartAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ServiceStopAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DecommissionAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	StartBatchJobAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListBatchJobsAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DescribeBatchJobAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CancelBatchJobAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ConfigUpdateAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	CreateUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	DeleteUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
@@ -219,13 +274,18 @@ var adminActionConditionKeyMap = map[Action]condition.KeySet{
 	RemoveServiceAccountAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ListServiceAccountsAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
 
-	CreatePolicyAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeletePolicyAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetPolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AttachPolicyAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUserPoliciesAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	SetBucketQuotaAdminAction:   condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetBucketQuotaAdminAction:   condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	SetBucketTargetAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetBucketTargetAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreatePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeletePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetPolicyAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AttachPolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUserPoliciesAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetTierAction:                  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListTierAction:                 condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SiteReplicationAddAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SiteReplicationInfoAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SiteReplicationOperationAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
 }
@@ -0,0 +1,485 @@
+// MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/secure-io/sio-go/sioutil"
+)
+
+const (
+	// keyLockTimeout is the maximum duration to wait for
+	// a key locked by another process.
+	keyLockTimeout = 10 * time.Second
+
+	// staleKeyLockAge is the age of a key lock after which
+	// its holder is considered to have crashed.
+	staleKeyLockAge = time.Minute
+)
+
+// KeyStore is a KMS that keeps multiple named keys
+// in a local directory. Each key is a file that holds
+// all versions of the key, encrypted under a single
+// master key. New DEKs are always generated with the
+// latest key version while DEKs generated by older
+// versions remain decryptable after a key rotation.
+type KeyStore struct {
+	dir          string
+	masterKey    secretKey
+	defaultKeyID string
+
+	lock sync.RWMutex
+	keys map[string]cachedKey // cache of decrypted keys
+}
+
+var _ KMS = (*KeyStore)(nil) // compiler check
+
+// keyVersion is a single, decrypted version of a key.
+type keyVersion struct {
+	Version   int
+	CreatedAt time.Time
+	Key       []byte
+}
+
+// cachedKey holds the decrypted versions of a key and the
+// modification time and size of the key file they have been
+// read from.
+type cachedKey struct {
+	versions []keyVersion
+	modTime  time.Time
+	size     int64
+}
+
+// keyFile is the on-disk representation of a key.
+// Every version is sealed by the master key.
+type keyFile struct {
+	Versions []keyFileVersion `json:"versions"`
+}
+
+type keyFileVersion struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created"`
+	Key       []byte    `json:"key"`
+}
+
+// versionedKey is the ciphertext of a DEK generated by
+// a KeyStore. It records the key version that has been
+// used to seal the DEK.
+type versionedKey struct {
+	Version    int    `json:"version"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// NewKeyStore returns a KeyStore that stores its keys in dir
+// and encrypts them with the given 256 bit master key.
+//
+// If defaultKeyID is not empty and no such key exists, it
+// gets created. The default key is used whenever no key ID
+// is specified.
+func NewKeyStore(dir string, masterKey []byte, defaultKeyID string) (*KeyStore, error) {
+	if len(masterKey) != 32 {
+		return nil, fmt.Errorf("kms: invalid master key length %d", len(masterKey))
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	ks := &KeyStore{
+		dir:          dir,
+		masterKey:    secretKey{key: masterKey},
+		defaultKeyID: defaultKeyID,
+		keys:         map[string]cachedKey{},
+	}
+	if defaultKeyID != "" {
+		if err := ks.CreateKey(defaultKeyID); err != nil && !errors.Is(err, ErrKeyExists) {
+			return nil, err
+		}
+		if _, err := ks.latest(defaultKeyID); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+// Stat returns the current KeyStore status.
+func (ks *KeyStore) Stat() (Status, error) {
+	return Status{
+		Name:       "KeyStore",
+		DefaultKey: ks.defaultKeyID,
+	}, nil
+}
+
+// CreateKey creates a new key with the given key ID. It
+// returns ErrKeyExists if such a key exists already.
+func (ks *KeyStore) CreateKey(keyID string) error {
+	if !validKeyID(keyID) {
+		return fmt.Errorf("kms: invalid key ID %q", keyID)
+	}
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	version, err := newKeyVersion(1)
+	if err != nil {
+		return err
+	}
+	data, err := ks.marshalKey(keyID, []keyVersion{version})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(ks.keyPath(keyID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		if os.IsExist(err) {
+			return ErrKeyExists
+		}
+		return err
+	}
+	if _, err = file.Write(data); err == nil {
+		err = file.Sync()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+// RotateKey adds a new version to the referenced key. All
+// subsequent DEKs are generated with the new key version.
+func (ks *KeyStore) RotateKey(keyID string) error {
+	if !validKeyID(keyID) {
+		return fmt.Errorf("kms: invalid key ID %q", keyID)
+	}
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	// The key store may be shared with other processes, hold
+	// the key lock such that no concurrent rotation is lost.
+	unlock, err := ks.lockKey(keyID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Drop the cached versions, the next lookup reads the
+	// rotated key - even if writing it fails half-way.
+	delete(ks.keys, keyID)
+
+	key, err := ks.readKey(keyID)
+	if err != nil {
+		return err
+	}
+	versions := key.versions
+	version, err := newKeyVersion(versions[len(versions)-1].Version + 1)
+	if err != nil {
+		return err
+	}
+	versions = append(versions, version)
+
+	data, err := ks.marshalKey(keyID, versions)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(ks.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), ks.keyPath(keyID))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// ListKeys returns the IDs of all keys in lexical order.
+func (ks *KeyStore) ListKeys() ([]string, error) {
+	entries, err := ioutil.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() && validKeyID(entry.Name()) {
+			keys = append(keys, entry.Name())
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// KeyStatus returns the versions of the referenced key. It
+// always reads the key from disk to report versions added
+// by other processes sharing the key store.
+func (ks *KeyStore) KeyStatus(keyID string) (KeyStatus, error) {
+	if keyID == "" {
+		keyID = ks.defaultKeyID
+	}
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	key, err := ks.readKey(keyID)
+	if err != nil {
+		return KeyStatus{}, err
+	}
+	ks.keys[keyID] = key
+
+	status := KeyStatus{KeyID: keyID, Versions: make([]int, 0, len(key.versions))}
+	for _, v := range key.versions {
+		status.Versions = append(status.Versions, v.Version)
+	}
+	return status, nil
+}
+
+// GenerateKey generates a new DEK sealed by the latest
+// version of the referenced key.
+func (ks *KeyStore) GenerateKey(keyID string, context Context) (DEK, error) {
+	if keyID == "" {
+		keyID = ks.defaultKeyID
+	}
+	version, err := ks.latest(keyID)
+	if err != nil {
+		return DEK{}, err
+	}
+	dek, err := secretKey{keyID: keyID, key: version.Key}.GenerateKey(keyID, context)
+	if err != nil {
+		return DEK{}, err
+	}
+	ciphertext, err := json.Marshal(versionedKey{
+		Version:    version.Version,
+		Ciphertext: dek.Ciphertext,
+	})
+	if err != nil {
+		return DEK{}, err
+	}
+	dek.Version, dek.Ciphertext = version.Version, ciphertext
+	return dek, nil
+}
+
+// DecryptKey decrypts the ciphertext with the key version
+// that has been used to generate it.
+func (ks *KeyStore) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	var sealedKey versionedKey
+	if err := json.Unmarshal(ciphertext, &sealedKey); err != nil {
+		return nil, err
+	}
+	version, err := ks.version(keyID, sealedKey.Version)
+	if err != nil {
+		return nil, err
+	}
+	return secretKey{keyID: keyID, key: version.Key}.DecryptKey(keyID, sealedKey.Ciphertext, context)
+}
+
+// latest returns the most recent version of the referenced key.
+// It reloads the key from disk if it is not cached or the key
+// file has changed, since the key may have been rotated by
+// another process.
+func (ks *KeyStore) latest(keyID string) (keyVersion, error) {
+	if !validKeyID(keyID) {
+		return keyVersion{}, ErrKeyNotFound
+	}
+	ks.lock.RLock()
+	key, ok := ks.keys[keyID]
+	ks.lock.RUnlock()
+	if ok {
+		if fi, err := os.Stat(ks.keyPath(keyID)); err == nil && fi.ModTime().Equal(key.modTime) && fi.Size() == key.size {
+			return key.versions[len(key.versions)-1], nil
+		}
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	key, err := ks.readKey(keyID)
+	if err != nil {
+		return keyVersion{}, err
+	}
+	ks.keys[keyID] = key
+	return key.versions[len(key.versions)-1], nil
+}
+
+// version returns the requested version of the referenced key.
+// It reloads the key from disk if the version is not cached,
+// since the key may have been rotated by another process.
+func (ks *KeyStore) version(keyID string, version int) (keyVersion, error) {
+	ks.lock.RLock()
+	key := ks.keys[keyID]
+	ks.lock.RUnlock()
+	for _, v := range key.versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	key, err := ks.readKey(keyID)
+	if err != nil {
+		return keyVersion{}, err
+	}
+	ks.keys[keyID] = key
+	for _, v := range key.versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return keyVersion{}, fmt.Errorf("kms: key %q has no version %d", keyID, version)
+}
+
+// readKey reads and decrypts all versions of the
+// referenced key from disk.
+func (ks *KeyStore) readKey(keyID string) (cachedKey, error) {
+	if !validKeyID(keyID) {
+		return cachedKey{}, ErrKeyNotFound
+	}
+	f, err := os.Open(ks.keyPath(keyID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cachedKey{}, ErrKeyNotFound
+		}
+		return cachedKey{}, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return cachedKey{}, err
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return cachedKey{}, err
+	}
+	var file keyFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return cachedKey{}, err
+	}
+	if len(file.Versions) == 0 {
+		return cachedKey{}, fmt.Errorf("kms: key %q has no versions", keyID)
+	}
+	versions := make([]keyVersion, 0, len(file.Versions))
+	for _, v := range file.Versions {
+		key, err := ks.masterKey.open(v.Key, keyAssociatedData(keyID, v.Version))
+		if err != nil {
+			return cachedKey{}, err
+		}
+		versions = append(versions, keyVersion{
+			Version:   v.Version,
+			CreatedAt: v.CreatedAt,
+			Key:       key,
+		})
+	}
+	return cachedKey{versions: versions, modTime: fi.ModTime(), size: fi.Size()}, nil
+}
+
+// lockKey creates the lock file of the referenced key exclusively,
+// such that processes sharing the key store modify a key one at a
+// time. Lock files older than staleKeyLockAge have been left over by
+// crashed processes and are removed. The returned function removes
+// the lock file.
+func (ks *KeyStore) lockKey(keyID string) (func(), error) {
+	path := filepath.Join(ks.dir, ".lock-"+keyID)
+	deadline := time.Now().Add(keyLockTimeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if fi, err := os.Stat(path); err == nil && time.Since(fi.ModTime()) > staleKeyLockAge {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("kms: key %q is locked by another process", keyID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// marshalKey seals all key versions with the master
+// key and encodes them in the on-disk format.
+func (ks *KeyStore) marshalKey(keyID string, versions []keyVersion) ([]byte, error) {
+	file := keyFile{Versions: make([]keyFileVersion, 0, len(versions))}
+	for _, v := range versions {
+		sealedKey, err := ks.masterKey.seal(v.Key, keyAssociatedData(keyID, v.Version))
+		if err != nil {
+			return nil, err
+		}
+		file.Versions = append(file.Versions, keyFileVersion{
+			Version:   v.Version,
+			CreatedAt: v.CreatedAt,
+			Key:       sealedKey,
+		})
+	}
+	return json.Marshal(file)
+}
+
+func (ks *KeyStore) keyPath(keyID string) string { return filepath.Join(ks.dir, keyID) }
+
+// keyAssociatedData binds a sealed key version to its key ID
+// such that key files cannot be swapped or renamed.
+func keyAssociatedData(keyID string, version int) []byte {
+	return []byte(fmt.Sprintf("%s/%d", keyID, version))
+}
+
+func newKeyVersion(version int) (keyVersion, error) {
+	key, err := sioutil.Random(32)
+	if err != nil {
+		return keyVersion{}, err
+	}
+	return keyVersion{
+		Version:   version,
+		CreatedAt: time.Now().UTC(),
+		Key:       key,
+	}, nil
+}
+
+// validKeyID returns true if the key ID can be used as
+// file name. Names starting with a dot are reserved for
+// temporary files.
+func validKeyID(keyID string) bool {
+	if keyID == "" || len(keyID) > 80 || keyID[0] == '.' {
+		return false
+	}
+	for _, r := range keyID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
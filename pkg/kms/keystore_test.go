@@ -0,0 +1,200 @@
+// MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+var keyStoreMasterKey = bytes.Repeat([]byte{0x42}, 32)
+
+func TestKeyStoreRoundtrip(t *testing.T) {
+	dir := t.TempDir()
+	KMS, err := NewKeyStore(dir, keyStoreMasterKey, "my-key")
+	if err != nil {
+		t.Fatalf("Failed to initialize key store: %v", err)
+	}
+
+	key, err := KMS.GenerateKey("", Context{"bucket": "object"})
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if key.KeyID != "my-key" {
+		t.Fatalf("Generated key has wrong key ID: got %q - want %q", key.KeyID, "my-key")
+	}
+	plaintext, err := KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{"bucket": "object"})
+	if err != nil {
+		t.Fatalf("Failed to decrypt key: %v", err)
+	}
+	if !bytes.Equal(key.Plaintext, plaintext) {
+		t.Fatalf("Decrypted key does not match generated one: got %x - want %x", plaintext, key.Plaintext)
+	}
+	if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{"bucket": "other-object"}); err == nil {
+		t.Fatal("Decryption with a different context should fail")
+	}
+
+	// A new key store with the same master key must be able to
+	// decrypt the key while a different master key must fail.
+	KMS, err = NewKeyStore(dir, keyStoreMasterKey, "my-key")
+	if err != nil {
+		t.Fatalf("Failed to re-open key store: %v", err)
+	}
+	if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{"bucket": "object"}); err != nil {
+		t.Fatalf("Failed to decrypt key after re-opening the key store: %v", err)
+	}
+	if _, err = NewKeyStore(dir, bytes.Repeat([]byte{0x01}, 32), "my-key"); err == nil {
+		t.Fatal("Opening the key store with a wrong master key should fail")
+	}
+}
+
+func TestKeyStoreCreateAndList(t *testing.T) {
+	dir := t.TempDir()
+	KMS, err := NewKeyStore(dir, keyStoreMasterKey, "")
+	if err != nil {
+		t.Fatalf("Failed to initialize key store: %v", err)
+	}
+	if _, err = KMS.GenerateKey("my-key", Context{}); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Generating a key with a non-existing key should fail with %v: got %v", ErrKeyNotFound, err)
+	}
+
+	for _, keyID := range []string{"key-2", "key-1", "key_3.v2"} {
+		if err = KMS.CreateKey(keyID); err != nil {
+			t.Fatalf("Failed to create key %q: %v", keyID, err)
+		}
+	}
+	if err = KMS.CreateKey("key-1"); !errors.Is(err, ErrKeyExists) {
+		t.Fatalf("Creating an existing key should fail with %v: got %v", ErrKeyExists, err)
+	}
+	for _, keyID := range []string{"", ".hidden", "../key", "key/1"} {
+		if err = KMS.CreateKey(keyID); err == nil {
+			t.Fatalf("Creating key %q should fail", keyID)
+		}
+	}
+
+	// Files that are not keys must not be listed.
+	if err = os.WriteFile(filepath.Join(dir, ".tmp-123"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := KMS.ListKeys()
+	if err != nil {
+		t.Fatalf("Failed to list keys: %v", err)
+	}
+	if want := []string{"key-1", "key-2", "key_3.v2"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("Listed keys do not match: got %v - want %v", keys, want)
+	}
+}
+
+func TestKeyStoreRotateKey(t *testing.T) {
+	dir := t.TempDir()
+	KMS, err := NewKeyStore(dir, keyStoreMasterKey, "my-key")
+	if err != nil {
+		t.Fatalf("Failed to initialize key store: %v", err)
+	}
+	oldKey, err := KMS.GenerateKey("my-key", Context{})
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	// Rotate the key with a different key store instance to
+	// verify that KMS picks up versions it has not seen yet.
+	other, err := NewKeyStore(dir, keyStoreMasterKey, "my-key")
+	if err != nil {
+		t.Fatalf("Failed to initialize key store: %v", err)
+	}
+	if err = other.RotateKey("my-key"); err != nil {
+		t.Fatalf("Failed to rotate key: %v", err)
+	}
+	newKey, err := other.GenerateKey("my-key", Context{})
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if oldKey.Version != 1 || newKey.Version != 2 {
+		t.Fatalf("Generated keys have wrong versions: got %d and %d - want 1 and 2", oldKey.Version, newKey.Version)
+	}
+	if key, err := KMS.GenerateKey("my-key", Context{}); err != nil || key.Version != 2 {
+		t.Fatalf("Failed to generate key with the rotated version: got version %d - %v", key.Version, err)
+	}
+	status, err := KMS.KeyStatus("")
+	if err != nil {
+		t.Fatalf("Failed to get key status: %v", err)
+	}
+	if want := []int{1, 2}; status.KeyID != "my-key" || !reflect.DeepEqual(status.Versions, want) || status.Version() != 2 {
+		t.Fatalf("Invalid key status: got %v - want versions %v", status, want)
+	}
+
+	for i, key := range []DEK{oldKey, newKey} {
+		plaintext, err := KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{})
+		if err != nil {
+			t.Fatalf("Test %d: failed to decrypt key: %v", i, err)
+		}
+		if !bytes.Equal(key.Plaintext, plaintext) {
+			t.Fatalf("Test %d: decrypted key does not match generated one: got %x - want %x", i, plaintext, key.Plaintext)
+		}
+	}
+	if err = KMS.RotateKey("unknown-key"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Rotating a non-existing key should fail with %v: got %v", ErrKeyNotFound, err)
+	}
+}
+
+func TestKeyStoreConcurrentRotateKey(t *testing.T) {
+	dir := t.TempDir()
+	const instances, rotations = 4, 5
+
+	// Every instance acts as a separate process sharing the key store.
+	stores := make([]*KeyStore, instances)
+	for i := range stores {
+		KMS, err := NewKeyStore(dir, keyStoreMasterKey, "my-key")
+		if err != nil {
+			t.Fatalf("Failed to initialize key store: %v", err)
+		}
+		stores[i] = KMS
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, instances*rotations)
+	for _, KMS := range stores {
+		wg.Add(1)
+		go func(KMS *KeyStore) {
+			defer wg.Done()
+			for i := 0; i < rotations; i++ {
+				if err := KMS.RotateKey("my-key"); err != nil {
+					errs <- err
+				}
+			}
+		}(KMS)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Failed to rotate key: %v", err)
+	}
+
+	status, err := stores[0].KeyStatus("my-key")
+	if err != nil {
+		t.Fatalf("Failed to get key status: %v", err)
+	}
+	if want := 1 + instances*rotations; status.Version() != want || len(status.Versions) != want {
+		t.Fatalf("Rotations have been lost: got versions %v - want %d versions", status.Versions, want)
+	}
+	if _, err = os.Stat(filepath.Join(dir, ".lock-my-key")); !os.IsNotExist(err) {
+		t.Fatalf("Key lock has not been released: %v", err)
+	}
+}
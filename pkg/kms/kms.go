@@ -17,6 +17,21 @@ package kms
 import (
 	"encoding"
 	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrKeyExists is returned by CreateKey when a key
+	// with the same key ID exists already.
+	ErrKeyExists = errors.New("kms: key already exists")
+
+	// ErrKeyNotFound is returned when the referenced
+	// key does not exist.
+	ErrKeyNotFound = errors.New("kms: key does not exist")
+
+	// ErrNotSupported is returned when the KMS does not
+	// support an operation - e.g. rotating keys.
+	ErrNotSupported = errors.New("kms: operation not supported")
 )
 
 // KMS is the generic interface that abstracts over
@@ -46,6 +61,24 @@ type KMS interface {
 	// by the key ID. The context must match the context value
 	// used to generate the ciphertext.
 	DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error)
+
+	// ListKeys returns the IDs of all keys at the KMS.
+	ListKeys() ([]string, error)
+
+	// KeyStatus returns the status of the key referenced
+	// by the key ID - in particular its versions.
+	//
+	// The KMS may use a default key if the key ID is empty.
+	KeyStatus(keyID string) (KeyStatus, error)
+
+	// RotateKey adds a new version to the key referenced
+	// by the key ID. Subsequent DEKs are generated with
+	// the new version while DEKs generated with previous
+	// versions can still be decrypted.
+	//
+	// RotateKey returns ErrNotSupported if the KMS does
+	// not version keys.
+	RotateKey(keyID string) error
 }
 
 // Status describes the current state of a KMS.
@@ -59,6 +92,25 @@ type Status struct {
 	DefaultKey string
 }
 
+// KeyStatus describes a key at the KMS.
+type KeyStatus struct {
+	KeyID string
+
+	// Versions are the versions of the key in
+	// ascending order. It is empty if the KMS
+	// does not version keys.
+	Versions []int
+}
+
+// Version returns the latest version of the key
+// or 0 if the KMS does not version keys.
+func (s KeyStatus) Version() int {
+	if len(s.Versions) == 0 {
+		return 0
+	}
+	return s.Versions[len(s.Versions)-1]
+}
+
 // DEK is a data encryption key. It consists of a
 // plaintext-ciphertext pair and the ID of the key
 // used to generate the ciphertext.
@@ -68,8 +120,13 @@ type Status struct {
 // ciphertext is the encrypted version of the
 // plaintext data and can be stored on untrusted
 // storage.
+//
+// The version is the version of the key used to
+// generate the ciphertext. It is 0 if the KMS does
+// not version keys.
 type DEK struct {
 	KeyID      string
+	Version    int
 	Plaintext  []byte
 	Ciphertext []byte
 }
@@ -88,6 +88,22 @@ func (secretKey) CreateKey(string) error {
 	return errors.New("kms: creating keys is not supported")
 }
 
+func (kms secretKey) ListKeys() ([]string, error) {
+	return []string{kms.keyID}, nil
+}
+
+func (kms secretKey) KeyStatus(keyID string) (KeyStatus, error) {
+	if keyID == "" {
+		keyID = kms.keyID
+	}
+	if keyID != kms.keyID {
+		return KeyStatus{}, fmt.Errorf("kms: key %q does not exist", keyID)
+	}
+	return KeyStatus{KeyID: keyID}, nil
+}
+
+func (secretKey) RotateKey(string) error { return ErrNotSupported }
+
 func (kms secretKey) GenerateKey(keyID string, context Context) (DEK, error) {
 	if keyID == "" {
 		keyID = kms.keyID
@@ -95,10 +111,41 @@ func (kms secretKey) GenerateKey(keyID string, context Context) (DEK, error) {
 	if keyID != kms.keyID {
 		return DEK{}, fmt.Errorf("kms: key %q does not exist", keyID)
 	}
-	iv, err := sioutil.Random(16)
+	plaintext, err := sioutil.Random(32)
+	if err != nil {
+		return DEK{}, err
+	}
+	associatedData, _ := context.MarshalText()
+	ciphertext, err := kms.seal(plaintext, associatedData)
 	if err != nil {
 		return DEK{}, err
 	}
+	return DEK{
+		KeyID:      keyID,
+		Plaintext:  plaintext,
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+func (kms secretKey) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	if keyID != kms.keyID {
+		return nil, fmt.Errorf("kms: key %q does not exist", keyID)
+	}
+
+	associatedData, _ := context.MarshalText()
+	return kms.open(ciphertext, associatedData)
+}
+
+// seal encrypts the plaintext with a key derived from
+// the secret key and a random IV and authenticates the
+// associated data. The returned ciphertext contains
+// everything, except the secret key, required to
+// decrypt it again.
+func (kms secretKey) seal(plaintext, associatedData []byte) ([]byte, error) {
+	iv, err := sioutil.Random(16)
+	if err != nil {
+		return nil, err
+	}
 
 	var algorithm string
 	if sioutil.NativeAES() {
@@ -117,59 +164,41 @@ func (kms secretKey) GenerateKey(keyID string, context Context) (DEK, error) {
 		var block cipher.Block
 		block, err = aes.NewCipher(sealingKey)
 		if err != nil {
-			return DEK{}, err
+			return nil, err
 		}
 		aead, err = cipher.NewGCM(block)
 		if err != nil {
-			return DEK{}, err
+			return nil, err
 		}
 	case algorithmChaCha20Poly1305:
 		var sealingKey []byte
 		sealingKey, err = chacha20.HChaCha20(kms.key, iv)
 		if err != nil {
-			return DEK{}, err
+			return nil, err
 		}
 		aead, err = chacha20poly1305.New(sealingKey)
 		if err != nil {
-			return DEK{}, err
+			return nil, err
 		}
 	default:
-		return DEK{}, errors.New("invalid algorithm: " + algorithm)
+		return nil, errors.New("invalid algorithm: " + algorithm)
 	}
 
 	nonce, err := sioutil.Random(aead.NonceSize())
 	if err != nil {
-		return DEK{}, err
-	}
-
-	plaintext, err := sioutil.Random(32)
-	if err != nil {
-		return DEK{}, err
+		return nil, err
 	}
-	associatedData, _ := context.MarshalText()
-	ciphertext := aead.Seal(nil, nonce, plaintext, associatedData)
-
-	ciphertext, err = json.Marshal(encryptedKey{
+	return json.Marshal(encryptedKey{
 		Algorithm: algorithm,
 		IV:        iv,
 		Nonce:     nonce,
-		Bytes:     ciphertext,
+		Bytes:     aead.Seal(nil, nonce, plaintext, associatedData),
 	})
-	if err != nil {
-		return DEK{}, err
-	}
-	return DEK{
-		KeyID:      keyID,
-		Plaintext:  plaintext,
-		Ciphertext: ciphertext,
-	}, nil
 }
 
-func (kms secretKey) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
-	if keyID != kms.keyID {
-		return nil, fmt.Errorf("kms: key %q does not exist", keyID)
-	}
-
+// open decrypts a ciphertext produced by seal and verifies
+// that it has been sealed together with the associated data.
+func (kms secretKey) open(ciphertext, associatedData []byte) ([]byte, error) {
 	var encryptedKey encryptedKey
 	if err := json.Unmarshal(ciphertext, &encryptedKey); err != nil {
 		return nil, err
@@ -210,7 +239,6 @@ func (kms secretKey) DecryptKey(keyID string, ciphertext []byte, context Context
 		return nil, fmt.Errorf("kms: invalid nonce size %d", n)
 	}
 
-	associatedData, _ := context.MarshalText()
 	plaintext, err := aead.Open(nil, encryptedKey.Nonce, encryptedKey.Bytes, associatedData)
 	if err != nil {
 		return nil, fmt.Errorf("kms: encrypted key is not authentic")
@@ -0,0 +1,217 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BatchJobType is the type of a batch job.
+type BatchJobType string
+
+// Supported batch job types.
+const (
+	BatchJobReplicate BatchJobType = "replicate"
+	BatchJobExpire    BatchJobType = "expire"
+	BatchJobKeyRotate BatchJobType = "keyrotate"
+)
+
+// SupportedJobTypes lists the batch job types known to the server.
+var SupportedJobTypes = []BatchJobType{
+	BatchJobReplicate,
+	BatchJobExpire,
+	BatchJobKeyRotate,
+}
+
+// BatchJobResult is returned when a batch job is started.
+type BatchJobResult struct {
+	ID      string       `json:"id"`
+	Type    BatchJobType `json:"type"`
+	User    string       `json:"user,omitempty"`
+	Started time.Time    `json:"started"`
+}
+
+// BatchJobStatus is the progress of a batch job. Object is the last
+// object processed, a restarted job resumes after it.
+type BatchJobStatus struct {
+	ID         string       `json:"id"`
+	Type       BatchJobType `json:"type"`
+	User       string       `json:"user,omitempty"`
+	Node       string       `json:"node,omitempty"`
+	Started    time.Time    `json:"started"`
+	LastUpdate time.Time    `json:"lastUpdate"`
+	Complete   bool         `json:"complete"`
+	Failed     bool         `json:"failed"`
+	Canceled   bool         `json:"canceled"`
+
+	Bucket           string `json:"bucket"`
+	Object           string `json:"object"`
+	Objects          int64  `json:"objects"`
+	ObjectsFailed    int64  `json:"objectsFailed"`
+	BytesTransferred int64  `json:"bytesTransferred"`
+	BytesFailed      int64  `json:"bytesFailed"`
+	RetryAttempts    int64  `json:"retryAttempts"`
+}
+
+// ListBatchJobsFilter selects the batch jobs returned by ListBatchJobs.
+type ListBatchJobsFilter struct {
+	ByJobType string
+}
+
+// ListBatchJobsResult is the list of batch jobs.
+type ListBatchJobsResult struct {
+	Jobs []BatchJobStatus `json:"jobs"`
+}
+
+// StartBatchJob submits a batch job described in YAML, or JSON, and
+// starts it.
+func (adm *AdminClient) StartBatchJob(ctx context.Context, job string) (BatchJobResult, error) {
+	ejob, err := EncryptData(adm.getSecretKey(), []byte(job))
+	if err != nil {
+		return BatchJobResult{}, err
+	}
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/start-job
+		relPath: adminAPIPrefix + "/start-job",
+		content: ejob,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return BatchJobResult{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return BatchJobResult{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return BatchJobResult{}, err
+	}
+	var res BatchJobResult
+	if err = json.Unmarshal(b, &res); err != nil {
+		return BatchJobResult{}, err
+	}
+	return res, nil
+}
+
+// ListBatchJobs lists the batch jobs, running and finished.
+func (adm *AdminClient) ListBatchJobs(ctx context.Context, fl *ListBatchJobsFilter) (ListBatchJobsResult, error) {
+	values := url.Values{}
+	if fl != nil && fl.ByJobType != "" {
+		values.Set("jobType", fl.ByJobType)
+	}
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/list-jobs?jobType=replicate
+		relPath:     adminAPIPrefix + "/list-jobs",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return ListBatchJobsResult{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return ListBatchJobsResult{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ListBatchJobsResult{}, err
+	}
+	var res ListBatchJobsResult
+	if err = json.Unmarshal(b, &res); err != nil {
+		return ListBatchJobsResult{}, err
+	}
+	return res, nil
+}
+
+// DescribeBatchJob returns the YAML description of a batch job, the
+// credentials it holds are redacted.
+func (adm *AdminClient) DescribeBatchJob(ctx context.Context, jobID string) (string, error) {
+	values := url.Values{}
+	values.Set("jobId", jobID)
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/describe-job?jobId=id
+		relPath:     adminAPIPrefix + "/describe-job",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// BatchJobStatus returns the progress of a batch job.
+func (adm *AdminClient) BatchJobStatus(ctx context.Context, jobID string) (BatchJobStatus, error) {
+	values := url.Values{}
+	values.Set("jobId", jobID)
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/status-job?jobId=id
+		relPath:     adminAPIPrefix + "/status-job",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return BatchJobStatus{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return BatchJobStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return BatchJobStatus{}, err
+	}
+	var status BatchJobStatus
+	if err = json.Unmarshal(b, &status); err != nil {
+		return BatchJobStatus{}, err
+	}
+	return status, nil
+}
+
+// CancelBatchJob stops a running batch job, a canceled job is not
+// resumed.
+func (adm *AdminClient) CancelBatchJob(ctx context.Context, jobID string) error {
+	values := url.Values{}
+	values.Set("id", jobID)
+	resp, err := adm.executeMethod(ctx, http.MethodDelete, requestData{
+		// DELETE <endpoint>/<admin-API>/cancel-job?id=id
+		relPath:     adminAPIPrefix + "/cancel-job",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
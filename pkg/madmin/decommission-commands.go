@@ -0,0 +1,149 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PoolDecommissionInfo is the progress of the decommissioning of a pool.
+type PoolDecommissionInfo struct {
+	StartTime time.Time `json:"startTime"`
+	// StartSize is the free space of the pool when the decommission
+	// started, TotalSize its capacity and CurrentSize its free space now.
+	StartSize   int64 `json:"startSize"`
+	TotalSize   int64 `json:"totalSize"`
+	CurrentSize int64 `json:"currentSize"`
+	Complete    bool  `json:"complete"`
+	Failed      bool  `json:"failed"`
+	Canceled    bool  `json:"canceled"`
+
+	ObjectsDecommissioned     int64 `json:"objectsDecommissioned"`
+	ObjectsDecommissionFailed int64 `json:"objectsDecommissionedFailed"`
+	BytesDone                 int64 `json:"bytesDecommissioned"`
+	BytesFailed               int64 `json:"bytesDecommissionedFailed"`
+}
+
+// PoolStatus is the status of a server pool, a pool is identified by
+// its command line argument.
+type PoolStatus struct {
+	ID           int                   `json:"id"`
+	CmdLine      string                `json:"cmdline"`
+	LastUpdate   time.Time             `json:"lastUpdate"`
+	Decommission *PoolDecommissionInfo `json:"decommissionInfo,omitempty"`
+}
+
+// DecommissionPool starts moving all the objects of the pool identified
+// by its command line argument to the other pools, or resumes a canceled
+// or failed decommission of the pool.
+func (adm *AdminClient) DecommissionPool(ctx context.Context, pool string) error {
+	values := url.Values{}
+	values.Set("pool", pool)
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/pools/decommission?pool=http://server{1...4}/disk{1...4}
+		relPath:     adminAPIPrefix + "/pools/decommission",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// CancelDecommissionPool stops the ongoing decommission of a pool, the
+// pool accepts new objects again.
+func (adm *AdminClient) CancelDecommissionPool(ctx context.Context, pool string) error {
+	values := url.Values{}
+	values.Set("pool", pool)
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/pools/cancel?pool=http://server{1...4}/disk{1...4}
+		relPath:     adminAPIPrefix + "/pools/cancel",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// StatusPool returns the status of a pool.
+func (adm *AdminClient) StatusPool(ctx context.Context, pool string) (PoolStatus, error) {
+	values := url.Values{}
+	values.Set("pool", pool)
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/pools/status?pool=http://server{1...4}/disk{1...4}
+		relPath:     adminAPIPrefix + "/pools/status",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return PoolStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return PoolStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return PoolStatus{}, err
+	}
+	var info PoolStatus
+	if err = json.Unmarshal(b, &info); err != nil {
+		return PoolStatus{}, err
+	}
+	return info, nil
+}
+
+// ListPoolsStatus returns the status of all the pools.
+func (adm *AdminClient) ListPoolsStatus(ctx context.Context) ([]PoolStatus, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/pools/list
+		relPath: adminAPIPrefix + "/pools/list",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var pools []PoolStatus
+	if err = json.Unmarshal(b, &pools); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
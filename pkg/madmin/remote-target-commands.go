@@ -306,3 +306,105 @@ func (adm *AdminClient) RemoveRemoteTarget(ctx context.Context, bucket, arn stri
 	}
 	return nil
 }
+
+// ResyncStatusType is the state of the resync of a bucket target.
+type ResyncStatusType string
+
+// Resync states.
+const (
+	ResyncStarted   ResyncStatusType = "Ongoing"
+	ResyncCompleted ResyncStatusType = "Completed"
+	ResyncFailed    ResyncStatusType = "Failed"
+)
+
+// TargetResyncStatus is the progress of the resync of a bucket target,
+// which replicates again all the object versions eligible to existing
+// object replication. Object is the last object replicated, an
+// interrupted resync resumes after it.
+type TargetResyncStatus struct {
+	Arn        string           `json:"arn"`
+	ResyncID   string           `json:"resyncID"`
+	Node       string           `json:"node,omitempty"`
+	StartTime  time.Time        `json:"startTime"`
+	LastUpdate time.Time        `json:"lastUpdate"`
+	Status     ResyncStatusType `json:"status"`
+
+	Object          string `json:"object"`
+	ReplicatedCount int64  `json:"replicatedCount"`
+	ReplicatedSize  int64  `json:"replicatedSize"`
+	FailedCount     int64  `json:"failedCount"`
+	FailedSize      int64  `json:"failedSize"`
+}
+
+// BucketResyncStatus is the progress of the resyncs of the targets of a
+// bucket.
+type BucketResyncStatus struct {
+	Bucket  string               `json:"bucket"`
+	Targets []TargetResyncStatus `json:"targets"`
+}
+
+// ResyncBucketTarget starts replicating again the existing objects of the
+// bucket to the target with the ARN, for instance after the target was
+// rebuilt. A resync of the target in progress is not restarted.
+func (adm *AdminClient) ResyncBucketTarget(ctx context.Context, bucket, arn string) (TargetResyncStatus, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("arn", arn)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/resync-remote-target",
+		queryValues: queryValues,
+	}
+
+	// Execute PUT on /minio/admin/v3/resync-remote-target to start the resync of a target
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return TargetResyncStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return TargetResyncStatus{}, httpRespToErrorResponse(resp)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return TargetResyncStatus{}, err
+	}
+	var status TargetResyncStatus
+	if err = json.Unmarshal(b, &status); err != nil {
+		return TargetResyncStatus{}, err
+	}
+	return status, nil
+}
+
+// BucketTargetsResyncStatus returns the progress of the resyncs of the
+// targets of the bucket, running and finished.
+func (adm *AdminClient) BucketTargetsResyncStatus(ctx context.Context, bucket string) (BucketResyncStatus, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/resync-remote-target-status",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/resync-remote-target-status
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return BucketResyncStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return BucketResyncStatus{}, httpRespToErrorResponse(resp)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return BucketResyncStatus{}, err
+	}
+	var status BucketResyncStatus
+	if err = json.Unmarshal(b, &status); err != nil {
+		return BucketResyncStatus{}, err
+	}
+	return status, nil
+}
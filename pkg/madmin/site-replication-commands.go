@@ -0,0 +1,475 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PeerSite - represents a cluster/site to be added to the set of replicated
+// sites.
+type PeerSite struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+// Meaningful values for ReplicateAddStatus.Status
+const (
+	ReplicateAddStatusSuccess = "Requested sites were configured for replication successfully."
+	ReplicateAddStatusPartial = "Some sites could not be configured for replication."
+)
+
+// ReplicateAddStatus - returns status of add request.
+type ReplicateAddStatus struct {
+	Success                 bool   `json:"success"`
+	Status                  string `json:"status"`
+	ErrDetail               string `json:"errorDetail,omitempty"`
+	InitialSyncErrorMessage string `json:"initialSyncErrorMessage,omitempty"`
+}
+
+// SiteReplicationAdd - sends the SR add API call.
+func (adm *AdminClient) SiteReplicationAdd(ctx context.Context, sites []PeerSite) (ReplicateAddStatus, error) {
+	sitesBytes, err := json.Marshal(sites)
+	if err != nil {
+		return ReplicateAddStatus{}, err
+	}
+	encBytes, err := EncryptData(adm.getSecretKey(), sitesBytes)
+	if err != nil {
+		return ReplicateAddStatus{}, err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/add",
+		content: encBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/site-replication/add
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return ReplicateAddStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ReplicateAddStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ReplicateAddStatus{}, err
+	}
+
+	var res ReplicateAddStatus
+	if err = json.Unmarshal(b, &res); err != nil {
+		return ReplicateAddStatus{}, err
+	}
+	return res, nil
+}
+
+// PeerInfo - contains some properties of a cluster peer.
+type PeerInfo struct {
+	Endpoint     string `json:"endpoint"`
+	Name         string `json:"name"`
+	DeploymentID string `json:"deploymentID"`
+}
+
+// SiteReplicationInfo - contains cluster replication information.
+type SiteReplicationInfo struct {
+	Enabled                 bool       `json:"enabled"`
+	Name                    string     `json:"name,omitempty"`
+	Sites                   []PeerInfo `json:"sites,omitempty"`
+	ServiceAccountAccessKey string     `json:"serviceAccountAccessKey,omitempty"`
+}
+
+// SiteReplicationInfo - returns cluster replication information.
+func (adm *AdminClient) SiteReplicationInfo(ctx context.Context) (info SiteReplicationInfo, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/info",
+	}
+
+	// Execute GET on /minio/admin/v3/site-replication/info
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(b, &info)
+	return info, err
+}
+
+// SRPeerJoinReq - arg body for SRPeerJoin
+type SRPeerJoinReq struct {
+	SvcAcctAccessKey string              `json:"svcAcctAccessKey"`
+	SvcAcctSecretKey string              `json:"svcAcctSecretKey"`
+	Peers            map[string]PeerInfo `json:"peers"`
+}
+
+// SRPeerJoin - used only by minio server to send SR join requests to peer
+// servers.
+func (adm *AdminClient) SRPeerJoin(ctx context.Context, r SRPeerJoinReq) error {
+	joinReqBytes, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	encBuf, err := EncryptData(adm.getSecretKey(), joinReqBytes)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/peer/join",
+		content: encBuf,
+	}
+
+	// Execute PUT on /minio/admin/v3/site-replication/peer/join
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// BktOp represents the bucket operation being requested.
+type BktOp string
+
+// BktOp value constants.
+const (
+	// make bucket and enable versioning
+	MakeWithVersioningBktOp BktOp = "make-with-versioning"
+	// add replication configuration
+	ConfigureReplBktOp BktOp = "configure-replication"
+	// delete bucket
+	DeleteBucketBktOp BktOp = "delete-bucket"
+	// delete bucket even if it is not empty
+	ForceDeleteBucketBktOp BktOp = "force-delete-bucket"
+)
+
+// SRPeerBucketOps - tells peers to create bucket and setup replication.
+func (adm *AdminClient) SRPeerBucketOps(ctx context.Context, bucket string, op BktOp, opts map[string]string) error {
+	v := url.Values{}
+	v.Add("bucket", bucket)
+	v.Add("operation", string(op))
+
+	// For make-bucket, bucket options may be sent via `opts`
+	if op == MakeWithVersioningBktOp {
+		for k, val := range opts {
+			v.Add(k, val)
+		}
+	}
+
+	reqData := requestData{
+		queryValues: v,
+		relPath:     adminAPIPrefix + "/site-replication/peer/bucket-ops",
+	}
+
+	// Execute PUT on /minio/admin/v3/site-replication/peer/bucket-ops
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// SRIAMItem.Type constants.
+const (
+	SRIAMItemPolicy        = "policy"
+	SRIAMItemUser          = "user"
+	SRIAMItemGroupInfo     = "group-info"
+	SRIAMItemPolicyMapping = "policy-mapping"
+	SRIAMItemSvcAcc        = "service-account"
+)
+
+// SRUserInfo - represents a regular (IAM) user to be replicated. When
+// IsDeleteReq is set the user is removed from the peer.
+type SRUserInfo struct {
+	AccessKey   string   `json:"accessKey"`
+	IsDeleteReq bool     `json:"isDeleteReq"`
+	UserInfo    UserInfo `json:"userInfo"`
+}
+
+// SRGroupInfo - represents a group membership or status change to be
+// replicated. An empty Status denotes a membership update.
+type SRGroupInfo struct {
+	UpdateReq GroupAddRemove `json:"updateReq"`
+	Status    string         `json:"status,omitempty"`
+}
+
+// SRPolicyMapping - represents mapping of a policy to a user or group.
+type SRPolicyMapping struct {
+	UserOrGroup string `json:"userOrGroup"`
+	IsGroup     bool   `json:"isGroup"`
+	Policy      string `json:"policy"`
+}
+
+// SRSvcAccCreate - create operation
+type SRSvcAccCreate struct {
+	Parent        string          `json:"parent"`
+	AccessKey     string          `json:"accessKey"`
+	SecretKey     string          `json:"secretKey"`
+	Groups        []string        `json:"groups"`
+	SessionPolicy json.RawMessage `json:"sessionPolicy,omitempty"`
+	Status        string          `json:"status"`
+}
+
+// SRSvcAccUpdate - update operation
+type SRSvcAccUpdate struct {
+	AccessKey     string          `json:"accessKey"`
+	SecretKey     string          `json:"secretKey,omitempty"`
+	Status        string          `json:"status,omitempty"`
+	SessionPolicy json.RawMessage `json:"sessionPolicy,omitempty"`
+}
+
+// SRSvcAccDelete - delete operation
+type SRSvcAccDelete struct {
+	AccessKey string `json:"accessKey"`
+}
+
+// SRSvcAccChange - sum-type to represent an svc account change.
+type SRSvcAccChange struct {
+	Create *SRSvcAccCreate `json:"crSvcAccCreate,omitempty"`
+	Update *SRSvcAccUpdate `json:"crSvcAccUpdate,omitempty"`
+	Delete *SRSvcAccDelete `json:"crSvcAccDelete,omitempty"`
+}
+
+// SRIAMItem - represents an IAM object that will be copied to a peer.
+type SRIAMItem struct {
+	Type string `json:"type"`
+
+	// Name and Policy below are used when Type == SRIAMItemPolicy. A nil
+	// policy denotes removal of the canned policy.
+	Name   string          `json:"name,omitempty"`
+	Policy json.RawMessage `json:"policy,omitempty"`
+
+	// Used when Type == SRIAMItemUser
+	UserInfo *SRUserInfo `json:"userInfo,omitempty"`
+
+	// Used when Type == SRIAMItemGroupInfo
+	GroupInfo *SRGroupInfo `json:"groupInfo,omitempty"`
+
+	// Used when Type == SRIAMItemPolicyMapping
+	PolicyMapping *SRPolicyMapping `json:"policyMapping,omitempty"`
+
+	// Used when Type == SRIAMItemSvcAcc
+	SvcAccChange *SRSvcAccChange `json:"serviceAccountChange,omitempty"`
+}
+
+// SRPeerReplicateIAMItem - copies an IAM object to a peer cluster.
+func (adm *AdminClient) SRPeerReplicateIAMItem(ctx context.Context, item SRIAMItem) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	// IAM items may carry secret keys, so they are always sent encrypted.
+	encBuf, err := EncryptData(adm.getSecretKey(), b)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/peer/iam-item",
+		content: encBuf,
+	}
+
+	// Execute PUT on /minio/admin/v3/site-replication/peer/iam-item
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// SRBucketMeta.Type constants
+const (
+	SRBucketMetaTypePolicy           = "policy"
+	SRBucketMetaTypeTags             = "tags"
+	SRBucketMetaTypeLifecycle        = "lifecycle-config"
+	SRBucketMetaTypeSSEConfig        = "sse-config"
+	SRBucketMetaTypeObjectLockConfig = "object-lock-config"
+	SRBucketMetaTypeQuotaConfig      = "quota-config"
+	SRBucketMetaTypeCorsConfig       = "cors-config"
+	SRBucketMetaTypeWebsiteConfig    = "website-config"
+	SRBucketMetaTypeLoggingConfig    = "logging-config"
+)
+
+// SRBucketMeta - represents a bucket metadata change that will be copied to
+// a peer. Config holds the raw configuration document (XML or JSON as
+// stored by the server); a nil Config denotes removal of the configuration.
+type SRBucketMeta struct {
+	Type   string `json:"type"`
+	Bucket string `json:"bucket"`
+	Config []byte `json:"config,omitempty"`
+}
+
+// SRPeerReplicateBucketMeta - copies a bucket metadata change to a peer
+// cluster.
+func (adm *AdminClient) SRPeerReplicateBucketMeta(ctx context.Context, item SRBucketMeta) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/peer/bucket-meta",
+		content: b,
+	}
+
+	// Execute PUT on /minio/admin/v3/site-replication/peer/bucket-meta
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// SRBucketInfo - bucket state on a site as reported to the site replication
+// status API. Configs maps each SRBucketMetaType* present on the bucket to
+// a checksum of its content.
+type SRBucketInfo struct {
+	Bucket             string            `json:"bucket"`
+	CreatedAt          time.Time         `json:"createdAt"`
+	ReplicationEnabled bool              `json:"replicationEnabled"`
+	Configs            map[string]string `json:"configs,omitempty"`
+}
+
+// SRInfo - contains the replicated IAM and bucket state of a single site,
+// used to compute site replication status.
+type SRInfo struct {
+	Enabled      bool                    `json:"enabled"`
+	Name         string                  `json:"name,omitempty"`
+	DeploymentID string                  `json:"deploymentID,omitempty"`
+	Buckets      map[string]SRBucketInfo `json:"buckets,omitempty"`
+	// Policies maps canned policy names to a checksum of the policy
+	Policies map[string]string    `json:"policies,omitempty"`
+	Users    map[string]UserInfo  `json:"users,omitempty"`
+	Groups   map[string]GroupDesc `json:"groups,omitempty"`
+}
+
+// SRPeerGetMetaInfo - returns the replicated state of a peer site. Used
+// only by minio server to compute site replication status.
+func (adm *AdminClient) SRPeerGetMetaInfo(ctx context.Context) (info SRInfo, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/peer/metainfo",
+	}
+
+	// Execute GET on /minio/admin/v3/site-replication/peer/metainfo
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(b, &info)
+	return info, err
+}
+
+// SRSiteSummary - per-site summary of replicated entities.
+type SRSiteSummary struct {
+	TotalBucketsCount      int `json:"totalBuckets"`
+	ReplicatedBucketsCount int `json:"replicatedBuckets"`
+	TotalPoliciesCount     int `json:"totalPolicies"`
+	TotalUsersCount        int `json:"totalUsers"`
+	TotalGroupsCount       int `json:"totalGroups"`
+}
+
+// SRStatusInfo - returns the replication status of IAM and bucket metadata
+// across all sites. The *OutOfSync maps list, for every entity that is
+// missing or differs on at least one site, the deployment IDs of the sites
+// where it is missing or where it differs from the majority of the sites.
+type SRStatusInfo struct {
+	Enabled           bool                     `json:"enabled"`
+	Sites             map[string]PeerInfo      `json:"sites,omitempty"`
+	StatsSummary      map[string]SRSiteSummary `json:"statsSummary,omitempty"`
+	BucketsOutOfSync  map[string][]string      `json:"bucketsOutOfSync,omitempty"`
+	PoliciesOutOfSync map[string][]string      `json:"policiesOutOfSync,omitempty"`
+	UsersOutOfSync    map[string][]string      `json:"usersOutOfSync,omitempty"`
+	GroupsOutOfSync   map[string][]string      `json:"groupsOutOfSync,omitempty"`
+	// Errors reports sites whose state could not be fetched.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// SiteReplicationStatus - returns the replication status of IAM and bucket
+// metadata across all sites.
+func (adm *AdminClient) SiteReplicationStatus(ctx context.Context) (info SRStatusInfo, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/status",
+	}
+
+	// Execute GET on /minio/admin/v3/site-replication/status
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(b, &info)
+	return info, err
+}
@@ -0,0 +1,228 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+)
+
+// TierConfigV1 is the current version of the remote tier configuration.
+const TierConfigV1 = "v1"
+
+// TierType represents the type of a remote tier backend.
+type TierType string
+
+const (
+	// S3Tier is an S3 compatible remote tier.
+	S3Tier TierType = "s3"
+	// AzureTier is an Azure blob storage compatible remote tier.
+	AzureTier TierType = "azure"
+	// GCSTier is a Google Cloud Storage remote tier.
+	GCSTier TierType = "gcs"
+	// FSTier is a remote tier backed by a local or network mounted directory.
+	FSTier TierType = "fs"
+)
+
+// IsValid returns true if the tier type is supported.
+func (t TierType) IsValid() bool {
+	switch t {
+	case S3Tier, AzureTier, GCSTier, FSTier:
+		return true
+	}
+	return false
+}
+
+// TierS3 represents the remote tier configuration for an S3 compatible
+// endpoint.
+type TierS3 struct {
+	Endpoint     string `json:"endpoint"`
+	AccessKey    string `json:"accesskey"`
+	SecretKey    string `json:"secretkey"`
+	Bucket       string `json:"bucket"`
+	Prefix       string `json:"prefix,omitempty"`
+	Region       string `json:"region,omitempty"`
+	StorageClass string `json:"storageclass,omitempty"`
+}
+
+// TierAzure represents the remote tier configuration for an Azure blob
+// storage compatible endpoint, Bucket being the container name.
+type TierAzure struct {
+	Endpoint    string `json:"endpoint"`
+	AccountName string `json:"accountname"`
+	AccountKey  string `json:"accountkey"`
+	Bucket      string `json:"bucket"`
+	Prefix      string `json:"prefix,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+// TierGCS represents the remote tier configuration for Google Cloud
+// Storage, Creds being the standard base64 encoding of the service account
+// JSON.
+type TierGCS struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	Creds    string `json:"creds"`
+	Bucket   string `json:"bucket"`
+	Prefix   string `json:"prefix,omitempty"`
+	Region   string `json:"region,omitempty"`
+}
+
+// TierFS represents the remote tier configuration for a directory, which
+// must be reachable at the same path from every server in the deployment.
+type TierFS struct {
+	Path   string `json:"path"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// TierConfig represents a remote tier that objects can be transitioned to
+// by setting its name as the storage class of a lifecycle transition.
+type TierConfig struct {
+	Version string     `json:"version"`
+	Type    TierType   `json:"type"`
+	Name    string     `json:"name"`
+	S3      *TierS3    `json:"s3,omitempty"`
+	Azure   *TierAzure `json:"azure,omitempty"`
+	GCS     *TierGCS   `json:"gcs,omitempty"`
+	FS      *TierFS    `json:"fs,omitempty"`
+}
+
+// TierCreds is used to update the credentials of a remote tier.
+type TierCreds struct {
+	AccessKey string `json:"access,omitempty"`
+	SecretKey string `json:"secret,omitempty"`
+	CredsJSON []byte `json:"creds,omitempty"`
+}
+
+// Errors returned while validating a remote tier configuration.
+var (
+	ErrTierNameEmpty     = errors.New("remote tier name is empty")
+	ErrTierInvalidName   = errors.New("remote tier name must consist of uppercase letters, digits, '-' and '_'")
+	ErrTierReservedName  = errors.New("remote tier name is a reserved storage class")
+	ErrTierTypeInvalid   = errors.New("unsupported remote tier type")
+	ErrTierInvalidConfig = errors.New("remote tier configuration is incomplete")
+)
+
+var validTierName = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]*$`)
+
+// Validate checks that the tier configuration is complete for its type.
+func (cfg *TierConfig) Validate() error {
+	switch {
+	case cfg.Name == "":
+		return ErrTierNameEmpty
+	case !validTierName.MatchString(cfg.Name):
+		return ErrTierInvalidName
+	case cfg.Name == "STANDARD" || cfg.Name == "REDUCED_REDUNDANCY":
+		return ErrTierReservedName
+	}
+
+	switch cfg.Type {
+	case S3Tier:
+		if cfg.S3 == nil || cfg.S3.Endpoint == "" || cfg.S3.AccessKey == "" || cfg.S3.SecretKey == "" || cfg.S3.Bucket == "" {
+			return ErrTierInvalidConfig
+		}
+	case AzureTier:
+		if cfg.Azure == nil || cfg.Azure.AccountName == "" || cfg.Azure.AccountKey == "" || cfg.Azure.Bucket == "" {
+			return ErrTierInvalidConfig
+		}
+	case GCSTier:
+		if cfg.GCS == nil || cfg.GCS.Creds == "" || cfg.GCS.Bucket == "" {
+			return ErrTierInvalidConfig
+		}
+	case FSTier:
+		if cfg.FS == nil || !filepath.IsAbs(cfg.FS.Path) {
+			return ErrTierInvalidConfig
+		}
+	default:
+		return ErrTierTypeInvalid
+	}
+	return nil
+}
+
+// Endpoint returns the remote endpoint of the tier.
+func (cfg *TierConfig) Endpoint() string {
+	switch cfg.Type {
+	case S3Tier:
+		return cfg.S3.Endpoint
+	case AzureTier:
+		return cfg.Azure.Endpoint
+	case GCSTier:
+		return cfg.GCS.Endpoint
+	case FSTier:
+		return cfg.FS.Path
+	}
+	return ""
+}
+
+// Bucket returns the remote bucket, container or directory of the tier.
+func (cfg *TierConfig) Bucket() string {
+	switch cfg.Type {
+	case S3Tier:
+		return cfg.S3.Bucket
+	case AzureTier:
+		return cfg.Azure.Bucket
+	case GCSTier:
+		return cfg.GCS.Bucket
+	case FSTier:
+		return cfg.FS.Path
+	}
+	return ""
+}
+
+// Prefix returns the prefix under which transitioned objects are stored.
+func (cfg *TierConfig) Prefix() string {
+	switch cfg.Type {
+	case S3Tier:
+		return cfg.S3.Prefix
+	case AzureTier:
+		return cfg.Azure.Prefix
+	case GCSTier:
+		return cfg.GCS.Prefix
+	case FSTier:
+		return cfg.FS.Prefix
+	}
+	return ""
+}
+
+// Clone returns a copy of the tier configuration with its secrets redacted.
+func (cfg *TierConfig) Clone() TierConfig {
+	c := TierConfig{
+		Version: cfg.Version,
+		Type:    cfg.Type,
+		Name:    cfg.Name,
+	}
+	const redacted = "REDACTED"
+	switch cfg.Type {
+	case S3Tier:
+		s3 := *cfg.S3
+		s3.SecretKey = redacted
+		c.S3 = &s3
+	case AzureTier:
+		az := *cfg.Azure
+		az.AccountKey = redacted
+		c.Azure = &az
+	case GCSTier:
+		gcs := *cfg.GCS
+		gcs.Creds = redacted
+		c.GCS = &gcs
+	case FSTier:
+		fs := *cfg.FS
+		c.FS = &fs
+	}
+	return c
+}
@@ -0,0 +1,61 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import "testing"
+
+func TestTierConfigValidate(t *testing.T) {
+	testCases := []struct {
+		cfg         TierConfig
+		expectedErr error
+	}{
+		{TierConfig{Type: FSTier, FS: &TierFS{Path: "/mnt/warm"}}, ErrTierNameEmpty},
+		{TierConfig{Name: "warm", Type: FSTier, FS: &TierFS{Path: "/mnt/warm"}}, ErrTierInvalidName},
+		{TierConfig{Name: "STANDARD", Type: FSTier, FS: &TierFS{Path: "/mnt/warm"}}, ErrTierReservedName},
+		{TierConfig{Name: "WARM", Type: "tape"}, ErrTierTypeInvalid},
+		{TierConfig{Name: "WARM", Type: FSTier, FS: &TierFS{Path: "mnt/warm"}}, ErrTierInvalidConfig},
+		{TierConfig{Name: "WARM", Type: FSTier, FS: &TierFS{Path: "/mnt/warm"}}, nil},
+		{TierConfig{Name: "WARM", Type: S3Tier, S3: &TierS3{Endpoint: "https://s3.amazonaws.com", Bucket: "b"}}, ErrTierInvalidConfig},
+		{TierConfig{Name: "WARM-1", Type: S3Tier, S3: &TierS3{Endpoint: "https://s3.amazonaws.com", AccessKey: "a", SecretKey: "s", Bucket: "b"}}, nil},
+		{TierConfig{Name: "WARM", Type: AzureTier, Azure: &TierAzure{AccountName: "a", AccountKey: "k"}}, ErrTierInvalidConfig},
+		{TierConfig{Name: "WARM", Type: AzureTier, Azure: &TierAzure{AccountName: "a", AccountKey: "k", Bucket: "c"}}, nil},
+		{TierConfig{Name: "WARM", Type: GCSTier}, ErrTierInvalidConfig},
+		{TierConfig{Name: "WARM", Type: GCSTier, GCS: &TierGCS{Creds: "e30=", Bucket: "b"}}, nil},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.cfg.Validate(); err != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestTierConfigClone(t *testing.T) {
+	cfg := TierConfig{
+		Name: "WARM",
+		Type: S3Tier,
+		S3:   &TierS3{Endpoint: "https://s3.amazonaws.com", AccessKey: "access", SecretKey: "secret", Bucket: "b"},
+	}
+	c := cfg.Clone()
+	if c.S3.SecretKey == "secret" || c.S3.AccessKey != "access" {
+		t.Fatalf("Unexpected cloned credentials %+v", c.S3)
+	}
+	if cfg.S3.SecretKey != "secret" {
+		t.Fatal("Clone modified the original configuration")
+	}
+}
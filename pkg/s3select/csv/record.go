@@ -69,9 +69,8 @@ func (r *Record) Reset() {
 	if len(r.csvRecord) > 0 {
 		r.csvRecord = r.csvRecord[:0]
 	}
-	for k := range r.nameIndexMap {
-		delete(r.nameIndexMap, k)
-	}
+	// The name index map is shared with the reader.
+	r.nameIndexMap = nil
 }
 
 // Clone the record.
@@ -88,6 +87,7 @@ func (r *Record) Clone(dst sql.Record) sql.Record {
 	}
 	other.columnNames = append(other.columnNames, r.columnNames...)
 	other.csvRecord = append(other.csvRecord, r.csvRecord...)
+	other.nameIndexMap = r.nameIndexMap
 	return other
 }
 
@@ -65,7 +65,7 @@ func errMalformedXML(err error) *s3Error {
 func errInvalidCompressionFormat(err error) *s3Error {
 	return &s3Error{
 		code:       "InvalidCompressionFormat",
-		message:    "The file is not in a supported compression format. Only GZIP and BZIP2 are supported.",
+		message:    "The file is not in a supported compression format. Only GZIP, BZIP2, ZSTD and LZ4 are supported.",
 		statusCode: 400,
 		cause:      err,
 	}
@@ -80,6 +80,24 @@ func errInvalidBZIP2CompressionFormat(err error) *s3Error {
 	}
 }
 
+func errInvalidZSTDCompressionFormat(err error) *s3Error {
+	return &s3Error{
+		code:       "InvalidCompressionFormat",
+		message:    "ZSTD is not applicable to the queried object. Please correct the request and try again.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errInvalidLZ4CompressionFormat(err error) *s3Error {
+	return &s3Error{
+		code:       "InvalidCompressionFormat",
+		message:    "LZ4 is not applicable to the queried object. Please correct the request and try again.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
 func errInvalidGZIPCompressionFormat(err error) *s3Error {
 	return &s3Error{
 		code:       "InvalidCompressionFormat",
@@ -142,3 +160,30 @@ func errTruncatedInput(err error) *s3Error {
 		cause:      err,
 	}
 }
+
+func errOverMaxRecordSize(err error) *s3Error {
+	return &s3Error{
+		code:       "OverMaxRecordSize",
+		message:    "The length of a record in the input or result is greater than maxCharsPerRecord of 1 MB.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errInvalidScanRange(err error) *s3Error {
+	return &s3Error{
+		code:       "InvalidRequestParameter",
+		message:    "The value of a parameter in ScanRange element is invalid. Check the service API documentation and try again.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errUnsupportedScanRangeInput(err error) *s3Error {
+	return &s3Error{
+		code:       "UnsupportedScanRangeInput",
+		message:    "Scan range queries are not supported on this type of object.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
@@ -410,8 +410,11 @@ func (column *Column) Merge(column2 *Column) {
 		column.maxBitWidth = column2.maxBitWidth
 	}
 
-	column.updateMinMaxValue(column2.minValue)
-	column.updateMinMaxValue(column2.maxValue)
+	// A column holding only null values has no min/max value.
+	if column2.minValue != nil {
+		column.updateMinMaxValue(column2.minValue)
+		column.updateMinMaxValue(column2.maxValue)
+	}
 }
 
 func (column *Column) String() string {
@@ -474,17 +477,24 @@ func (column *Column) toDataPageV2(element *schema.Element, parquetEncoding parq
 		panic(err)
 	}
 
-	DLData := encoding.RLEBitPackedHybridEncode(
-		column.definitionLevels,
-		common.BitWidth(uint64(element.MaxDefinitionLevel)),
-		parquet.Type_INT64,
-	)
+	// Levels of V2 data pages are not prefixed by their length,
+	// and are omitted when their maximum is zero.
+	var DLData, RLData []byte
+	if element.MaxDefinitionLevel > 0 {
+		DLData = encoding.RLEBitPackedHybridEncode(
+			column.definitionLevels,
+			common.BitWidth(uint64(element.MaxDefinitionLevel)),
+			parquet.Type_INT64,
+		)[4:]
+	}
 
-	RLData := encoding.RLEBitPackedHybridEncode(
-		column.repetitionLevels,
-		common.BitWidth(uint64(element.MaxRepetitionLevel)),
-		parquet.Type_INT64,
-	)
+	if element.MaxRepetitionLevel > 0 {
+		RLData = encoding.RLEBitPackedHybridEncode(
+			column.repetitionLevels,
+			common.BitWidth(uint64(element.MaxRepetitionLevel)),
+			parquet.Type_INT64,
+		)[4:]
+	}
 
 	pageHeader := parquet.NewPageHeader()
 	pageHeader.Type = parquet.PageType_DATA_PAGE_V2
@@ -183,6 +183,8 @@ func toParquetSchema(tree *Tree, treePrefix string, schemaPrefix string, schemaL
 			case parquet.ConvertedType_UINT_32, parquet.ConvertedType_UINT_64, parquet.ConvertedType_INT_8:
 				fallthrough
 			case parquet.ConvertedType_INT_16, parquet.ConvertedType_INT_32, parquet.ConvertedType_INT_64:
+				fallthrough
+			case parquet.ConvertedType_DATE, parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIMESTAMP_MICROS:
 				if element.Type == nil {
 					err = fmt.Errorf("%v: ConvertedType %v must have Type value", pathInTree, element.ConvertedType)
 					return false
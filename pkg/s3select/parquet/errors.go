@@ -51,3 +51,12 @@ func errParquetParsingError(err error) *s3Error {
 		cause:      err,
 	}
 }
+
+func errParquetOutputSchemaMismatch(err error) *s3Error {
+	return &s3Error{
+		code:       "ParquetOutputSchemaMismatch",
+		message:    "The result does not match the Parquet schema inferred from its first records: " + err.Error(),
+		statusCode: 400,
+		cause:      err,
+	}
+}
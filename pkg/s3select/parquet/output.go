@@ -0,0 +1,297 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bcicen/jstream"
+	jsonfmt "github.com/minio/minio/pkg/s3select/json"
+	"github.com/minio/minio/pkg/s3select/sql"
+	"github.com/minio/simdjson-go"
+)
+
+// RecordWriter - writes the output records of S3 Select as a single
+// Parquet file. The columns and their types are inferred from the
+// records of the first row group, which are kept until then.
+type RecordWriter struct {
+	names   []string
+	index   map[string]int
+	pending [][]interface{}
+
+	writer *Writer
+	output bytes.Buffer
+}
+
+// Write - writes a record, its encoded output is available once a
+// row group is complete.
+func (w *RecordWriter) Write(record sql.Record) error {
+	names, values, err := recordValues(record)
+	if err != nil {
+		return err
+	}
+
+	if w.writer == nil {
+		row := make([]interface{}, len(w.names))
+		for i, name := range names {
+			j, ok := w.index[name]
+			if !ok {
+				j = len(w.names)
+				w.index[name] = j
+				w.names = append(w.names, name)
+				row = append(row, nil)
+			}
+			row[j] = values[i]
+		}
+		w.pending = append(w.pending, row)
+		if len(w.pending) < writerRowGroupCount {
+			return nil
+		}
+		return w.start()
+	}
+
+	row := make([]interface{}, len(w.names))
+	for i, name := range names {
+		j, ok := w.index[name]
+		if !ok {
+			return errParquetOutputSchemaMismatch(fmt.Errorf("unexpected column %s", name))
+		}
+		if row[j], err = convertValue(values[i], w.writer.columns[j]); err != nil {
+			return err
+		}
+	}
+	return w.writer.Write(row...)
+}
+
+// start - infers the columns from the pending records, creates the
+// Parquet writer and writes the pending records.
+func (w *RecordWriter) start() error {
+	columns := make([]Column, len(w.names))
+	for i, name := range w.names {
+		// Dots separate the path of nested columns in the schema.
+		columns[i].Name = strings.Replace(name, ".", "_", -1)
+		columns[i].Type = inferColumnType(w.pending, i)
+	}
+
+	var err error
+	if w.writer, err = NewWriter(nopWriteCloser{&w.output}, columns); err != nil {
+		return err
+	}
+
+	pending := w.pending
+	w.pending = nil
+	for _, row := range pending {
+		for len(row) < len(columns) {
+			row = append(row, nil)
+		}
+		for i, v := range row {
+			if row[i], err = convertValue(v, columns[i]); err != nil {
+				return err
+			}
+		}
+		if err = w.writer.Write(row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush - moves the encoded output written so far to buf.
+func (w *RecordWriter) Flush(buf *bytes.Buffer) {
+	buf.Write(w.output.Bytes())
+	w.output.Reset()
+}
+
+// Close - writes the pending records and the footer, the remaining
+// output must then be flushed. Nothing is written if no record was.
+func (w *RecordWriter) Close() error {
+	if w.writer == nil {
+		if len(w.pending) == 0 {
+			return nil
+		}
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	return w.writer.Close()
+}
+
+// NewRecordWriter - creates new Parquet writer of output records.
+func NewRecordWriter() *RecordWriter {
+	return &RecordWriter{
+		index: make(map[string]int),
+	}
+}
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// inferColumnType - returns the type of the values of a column,
+// integers are widened to floats and columns with values of different
+// types or without any value are strings.
+func inferColumnType(rows [][]interface{}, i int) ColumnType {
+	var (
+		columnType ColumnType
+		found      bool
+	)
+	for _, row := range rows {
+		if i >= len(row) || row[i] == nil {
+			continue
+		}
+		t := valueType(row[i])
+		switch {
+		case !found:
+			columnType, found = t, true
+		case t == columnType:
+		case t == ColumnInt64 && columnType == ColumnFloat64,
+			t == ColumnFloat64 && columnType == ColumnInt64:
+			columnType = ColumnFloat64
+		default:
+			return ColumnString
+		}
+	}
+	if !found {
+		return ColumnString
+	}
+	return columnType
+}
+
+func valueType(v interface{}) ColumnType {
+	switch v.(type) {
+	case int64:
+		return ColumnInt64
+	case bool:
+		return ColumnBool
+	case float64:
+		return ColumnFloat64
+	case time.Time:
+		return ColumnTimestamp
+	}
+	return ColumnString
+}
+
+// convertValue - converts a value to the type of its column.
+func convertValue(v interface{}, column Column) (interface{}, error) {
+	if v == nil || valueType(v) == column.Type {
+		return v, nil
+	}
+
+	switch column.Type {
+	case ColumnString:
+		switch x := v.(type) {
+		case int64:
+			return strconv.FormatInt(x, 10), nil
+		case bool:
+			return strconv.FormatBool(x), nil
+		case float64:
+			return strconv.FormatFloat(x, 'g', -1, 64), nil
+		case time.Time:
+			return sql.FormatSQLTimestamp(x), nil
+		}
+	case ColumnFloat64:
+		if x, ok := v.(int64); ok {
+			return float64(x), nil
+		}
+	}
+	return nil, errParquetOutputSchemaMismatch(fmt.Errorf("column %s of type %s has a %s value", column.Name, column.Type, valueType(v)))
+}
+
+// recordValues - returns the column names and the values of a record,
+// records of SELECT * queries have the type of the input records.
+func recordValues(record sql.Record) (names []string, values []interface{}, err error) {
+	_, raw := record.Raw()
+	switch raw := raw.(type) {
+	case *Record:
+		return raw.names, raw.values, nil
+	case jstream.KVS:
+		for _, kv := range raw {
+			names = append(names, kv.Key)
+			values = append(values, kv.Value)
+		}
+	case simdjson.Object:
+		elems, err := raw.Parse(nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, elem := range elems.Elements {
+			v, err := sql.IterToValue(elem.Iter)
+			if err != nil {
+				return nil, nil, err
+			}
+			names = append(names, elem.Name)
+			values = append(values, v)
+		}
+	default:
+		// CSV records are converted through their JSON encoding,
+		// all their values are strings.
+		var buf bytes.Buffer
+		if err = record.WriteJSON(&buf); err != nil {
+			return nil, nil, err
+		}
+		decoder := jstream.NewDecoder(&buf, 0).ObjectAsKVS()
+		for mv := range decoder.Stream() {
+			kvs, ok := mv.Value.(jstream.KVS)
+			if !ok {
+				return nil, nil, fmt.Errorf("unexpected JSON value %T of record", mv.Value)
+			}
+			for _, kv := range kvs {
+				names = append(names, kv.Key)
+				values = append(values, kv.Value)
+			}
+		}
+		if err = decoder.Err(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for i, v := range values {
+		if values[i], err = jsonValue(v); err != nil {
+			return nil, nil, err
+		}
+	}
+	return names, values, nil
+}
+
+// jsonValue - converts a value of a JSON record to a column value,
+// objects and arrays are written as their JSON representation.
+func jsonValue(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case nil, bool, int64, float64, string:
+		return v, nil
+	case jsonfmt.RawJSON:
+		return string(x), nil
+	case simdjson.Object:
+		m, err := x.Map(nil)
+		if err != nil {
+			return nil, err
+		}
+		v = m
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,160 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bcicen/jstream"
+	jsonfmt "github.com/minio/minio/pkg/s3select/json"
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+func newOutputRecord(t *testing.T, kvs jstream.KVS) sql.Record {
+	record := NewRecord()
+	for _, kv := range kvs {
+		var v *sql.Value
+		switch x := kv.Value.(type) {
+		case nil:
+			v = sql.FromNull()
+		case int64:
+			v = sql.FromInt(x)
+		case float64:
+			v = sql.FromFloat(x)
+		case string:
+			v = sql.FromString(x)
+		case bool:
+			v = sql.FromBool(x)
+		case time.Time:
+			v = sql.FromTimestamp(x)
+		default:
+			t.Fatalf("unexpected value %T", x)
+		}
+		if _, err := record.Set(kv.Key, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return record
+}
+
+func readOutput(t *testing.T, data []byte) []jstream.KVS {
+	r, err := NewReader(func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset = int64(len(data)) + offset
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}, &ReaderArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var records []jstream.KVS
+	for {
+		rec, err := r.Read(nil)
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rec.(*jsonfmt.Record).KVS)
+	}
+}
+
+func TestRecordWriterInference(t *testing.T) {
+	created := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []jstream.KVS{
+		{{Key: "a", Value: int64(1)}, {Key: "b", Value: int64(1)}, {Key: "c", Value: nil}, {Key: "t", Value: created}},
+		{{Key: "a", Value: 1.5}, {Key: "b", Value: "x"}, {Key: "c", Value: nil}, {Key: "d", Value: true}},
+		// Records of SELECT * queries have the type of the input records.
+		{{Key: "b", Value: false}, {Key: "a", Value: "2.5"}},
+	}
+	// The string of the last record makes column a a string column.
+	want := []jstream.KVS{
+		{{Key: "a", Value: "1"}, {Key: "b", Value: "1"}, {Key: "c", Value: nil}, {Key: "t", Value: "2021-01-02T03:04:05Z"}, {Key: "d", Value: nil}},
+		{{Key: "a", Value: "1.5"}, {Key: "b", Value: "x"}, {Key: "c", Value: nil}, {Key: "t", Value: nil}, {Key: "d", Value: true}},
+		{{Key: "a", Value: "2.5"}, {Key: "b", Value: "false"}, {Key: "c", Value: nil}, {Key: "t", Value: nil}, {Key: "d", Value: nil}},
+	}
+
+	w := NewRecordWriter()
+	for i, kvs := range records {
+		var record sql.Record
+		if i == len(records)-1 {
+			record = &jsonfmt.Record{KVS: kvs, SelectFormat: sql.SelectFmtJSON}
+		} else {
+			record = newOutputRecord(t, kvs)
+		}
+		if err := w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w.Flush(&buf)
+
+	if got := readOutput(t, buf.Bytes()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestRecordWriterSchemaMismatch(t *testing.T) {
+	w := NewRecordWriter()
+	var buf bytes.Buffer
+	for i := 0; i < writerRowGroupCount; i++ {
+		if err := w.Write(newOutputRecord(t, jstream.KVS{{Key: "id", Value: int64(i)}, {Key: "name", Value: "x"}})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Flush(&buf)
+	if buf.Len() == 0 {
+		t.Fatal("expected the first row group to be written")
+	}
+
+	// Integers are written as strings to a string column.
+	if err := w.Write(newOutputRecord(t, jstream.KVS{{Key: "id", Value: nil}, {Key: "name", Value: int64(1)}})); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(newOutputRecord(t, jstream.KVS{{Key: "id", Value: "1"}})); err == nil {
+		t.Fatal("expected an error writing a string to an int64 column")
+	} else if serr, ok := err.(*s3Error); !ok || serr.ErrorCode() != "ParquetOutputSchemaMismatch" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := w.Write(newOutputRecord(t, jstream.KVS{{Key: "other", Value: int64(1)}})); err == nil {
+		t.Fatal("expected an error writing an unknown column")
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush(&buf)
+	got := readOutput(t, buf.Bytes())
+	if len(got) != writerRowGroupCount+1 {
+		t.Fatalf("expected %d records, got %d", writerRowGroupCount+1, len(got))
+	}
+	want := jstream.KVS{{Key: "id", Value: nil}, {Key: "name", Value: "1"}}
+	if !reflect.DeepEqual(got[writerRowGroupCount], want) {
+		t.Fatalf("expected %v, got %v", want, got[writerRowGroupCount])
+	}
+}
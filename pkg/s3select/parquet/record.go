@@ -0,0 +1,109 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"errors"
+	"io"
+
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// Record - is an output record of Parquet output serialization, it
+// keeps the values of the columns typed.
+type Record struct {
+	names  []string
+	values []interface{}
+}
+
+// Get - gets the value for a column name.
+func (r *Record) Get(name string) (*sql.Value, error) {
+	return nil, errors.New("Get is not supported for Parquet output records")
+}
+
+// Set - sets the value for a column name.
+func (r *Record) Set(name string, value *sql.Value) (sql.Record, error) {
+	var v interface{}
+	if b, ok := value.ToBool(); ok {
+		v = b
+	} else if i, ok := value.ToInt(); ok {
+		v = i
+	} else if f, ok := value.ToFloat(); ok {
+		v = f
+	} else if t, ok := value.ToTimestamp(); ok {
+		v = t
+	} else if s, ok := value.ToString(); ok {
+		v = s
+	} else if value.IsNull() {
+		v = nil
+	} else {
+		// Byte values are not typed and arrays are written as
+		// their JSON representation.
+		v = value.CSVString()
+	}
+
+	r.names = append(r.names, name)
+	r.values = append(r.values, v)
+	return r, nil
+}
+
+// Reset data in record.
+func (r *Record) Reset() {
+	if len(r.names) > 0 {
+		r.names = r.names[:0]
+	}
+	if len(r.values) > 0 {
+		r.values = r.values[:0]
+	}
+}
+
+// Clone the record and if possible use the destination provided.
+func (r *Record) Clone(dst sql.Record) sql.Record {
+	other, ok := dst.(*Record)
+	if !ok {
+		other = &Record{}
+	}
+	other.Reset()
+	other.names = append(other.names, r.names...)
+	other.values = append(other.values, r.values...)
+	return other
+}
+
+// WriteCSV - is not supported for Parquet output records.
+func (r *Record) WriteCSV(writer io.Writer, opts sql.WriteCSVOpts) error {
+	return errors.New("WriteCSV is not supported for Parquet output records")
+}
+
+// WriteJSON - is not supported for Parquet output records.
+func (r *Record) WriteJSON(writer io.Writer) error {
+	return errors.New("WriteJSON is not supported for Parquet output records")
+}
+
+// Raw - returns the underlying data with format info.
+func (r *Record) Raw() (sql.SelectObjectFormat, interface{}) {
+	return sql.SelectFmtParquet, r
+}
+
+// Replace - is not supported for Parquet output records.
+func (r *Record) Replace(_ interface{}) error {
+	return errors.New("Replace is not supported for Parquet output records")
+}
+
+// NewRecord - creates new Parquet output record.
+func NewRecord() *Record {
+	return &Record{}
+}
@@ -0,0 +1,182 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	parquetgo "github.com/minio/minio/pkg/s3select/internal/parquet-go"
+	"github.com/minio/minio/pkg/s3select/internal/parquet-go/data"
+	parquetgen "github.com/minio/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+	"github.com/minio/minio/pkg/s3select/internal/parquet-go/schema"
+)
+
+// Number of records of a row group written by Writer.
+const writerRowGroupCount = 10000
+
+// ColumnType - type of the values of a column.
+type ColumnType int
+
+// Supported column types.
+const (
+	ColumnString ColumnType = iota
+	ColumnInt64
+	ColumnBool
+	ColumnFloat64
+	ColumnTimestamp
+)
+
+// String - returns the name of the column type.
+func (t ColumnType) String() string {
+	switch t {
+	case ColumnString:
+		return "string"
+	case ColumnInt64:
+		return "int64"
+	case ColumnBool:
+		return "bool"
+	case ColumnFloat64:
+		return "float64"
+	case ColumnTimestamp:
+		return "timestamp"
+	}
+	return fmt.Sprintf("ColumnType(%d)", int(t))
+}
+
+// Column - describes a column of the records written by Writer,
+// all columns are optional.
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// Writer - Parquet writer of flat records.
+type Writer struct {
+	columns []Column
+	writer  *parquetgo.Writer
+}
+
+// Write - writes a record, values are given in the order of the columns
+// and are either nil or of the type of their column.
+func (w *Writer) Write(values ...interface{}) error {
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("parquet: expected %d values, got %d", len(w.columns), len(values))
+	}
+
+	record := make(map[string]*data.Column, len(w.columns))
+	for i, column := range w.columns {
+		var c *data.Column
+		switch column.Type {
+		case ColumnString:
+			c = data.NewColumn(parquetgen.Type_BYTE_ARRAY)
+		case ColumnInt64:
+			c = data.NewColumn(parquetgen.Type_INT64)
+		case ColumnBool:
+			c = data.NewColumn(parquetgen.Type_BOOLEAN)
+		case ColumnFloat64:
+			c = data.NewColumn(parquetgen.Type_DOUBLE)
+		case ColumnTimestamp:
+			c = data.NewColumn(parquetgen.Type_INT64)
+		}
+
+		switch v := values[i].(type) {
+		case nil:
+			c.AddNull(0, 0)
+		case string:
+			if column.Type != ColumnString {
+				return fmt.Errorf("parquet: unexpected string value of column %s", column.Name)
+			}
+			c.AddByteArray([]byte(v), 1, 0)
+		case int64:
+			if column.Type != ColumnInt64 {
+				return fmt.Errorf("parquet: unexpected int64 value of column %s", column.Name)
+			}
+			c.AddInt64(v, 1, 0)
+		case bool:
+			if column.Type != ColumnBool {
+				return fmt.Errorf("parquet: unexpected bool value of column %s", column.Name)
+			}
+			c.AddBoolean(v, 1, 0)
+		case float64:
+			if column.Type != ColumnFloat64 {
+				return fmt.Errorf("parquet: unexpected float64 value of column %s", column.Name)
+			}
+			c.AddDouble(v, 1, 0)
+		case time.Time:
+			if column.Type != ColumnTimestamp {
+				return fmt.Errorf("parquet: unexpected timestamp value of column %s", column.Name)
+			}
+			c.AddInt64(v.UnixNano()/int64(time.Millisecond), 1, 0)
+		default:
+			return fmt.Errorf("parquet: unsupported value %T of column %s", v, column.Name)
+		}
+		record[column.Name] = c
+	}
+	return w.writer.Write(record)
+}
+
+// Close - writes the pending records and the footer and closes the
+// underlying writer.
+func (w *Writer) Close() error {
+	return w.writer.Close()
+}
+
+// NewWriter - creates new Parquet writer of records with given columns.
+func NewWriter(wc io.WriteCloser, columns []Column) (*Writer, error) {
+	tree := schema.NewTree()
+	for _, column := range columns {
+		var (
+			parquetType   parquetgen.Type
+			convertedType *parquetgen.ConvertedType
+		)
+		switch column.Type {
+		case ColumnString:
+			parquetType = parquetgen.Type_BYTE_ARRAY
+			convertedType = parquetgen.ConvertedTypePtr(parquetgen.ConvertedType_UTF8)
+		case ColumnInt64:
+			parquetType = parquetgen.Type_INT64
+		case ColumnBool:
+			parquetType = parquetgen.Type_BOOLEAN
+		case ColumnFloat64:
+			parquetType = parquetgen.Type_DOUBLE
+		case ColumnTimestamp:
+			parquetType = parquetgen.Type_INT64
+			convertedType = parquetgen.ConvertedTypePtr(parquetgen.ConvertedType_TIMESTAMP_MILLIS)
+		default:
+			return nil, fmt.Errorf("parquet: unsupported type of column %s", column.Name)
+		}
+		// Values are plain encoded, the other encodings of
+		// the writer do not support null values.
+		element, err := schema.NewElement(column.Name, parquetgen.FieldRepetitionType_OPTIONAL,
+			parquetgen.TypePtr(parquetType), convertedType,
+			parquetgen.EncodingPtr(parquetgen.Encoding_PLAIN), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err = tree.Set(column.Name, element); err != nil {
+			return nil, err
+		}
+	}
+
+	writer, err := parquetgo.NewWriter(wc, tree, writerRowGroupCount)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{columns: columns, writer: writer}, nil
+}
@@ -0,0 +1,97 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	jsonfmt "github.com/minio/minio/pkg/s3select/json"
+)
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestWriterRoundTrip(t *testing.T) {
+	columns := []Column{
+		{Name: "Key", Type: ColumnString},
+		{Name: "Size", Type: ColumnInt64},
+		{Name: "IsLatest", Type: ColumnBool},
+	}
+	records := [][]interface{}{
+		{"photos/1.jpg", int64(1024), true},
+		{"photos/2.jpg", nil, false},
+		{"photos/3.jpg", int64(0), nil},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(nopCloser{&buf}, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if err = w.Write(record...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Write("photos/4.jpg"); err == nil {
+		t.Fatal("expected an error writing a record with missing values")
+	}
+	if err = w.Write("photos/4.jpg", "1024", true); err == nil {
+		t.Fatal("expected an error writing a value of the wrong type")
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	r, err := NewReader(func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset = int64(len(data)) + offset
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}, &ReaderArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i, record := range records {
+		rec, err := r.Read(nil)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		kvs := rec.(*jsonfmt.Record).KVS
+		if len(kvs) != len(columns) {
+			t.Fatalf("Test %d: expected %d values, got %v", i+1, len(columns), kvs)
+		}
+		for j, kv := range kvs {
+			if kv.Key != columns[j].Name || !reflect.DeepEqual(kv.Value, record[j]) {
+				t.Fatalf("Test %d: expected %s=%v, got %s=%v", i+1, columns[j].Name, record[j], kv.Key, kv.Value)
+			}
+		}
+	}
+	if _, err = r.Read(nil); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
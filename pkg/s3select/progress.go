@@ -24,7 +24,9 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/klauspost/compress/zstd"
 	gzip "github.com/klauspost/pgzip"
+	"github.com/pierrec/lz4"
 )
 
 type countUpReader struct {
@@ -58,6 +60,7 @@ type progressReader struct {
 
 	closedMu sync.Mutex
 	gzr      *gzip.Reader
+	zstdr    *zstd.Decoder
 	closed   bool
 }
 
@@ -82,6 +85,9 @@ func (pr *progressReader) Close() error {
 	if pr.gzr != nil {
 		pr.gzr.Close()
 	}
+	if pr.zstdr != nil {
+		pr.zstdr.Close()
+	}
 	return pr.rc.Close()
 }
 
@@ -118,6 +124,16 @@ func newProgressReader(rc io.ReadCloser, compType CompressionType) (*progressRea
 		r = pr.gzr
 	case bzip2Type:
 		r = bzip2.NewReader(scannedReader)
+	case zstdType:
+		// The records are parsed sequentially, a single decoding
+		// goroutine is enough.
+		pr.zstdr, err = zstd.NewReader(scannedReader, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, errInvalidZSTDCompressionFormat(err)
+		}
+		r = pr.zstdr
+	case lz4Type:
+		r = lz4.NewReader(scannedReader)
 	default:
 		return nil, errInvalidCompressionFormat(fmt.Errorf("unknown compression type '%v'", compType))
 	}
@@ -0,0 +1,239 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// ScanRange - represents elements inside <ScanRange/> in request XML.
+// Only the records starting between Start and End, both inclusive, are
+// queried; with only End set the records starting in the last End bytes
+// of the object are queried.
+type ScanRange struct {
+	Start *int64 `xml:"Start"`
+	End   *int64 `xml:"End"`
+}
+
+func (s *ScanRange) validate() error {
+	switch {
+	case s.Start == nil && s.End == nil:
+		return errInvalidScanRange(errors.New("either Start or End must be provided"))
+	case s.Start != nil && *s.Start < 0:
+		return errInvalidScanRange(fmt.Errorf("invalid Start '%v'", *s.Start))
+	case s.End != nil && *s.End < 0:
+		return errInvalidScanRange(fmt.Errorf("invalid End '%v'", *s.End))
+	case s.Start != nil && s.End != nil && *s.Start > *s.End:
+		return errInvalidScanRange(fmt.Errorf("Start '%v' is after End '%v'", *s.Start, *s.End))
+	}
+	return nil
+}
+
+// bounds - returns the offsets of the first and the last byte of the
+// scan range in an object of the given size, start is after end if the
+// range is empty.
+func (s *ScanRange) bounds(size int64) (start, end int64) {
+	start, end = 0, size-1
+	switch {
+	case s.Start == nil:
+		start = size - *s.End
+		if start < 0 {
+			start = 0
+		}
+	case s.End == nil:
+		start = *s.Start
+	default:
+		start = *s.Start
+		if *s.End < end {
+			end = *s.End
+		}
+	}
+	return start, end
+}
+
+// openScanRange - returns a reader of the records of the object starting
+// in the scan range, or of the whole object when there is no scan range.
+// Records are separated by delimiter, the partial record at the start of
+// the range is skipped and the last record of the range is read past its
+// end. At most maxRecordSize bytes past the range are read.
+func (s3Select *S3Select) openScanRange(getReader func(offset, length int64) (io.ReadCloser, error), delimiter string) (io.ReadCloser, error) {
+	if s3Select.ScanRange == nil {
+		return getReader(0, -1)
+	}
+	if s3Select.compressionType() != noneType {
+		return nil, errUnsupportedScanRangeInput(errors.New("ScanRange is not supported on compressed objects"))
+	}
+	if s3Select.objectSize < 0 {
+		return nil, errors.New("ScanRange requires the size of the object")
+	}
+
+	start, end := s3Select.ScanRange.bounds(s3Select.objectSize)
+	if start > end {
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	// Read the delimiter preceding the start of the range, if any,
+	// to find out whether a record starts at the start.
+	offset := start - int64(len(delimiter))
+	if offset < 0 {
+		offset = 0
+	}
+	last := end + maxRecordSize
+	if last > s3Select.objectSize-1 {
+		last = s3Select.objectSize - 1
+	}
+	rc, err := getReader(offset, last-offset+1)
+	if err != nil {
+		return nil, err
+	}
+	return &scanRangeReader{
+		rc:        rc,
+		br:        bufio.NewReader(rc),
+		delimiter: []byte(delimiter),
+		skip:      start > 0,
+		pos:       offset,
+		end:       end,
+		truncated: last < s3Select.objectSize-1,
+	}, nil
+}
+
+// prependCSVHeader - returns a reader of the first record of the object,
+// the CSV header, followed by the records read by rc.
+func (s3Select *S3Select) prependCSVHeader(getReader func(offset, length int64) (io.ReadCloser, error), rc io.ReadCloser) (io.ReadCloser, error) {
+	length := s3Select.objectSize
+	if length > maxRecordSize {
+		length = maxRecordSize
+	}
+	hrc, err := getReader(0, length)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	header, err := ioutil.ReadAll(&scanRangeReader{
+		rc:        hrc,
+		br:        bufio.NewReader(hrc),
+		delimiter: []byte(s3Select.Input.CSVArgs.RecordDelimiter),
+		truncated: length < s3Select.objectSize,
+	})
+	hrc.Close()
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(header), rc), rc}, nil
+}
+
+// scanRangeReader - reads the records starting between the current
+// position, after skipping the partial record it is in, and end.
+type scanRangeReader struct {
+	rc        io.ReadCloser
+	br        *bufio.Reader
+	delimiter []byte
+	skip      bool
+	pos, end  int64
+	truncated bool
+
+	// tail holds the last bytes read, to match the delimiter.
+	tail []byte
+	err  error
+}
+
+func (r *scanRangeReader) readByte() (byte, error) {
+	c, err := r.br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	r.pos++
+	r.track([]byte{c})
+	return c, nil
+}
+
+func (r *scanRangeReader) track(p []byte) {
+	r.tail = append(r.tail, p...)
+	if n := len(r.tail) - len(r.delimiter); n > 0 {
+		r.tail = append(r.tail[:0], r.tail[n:]...)
+	}
+}
+
+func (r *scanRangeReader) atRecordStart() bool {
+	return bytes.Equal(r.tail, r.delimiter)
+}
+
+func (r *scanRangeReader) Read(p []byte) (n int, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, r.err = r.read(p)
+	if n > 0 && r.err == io.EOF {
+		return n, nil
+	}
+	return n, r.err
+}
+
+func (r *scanRangeReader) read(p []byte) (n int, err error) {
+	if r.skip {
+		// Skip the record started before the range.
+		r.skip = false
+		for !r.atRecordStart() {
+			if _, err = r.readByte(); err != nil {
+				return 0, err
+			}
+		}
+		if r.pos > r.end {
+			return 0, io.EOF
+		}
+	}
+
+	if r.pos <= r.end {
+		if max := r.end - r.pos + 1; int64(len(p)) > max {
+			p = p[:max]
+		}
+		n, err = r.br.Read(p)
+		r.pos += int64(n)
+		r.track(p[:n])
+		return n, err
+	}
+
+	// Read the rest of the last record.
+	for n < len(p) && !r.atRecordStart() {
+		var c byte
+		if c, err = r.readByte(); err != nil {
+			if err == io.EOF && r.truncated {
+				err = errOverMaxRecordSize(errors.New("the last record of the ScanRange is too long"))
+			}
+			return n, err
+		}
+		p[n] = c
+		n++
+	}
+	if r.atRecordStart() {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *scanRangeReader) Close() error {
+	return r.rc.Close()
+}
@@ -25,17 +25,21 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"os"
+	"path"
 	"strings"
 	"sync"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/minio/minio/pkg/s3select/csv"
 	"github.com/minio/minio/pkg/s3select/json"
 	"github.com/minio/minio/pkg/s3select/parquet"
 	"github.com/minio/minio/pkg/s3select/simdj"
 	"github.com/minio/minio/pkg/s3select/sql"
 	"github.com/minio/simdjson-go"
+	"github.com/pierrec/lz4"
 )
 
 type recordReader interface {
@@ -58,6 +62,28 @@ const (
 	noneType  CompressionType = "none"
 	gzipType  CompressionType = "gzip"
 	bzip2Type CompressionType = "bzip2"
+	zstdType  CompressionType = "zstd"
+	lz4Type   CompressionType = "lz4"
+)
+
+// Compression of the objects by content type and by extension, used
+// when CompressionType is omitted.
+var (
+	contentTypeCompression = map[string]CompressionType{
+		"application/gzip":    gzipType,
+		"application/x-gzip":  gzipType,
+		"application/x-bzip2": bzip2Type,
+		"application/zstd":    zstdType,
+		"application/x-zstd":  zstdType,
+		"application/x-lz4":   lz4Type,
+	}
+	extensionCompression = map[string]CompressionType{
+		".gz":   gzipType,
+		".bz2":  bzip2Type,
+		".zst":  zstdType,
+		".zstd": zstdType,
+		".lz4":  lz4Type,
+	}
 )
 
 const (
@@ -92,7 +118,7 @@ func (c *CompressionType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 	}
 
 	switch parsedType {
-	case noneType, gzipType, bzip2Type:
+	case noneType, gzipType, bzip2Type, zstdType, lz4Type:
 	default:
 		return errInvalidCompressionFormat(fmt.Errorf("invalid compression format '%v'", s))
 	}
@@ -125,11 +151,8 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 		return errMalformedXML(err)
 	}
 
-	// If no compression is specified, set to noneType
-	if parsedInput.CompressionType == CompressionType("") {
-		parsedInput.CompressionType = noneType
-	}
-
+	// If no compression is specified, it is detected from the
+	// object when opened.
 	found := 0
 	if !parsedInput.CSVArgs.IsEmpty() {
 		parsedInput.format = csvFormat
@@ -159,8 +182,9 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 
 // OutputSerialization - represents elements inside <OutputSerialization/> in request XML.
 type OutputSerialization struct {
-	CSVArgs     csv.WriterArgs  `xml:"CSV"`
-	JSONArgs    json.WriterArgs `xml:"JSON"`
+	CSVArgs     csv.WriterArgs     `xml:"CSV"`
+	JSONArgs    json.WriterArgs    `xml:"JSON"`
+	ParquetArgs parquet.WriterArgs `xml:"Parquet"`
 	unmarshaled bool
 	format      string
 }
@@ -188,8 +212,12 @@ func (output *OutputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartE
 		parsedOutput.format = jsonFormat
 		found++
 	}
+	if !parsedOutput.ParquetArgs.IsEmpty() {
+		parsedOutput.format = parquetFormat
+		found++
+	}
 	if found != 1 {
-		return errObjectSerializationConflict(fmt.Errorf("either CSV or JSON should be present in OutputSerialization"))
+		return errObjectSerializationConflict(fmt.Errorf("either CSV, JSON or Parquet should be present in OutputSerialization"))
 	}
 
 	*output = OutputSerialization(parsedOutput)
@@ -212,11 +240,16 @@ type S3Select struct {
 	Input          InputSerialization  `xml:"InputSerialization"`
 	Output         OutputSerialization `xml:"OutputSerialization"`
 	Progress       RequestProgress     `xml:"RequestProgress"`
+	ScanRange      *ScanRange          `xml:"ScanRange"`
 
 	statement      *sql.SelectStatement
 	progressReader *progressReader
 	recordReader   recordReader
+	parquetWriter  *parquet.RecordWriter
 	close          func() error
+	objectSize     int64
+	objectName     string
+	contentType    string
 }
 
 var (
@@ -257,6 +290,23 @@ func (s3Select *S3Select) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 		return errMissingRequiredParameter(fmt.Errorf("OutputSerialization must be provided"))
 	}
 
+	if parsedS3Select.ScanRange != nil {
+		if err := parsedS3Select.ScanRange.validate(); err != nil {
+			return err
+		}
+		input := parsedS3Select.Input
+		switch {
+		case input.CompressionType != "" && input.CompressionType != noneType:
+			return errUnsupportedScanRangeInput(errors.New("ScanRange is not supported on compressed objects"))
+		case input.format == csvFormat && input.CSVArgs.AllowQuotedRecordDelimiter:
+			return errUnsupportedScanRangeInput(errors.New("ScanRange is not supported with quoted record delimiters"))
+		case input.format == jsonFormat && !strings.EqualFold(input.JSONArgs.ContentType, "lines"):
+			return errUnsupportedScanRangeInput(errors.New("ScanRange is only supported on JSON LINES"))
+		case input.format == parquetFormat:
+			return errUnsupportedScanRangeInput(errors.New("ScanRange is not supported on Parquet objects"))
+		}
+	}
+
 	statement, err := sql.ParseSelectStatement(parsedS3Select.Expression)
 	if err != nil {
 		return err
@@ -274,6 +324,8 @@ func (s3Select *S3Select) outputRecord() sql.Record {
 		return csv.NewRecord()
 	case jsonFormat:
 		return json.NewRecord(sql.SelectFmtJSON)
+	case parquetFormat:
+		return parquet.NewRecord()
 	}
 
 	panic(fmt.Errorf("unknown output format '%v'", s3Select.Output.format))
@@ -287,17 +339,57 @@ func (s3Select *S3Select) getProgress() (bytesScanned, bytesProcessed int64) {
 	return -1, -1
 }
 
+// SetObjectSize - sets the size of the S3 object, the ScanRange is
+// resolved against it.
+func (s3Select *S3Select) SetObjectSize(size int64) {
+	s3Select.objectSize = size
+}
+
+// SetObjectType - sets the name and the content type of the S3 object,
+// its compression is detected from them when CompressionType is omitted.
+func (s3Select *S3Select) SetObjectType(name, contentType string) {
+	s3Select.objectName = name
+	s3Select.contentType = contentType
+}
+
+// compressionType - returns the compression of the object, given by
+// CompressionType or else detected from its content type or extension.
+func (s3Select *S3Select) compressionType() CompressionType {
+	if s3Select.Input.CompressionType != "" {
+		return s3Select.Input.CompressionType
+	}
+	if mediaType, _, err := mime.ParseMediaType(s3Select.contentType); err == nil {
+		if compType, ok := contentTypeCompression[mediaType]; ok {
+			return compType
+		}
+	}
+	if compType, ok := extensionCompression[strings.ToLower(path.Ext(s3Select.objectName))]; ok {
+		return compType
+	}
+	return noneType
+}
+
 // Open - opens S3 object by using callback for SQL selection query.
 // Currently CSV, JSON and Apache Parquet formats are supported.
 func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadCloser, error)) error {
 	switch s3Select.Input.format {
 	case csvFormat:
-		rc, err := getReader(0, -1)
+		rc, err := s3Select.openScanRange(getReader, s3Select.Input.CSVArgs.RecordDelimiter)
 		if err != nil {
 			return err
 		}
 
-		s3Select.progressReader, err = newProgressReader(rc, s3Select.Input.CompressionType)
+		// The header is not in the range, read it from the start of
+		// the object.
+		if s3Select.Input.CSVArgs.FileHeaderInfo != "none" && s3Select.ScanRange != nil {
+			if start, _ := s3Select.ScanRange.bounds(s3Select.objectSize); start > 0 && s3Select.objectSize > 0 {
+				if rc, err = s3Select.prependCSVHeader(getReader, rc); err != nil {
+					return err
+				}
+			}
+		}
+
+		s3Select.progressReader, err = newProgressReader(rc, s3Select.compressionType())
 		if err != nil {
 			rc.Close()
 			return err
@@ -305,22 +397,27 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 
 		s3Select.recordReader, err = csv.NewReader(s3Select.progressReader, &s3Select.Input.CSVArgs)
 		if err != nil {
-			rc.Close()
+			s3Select.progressReader.Close()
 			var stErr bzip2.StructuralError
-			if errors.As(err, &stErr) {
+			switch {
+			case errors.As(err, &stErr):
 				return errInvalidBZIP2CompressionFormat(err)
+			case errors.Is(err, zstd.ErrMagicMismatch):
+				return errInvalidZSTDCompressionFormat(err)
+			case errors.Is(err, lz4.ErrInvalid):
+				return errInvalidLZ4CompressionFormat(err)
 			}
 			return err
 		}
 		s3Select.close = rc.Close
 		return nil
 	case jsonFormat:
-		rc, err := getReader(0, -1)
+		rc, err := s3Select.openScanRange(getReader, "\n")
 		if err != nil {
 			return err
 		}
 
-		s3Select.progressReader, err = newProgressReader(rc, s3Select.Input.CompressionType)
+		s3Select.progressReader, err = newProgressReader(rc, s3Select.compressionType())
 		if err != nil {
 			rc.Close()
 			return err
@@ -393,6 +490,10 @@ func (s3Select *S3Select) marshal(buf *bytes.Buffer, record sql.Record) error {
 		buf.WriteString(s3Select.Output.JSONArgs.RecordDelimiter)
 
 		return nil
+	case parquetFormat:
+		// The encoded records are flushed once their row group
+		// is complete.
+		return s3Select.parquetWriter.Write(record)
 	}
 
 	panic(fmt.Errorf("unknown output format '%v'", s3Select.Output.format))
@@ -411,15 +512,13 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 		getProgressFunc = nil
 	}
 	writer := newMessageWriter(w, getProgressFunc)
-
-	var outputQueue []sql.Record
-
-	// Create queue based on the type.
-	if s3Select.statement.IsAggregated() {
-		outputQueue = make([]sql.Record, 0, 1)
-	} else {
-		outputQueue = make([]sql.Record, 0, 100)
+	if s3Select.Output.format == parquetFormat {
+		s3Select.parquetWriter = parquet.NewRecordWriter()
 	}
+
+	// Create queue, aggregation and ORDER BY queries output their
+	// records once all input records are read.
+	outputQueue := make([]sql.Record, 0, 100)
 	var err error
 	sendRecord := func() bool {
 		buf := bufPool.Get().(*bytes.Buffer)
@@ -434,12 +533,15 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 				bufPool.Put(buf)
 				return false
 			}
-			if buf.Len()-before > maxRecordSize {
+			if s3Select.parquetWriter == nil && buf.Len()-before > maxRecordSize {
 				writer.FinishWithError("OverMaxRecordSize", "The length of a record in the input or result is greater than maxCharsPerRecord of 1 MB.")
 				bufPool.Put(buf)
 				return false
 			}
 		}
+		if s3Select.parquetWriter != nil {
+			s3Select.parquetWriter.Flush(buf)
+		}
 
 		if err = writer.SendRecord(buf); err != nil {
 			// FIXME: log this error.
@@ -451,11 +553,26 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 		return true
 	}
 
+	// Sends the records left in the queue and, for Parquet output,
+	// the last row group and the footer of the file.
+	sendLastRecords := func() bool {
+		if !sendRecord() {
+			return false
+		}
+		if s3Select.parquetWriter == nil {
+			return true
+		}
+		if err = s3Select.parquetWriter.Close(); err != nil {
+			return false
+		}
+		return sendRecord()
+	}
+
 	var rec sql.Record
 OuterLoop:
 	for {
 		if s3Select.statement.LimitReached() {
-			if !sendRecord() {
+			if !sendLastRecords() {
 				break
 			}
 			if err = writer.Finish(s3Select.getProgress()); err != nil {
@@ -470,15 +587,23 @@ OuterLoop:
 				break
 			}
 
-			if s3Select.statement.IsAggregated() {
-				outputRecord := s3Select.outputRecord()
-				if err = s3Select.statement.AggregateResult(outputRecord); err != nil {
+			if s3Select.statement.IsAggregated() || s3Select.statement.IsOrdered() {
+				var results []sql.Record
+				if results, err = s3Select.statement.Results(s3Select.outputRecord); err != nil {
 					break
 				}
-				outputQueue = append(outputQueue, outputRecord)
+				// Send the records as many messages as needed.
+				for len(results) > cap(outputQueue) {
+					outputQueue = append(outputQueue, results[:cap(outputQueue)]...)
+					results = results[cap(outputQueue):]
+					if !sendRecord() {
+						break OuterLoop
+					}
+				}
+				outputQueue = append(outputQueue, results...)
 			}
 
-			if !sendRecord() {
+			if !sendLastRecords() {
 				break
 			}
 
@@ -499,6 +624,12 @@ OuterLoop:
 				if err = s3Select.statement.AggregateRow(*inputRecord); err != nil {
 					break OuterLoop
 				}
+			} else if s3Select.statement.IsOrdered() {
+				// The output records are kept until all input
+				// records are sorted, they are not reused.
+				if _, err = s3Select.statement.Eval(*inputRecord, s3Select.outputRecord()); err != nil {
+					break OuterLoop
+				}
 			} else {
 				var outputRecord sql.Record
 				// We will attempt to reuse the records in the table.
@@ -538,7 +669,11 @@ OuterLoop:
 	}
 
 	if err != nil {
-		_ = writer.FinishWithError("InternalError", err.Error())
+		if serr, ok := err.(SelectError); ok {
+			_ = writer.FinishWithError(serr.ErrorCode(), serr.ErrorMessage())
+		} else {
+			_ = writer.FinishWithError("InternalError", err.Error())
+		}
 	}
 }
 
@@ -553,6 +688,7 @@ func NewS3Select(r io.Reader) (*S3Select, error) {
 	if err := xml.NewDecoder(r).Decode(s3Select); err != nil {
 		return nil, err
 	}
+	s3Select.objectSize = -1
 
 	return s3Select, nil
 }
@@ -28,9 +28,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bcicen/jstream"
+	"github.com/klauspost/compress/zstd"
 	"github.com/klauspost/cpuid/v2"
+	gzip "github.com/klauspost/pgzip"
 	"github.com/minio/minio-go/v7"
+	jsonfmt "github.com/minio/minio/pkg/s3select/json"
+	"github.com/minio/minio/pkg/s3select/parquet"
 	"github.com/minio/simdjson-go"
+	"github.com/pierrec/lz4"
 )
 
 type testResponseWriter struct {
@@ -1512,3 +1518,576 @@ func TestParquetInputSchemaCSV(t *testing.T) {
 		})
 	}
 }
+
+func TestScanRange(t *testing.T) {
+	csvData := "id,name\n1,a\n2,bb\n3,ccc\n4,dddd\n5,e"
+	jsonData := "{\"id\":1}\n{\"id\":2,\"name\":\"bb\"}\n\n{\"id\":3}\n{\"id\":4,\"name\":\"dddd\"}\n"
+
+	csvRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT %s FROM S3Object</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+            <FileHeaderInfo>%s</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <ScanRange>%s</ScanRange>
+</SelectObjectContentRequest>`
+
+	jsonRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT s.id FROM S3Object s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <JSON>
+            <Type>LINES</Type>
+        </JSON>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <ScanRange>%s</ScanRange>
+</SelectObjectContentRequest>`
+
+	query := func(t *testing.T, data, request string) string {
+		t.Helper()
+		s3Select, err := NewS3Select(strings.NewReader(request))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s3Select.SetObjectSize(int64(len(data)))
+		if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+			if offset < 0 || offset >= int64(len(data)) {
+				t.Fatalf("invalid offset %d", offset)
+			}
+			b := data[offset:]
+			if length >= 0 && length < int64(len(b)) {
+				b = b[:length]
+			}
+			return ioutil.NopCloser(strings.NewReader(b)), nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+		resp := http.Response{
+			StatusCode:    http.StatusOK,
+			Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+			ContentLength: int64(len(w.response)),
+		}
+		res, err := minio.NewSelectResults(&resp, "testbucket")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(got)
+	}
+
+	// Splitting the object in ranges of any size must return every
+	// record exactly once.
+	for _, testCase := range []struct {
+		name    string
+		data    string
+		request string
+		want    string
+	}{
+		{"csv-header", csvData, fmt.Sprintf(csvRequest, "id", "USE", "%s"), "1\n2\n3\n4\n5\n"},
+		{"csv-no-header", csvData, fmt.Sprintf(csvRequest, "_1", "NONE", "%s"), "id\n1\n2\n3\n4\n5\n"},
+		{"csv-ignore-header", csvData, fmt.Sprintf(csvRequest, "_2", "IGNORE", "%s"), "a\nbb\nccc\ndddd\ne\n"},
+		{"json-lines", jsonData, jsonRequest, "1\n2\n3\n4\n"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			for size := 1; size <= len(testCase.data); size++ {
+				var got string
+				for start := 0; start < len(testCase.data); start += size {
+					scanRange := fmt.Sprintf("<Start>%d</Start><End>%d</End>", start, start+size-1)
+					got += query(t, testCase.data, fmt.Sprintf(testCase.request, scanRange))
+				}
+				if got != testCase.want {
+					t.Fatalf("ranges of %d bytes: got %q, want %q", size, got, testCase.want)
+				}
+			}
+		})
+	}
+
+	for i, testCase := range []struct {
+		scanRange string
+		want      string
+	}{
+		{"<Start>0</Start>", "1\n2\n3\n4\n5\n"},
+		{"<Start>12</Start>", "2\n3\n4\n5\n"},
+		{"<Start>13</Start>", "3\n4\n5\n"},
+		{"<Start>100</Start>", ""},
+		{"<Start>8</Start><End>8</End>", "1\n"},
+		{"<Start>9</Start><End>100</End>", "2\n3\n4\n5\n"},
+		{"<End>10</End>", "4\n5\n"},
+		{"<End>100</End>", "1\n2\n3\n4\n5\n"},
+	} {
+		got := query(t, csvData, fmt.Sprintf(csvRequest, "id", "USE", testCase.scanRange))
+		if got != testCase.want {
+			t.Errorf("case %d: got %q, want %q", i+1, got, testCase.want)
+		}
+	}
+
+	for i, testCase := range []struct {
+		request string
+		code    string
+	}{
+		{fmt.Sprintf(csvRequest, "id", "USE", ""), "InvalidRequestParameter"},
+		{fmt.Sprintf(csvRequest, "id", "USE", "<Start>10</Start><End>9</End>"), "InvalidRequestParameter"},
+		{fmt.Sprintf(csvRequest, "id", "USE", "<Start>-1</Start>"), "InvalidRequestParameter"},
+		{strings.Replace(fmt.Sprintf(csvRequest, "id", "USE", "<Start>1</Start>"), "NONE", "GZIP", 1), "UnsupportedScanRangeInput"},
+		{strings.Replace(fmt.Sprintf(jsonRequest, "<Start>1</Start>"), "LINES", "DOCUMENT", 1), "UnsupportedScanRangeInput"},
+	} {
+		_, err := NewS3Select(strings.NewReader(testCase.request))
+		serr, ok := err.(SelectError)
+		if !ok || serr.ErrorCode() != testCase.code {
+			t.Errorf("case %d: expected %s, got %v", i+1, testCase.code, err)
+		}
+	}
+}
+
+func TestGroupByOrderBy(t *testing.T) {
+	csvInput := `host,status,bytes
+a,200,100
+b,404,10
+a,500,50
+c,200,70
+b,200,30
+a,200,20
+`
+	jsonInput := `{"host":"a","status":200,"bytes":100}
+{"host":"b","status":404,"bytes":10}
+{"host":"a","status":500,"bytes":50}
+{"host":"c","status":200,"bytes":70}
+{"host":"b","status":200,"bytes":30}
+{"host":"a","status":200,"bytes":20}
+`
+
+	var testTable = []struct {
+		name       string
+		query      string
+		wantResult string
+	}{
+		{
+			name:       "group-by",
+			query:      `SELECT host, COUNT(*), SUM(bytes) FROM S3Object GROUP BY host`,
+			wantResult: "a,3,170\nb,2,40\nc,1,70",
+		},
+		{
+			name:       "group-by-order-by-alias",
+			query:      `SELECT host, SUM(bytes) AS total FROM S3Object GROUP BY host ORDER BY total DESC`,
+			wantResult: "a,170\nc,70\nb,40",
+		},
+		{
+			name:       "group-by-having",
+			query:      `SELECT host, AVG(bytes) FROM S3Object GROUP BY host HAVING COUNT(*) > 1 ORDER BY AVG(bytes)`,
+			wantResult: "b,20\na,56.666666666666664",
+		},
+		{
+			name:       "group-by-multiple-where-limit",
+			query:      `SELECT s.host, s.status, MAX(s.bytes) FROM S3Object s WHERE s.status = 200 GROUP BY s.host, s.status ORDER BY s.host DESC LIMIT 2`,
+			wantResult: "c,200,70\nb,200,30",
+		},
+		{
+			name:       "order-by",
+			query:      `SELECT host, bytes FROM S3Object ORDER BY host, bytes DESC`,
+			wantResult: "a,100\na,50\na,20\nb,30\nb,10\nc,70",
+		},
+		{
+			name:       "order-by-numeric-limit",
+			query:      `SELECT bytes FROM S3Object ORDER BY bytes LIMIT 3`,
+			wantResult: "10\n20\n30",
+		},
+		{
+			name:       "order-by-column-not-selected",
+			query:      `SELECT host FROM S3Object WHERE status = 200 ORDER BY bytes DESC LIMIT 2`,
+			wantResult: "a\nc",
+		},
+		{
+			name:       "order-by-select-all",
+			query:      `SELECT * FROM S3Object ORDER BY status DESC, host LIMIT 2`,
+			wantResult: "a,500,50\nb,404,10",
+		},
+		{
+			name:       "aggregate-having",
+			query:      `SELECT COUNT(*) FROM S3Object HAVING COUNT(*) > 10`,
+			wantResult: "",
+		},
+	}
+
+	csvRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+            <FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>`
+
+	jsonRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <JSON>
+            <Type>LINES</Type>
+        </JSON>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>`
+
+	for _, format := range []struct {
+		name, request, input string
+	}{
+		{"csv", csvRequest, csvInput},
+		{"json", jsonRequest, jsonInput},
+	} {
+		for _, testCase := range testTable {
+			t.Run(format.name+"-"+testCase.name, func(t *testing.T) {
+				var escaped bytes.Buffer
+				xml.EscapeText(&escaped, []byte(testCase.query))
+				s3Select, err := NewS3Select(strings.NewReader(fmt.Sprintf(format.request, escaped.String())))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+					return ioutil.NopCloser(bytes.NewBufferString(format.input)), nil
+				}); err != nil {
+					t.Fatal(err)
+				}
+
+				w := &testResponseWriter{}
+				s3Select.Evaluate(w)
+				s3Select.Close()
+				resp := http.Response{
+					StatusCode:    http.StatusOK,
+					Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+					ContentLength: int64(len(w.response)),
+				}
+				res, err := minio.NewSelectResults(&resp, "testbucket")
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := ioutil.ReadAll(res)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotS := strings.TrimSpace(string(got))
+				if gotS != testCase.wantResult {
+					t.Errorf("received response does not match with expected reply. Query: %s\ngot: %s\nwant:%s", testCase.query, gotS, testCase.wantResult)
+				}
+			})
+		}
+	}
+}
+
+func TestParquetOutput(t *testing.T) {
+	jsonInput := `{"id":1,"name":"one","price":1.5,"available":true,"created":"2021-01-02T03:04:05Z"}
+{"id":2,"name":"two","price":2,"available":false,"created":"2021-02-03T04:05:06Z"}
+{"id":3,"price":null,"available":true,"created":"2021-03-04T05:06:07Z"}
+`
+	csvInput := `id,name,price
+1,one,1.5
+2,two,2
+`
+
+	var testTable = []struct {
+		name       string
+		input      string
+		query      string
+		wantResult []jstream.KVS
+	}{
+		{
+			name:  "json-select-all",
+			input: jsonInput,
+			query: `SELECT id, name, price, available FROM S3Object`,
+			wantResult: []jstream.KVS{
+				{{Key: "id", Value: int64(1)}, {Key: "name", Value: "one"}, {Key: "price", Value: 1.5}, {Key: "available", Value: true}},
+				{{Key: "id", Value: int64(2)}, {Key: "name", Value: "two"}, {Key: "price", Value: 2.0}, {Key: "available", Value: false}},
+				{{Key: "id", Value: int64(3)}, {Key: "name", Value: nil}, {Key: "price", Value: nil}, {Key: "available", Value: true}},
+			},
+		},
+		{
+			name:  "json-timestamp",
+			input: jsonInput,
+			query: `SELECT s.id, CAST(s.created AS TIMESTAMP) AS created FROM S3Object s WHERE s.available`,
+			wantResult: []jstream.KVS{
+				{{Key: "id", Value: int64(1)}, {Key: "created", Value: "2021-01-02T03:04:05Z"}},
+				{{Key: "id", Value: int64(3)}, {Key: "created", Value: "2021-03-04T05:06:07Z"}},
+			},
+		},
+		{
+			name:  "json-aggregation",
+			input: jsonInput,
+			query: `SELECT COUNT(*), SUM(price) AS total FROM S3Object`,
+			wantResult: []jstream.KVS{
+				{{Key: "_1", Value: int64(3)}, {Key: "total", Value: 3.5}},
+			},
+		},
+		{
+			name:  "json-no-records",
+			input: jsonInput,
+			query: `SELECT id FROM S3Object WHERE id > 10`,
+		},
+		{
+			name:  "csv-select-all",
+			input: csvInput,
+			query: `SELECT * FROM S3Object`,
+			wantResult: []jstream.KVS{
+				{{Key: "id", Value: "1"}, {Key: "name", Value: "one"}, {Key: "price", Value: "1.5"}},
+				{{Key: "id", Value: "2"}, {Key: "name", Value: "two"}, {Key: "price", Value: "2"}},
+			},
+		},
+		{
+			name:  "csv-cast",
+			input: csvInput,
+			query: `SELECT CAST(id AS INT) AS id, CAST(price AS FLOAT) AS price FROM S3Object`,
+			wantResult: []jstream.KVS{
+				{{Key: "id", Value: int64(1)}, {Key: "price", Value: 1.5}},
+				{{Key: "id", Value: int64(2)}, {Key: "price", Value: 2.0}},
+			},
+		},
+	}
+
+	requestXML := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        %s
+    </InputSerialization>
+    <OutputSerialization>
+        <Parquet/>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>`
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			inputXML := `<JSON><Type>LINES</Type></JSON>`
+			if strings.HasPrefix(testCase.name, "csv") {
+				inputXML = `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`
+			}
+			s3Select, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, testCase.query, inputXML)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewBufferString(testCase.input)), nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			w := &testResponseWriter{}
+			s3Select.Evaluate(w)
+			s3Select.Close()
+			resp := http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+				ContentLength: int64(len(w.response)),
+			}
+			res, err := minio.NewSelectResults(&resp, "testbucket")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(testCase.wantResult) == 0 {
+				if len(got) != 0 {
+					t.Fatalf("expected no output, got %d bytes", len(got))
+				}
+				return
+			}
+
+			r, err := parquet.NewReader(func(offset, length int64) (io.ReadCloser, error) {
+				if offset < 0 {
+					offset = int64(len(got)) + offset
+				}
+				return ioutil.NopCloser(bytes.NewReader(got[offset : offset+length])), nil
+			}, &parquet.ReaderArgs{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			for i, want := range testCase.wantResult {
+				rec, err := r.Read(nil)
+				if err != nil {
+					t.Fatalf("record %d: %v", i+1, err)
+				}
+				if kvs := rec.(*jsonfmt.Record).KVS; !reflect.DeepEqual(kvs, want) {
+					t.Errorf("record %d: expected %v, got %v", i+1, want, kvs)
+				}
+			}
+			if _, err = r.Read(nil); err != io.EOF {
+				t.Fatalf("expected EOF, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCompressedInput(t *testing.T) {
+	csvData := "id,name\n1,one\n2,two\n3,three\n"
+	jsonData := `{"id":1,"name":"one"}
+{"id":2,"name":"two"}
+{"id":3,"name":"three"}
+`
+
+	compress := func(t *testing.T, compType CompressionType, data string) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch compType {
+		case gzipType:
+			w = gzip.NewWriter(&buf)
+		case zstdType:
+			zw, err := zstd.NewWriter(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w = zw
+		case lz4Type:
+			w = lz4.NewWriter(&buf)
+		default:
+			return []byte(data)
+		}
+		if _, err := io.WriteString(w, data); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	var testTable = []struct {
+		name            string
+		format          string
+		compType        CompressionType
+		requestCompType string
+		objectName      string
+		contentType     string
+		scanRange       bool
+		wantErrCode     string
+	}{
+		{name: "zstd", format: csvFormat, compType: zstdType, requestCompType: "ZSTD"},
+		{name: "lz4", format: csvFormat, compType: lz4Type, requestCompType: "LZ4"},
+		{name: "zstd-json", format: jsonFormat, compType: zstdType, requestCompType: "ZSTD"},
+		{name: "lz4-json", format: jsonFormat, compType: lz4Type, requestCompType: "LZ4"},
+		{name: "detect-extension-zstd", format: csvFormat, compType: zstdType, objectName: "logs/2021-01-01.csv.zst"},
+		{name: "detect-extension-lz4", format: jsonFormat, compType: lz4Type, objectName: "logs/2021-01-01.json.LZ4"},
+		{name: "detect-extension-gzip", format: csvFormat, compType: gzipType, objectName: "logs/2021-01-01.csv.gz"},
+		{name: "detect-content-type", format: csvFormat, compType: zstdType, objectName: "logs/2021-01-01", contentType: "application/zstd"},
+		{name: "detect-content-type-params", format: jsonFormat, compType: gzipType, objectName: "data.json", contentType: "application/x-gzip; charset=binary"},
+		{name: "detect-none", format: csvFormat, compType: noneType, objectName: "data.csv", contentType: "text/csv"},
+		{name: "explicit-none", format: csvFormat, compType: noneType, requestCompType: "NONE", objectName: "data.csv.zst", contentType: "application/zstd"},
+		{name: "invalid-zstd", format: csvFormat, compType: noneType, requestCompType: "ZSTD", wantErrCode: "InvalidCompressionFormat"},
+		{name: "invalid-lz4", format: csvFormat, compType: noneType, requestCompType: "LZ4", wantErrCode: "InvalidCompressionFormat"},
+		{name: "detect-scan-range", format: csvFormat, compType: zstdType, objectName: "data.csv.zst", scanRange: true, wantErrCode: "UnsupportedScanRangeInput"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			inputXML := `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`
+			data := compress(t, testCase.compType, csvData)
+			if testCase.format == jsonFormat {
+				inputXML = `<JSON><Type>LINES</Type></JSON>`
+				data = compress(t, testCase.compType, jsonData)
+			}
+			if testCase.requestCompType != "" {
+				inputXML = "<CompressionType>" + testCase.requestCompType + "</CompressionType>" + inputXML
+			}
+			scanRangeXML := ""
+			if testCase.scanRange {
+				scanRangeXML = "<ScanRange><Start>0</Start></ScanRange>"
+			}
+			requestXML := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT name FROM S3Object WHERE id &gt; 1</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>%s</InputSerialization>
+    <OutputSerialization><CSV/></OutputSerialization>
+    <RequestProgress><Enabled>FALSE</Enabled></RequestProgress>
+    %s
+</SelectObjectContentRequest>`, inputXML, scanRangeXML)
+
+			s3Select, err := NewS3Select(strings.NewReader(requestXML))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s3Select.SetObjectSize(int64(len(data)))
+			s3Select.SetObjectType(testCase.objectName, testCase.contentType)
+
+			err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewReader(data)), nil
+			})
+			if testCase.wantErrCode != "" {
+				serr, ok := err.(SelectError)
+				if !ok || serr.ErrorCode() != testCase.wantErrCode {
+					t.Fatalf("expected error %s, got %v", testCase.wantErrCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := &testResponseWriter{}
+			s3Select.Evaluate(w)
+			s3Select.Close()
+			resp := http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+				ContentLength: int64(len(w.response)),
+			}
+			res, err := minio.NewSelectResults(&resp, "testbucket")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "two\nthree\n"; string(got) != want {
+				t.Fatalf("expected %q, got %q", want, string(got))
+			}
+		})
+	}
+}
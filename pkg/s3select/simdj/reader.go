@@ -17,6 +17,7 @@
 package simdj
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"sync"
@@ -157,12 +158,35 @@ func NewReader(readCloser io.ReadCloser, args *json.ReaderArgs) *Reader {
 		input:      make(chan simdjson.Stream, 2),
 		exitReader: make(chan struct{}),
 	}
-	simdjson.ParseNDStream(readCloser, r.input, nil)
+	simdjson.ParseNDStream(&skipSpaceReader{r: bufio.NewReader(readCloser)}, r.input, nil)
 	r.readerWg.Add(1)
 	go r.startReader()
 	return &r
 }
 
+// skipSpaceReader skips the whitespace at the start of the input, such as
+// the empty lines of a scan range, which fails to parse when nothing follows.
+type skipSpaceReader struct {
+	r       *bufio.Reader
+	skipped bool
+}
+
+func (s *skipSpaceReader) Read(p []byte) (int, error) {
+	for !s.skipped {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+		default:
+			s.skipped = true
+			s.r.UnreadByte()
+		}
+	}
+	return s.r.Read(p)
+}
+
 // NewElementReader - creates new JSON reader using readCloser.
 func NewElementReader(ch chan simdjson.Object, err *error, args *json.ReaderArgs) *Reader {
 	return &Reader{
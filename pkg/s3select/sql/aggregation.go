@@ -149,146 +149,9 @@ func (e *FuncExpr) evalAggregationNode(r Record, tableAlias string) error {
 	return err
 }
 
-func (e *AliasedExpression) aggregateRow(r Record, tableAlias string) error {
-	return e.Expression.aggregateRow(r, tableAlias)
-}
-
-func (e *Expression) aggregateRow(r Record, tableAlias string) error {
-	for _, ex := range e.And {
-		err := ex.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (e *ListExpr) aggregateRow(r Record, tableAlias string) error {
-	for _, ex := range e.Elements {
-		err := ex.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (e *AndCondition) aggregateRow(r Record, tableAlias string) error {
-	for _, ex := range e.Condition {
-		err := ex.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (e *Condition) aggregateRow(r Record, tableAlias string) error {
-	if e.Operand != nil {
-		return e.Operand.aggregateRow(r, tableAlias)
-	}
-	return e.Not.aggregateRow(r, tableAlias)
-}
-
-func (e *ConditionOperand) aggregateRow(r Record, tableAlias string) error {
-	err := e.Operand.aggregateRow(r, tableAlias)
-	if err != nil {
-		return err
-	}
-
-	if e.ConditionRHS == nil {
-		return nil
-	}
-
-	switch {
-	case e.ConditionRHS.Compare != nil:
-		return e.ConditionRHS.Compare.Operand.aggregateRow(r, tableAlias)
-	case e.ConditionRHS.Between != nil:
-		err = e.ConditionRHS.Between.Start.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-		return e.ConditionRHS.Between.End.aggregateRow(r, tableAlias)
-	case e.ConditionRHS.In != nil:
-		elt := e.ConditionRHS.In.ListExpression
-		err = elt.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-		return nil
-	case e.ConditionRHS.Like != nil:
-		err = e.ConditionRHS.Like.Pattern.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-		return e.ConditionRHS.Like.EscapeChar.aggregateRow(r, tableAlias)
-	default:
-		return errInvalidASTNode
-	}
-}
-
-func (e *Operand) aggregateRow(r Record, tableAlias string) error {
-	err := e.Left.aggregateRow(r, tableAlias)
-	if err != nil {
-		return err
-	}
-	for _, rt := range e.Right {
-		err = rt.Right.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (e *MultOp) aggregateRow(r Record, tableAlias string) error {
-	err := e.Left.aggregateRow(r, tableAlias)
-	if err != nil {
-		return err
-	}
-	for _, rt := range e.Right {
-		err = rt.Right.aggregateRow(r, tableAlias)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (e *UnaryTerm) aggregateRow(r Record, tableAlias string) error {
-	if e.Negated != nil {
-		return e.Negated.Term.aggregateRow(r, tableAlias)
-	}
-	return e.Primary.aggregateRow(r, tableAlias)
-}
-
-func (e *PrimaryTerm) aggregateRow(r Record, tableAlias string) error {
-	switch {
-	case e.ListExpr != nil:
-		return e.ListExpr.aggregateRow(r, tableAlias)
-	case e.SubExpression != nil:
-		return e.SubExpression.aggregateRow(r, tableAlias)
-	case e.FuncCall != nil:
-		return e.FuncCall.aggregateRow(r, tableAlias)
-	}
-	return nil
-}
-
-func (e *FuncExpr) aggregateRow(r Record, tableAlias string) error {
-	switch e.getFunctionName() {
-	case aggFnAvg, aggFnSum, aggFnMax, aggFnMin, aggFnCount:
-		return e.evalAggregationNode(r, tableAlias)
-	default:
-		// TODO: traverse arguments and call aggregateRow on
-		// them if they could be an ancestor of an
-		// aggregation.
-	}
-	return nil
-}
-
 // getAggregate() implementation for each AST node follows. This is
-// called after calling aggregateRow() on each input row, to calculate
-// the final aggregate result.
+// called after calling evalAggregationNode() on each input row, to
+// calculate the final aggregate result.
 
 func (e *FuncExpr) getAggregate() (*Value, error) {
 	switch e.getFunctionName() {
@@ -300,8 +163,11 @@ func (e *FuncExpr) getAggregate() (*Value, error) {
 			// No rows were seen by AVG.
 			return FromNull(), nil
 		}
-		err := e.aggregate.runningSum.arithOp(opDivide, FromInt(e.aggregate.runningCount))
-		return e.aggregate.runningSum, err
+		// The running sum is left as is, the result can be
+		// requested more than once.
+		avg := *e.aggregate.runningSum
+		err := avg.arithOp(opDivide, FromInt(e.aggregate.runningCount))
+		return &avg, err
 
 	case aggFnMin:
 		if !e.aggregate.seen {
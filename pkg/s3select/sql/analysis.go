@@ -49,12 +49,16 @@ var (
 type qProp struct {
 	isAggregation, isRowFunc bool
 
+	// Columns referenced outside of aggregation functions.
+	columns []*JSONPath
+
 	err error
 }
 
 // `combine` combines a pair of `qProp`s, so that errors are
-// propagated correctly, and checks that an aggregation is not being
-// combined with a row-function term.
+// propagated correctly. Whether an aggregation is combined with a
+// row-function term is checked on the whole clause, as it is allowed
+// for the columns of the GROUP BY clause.
 func (p *qProp) combine(q qProp) {
 	switch {
 	case p.err != nil:
@@ -64,9 +68,7 @@ func (p *qProp) combine(q qProp) {
 	default:
 		p.isAggregation = p.isAggregation || q.isAggregation
 		p.isRowFunc = p.isRowFunc || q.isRowFunc
-		if p.isAggregation && p.isRowFunc {
-			p.err = errNestedAggregation
-		}
+		p.columns = append(p.columns, q.columns...)
 	}
 }
 
@@ -183,7 +185,7 @@ func (e *PrimaryTerm) analyze(s *Select) (result qProp) {
 				return
 			}
 		}
-		result = qProp{isRowFunc: true}
+		result = qProp{isRowFunc: true, columns: []*JSONPath{e.JPathExpr}}
 
 	case e.ListExpr != nil:
 		result = e.ListExpr.analyze(s)
@@ -224,6 +226,7 @@ func (e *FuncExpr) analyze(s *Select) (result qProp) {
 	case aggFnAvg, aggFnMax, aggFnMin, aggFnSum, aggFnCount:
 		// Initialize accumulator
 		e.aggregate = newAggVal(funcName)
+		s.addAggregate(e)
 
 		var exprA qProp
 		if funcName == aggFnCount {
@@ -321,3 +324,14 @@ func (e *FuncExpr) analyze(s *Select) (result qProp) {
 	// TODO: implement other functions
 	return qProp{err: errFunctionNotImplemented}
 }
+
+// addAggregate - records an aggregation function call of the
+// statement, the accumulators of the calls are kept for each group.
+func (s *Select) addAggregate(e *FuncExpr) {
+	for _, agg := range s.aggregates {
+		if agg == e {
+			return
+		}
+	}
+	s.aggregates = append(s.aggregates, e)
+}
@@ -0,0 +1,186 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxGroups is the maximum number of groups of a GROUP BY query, the
+// accumulators of the aggregations of every group are held in memory.
+const maxGroups = 100000
+
+var (
+	errGroupByAggregation  = errors.New("GROUP BY clause cannot have an aggregation")
+	errGroupBySelectAll    = errors.New("SELECT * cannot be used with GROUP BY")
+	errHavingNoAggregation = errors.New("HAVING clause requires GROUP BY or an aggregation")
+	errTooManyGroups       = fmt.Errorf("GROUP BY query has more than %d groups", maxGroups)
+)
+
+// group holds the first record of a group of a GROUP BY query, to
+// evaluate the columns of the GROUP BY clause, and the accumulators of
+// the aggregation functions of the statement for the group.
+type group struct {
+	record     Record
+	aggregates []*aggVal
+}
+
+// columnName - returns the path of a column without the table alias.
+func (e *JSONPath) columnName(tableAlias string) string {
+	if tableAlias == "" {
+		tableAlias = baseTableName
+	}
+	var b strings.Builder
+	for _, pe := range e.StripTableAlias(tableAlias) {
+		b.WriteString(pe.String())
+	}
+	return b.String()
+}
+
+// analyzeGroupBy - checks the GROUP BY and HAVING clauses, and that the
+// columns used outside of aggregation functions in the select and
+// HAVING clauses are in the GROUP BY clause.
+func (e *SelectStatement) analyzeGroupBy() error {
+	s := e.selectAST
+	if !e.IsAggregated() {
+		if s.Having != nil {
+			return errHavingNoAggregation
+		}
+		return nil
+	}
+
+	if len(s.GroupBy) == 0 {
+		// Aggregation of the whole table.
+		if e.selectQProp.isRowFunc {
+			return errNestedAggregation
+		}
+	}
+	if s.Expression.All {
+		return errGroupBySelectAll
+	}
+
+	columns := make(map[string]bool)
+	for _, expr := range s.GroupBy {
+		p := expr.analyze(s)
+		if p.err != nil {
+			return fmt.Errorf("GROUP BY clause error: %w", p.err)
+		}
+		if p.isAggregation {
+			return errGroupByAggregation
+		}
+		for _, column := range p.columns {
+			columns[column.columnName(e.tableAlias)] = true
+		}
+	}
+	e.groupColumns = columns
+
+	if err := e.checkGroupColumns(e.selectQProp); err != nil {
+		return err
+	}
+	if s.Having != nil {
+		p := s.Having.analyze(s)
+		if p.err != nil {
+			return fmt.Errorf("HAVING clause error: %w", p.err)
+		}
+		return e.checkGroupColumns(p)
+	}
+	return nil
+}
+
+// checkGroupColumns - checks that the columns used outside of
+// aggregation functions in an aggregation query are in the GROUP BY
+// clause.
+func (e *SelectStatement) checkGroupColumns(p qProp) error {
+	for _, column := range p.columns {
+		if !e.groupColumns[column.columnName(e.tableAlias)] {
+			return fmt.Errorf("column %s must be in the GROUP BY clause or used in an aggregation", column)
+		}
+	}
+	return nil
+}
+
+// groupKey - returns the key of the group of the input record.
+func (e *SelectStatement) groupKey(input Record) (string, error) {
+	var b strings.Builder
+	for _, expr := range e.selectAST.GroupBy {
+		v, err := expr.evalNode(input, e.tableAlias)
+		if err != nil {
+			return "", err
+		}
+		normalizeKey(v)
+		s := v.CSVString()
+		fmt.Fprintf(&b, "%s:%d:%s", v.GetTypeString(), len(s), s)
+	}
+	return b.String(), nil
+}
+
+// getGroup - returns the group of the input record, created when the
+// record is the first one of the group.
+func (e *SelectStatement) getGroup(input Record) (*group, error) {
+	key, err := e.groupKey(input)
+	if err != nil {
+		return nil, err
+	}
+	if g, ok := e.groups[key]; ok {
+		return g, nil
+	}
+	if len(e.groups) >= maxGroups {
+		return nil, errTooManyGroups
+	}
+
+	g := &group{
+		record:     input.Clone(nil),
+		aggregates: make([]*aggVal, len(e.selectAST.aggregates)),
+	}
+	for i, agg := range e.selectAST.aggregates {
+		g.aggregates[i] = newAggVal(agg.getFunctionName())
+	}
+	if e.groups == nil {
+		e.groups = make(map[string]*group)
+	}
+	e.groups[key] = g
+	e.groupList = append(e.groupList, g)
+	return g, nil
+}
+
+// setAggregates - sets the accumulators of the aggregation functions
+// of the statement to those of the group.
+func (e *SelectStatement) setAggregates(g *group) {
+	for i, agg := range e.selectAST.aggregates {
+		agg.aggregate = g.aggregates[i]
+	}
+}
+
+// isPassingHavingClause - returns whether the group of the current
+// accumulators passes the HAVING clause.
+func (e *SelectStatement) isPassingHavingClause(record Record) (bool, error) {
+	if e.selectAST.Having == nil {
+		return true, nil
+	}
+	value, err := e.selectAST.Having.evalNode(record, e.tableAlias)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := value.ToBool()
+	if !ok {
+		return false, fmt.Errorf("HAVING expression did not return bool")
+	}
+	return b, nil
+}
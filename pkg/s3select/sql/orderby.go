@@ -0,0 +1,310 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSortedRecords is the maximum number of records of an ORDER BY
+// query held in memory to be sorted: all the records of the query, or
+// the number of records of its LIMIT clause.
+const maxSortedRecords = 100000
+
+var (
+	errOrderByAggregation = errors.New("ORDER BY clause cannot have an aggregation")
+	errTooManySorted      = fmt.Errorf("ORDER BY query has more than %d records, use a LIMIT clause", maxSortedRecords)
+)
+
+// isDescending - returns whether the ORDER BY item sorts in descending order.
+func (e *OrderByExpression) isDescending() bool {
+	return strings.EqualFold(e.Direction, "DESC")
+}
+
+// analyzeOrderBy - checks the ORDER BY clause. An item that is the
+// alias of a select expression is replaced by the expression.
+func (e *SelectStatement) analyzeOrderBy() error {
+	s := e.selectAST
+	for _, item := range s.OrderBy {
+		if item.Direction != "" && !strings.EqualFold(item.Direction, "ASC") && !item.isDescending() {
+			return fmt.Errorf("ORDER BY clause error: unexpected sort direction %q", item.Direction)
+		}
+		if expr := e.selectAlias(item.Expression); expr != nil {
+			item.Expression = expr
+			continue
+		}
+
+		p := item.Expression.analyze(s)
+		if p.err != nil {
+			return fmt.Errorf("ORDER BY clause error: %w", p.err)
+		}
+		if !e.IsAggregated() {
+			if p.isAggregation {
+				return errOrderByAggregation
+			}
+			continue
+		}
+		if err := e.checkGroupColumns(p); err != nil {
+			return err
+		}
+	}
+	if len(s.OrderBy) > 0 {
+		e.sorter = newRecordSorter(s.OrderBy, e.limitValue)
+	}
+	return nil
+}
+
+// selectAlias - returns the select expression named by the ORDER BY
+// expression, if any.
+func (e *SelectStatement) selectAlias(expr *Expression) *Expression {
+	if e.selectAST.Expression.All {
+		return nil
+	}
+	if len(expr.And) != 1 || len(expr.And[0].Condition) != 1 || expr.And[0].Condition[0].Operand == nil {
+		return nil
+	}
+	operand := expr.And[0].Condition[0].Operand
+	if operand.ConditionRHS != nil ||
+		len(operand.Operand.Right) > 0 ||
+		len(operand.Operand.Left.Right) > 0 ||
+		operand.Operand.Left.Left.Primary == nil ||
+		operand.Operand.Left.Left.Primary.JPathExpr == nil {
+		return nil
+	}
+	path := operand.Operand.Left.Left.Primary.JPathExpr
+	if len(path.PathExpr) > 0 {
+		return nil
+	}
+	for _, item := range e.selectAST.Expression.Expressions {
+		if item.As != "" && item.As == path.BaseKey.String() {
+			return item.Expression
+		}
+	}
+	return nil
+}
+
+// orderKeys - evaluates the ORDER BY expressions on the record.
+func (e *SelectStatement) orderKeys(r Record) ([]Value, error) {
+	keys := make([]Value, len(e.selectAST.OrderBy))
+	for i, item := range e.selectAST.OrderBy {
+		v, err := item.Expression.evalNode(r, e.tableAlias)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = *v
+		normalizeKey(&keys[i])
+	}
+	return keys, nil
+}
+
+// normalizeKey - infers the type of a value read from the input, so
+// the values of a column are compared and grouped alike, and copies
+// the bytes it references.
+func normalizeKey(v *Value) {
+	b, ok := v.ToBytes()
+	if !ok {
+		return
+	}
+	if err := v.InferBytesType(); err != nil {
+		v.setString(string(b))
+		return
+	}
+	if b, ok = v.ToBytes(); ok {
+		v.setString(string(b))
+	}
+}
+
+// keyTypeRank - orders the values of different types.
+func keyTypeRank(v *Value) int {
+	switch v.value.(type) {
+	case int64, float64:
+		return 0
+	case string:
+		return 1
+	case bool:
+		return 2
+	case time.Time:
+		return 3
+	case nil:
+		return 5
+	default:
+		return 4
+	}
+}
+
+// compareKeys - compares two normalized values, NULL values are
+// greater than all others.
+func compareKeys(a, b *Value) int {
+	ra, rb := keyTypeRank(a), keyTypeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+
+	switch x := a.value.(type) {
+	case int64:
+		if y, ok := b.value.(int64); ok {
+			return compareInts(x, y)
+		}
+	case string:
+		return strings.Compare(x, b.value.(string))
+	case bool:
+		y := b.value.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	case time.Time:
+		y := b.value.(time.Time)
+		switch {
+		case x.Equal(y):
+			return 0
+		case x.Before(y):
+			return -1
+		}
+		return 1
+	case nil:
+		return 0
+	}
+
+	if ra == 0 {
+		fa, _ := a.ToFloat()
+		fb, _ := b.ToFloat()
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a.CSVString(), b.CSVString())
+}
+
+func compareInts(x, y int64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// sortedRecord is a record of an ORDER BY query with the values of
+// the ORDER BY expressions, records with equal values are kept in
+// input order.
+type sortedRecord struct {
+	keys   []Value
+	seq    int64
+	record Record
+}
+
+// recordSorter sorts the records of an ORDER BY query. With a LIMIT
+// clause only the first records are held, in a heap whose root is the
+// last of them.
+type recordSorter struct {
+	orderBy []*OrderByExpression
+	limit   int64
+	seq     int64
+	records []sortedRecord
+}
+
+func newRecordSorter(orderBy []*OrderByExpression, limit int64) *recordSorter {
+	return &recordSorter{
+		orderBy: orderBy,
+		limit:   limit,
+	}
+}
+
+// before - returns whether the record a is sorted before b.
+func (s *recordSorter) before(a, b *sortedRecord) bool {
+	for i := range a.keys {
+		c := compareKeys(&a.keys[i], &b.keys[i])
+		if c == 0 {
+			continue
+		}
+		if s.orderBy[i].isDescending() {
+			return c > 0
+		}
+		return c < 0
+	}
+	return a.seq < b.seq
+}
+
+// heap.Interface of the records, the root is sorted last.
+func (s *recordSorter) Len() int           { return len(s.records) }
+func (s *recordSorter) Less(i, j int) bool { return s.before(&s.records[j], &s.records[i]) }
+func (s *recordSorter) Swap(i, j int)      { s.records[i], s.records[j] = s.records[j], s.records[i] }
+
+func (s *recordSorter) Push(x interface{}) {
+	s.records = append(s.records, x.(sortedRecord))
+}
+
+func (s *recordSorter) Pop() interface{} {
+	n := len(s.records) - 1
+	x := s.records[n]
+	s.records = s.records[:n]
+	return x
+}
+
+// add - adds a record with the values of the ORDER BY expressions.
+func (s *recordSorter) add(keys []Value, record Record) error {
+	r := sortedRecord{keys: keys, seq: s.seq, record: record}
+	s.seq++
+
+	switch {
+	case s.limit == 0:
+		return nil
+	case s.limit < 0 || int64(len(s.records)) < s.limit:
+		if len(s.records) >= maxSortedRecords {
+			return errTooManySorted
+		}
+		if s.limit < 0 {
+			s.records = append(s.records, r)
+		} else {
+			heap.Push(s, r)
+		}
+	case s.before(&r, &s.records[0]):
+		s.records[0] = r
+		heap.Fix(s, 0)
+	}
+	return nil
+}
+
+// sorted - returns the records in order.
+func (s *recordSorter) sorted() []Record {
+	sort.Slice(s.records, func(i, j int) bool {
+		return s.before(&s.records[i], &s.records[j])
+	})
+	records := make([]Record, len(s.records))
+	for i := range s.records {
+		records[i] = s.records[i].record
+	}
+	s.records = nil
+	return records
+}
@@ -85,10 +85,16 @@ func (qi *QuotedIdentifier) Capture(values []string) error {
 
 // Select is the top level AST node type
 type Select struct {
-	Expression *SelectExpression `parser:"\"SELECT\" @@"`
-	From       *TableExpression  `parser:"\"FROM\" @@"`
-	Where      *Expression       `parser:"( \"WHERE\" @@ )?"`
-	Limit      *LitValue         `parser:"( \"LIMIT\" @@ )?"`
+	Expression *SelectExpression    `parser:"\"SELECT\" @@"`
+	From       *TableExpression     `parser:"\"FROM\" @@"`
+	Where      *Expression          `parser:"( \"WHERE\" @@ )?"`
+	GroupBy    []*Expression        `parser:"( \"GROUP\" \"BY\" @@ ( \",\" @@ )* )?"`
+	Having     *Expression          `parser:"( \"HAVING\" @@ )?"`
+	OrderBy    []*OrderByExpression `parser:"( \"ORDER\" \"BY\" @@ ( \",\" @@ )* )?"`
+	Limit      *LitValue            `parser:"( \"LIMIT\" @@ )?"`
+
+	// Aggregation function calls found during analysis.
+	aggregates []*FuncExpr
 }
 
 // SelectExpression represents the items requested in the select
@@ -104,6 +110,14 @@ type TableExpression struct {
 	As    string    `parser:"( \"AS\"? @Ident )?"`
 }
 
+// OrderByExpression represents an item of the ORDER BY clause. ASC and
+// DESC are not keywords, such that they remain valid column names, the
+// direction is checked during analysis.
+type OrderByExpression struct {
+	Expression *Expression `parser:"@@"`
+	Direction  string      `parser:"@Ident?"`
+}
+
 // JSONPathElement represents a keypath component
 type JSONPathElement struct {
 	Key            *ObjectKey `parser:"  @@"`               // ['name'] and .name forms
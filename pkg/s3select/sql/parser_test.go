@@ -355,6 +355,12 @@ func TestSelectParsing(t *testing.T) {
 		"select * from s3object where name > 2 or value > 1 or word > 2",
 		"select s.word.id + 2 from s3object s",
 		"select 1-2-3 from s3object s limit 1",
+		"select s.a, count(*) from s3object s group by s.a having count(*) > 1 order by s.a desc limit 2",
+		"select a, b from s3object order by b, a asc",
+		// ASC and DESC remain valid column names.
+		"SELECT s.desc FROM S3Object s",
+		"SELECT s.desc, s.asc FROM S3Object s WHERE s.asc = '1' ORDER BY s.desc DESC, s.asc",
+		"select desc from s3object order by desc asc",
 	}
 	for i, tc := range cases {
 		err := p.ParseString(tc, &s)
@@ -366,6 +372,38 @@ func TestSelectParsing(t *testing.T) {
 	}
 }
 
+func TestGroupByOrderByAnalysis(t *testing.T) {
+	cases := []struct {
+		query string
+		valid bool
+	}{
+		{"SELECT s.a, COUNT(*) FROM S3Object s GROUP BY s.a", true},
+		{"SELECT a, SUM(b) AS total FROM S3Object GROUP BY a HAVING SUM(b) > 10 ORDER BY total DESC", true},
+		{"SELECT LOWER(a), COUNT(*) FROM S3Object GROUP BY a, c ORDER BY c", true},
+		{"SELECT a, b FROM S3Object ORDER BY c DESC, a LIMIT 5", true},
+		{"SELECT COUNT(*) FROM S3Object HAVING COUNT(*) > 1", true},
+		{"SELECT b, COUNT(*) FROM S3Object GROUP BY a", false},
+		{"SELECT a FROM S3Object GROUP BY COUNT(*)", false},
+		{"SELECT * FROM S3Object GROUP BY a", false},
+		{"SELECT a FROM S3Object HAVING a > 1", false},
+		{"SELECT a, COUNT(*) FROM S3Object GROUP BY a HAVING b > 1", false},
+		{"SELECT a, COUNT(*) FROM S3Object GROUP BY a ORDER BY b", false},
+		{"SELECT a FROM S3Object ORDER BY a desc, b Asc", true},
+		{"SELECT a FROM S3Object ORDER BY a DOWN", false},
+		{"SELECT a FROM S3Object ORDER BY COUNT(*)", false},
+		{"SELECT a, COUNT(*) FROM S3Object", false},
+	}
+	for i, tc := range cases {
+		_, err := ParseSelectStatement(tc.query)
+		if tc.valid && err != nil {
+			t.Errorf("%d: %s: unexpected error %v", i+1, tc.query, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%d: %s: expected an error", i+1, tc.query)
+		}
+	}
+}
+
 func TestSqlLexerArithOps(t *testing.T) {
 	s := bytes.NewBuffer([]byte("year from select month hour distinct"))
 	lex, err := sqlLexer.Lex(s)
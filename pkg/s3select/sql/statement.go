@@ -49,6 +49,15 @@ type SelectStatement struct {
 
 	// Table alias
 	tableAlias string
+
+	// Columns of the GROUP BY clause, and groups of the input
+	// records in the order they are first seen.
+	groupColumns map[string]bool
+	groups       map[string]*group
+	groupList    []*group
+
+	// Sorter of the output records of an ORDER BY query.
+	sorter *recordSorter
 }
 
 // ParseSelectStatement - parses a select query from the given string
@@ -109,10 +118,20 @@ func ParseSelectStatement(s string) (stmt SelectStatement, err error) {
 	err = stmt.selectQProp.err
 	if err != nil {
 		err = errQueryAnalysisFailure(err)
+		return
 	}
 
 	// Set table alias
 	stmt.tableAlias = selectAST.From.As
+
+	// Analyze group by, having and order by clauses
+	if err = stmt.analyzeGroupBy(); err != nil {
+		err = errQueryAnalysisFailure(err)
+		return
+	}
+	if err = stmt.analyzeOrderBy(); err != nil {
+		err = errQueryAnalysisFailure(err)
+	}
 	return
 }
 
@@ -225,27 +244,91 @@ func (e *SelectStatement) EvalFrom(format string, input Record) ([]*Record, erro
 
 // IsAggregated returns if the statement involves SQL aggregation
 func (e *SelectStatement) IsAggregated() bool {
-	return e.selectQProp.isAggregation
+	return e.selectQProp.isAggregation || len(e.selectAST.GroupBy) > 0
+}
+
+// IsOrdered returns if the statement has an ORDER BY clause, its
+// records are output once all input records have been processed.
+func (e *SelectStatement) IsOrdered() bool {
+	return e.sorter != nil
 }
 
-// AggregateResult - returns the aggregated result after all input
-// records have been processed. Applies only to aggregation queries.
-func (e *SelectStatement) AggregateResult(output Record) error {
-	for i, expr := range e.selectAST.Expression.Expressions {
-		v, err := expr.evalNode(nil, e.tableAlias)
+// Results - returns the records output after all input records have
+// been processed. Applies only to aggregation and ORDER BY queries.
+func (e *SelectStatement) Results(newRecord func() Record) ([]Record, error) {
+	if !e.IsAggregated() {
+		if e.sorter == nil {
+			return nil, nil
+		}
+		return e.sorter.sorted(), nil
+	}
+
+	groups := e.groupList
+	if len(e.selectAST.GroupBy) == 0 {
+		// The whole table is a single group, with the
+		// accumulators of the statement.
+		g := &group{aggregates: make([]*aggVal, len(e.selectAST.aggregates))}
+		for i, agg := range e.selectAST.aggregates {
+			g.aggregates[i] = agg.aggregate
+		}
+		groups = []*group{g}
+	}
+
+	var records []Record
+	for _, g := range groups {
+		e.setAggregates(g)
+		ok, err := e.isPassingHavingClause(g.record)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		output := newRecord()
+		for i, expr := range e.selectAST.Expression.Expressions {
+			v, err := expr.evalNode(g.record, e.tableAlias)
+			if err != nil {
+				return nil, err
+			}
+			if output, err = setOutputColumn(output, i, expr, v); err != nil {
+				return nil, err
+			}
 		}
-		if expr.As != "" {
-			output, err = output.Set(expr.As, v)
-		} else {
-			output, err = output.Set(fmt.Sprintf("_%d", i+1), v)
+
+		if e.sorter == nil {
+			records = append(records, output)
+			continue
 		}
+		keys, err := e.orderKeys(g.record)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if err = e.sorter.add(keys, output); err != nil {
+			return nil, err
 		}
 	}
-	return nil
+
+	if e.sorter != nil {
+		records = e.sorter.sorted()
+	}
+	if e.limitValue > -1 && int64(len(records)) > e.limitValue {
+		records = records[:e.limitValue]
+	}
+	return records, nil
+}
+
+// setOutputColumn - sets the value of the i-th select expression in
+// the output record.
+func setOutputColumn(output Record, i int, expr *AliasedExpression, v *Value) (Record, error) {
+	// Pick output column names
+	if expr.As != "" {
+		return output.Set(expr.As, v)
+	}
+	if comp, ok := getLastKeypathComponent(expr.Expression); ok {
+		return output.Set(comp, v)
+	}
+	return output.Set(fmt.Sprintf("_%d", i+1), v)
 }
 
 func (e *SelectStatement) isPassingWhereClause(input Record) (bool, error) {
@@ -277,11 +360,18 @@ func (e *SelectStatement) AggregateRow(input Record) error {
 		return nil
 	}
 
-	for _, expr := range e.selectAST.Expression.Expressions {
-		err := expr.aggregateRow(input, e.tableAlias)
+	if len(e.selectAST.GroupBy) > 0 {
+		g, err := e.getGroup(input)
 		if err != nil {
 			return err
 		}
+		e.setAggregates(g)
+	}
+
+	for _, agg := range e.selectAST.aggregates {
+		if err := agg.evalAggregationNode(input, e.tableAlias); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -289,6 +379,8 @@ func (e *SelectStatement) AggregateRow(input Record) error {
 // Eval - evaluates the Select statement for the given record. It
 // applies only to non-aggregation queries.
 // The function returns whether the statement passed the WHERE clause and should be outputted.
+// The output record of an ORDER BY query is kept to be returned by
+// Results, so nil is returned and the record must not be reused.
 func (e *SelectStatement) Eval(input, output Record) (Record, error) {
 	ok, err := e.isPassingWhereClause(input)
 	if err != nil || !ok {
@@ -299,31 +391,25 @@ func (e *SelectStatement) Eval(input, output Record) (Record, error) {
 	if e.selectAST.Expression.All {
 		// Return the input record for `SELECT * FROM
 		// .. WHERE ..`
-
-		// Update count of records output.
-		if e.limitValue > -1 {
-			e.outputCount++
+		output = input.Clone(output)
+	} else {
+		for i, expr := range e.selectAST.Expression.Expressions {
+			v, err := expr.evalNode(input, e.tableAlias)
+			if err != nil {
+				return nil, err
+			}
+			if output, err = setOutputColumn(output, i, expr, v); err != nil {
+				return nil, err
+			}
 		}
-		return input.Clone(output), nil
 	}
 
-	for i, expr := range e.selectAST.Expression.Expressions {
-		v, err := expr.evalNode(input, e.tableAlias)
-		if err != nil {
-			return nil, err
-		}
-
-		// Pick output column names
-		if expr.As != "" {
-			output, err = output.Set(expr.As, v)
-		} else if comp, ok := getLastKeypathComponent(expr.Expression); ok {
-			output, err = output.Set(comp, v)
-		} else {
-			output, err = output.Set(fmt.Sprintf("_%d", i+1), v)
-		}
+	if e.sorter != nil {
+		keys, err := e.orderKeys(input)
 		if err != nil {
 			return nil, err
 		}
+		return nil, e.sorter.add(keys, output)
 	}
 
 	// Update count of records output.
@@ -50,15 +50,6 @@ func errOverMaxColumn(err error) *s3Error {
 	}
 }
 
-func errOverMaxRecordSize(err error) *s3Error {
-	return &s3Error{
-		code:       "OverMaxRecordSize",
-		message:    "The length of a record in the input or result is greater than maxCharsPerRecord of 1 MB.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
 func errInvalidColumnIndex(err error) *s3Error {
 	return &s3Error{
 		code:       "InvalidColumnIndex",
@@ -0,0 +1,214 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store persists the entries of the event, logger and audit
+// targets on disk, such that they are replayed once the target is
+// reachable instead of being lost.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio/pkg/sys"
+)
+
+const defaultLimit = 100000 // Default store limit.
+
+// ErrLimitExceeded is returned when the maximum number of entries is
+// reached.
+var ErrLimitExceeded = errors.New("the maximum store limit reached")
+
+// Store - To persist the entries.
+type Store interface {
+	Put(entry interface{}) error
+	Get(key string) ([]byte, error)
+	List() ([]string, error)
+	Del(key string) error
+	Open() error
+}
+
+// QueueStore - Filestore for persisting entries.
+type QueueStore struct {
+	sync.RWMutex
+	currentEntries uint64
+	entryLimit     uint64
+	directory      string
+	ext            string
+}
+
+// NewQueueStore - Creates an instance for QueueStore, the entries are
+// stored in files named by their key and the ext extension.
+func NewQueueStore(directory string, limit uint64, ext string) Store {
+	if limit == 0 {
+		limit = defaultLimit
+		_, maxRLimit, err := sys.GetMaxOpenFileLimit()
+		if err == nil {
+			// Limit the maximum number of entries
+			// to maximum open file limit
+			if maxRLimit < limit {
+				limit = maxRLimit
+			}
+		}
+	}
+
+	return &QueueStore{
+		directory:  directory,
+		entryLimit: limit,
+		ext:        ext,
+	}
+}
+
+// Open - Creates the directory if not present.
+func (store *QueueStore) Open() error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := os.MkdirAll(store.directory, os.FileMode(0770)); err != nil {
+		return err
+	}
+
+	names, err := store.list()
+	if err != nil {
+		return err
+	}
+
+	currentEntries := uint64(len(names))
+	if currentEntries >= store.entryLimit {
+		return ErrLimitExceeded
+	}
+
+	store.currentEntries = currentEntries
+
+	return nil
+}
+
+// write - writes the entry to the directory.
+func (store *QueueStore) write(key string, entry interface{}) error {
+	// Marshals the entry.
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(store.directory, key+store.ext)
+	if err := ioutil.WriteFile(path, data, os.FileMode(0770)); err != nil {
+		return err
+	}
+
+	// Increment the entry count.
+	store.currentEntries++
+
+	return nil
+}
+
+// Put - puts an entry to the store.
+func (store *QueueStore) Put(entry interface{}) error {
+	store.Lock()
+	defer store.Unlock()
+	if store.currentEntries >= store.entryLimit {
+		return ErrLimitExceeded
+	}
+	u, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	return store.write(u.String(), entry)
+}
+
+// Get - gets the JSON encoded entry from the store.
+func (store *QueueStore) Get(key string) (data []byte, err error) {
+	store.RLock()
+
+	defer func(store *QueueStore) {
+		store.RUnlock()
+		if err != nil {
+			// Upon error we remove the entry, empty entries
+			// are reported as not existing and removed too.
+			store.Del(key)
+		}
+	}(store)
+
+	data, err = ioutil.ReadFile(filepath.Join(store.directory, key+store.ext))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, os.ErrNotExist
+	}
+
+	return data, nil
+}
+
+// Del - Deletes an entry from the store.
+func (store *QueueStore) Del(key string) error {
+	store.Lock()
+	defer store.Unlock()
+	return store.del(key)
+}
+
+// lockless call
+func (store *QueueStore) del(key string) error {
+	if err := os.Remove(filepath.Join(store.directory, key+store.ext)); err != nil {
+		return err
+	}
+
+	// Decrement the current entries count.
+	store.currentEntries--
+
+	// Current entries can underflow, when multiple
+	// entries are being pushed in parallel, this code
+	// is needed to ensure that we don't underflow.
+	if store.currentEntries == math.MaxUint64 {
+		store.currentEntries = 0
+	}
+	return nil
+}
+
+// List - lists all files from the directory.
+func (store *QueueStore) List() ([]string, error) {
+	store.RLock()
+	defer store.RUnlock()
+	return store.list()
+}
+
+// list lock less.
+func (store *QueueStore) list() ([]string, error) {
+	var names []string
+	files, err := ioutil.ReadDir(store.directory)
+	if err != nil {
+		return names, err
+	}
+
+	// Sort the dentries.
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+
+	return names, nil
+}
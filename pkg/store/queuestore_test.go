@@ -0,0 +1,196 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testExt = ".test"
+
+type testEntry struct {
+	API string `json:"api"`
+	Seq int    `json:"seq"`
+}
+
+// Initialize the store.
+func setUpStore(t *testing.T, limit uint64) (Store, string) {
+	dir, err := ioutil.TempDir("", "minio-store-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewQueueStore(dir, limit, testExt)
+	if err = store.Open(); err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	return store, dir
+}
+
+func TestQueueStore(t *testing.T) {
+	store, dir := setUpStore(t, 10)
+	defer os.RemoveAll(dir)
+
+	for i := 0; i < 10; i++ {
+		if err := store.Put(testEntry{API: "PutObject", Seq: i}); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+	if err := store.Put(testEntry{}); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Expected %v, got %v", ErrLimitExceeded, err)
+	}
+
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 10 {
+		t.Fatalf("List() Expected: 10, got %d", len(names))
+	}
+
+	key := names[0][:len(names[0])-len(testExt)]
+	data, err := store.Get(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry testEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.API != "PutObject" {
+		t.Fatalf("Unexpected entry %v", entry)
+	}
+
+	if err = store.Del(key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Get(key); !os.IsNotExist(err) {
+		t.Fatalf("Expected not found, got %v", err)
+	}
+	// Deleting makes room for new entries.
+	if err = store.Put(testEntry{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries persisted before are counted when reopened.
+	store = NewQueueStore(dir, 10, testExt)
+	if err = store.Open(); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Expected %v, got %v", ErrLimitExceeded, err)
+	}
+}
+
+// Empty entries, e.g. left by a crash while writing, are removed
+// instead of being replayed forever.
+func TestQueueStoreEmptyEntry(t *testing.T) {
+	store, dir := setUpStore(t, 1)
+	defer os.RemoveAll(dir)
+
+	if err := store.Put(testEntry{API: "PutObject"}); err != nil {
+		t.Fatal(err)
+	}
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, names[0]), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = store.Get(names[0][:len(names[0])-len(testExt)]); !os.IsNotExist(err) {
+		t.Fatalf("Expected not found, got %v", err)
+	}
+	if names, err = store.List(); err != nil || len(names) != 0 {
+		t.Fatalf("Expected the empty entry to be removed, got %v: %v", names, err)
+	}
+	// The removed entry makes room for a new one.
+	if err = store.Put(testEntry{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	store, dir := setUpStore(t, 100)
+	defer os.RemoveAll(dir)
+
+	for i := 0; i < 3; i++ {
+		if err := store.Put(testEntry{Seq: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		received []testEntry
+		failed   bool
+	)
+	send := func(key string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		// The first attempt fails, as if the target is offline.
+		if !failed {
+			failed = true
+			return errors.New("target offline")
+		}
+		data, err := store.Get(key)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		var entry testEntry
+		if err = json.Unmarshal(data, &entry); err != nil {
+			return err
+		}
+		received = append(received, entry)
+		return store.Del(key)
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	logOnce := func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {}
+	go Replay(store, send, doneCh, logOnce, "test")
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		mu.Lock()
+		n := len(received)
+		mu.Unlock()
+		if n == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 3 entries replayed, got %d", n)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Fatalf("Expected an empty store, got %d entries", len(names))
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const retryInterval = 3 * time.Second
+
+// LogOnce logs an error at most once per id.
+type LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+
+// Lister - lists the names of the entries in a store, oldest first.
+type Lister interface {
+	List() ([]string, error)
+}
+
+// replayEntries - Reads the keys of the entries from the store.
+func replayEntries(store Lister, doneCh <-chan struct{}, logOnce LogOnce, id string) <-chan string {
+	keyCh := make(chan string)
+
+	go func() {
+		retryTicker := time.NewTicker(retryInterval)
+		defer retryTicker.Stop()
+		defer close(keyCh)
+		for {
+			names, err := store.List()
+			if err == nil {
+				for _, name := range names {
+					select {
+					case keyCh <- strings.TrimSuffix(name, filepath.Ext(name)):
+						// Get next key.
+					case <-doneCh:
+						return
+					}
+				}
+			}
+
+			if len(names) < 2 {
+				select {
+				case <-retryTicker.C:
+					if err != nil {
+						logOnce(context.Background(),
+							fmt.Errorf("store.List() failed '%w'", err), id)
+					}
+				case <-doneCh:
+					return
+				}
+			}
+		}
+	}()
+
+	return keyCh
+}
+
+// Replay - sends the entries of the store, in the order they were
+// stored, until doneCh is closed. The entries which send fails to
+// deliver are retried after a back-off, send is expected to delete
+// the delivered entries from the store.
+func Replay(store Lister, send func(key string) error, doneCh <-chan struct{}, logOnce LogOnce, id string) {
+	keyCh := replayEntries(store, doneCh, logOnce, id)
+
+	retryTicker := time.NewTicker(retryInterval)
+	defer retryTicker.Stop()
+
+	sendEntry := func(key string) bool {
+		for {
+			err := send(key)
+			if err == nil {
+				return true
+			}
+
+			logOnce(context.Background(),
+				fmt.Errorf("unable to send the entry '%s' to '%s': %w", key, id, err), id)
+
+			// Retrying after 3secs back-off
+			select {
+			case <-retryTicker.C:
+			case <-doneCh:
+				return false
+			}
+		}
+	}
+
+	for {
+		select {
+		case key, ok := <-keyCh:
+			if !ok {
+				// closed channel.
+				return
+			}
+
+			if !sendEntry(key) {
+				return
+			}
+		case <-doneCh:
+			return
+		}
+	}
+}
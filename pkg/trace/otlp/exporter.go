@@ -0,0 +1,314 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Maximum number of spans sent in a request.
+	exportBatchSize = 512
+	// Maximum duration a span waits to be sent.
+	exportInterval = 5 * time.Second
+	// Spans are dropped when the queue is full.
+	exportQueueSize = 10000
+
+	// Status code of failed spans.
+	statusCodeError = 2
+)
+
+// Exporter - sends the ended spans in batches to an OTLP/HTTP
+// collector endpoint, such as http://localhost:4318/v1/traces, using
+// the JSON encoding.
+type Exporter struct {
+	endpoint  string
+	authToken string
+	client    http.Client
+	resource  []Attribute
+	onError   func(error)
+
+	mu     sync.RWMutex
+	closed bool
+	spanCh chan *Span
+	doneCh chan struct{}
+}
+
+// ExporterOption - configures an exporter.
+type ExporterOption func(*Exporter)
+
+// WithEndpoint - sets the collector endpoint URL.
+func WithEndpoint(endpoint string) ExporterOption {
+	return func(e *Exporter) {
+		e.endpoint = endpoint
+	}
+}
+
+// WithAuthToken - sets the Authorization header sent to the collector.
+func WithAuthToken(authToken string) ExporterOption {
+	return func(e *Exporter) {
+		e.authToken = authToken
+	}
+}
+
+// WithTransport - sets the transport of the requests to the collector.
+func WithTransport(transport http.RoundTripper) ExporterOption {
+	return func(e *Exporter) {
+		e.client = http.Client{
+			Transport: transport,
+		}
+	}
+}
+
+// WithResource - sets the attributes of the process emitting the
+// spans, such as service.name.
+func WithResource(attributes ...Attribute) ExporterOption {
+	return func(e *Exporter) {
+		e.resource = attributes
+	}
+}
+
+// WithErrorHandler - sets the function called when spans could not be
+// sent.
+func WithErrorHandler(onError func(error)) ExporterOption {
+	return func(e *Exporter) {
+		e.onError = onError
+	}
+}
+
+// NewExporter - creates an exporter and starts sending the spans.
+func NewExporter(opts ...ExporterOption) *Exporter {
+	e := &Exporter{
+		spanCh: make(chan *Span, exportQueueSize),
+		doneCh: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	go e.run()
+	return e
+}
+
+// export - queues an ended span, the span is dropped if the queue is
+// full or the exporter closed.
+func (e *Exporter) export(span *Span) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return
+	}
+	select {
+	case e.spanCh <- span:
+	default:
+	}
+}
+
+// Close - sends the queued spans and stops the exporter.
+func (e *Exporter) Close() {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.spanCh)
+	}
+	e.mu.Unlock()
+	<-e.doneCh
+}
+
+func (e *Exporter) run() {
+	defer close(e.doneCh)
+
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, exportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil && e.onError != nil {
+			e.onError(err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case span, ok := <-e.spanCh:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) == exportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (e *Exporter) send(spans []*Span) error {
+	body, err := json.Marshal(newExportRequest(e.resource, spans))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportInterval)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.authToken != "" {
+		req.Header.Set("Authorization", e.authToken)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration", e.endpoint, err)
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s returned '%s', please check your endpoint configuration", e.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// The types below are the JSON encoding of an OTLP
+// ExportTraceServiceRequest.
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []spanJSON `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type spanJSON struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              SpanKind   `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            *status    `json:"status,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func newKeyValues(attributes []Attribute) []keyValue {
+	kvs := make([]keyValue, 0, len(attributes))
+	for _, attribute := range attributes {
+		kv := keyValue{Key: attribute.Key}
+		switch v := attribute.Value.(type) {
+		case string:
+			kv.Value.StringValue = &v
+		case int64:
+			// 64 bits integers are strings in the JSON
+			// encoding of protocol buffers.
+			s := strconv.FormatInt(v, 10)
+			kv.Value.IntValue = &s
+		case float64:
+			kv.Value.DoubleValue = &v
+		case bool:
+			kv.Value.BoolValue = &v
+		default:
+			s := fmt.Sprint(v)
+			kv.Value.StringValue = &s
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs
+}
+
+func newExportRequest(resourceAttributes []Attribute, spans []*Span) exportRequest {
+	encoded := make([]spanJSON, 0, len(spans))
+	for _, span := range spans {
+		span.mu.Lock()
+		s := spanJSON{
+			TraceID:           span.context.TraceID.String(),
+			SpanID:            span.context.SpanID.String(),
+			Name:              span.name,
+			Kind:              span.kind,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes:        newKeyValues(span.attributes),
+		}
+		if span.parent.IsValid() {
+			s.ParentSpanID = span.parent.String()
+		}
+		if span.isError {
+			s.Status = &status{Code: statusCodeError, Message: span.errMessage}
+		}
+		span.mu.Unlock()
+		encoded = append(encoded, s)
+	}
+
+	return exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{Attributes: newKeyValues(resourceAttributes)},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: "minio"},
+				Spans: encoded,
+			}},
+		}},
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestTraceparent(t *testing.T) {
+	testCases := []struct {
+		value   string
+		valid   bool
+		sampled bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bz-01", false, false},
+		{"", false, false},
+	}
+	for i, testCase := range testCases {
+		sc, ok := parseTraceparent(testCase.value)
+		if ok != testCase.valid {
+			t.Fatalf("Test %d: expected valid %v, got %v", i+1, testCase.valid, ok)
+		}
+		if ok && sc.Sampled != testCase.sampled {
+			t.Fatalf("Test %d: expected sampled %v, got %v", i+1, testCase.sampled, sc.Sampled)
+		}
+	}
+
+	h := http.Header{}
+	h.Set(TraceparentHeader, testCases[0].value)
+	ctx := Extract(context.Background(), h)
+	out := http.Header{}
+	Inject(ctx, out)
+	if got := out.Get(TraceparentHeader); got != testCases[0].value {
+		t.Fatalf("expected %s, got %s", testCases[0].value, got)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	SetTracer(nil)
+	ctx, span := Start(context.Background(), "test", SpanKindInternal)
+	if span != nil || SpanFromContext(ctx) != nil {
+		t.Fatal("expected no span when tracing is disabled")
+	}
+	// Methods of nil spans do nothing.
+	span.SetAttributes(String("key", "value"))
+	span.SetError(errors.New("error"))
+	span.End()
+
+	h := http.Header{}
+	Inject(ctx, h)
+	if len(h) != 0 {
+		t.Fatalf("unexpected headers %v", h)
+	}
+}
+
+func TestExport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []exportRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req exportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	exporter := NewExporter(WithEndpoint(server.URL), WithResource(String("service.name", "minio")))
+	SetTracer(NewTracer(exporter, 1))
+
+	// A request of another process.
+	h := http.Header{}
+	h.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := Extract(context.Background(), h)
+
+	ctx, server1 := Start(ctx, "s3.PutObject", SpanKindServer, String("http.method", "PUT"))
+	childCtx, client := Start(ctx, "rest.CreateFile", SpanKindClient)
+	client.SetError(errors.New("disk not found"))
+	client.End()
+	client.End()
+	server1.SetAttributes(Int("http.status_code", 200))
+	server1.End()
+
+	// An unsampled trace is not exported.
+	unsampled := http.Header{}
+	unsampled.Set(TraceparentHeader, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00")
+	_, span := Start(Extract(context.Background(), unsampled), "s3.GetObject", SpanKindServer)
+	span.End()
+
+	SetTracer(nil).Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	rs := requests[0].ResourceSpans
+	if len(rs) != 1 || len(rs[0].ScopeSpans) != 1 || *rs[0].Resource.Attributes[0].Value.StringValue != "minio" {
+		t.Fatalf("unexpected request %+v", requests[0])
+	}
+	spans := rs[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	child, parent := spans[0], spans[1]
+	if parent.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || child.TraceID != parent.TraceID {
+		t.Fatalf("unexpected trace IDs %s and %s", parent.TraceID, child.TraceID)
+	}
+	if parent.ParentSpanID != "00f067aa0ba902b7" || child.ParentSpanID != parent.SpanID {
+		t.Fatalf("unexpected parents %s and %s", parent.ParentSpanID, child.ParentSpanID)
+	}
+	if child.Kind != SpanKindClient || child.Status == nil || child.Status.Code != statusCodeError || child.Status.Message != "disk not found" {
+		t.Fatalf("unexpected child span %+v", child)
+	}
+	if parent.Name != "s3.PutObject" || parent.Status != nil || len(parent.Attributes) != 2 || *parent.Attributes[1].Value.IntValue != "200" {
+		t.Fatalf("unexpected parent span %+v", parent)
+	}
+
+	out := http.Header{}
+	Inject(childCtx, out)
+	if want := "00-" + child.TraceID + "-" + child.SpanID + "-01"; out.Get(TraceparentHeader) != want {
+		t.Fatalf("expected %s, got %s", want, out.Get(TraceparentHeader))
+	}
+}
+
+func TestUnsampled(t *testing.T) {
+	exporter := NewExporter(WithEndpoint("http://127.0.0.1:0"))
+	SetTracer(NewTracer(exporter, 0))
+	defer func() { SetTracer(nil).Close() }()
+
+	// New traces carry the sampling decision to their children.
+	ctx, root := Start(context.Background(), "s3.GetObject", SpanKindServer)
+	if !root.SpanContext().IsValid() || root.SpanContext().Sampled {
+		t.Fatalf("expected a valid unsampled span context, got %+v", root.SpanContext())
+	}
+	ctx, child := Start(ctx, "rest.ReadFile", SpanKindClient)
+	if child.SpanContext() != root.SpanContext() {
+		t.Fatalf("expected the context %+v of the unsampled parent, got %+v", root.SpanContext(), child.SpanContext())
+	}
+	child.End()
+	root.End()
+
+	h := http.Header{}
+	Inject(ctx, h)
+	if got, want := h.Get(TraceparentHeader), "00-"+root.SpanContext().TraceID.String()+"-"+root.SpanContext().SpanID.String()+"-00"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
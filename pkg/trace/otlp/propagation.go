@@ -0,0 +1,85 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// TraceparentHeader - W3C trace context header identifying the parent
+// span of a request.
+const TraceparentHeader = "traceparent"
+
+// Inject - sets the trace context of the span of ctx in the headers of
+// an outgoing request.
+func Inject(ctx context.Context, h http.Header) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	h.Set(TraceparentHeader, "00-"+sc.TraceID.String()+"-"+sc.SpanID.String()+"-"+flags)
+}
+
+// Extract - returns a context whose spans are children of the span
+// given by the trace context headers of an incoming request, if any.
+func Extract(ctx context.Context, h http.Header) context.Context {
+	sc, ok := parseTraceparent(h.Get(TraceparentHeader))
+	if !ok {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// parseTraceparent - parses a traceparent header value of the form
+// version-traceid-parentid-flags.
+func parseTraceparent(s string) (sc SpanContext, ok bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, false
+	}
+	// Version 00 has exactly four fields, later versions may
+	// append more.
+	if parts[0] == "00" && len(parts) != 4 {
+		return sc, false
+	}
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || len(version) != 1 {
+		return sc, false
+	}
+	if len(parts[1]) != 2*len(sc.TraceID) || len(parts[2]) != 2*len(sc.SpanID) || len(parts[3]) != 2 {
+		return sc, false
+	}
+	if _, err = hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, false
+	}
+	if _, err = hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return sc, false
+	}
+	sc.Sampled = flags[0]&0x01 == 0x01
+	return sc, sc.IsValid()
+}
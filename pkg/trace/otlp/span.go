@@ -0,0 +1,286 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package otlp records the spans of distributed traces and exports
+// them to an OpenTelemetry collector using OTLP over HTTP.
+package otlp
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceID - identifier of a trace.
+type TraceID [16]byte
+
+// String - returns the hex encoding of the trace ID.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid - returns whether the trace ID is not all zeros.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// SpanID - identifier of a span.
+type SpanID [8]byte
+
+// String - returns the hex encoding of the span ID.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid - returns whether the span ID is not all zeros.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// SpanKind - role of a span in its trace, values are the OTLP ones.
+type SpanKind int
+
+// Supported span kinds.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// SpanContext - identifies a span within and across processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid - returns whether the span context identifies a span.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Attribute - a key value pair describing a span, values are either
+// strings, int64, float64 or bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String - returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int - returns an integer attribute.
+func Int(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span - a timed operation of a trace. A nil span is valid, all its
+// methods do nothing; it is returned when tracing is disabled.
+type Span struct {
+	tracer  *Tracer
+	name    string
+	kind    SpanKind
+	context SpanContext
+	parent  SpanID
+	start   time.Time
+
+	mu         sync.Mutex
+	end        time.Time
+	attributes []Attribute
+	errMessage string
+	isError    bool
+	ended      bool
+}
+
+// SpanContext - returns the span context of the span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.context
+}
+
+// SetAttributes - adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil || !s.context.Sampled {
+		return
+	}
+	s.mu.Lock()
+	s.attributes = append(s.attributes, attributes...)
+	s.mu.Unlock()
+}
+
+// SetError - marks the span as failed if err is not nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil || !s.context.Sampled {
+		return
+	}
+	s.mu.Lock()
+	s.isError = true
+	s.errMessage = err.Error()
+	s.mu.Unlock()
+}
+
+// End - ends the span and exports it if it is sampled, only the first
+// call has an effect.
+func (s *Span) End() {
+	if s == nil || !s.context.Sampled {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+	s.tracer.exporter.export(s)
+}
+
+// Tracer - creates the spans and samples the new traces.
+type Tracer struct {
+	exporter    *Exporter
+	sampleRatio float64
+}
+
+// NewTracer - creates a tracer exporting the spans of sampleRatio of
+// the new traces with the exporter. Traces started by a remote parent
+// follow the sampling decision of their parent.
+func NewTracer(exporter *Exporter, sampleRatio float64) *Tracer {
+	return &Tracer{
+		exporter:    exporter,
+		sampleRatio: sampleRatio,
+	}
+}
+
+// Close - exports the pending spans and stops the exporter.
+func (t *Tracer) Close() {
+	t.exporter.Close()
+}
+
+// randPool - sources of the sampling decisions and identifiers, pooled
+// such that concurrent spans do not contend on a shared lock.
+var randPool = sync.Pool{
+	New: func() interface{} {
+		var seed [8]byte
+		if _, err := cryptorand.Read(seed[:]); err != nil {
+			binary.LittleEndian.PutUint64(seed[:], uint64(time.Now().UnixNano()))
+		}
+		return rand.New(rand.NewSource(int64(binary.LittleEndian.Uint64(seed[:]))))
+	},
+}
+
+// newSpanContext - returns the context of a new span, a child of parent
+// if valid. The sampling decision is taken first: the children of
+// unsampled spans are never exported and only carry the decision to
+// their own children, hence they reuse the context of their parent.
+func (t *Tracer) newSpanContext(parent SpanContext) (sc SpanContext) {
+	if parent.IsValid() && !parent.Sampled {
+		return parent
+	}
+
+	r := randPool.Get().(*rand.Rand)
+	defer randPool.Put(r)
+	if parent.IsValid() {
+		sc.TraceID, sc.Sampled = parent.TraceID, true
+	} else {
+		sc.Sampled = r.Float64() < t.sampleRatio
+		for !sc.TraceID.IsValid() {
+			r.Read(sc.TraceID[:])
+		}
+	}
+	for !sc.SpanID.IsValid() {
+		r.Read(sc.SpanID[:])
+	}
+	return sc
+}
+
+var globalTracer atomic.Value
+
+type tracerHolder struct {
+	tracer *Tracer
+}
+
+// SetTracer - sets the tracer of the spans and returns the previous
+// one, a nil tracer disables tracing.
+func SetTracer(t *Tracer) *Tracer {
+	old, _ := globalTracer.Load().(tracerHolder)
+	globalTracer.Store(tracerHolder{tracer: t})
+	return old.tracer
+}
+
+// Enabled - returns whether tracing is enabled.
+func Enabled() bool {
+	holder, _ := globalTracer.Load().(tracerHolder)
+	return holder.tracer != nil
+}
+
+type spanContextKey struct{}
+
+type remoteSpanContextKey struct{}
+
+// Start - starts a span as a child of the span of ctx, if any, and
+// returns a context holding the new span. The span must be ended.
+func Start(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	holder, _ := globalTracer.Load().(tracerHolder)
+	if holder.tracer == nil {
+		return ctx, nil
+	}
+
+	parent := SpanContextFromContext(ctx)
+	span := &Span{
+		tracer:  holder.tracer,
+		name:    name,
+		kind:    kind,
+		context: holder.tracer.newSpanContext(parent),
+		parent:  parent.SpanID,
+		start:   time.Now(),
+	}
+	if span.context.Sampled {
+		span.attributes = attributes
+	}
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// SpanFromContext - returns the span of ctx, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// SpanContextFromContext - returns the span context of the span of
+// ctx, or else of its remote parent.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.context
+	}
+	sc, _ := ctx.Value(remoteSpanContextKey{}).(SpanContext)
+	return sc
+}
+
+// ContextWithRemoteSpanContext - returns a context whose spans are
+// children of a span of another process.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanContextKey{}, sc)
+}